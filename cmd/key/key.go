@@ -1,6 +1,7 @@
 package key
 
 import (
+	"database/sql"
 	"io"
 	"os"
 	"strings"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 )
 
@@ -22,6 +24,7 @@ const (
 
 type Config struct {
 	Database database.Config
+	KMS      *kms.Config
 }
 
 func New() *cobra.Command {
@@ -31,6 +34,7 @@ func New() *cobra.Command {
 	}
 	AddMasterKeyFlag(cmd)
 	cmd.AddCommand(newKey())
+	cmd.AddCommand(rotateKeys())
 	return cmd
 }
 
@@ -38,7 +42,7 @@ func newKey() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "new [keyID=key]... [-f file]",
 		Short: "create new encryption key(s)",
-		Long: `create new encryption key(s) (encrypted by the provided master key)
+		Long: `create new encryption key(s) (encrypted by the provided master key or the configured KMS)
 provide key(s) by YAML file and/or by argument
 Requirements:
 - cockroachdb`,
@@ -66,11 +70,15 @@ new -f keys.yaml key2=anotherkey`,
 			if err := viper.Unmarshal(config); err != nil {
 				return err
 			}
-			masterKey, err := MasterKey(cmd)
+			masterKey, err := MasterKeyUnlessKMS(cmd, config.KMS)
 			if err != nil {
 				return err
 			}
-			storage, err := keyStorage(config.Database, masterKey)
+			db, err := database.Connect(config.Database, false)
+			if err != nil {
+				return err
+			}
+			storage, err := Storage(db.DB, masterKey, config.KMS)
 			if err != nil {
 				return err
 			}
@@ -123,10 +131,15 @@ func openFile(fileName string) (io.Reader, error) {
 	return file, nil
 }
 
-func keyStorage(config database.Config, masterKey string) (crypto.KeyStorage, error) {
-	db, err := database.Connect(config, false)
+// Storage returns the key storage of the database,
+// the keys are wrapped by the KMS if configured, otherwise they are encrypted by the masterkey
+func Storage(db *sql.DB, masterKey string, kmsConfig *kms.Config) (crypto.KeyStorage, error) {
+	if !kmsConfig.Enabled() {
+		return cryptoDB.NewKeyStorage(db, masterKey)
+	}
+	kms, err := kmsConfig.NewKMS()
 	if err != nil {
 		return nil, err
 	}
-	return cryptoDB.NewKeyStorage(db.DB, masterKey)
+	return cryptoDB.NewKMSKeyStorage(db, kms), nil
 }
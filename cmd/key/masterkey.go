@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 )
 
 const (
@@ -30,9 +32,7 @@ func AddMasterKeyFlag(cmd *cobra.Command) {
 }
 
 func MasterKey(cmd *cobra.Command) (string, error) {
-	masterKeyFile, _ := cmd.Flags().GetString(flagMasterKey)
-	masterKeyFromArg, _ := cmd.Flags().GetString(flagMasterKeyArg)
-	masterKeyFromEnv, _ := cmd.Flags().GetBool(flagMasterKeyEnv)
+	masterKeyFile, masterKeyFromArg, masterKeyFromEnv := masterKeyFlags(cmd)
 	if err := checkSingleFlag(masterKeyFile, masterKeyFromArg, masterKeyFromEnv); err != nil {
 		return "", err
 	}
@@ -49,6 +49,26 @@ func MasterKey(cmd *cobra.Command) (string, error) {
 	return string(data), nil
 }
 
+// MasterKeyUnlessKMS returns the masterkey, which is not required if the encryption keys are wrapped by a KMS
+func MasterKeyUnlessKMS(cmd *cobra.Command, kmsConfig *kms.Config) (string, error) {
+	if kmsConfig.Enabled() {
+		return "", nil
+	}
+	return MasterKey(cmd)
+}
+
+func masterKeyProvided(cmd *cobra.Command) bool {
+	masterKeyFile, masterKeyFromArg, masterKeyFromEnv := masterKeyFlags(cmd)
+	return masterKeyFile != "" || masterKeyFromArg != "" || masterKeyFromEnv
+}
+
+func masterKeyFlags(cmd *cobra.Command) (masterKeyFile, masterKeyFromArg string, masterKeyFromEnv bool) {
+	masterKeyFile, _ = cmd.Flags().GetString(flagMasterKey)
+	masterKeyFromArg, _ = cmd.Flags().GetString(flagMasterKeyArg)
+	masterKeyFromEnv, _ = cmd.Flags().GetBool(flagMasterKeyEnv)
+	return masterKeyFile, masterKeyFromArg, masterKeyFromEnv
+}
+
 func checkSingleFlag(masterKeyFile, masterKeyFromArg string, masterKeyFromEnv bool) error {
 	var flags int
 	if masterKeyFile != "" {
@@ -0,0 +1,87 @@
+package key
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+type keyUpdater interface {
+	UpdateKeys(...*crypto.Key) error
+}
+
+func rotateKeys() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate",
+		Short: "rotate the key encryption key of the KMS",
+		Long: `creates a new version of the key encryption key in the configured KMS and encrypts all encryption keys with it
+older versions of the key encryption key must stay available for decryption until the rotation succeeded
+
+if a master key is provided, the encryption keys are decrypted by it instead and encrypted by the KMS,
+which migrates the encryption keys from the master key to the KMS
+Requirements:
+- cockroachdb
+- KMS`,
+		Example: `rotate
+rotate --masterkeyFromEnv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := new(Config)
+			if err := viper.Unmarshal(config); err != nil {
+				return err
+			}
+			if !config.KMS.Enabled() {
+				return caos_errs.ThrowPreconditionFailed(nil, "KEY-Xk3nd", "rotation requires a configured KMS")
+			}
+			kms, err := config.KMS.NewKMS()
+			if err != nil {
+				return err
+			}
+			db, err := database.Connect(config.Database, false)
+			if err != nil {
+				return err
+			}
+			target := cryptoDB.NewKMSKeyStorage(db.DB, kms)
+			if !masterKeyProvided(cmd) {
+				return rotate(target, target, kms.Rotate)
+			}
+			masterKey, err := MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			source, err := cryptoDB.NewKeyStorage(db.DB, masterKey)
+			if err != nil {
+				return err
+			}
+			return rotate(source, target, nil)
+		},
+	}
+}
+
+// rotate reads all keys of the source and updates them in the target,
+// rotateKEK is called after reading, as the keys might still be wrapped by the current version
+func rotate(source crypto.KeyStorage, target keyUpdater, rotateKEK func() error) error {
+	readKeys, err := source.ReadKeys()
+	if err != nil {
+		return err
+	}
+	if rotateKEK != nil {
+		if err = rotateKEK(); err != nil {
+			return err
+		}
+	}
+	keys := make([]*crypto.Key, 0, len(readKeys))
+	for id, value := range readKeys {
+		keys = append(keys, &crypto.Key{
+			ID:    id,
+			Value: value,
+		})
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return target.UpdateKeys(keys...)
+}
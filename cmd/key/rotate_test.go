@@ -0,0 +1,90 @@
+package key
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+type testStorage struct {
+	keys    crypto.Keys
+	readErr error
+	updated []*crypto.Key
+	calls   []string
+}
+
+func (s *testStorage) ReadKeys() (crypto.Keys, error) {
+	s.calls = append(s.calls, "read")
+	return s.keys, s.readErr
+}
+
+func (s *testStorage) ReadKey(id string) (*crypto.Key, error) {
+	return &crypto.Key{ID: id, Value: s.keys[id]}, nil
+}
+
+func (s *testStorage) CreateKeys(...*crypto.Key) error {
+	return nil
+}
+
+func (s *testStorage) UpdateKeys(keys ...*crypto.Key) error {
+	s.calls = append(s.calls, "update")
+	s.updated = keys
+	return nil
+}
+
+func Test_rotate(t *testing.T) {
+	errRead := errors.New("read failed")
+	tests := []struct {
+		name        string
+		storage     *testStorage
+		rotateKEK   bool
+		wantErr     error
+		wantCalls   []string
+		wantUpdated []*crypto.Key
+	}{
+		{
+			name:      "read fails, error",
+			storage:   &testStorage{readErr: errRead},
+			rotateKEK: true,
+			wantErr:   errRead,
+			wantCalls: []string{"read"},
+		},
+		{
+			name:      "no keys, rotated",
+			storage:   &testStorage{keys: crypto.Keys{}},
+			rotateKEK: true,
+			wantCalls: []string{"read", "rotate"},
+		},
+		{
+			name:        "rotate and update",
+			storage:     &testStorage{keys: crypto.Keys{"id1": "key1"}},
+			rotateKEK:   true,
+			wantCalls:   []string{"read", "rotate", "update"},
+			wantUpdated: []*crypto.Key{{ID: "id1", Value: "key1"}},
+		},
+		{
+			name:        "migrate without rotation",
+			storage:     &testStorage{keys: crypto.Keys{"id1": "key1"}},
+			wantCalls:   []string{"read", "update"},
+			wantUpdated: []*crypto.Key{{ID: "id1", Value: "key1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rotateKEK func() error
+			if tt.rotateKEK {
+				rotateKEK = func() error {
+					tt.storage.calls = append(tt.storage.calls, "rotate")
+					return nil
+				}
+			}
+			err := rotate(tt.storage, tt.storage, rotateKEK)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, tt.storage.calls)
+			assert.Equal(t, tt.wantUpdated, tt.storage.updated)
+		})
+	}
+}
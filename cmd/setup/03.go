@@ -9,11 +9,12 @@ import (
 
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
-	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
@@ -28,6 +29,7 @@ type FirstInstance struct {
 	userEncryptionKey *crypto.KeyConfig
 	smtpEncryptionKey *crypto.KeyConfig
 	masterKey         string
+	kmsConfig         *kms.Config
 	db                *sql.DB
 	es                *eventstore.Eventstore
 	defaults          systemdefaults.SystemDefaults
@@ -39,7 +41,7 @@ type FirstInstance struct {
 }
 
 func (mig *FirstInstance) Execute(ctx context.Context) error {
-	keyStorage, err := crypto_db.NewKeyStorage(mig.db, mig.masterKey)
+	keyStorage, err := key.Storage(mig.db, mig.masterKey, mig.kmsConfig)
 	if err != nil {
 		return fmt.Errorf("cannot start key storage: %w", err)
 	}
@@ -76,6 +78,8 @@ func (mig *FirstInstance) Execute(ctx context.Context) error {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 	)
 
 	if err != nil {
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 10.sql
+	otpSMSEmailColumnsStmts string
+)
+
+type OTPSMSEmailColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *OTPSMSEmailColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, otpSMSEmailColumnsStmts)
+	return err
+}
+
+func (mig *OTPSMSEmailColumns) String() string {
+	return "10_otp_sms_email_columns"
+}
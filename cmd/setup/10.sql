@@ -0,0 +1,2 @@
+ALTER TABLE auth.users2 ADD COLUMN IF NOT EXISTS otp_sms_added BOOL DEFAULT false;
+ALTER TABLE auth.users2 ADD COLUMN IF NOT EXISTS otp_email_added BOOL DEFAULT false;
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 11.sql
+	tokenActorColumnsStmts string
+)
+
+type TokenActorColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *TokenActorColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, tokenActorColumnsStmts)
+	return err
+}
+
+func (mig *TokenActorColumns) String() string {
+	return "11_token_actor_columns"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS actor_user_id TEXT NULL;
+ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS actor_org_id TEXT NULL;
@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+var (
+	//go:embed 12.sql
+	lockedUntilColumnStmts string
+)
+
+type LockedUntilColumn struct {
+	dbClient *sql.DB
+}
+
+func (mig *LockedUntilColumn) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, lockedUntilColumnStmts)
+	return err
+}
+
+func (mig *LockedUntilColumn) String() string {
+	return "12_locked_until_column"
+}
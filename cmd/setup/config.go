@@ -14,6 +14,7 @@ import (
 	"github.com/zitadel/zitadel/internal/config/hook"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -28,6 +29,7 @@ type Config struct {
 	ExternalSecure  bool
 	Log             *logging.Config
 	EncryptionKeys  *encryptionKeyConfig
+	KMS             *kms.Config
 	DefaultInstance command.InstanceSetup
 	Machine         *id.Config
 	Projections     projection.Config
@@ -50,6 +52,8 @@ func MustNewConfig(v *viper.Viper) *Config {
 	err = config.Log.SetLogger()
 	logging.OnError(err).Fatal("unable to set logger")
 
+	config.SystemDefaults.MapDeprecated()
+
 	id.Configure(config.Machine)
 
 	return config
@@ -65,6 +69,9 @@ type Steps struct {
 	s7LogstoreTables     *LogstoreTables
 	s8AuthTokens         *AuthTokenIndexes
 	s9EventstoreIndexes2 *EventstoreIndexesNew
+	s10OTPSMSEmail       *OTPSMSEmailColumns
+	s11TokenActor        *TokenActorColumns
+	s12LockedUntil       *LockedUntilColumn
 }
 
 type encryptionKeyConfig struct {
@@ -50,6 +50,8 @@ func (mig *externalConfigChange) Execute(ctx context.Context) error {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 	)
 
 	if err != nil {
@@ -38,7 +38,7 @@ Requirements:
 			config := MustNewConfig(viper.GetViper())
 			steps := MustNewSteps(viper.New())
 
-			masterKey, err := key.MasterKey(cmd)
+			masterKey, err := key.MasterKeyUnlessKMS(cmd, config.KMS)
 			logging.OnError(err).Panic("No master key provided")
 
 			Setup(config, steps, masterKey)
@@ -74,6 +74,7 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.FirstInstance.userEncryptionKey = config.EncryptionKeys.User
 	steps.FirstInstance.smtpEncryptionKey = config.EncryptionKeys.SMTP
 	steps.FirstInstance.masterKey = masterKey
+	steps.FirstInstance.kmsConfig = config.KMS
 	steps.FirstInstance.db = dbClient.DB
 	steps.FirstInstance.es = eventstoreClient
 	steps.FirstInstance.defaults = config.SystemDefaults
@@ -88,6 +89,9 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s7LogstoreTables = &LogstoreTables{dbClient: dbClient.DB, username: config.Database.Username(), dbType: config.Database.Type()}
 	steps.s8AuthTokens = &AuthTokenIndexes{dbClient: dbClient}
 	steps.s9EventstoreIndexes2 = New09(dbClient)
+	steps.s10OTPSMSEmail = &OTPSMSEmailColumns{dbClient: dbClient.DB}
+	steps.s11TokenActor = &TokenActorColumns{dbClient: dbClient.DB}
+	steps.s12LockedUntil = &LockedUntilColumn{dbClient: dbClient.DB}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -123,6 +127,12 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 8")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s9EventstoreIndexes2)
 	logging.OnError(err).Fatal("unable to migrate step 9")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s10OTPSMSEmail)
+	logging.OnError(err).Fatal("unable to migrate step 10")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s11TokenActor)
+	logging.OnError(err).Fatal("unable to migrate step 11")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s12LockedUntil)
+	logging.OnError(err).Fatal("unable to migrate step 12")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
@@ -13,19 +13,26 @@ import (
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/saml"
+	"github.com/zitadel/zitadel/internal/api/scim"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
+	cache_config "github.com/zitadel/zitadel/internal/cache/config"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/hook"
 	"github.com/zitadel/zitadel/internal/config/network"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/eventaction"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/exporter"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/webhook"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 	static_config "github.com/zitadel/zitadel/internal/static/config"
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
 	tracing "github.com/zitadel/zitadel/internal/telemetry/tracing/config"
@@ -51,17 +58,23 @@ type Config struct {
 	OIDC              oidc.Config
 	SAML              saml.Config
 	Login             login.Config
+	LoginRateLimit    *ratelimit.Config
 	Console           console.Config
 	AssetStorage      static_config.AssetStorageConfig
 	InternalAuthZ     internal_authz.Config
 	SystemDefaults    systemdefaults.SystemDefaults
 	EncryptionKeys    *encryptionKeyConfig
+	KMS               *kms.Config
 	DefaultInstance   command.InstanceSetup
 	AuditLogRetention time.Duration
 	SystemAPIUsers    map[string]*internal_authz.SystemAPIUser
 	CustomerPortal    string
 	Machine           *id.Config
 	Actions           *actions.Config
+	EventActions      *eventaction.Config
+	Webhooks          *webhook.Config
+	EventExporters    []*exporter.Config
+	SCIM              *scim.Config
 	Eventstore        *eventstore.Config
 	LogStore          *logstore.Configs
 	Quotas            *QuotasConfig
@@ -83,6 +96,7 @@ func MustNewConfig(v *viper.Viper) *Config {
 			mapstructure.StringToSliceHookFunc(","),
 			database.DecodeHook,
 			actions.HTTPConfigDecodeHook,
+			cache_config.DecodeHook,
 		)),
 	)
 	logging.OnError(err).Fatal("unable to read config")
@@ -90,6 +104,8 @@ func MustNewConfig(v *viper.Viper) *Config {
 	err = config.Log.SetLogger()
 	logging.OnError(err).Fatal("unable to set logger")
 
+	config.SystemDefaults.MapDeprecated()
+
 	err = config.Tracing.NewTracer()
 	logging.OnError(err).Fatal("unable to set tracer")
 
@@ -111,6 +127,8 @@ type encryptionKeyConfig struct {
 	SMS                  *crypto.KeyConfig
 	SMTP                 *crypto.KeyConfig
 	User                 *crypto.KeyConfig
+	Webhook              *crypto.KeyConfig
+	Action               *crypto.KeyConfig
 	CSRFCookieKeyID      string
 	UserAgentCookieKeyID string
 }
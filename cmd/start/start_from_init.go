@@ -26,12 +26,12 @@ Requirements:
 			err := tls.ModeFromFlag(cmd)
 			logging.OnError(err).Fatal("invalid tlsMode")
 
-			masterKey, err := key.MasterKey(cmd)
-			logging.OnError(err).Panic("No master key provided")
-
 			initialise.InitAll(initialise.MustNewConfig(viper.GetViper()))
 
 			setupConfig := setup.MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKeyUnlessKMS(cmd, setupConfig.KMS)
+			logging.OnError(err).Panic("No master key provided")
+
 			setupSteps := setup.MustNewSteps(viper.New())
 			setup.Setup(setupConfig, setupSteps, masterKey)
 
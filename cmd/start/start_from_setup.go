@@ -26,10 +26,10 @@ Requirements:
 			err := tls.ModeFromFlag(cmd)
 			logging.OnError(err).Fatal("invalid tlsMode")
 
-			masterKey, err := key.MasterKey(cmd)
+			setupConfig := setup.MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKeyUnlessKMS(cmd, setupConfig.KMS)
 			logging.OnError(err).Panic("No master key provided")
 
-			setupConfig := setup.MustNewConfig(viper.GetViper())
 			setupSteps := setup.MustNewSteps(viper.New())
 			setup.Setup(setupConfig, setupSteps, masterKey)
 
@@ -116,6 +116,7 @@ export class PasswordComplexityPolicyComponent implements OnInit {
                 this.complexityData.hasUppercase,
                 this.complexityData.hasNumber,
                 this.complexityData.hasSymbol,
+                this.complexityData.checkBreached,
                 this.complexityData.minLength,
               )
               .then(() => {
@@ -131,6 +132,7 @@ export class PasswordComplexityPolicyComponent implements OnInit {
                 this.complexityData.hasUppercase,
                 this.complexityData.hasNumber,
                 this.complexityData.hasSymbol,
+                this.complexityData.checkBreached,
                 this.complexityData.minLength,
               )
               .then(() => {
@@ -148,6 +150,7 @@ export class PasswordComplexityPolicyComponent implements OnInit {
               this.complexityData.hasUppercase,
               this.complexityData.hasNumber,
               this.complexityData.hasSymbol,
+              this.complexityData.checkBreached,
               this.complexityData.minLength,
             )
             .then(() => {
@@ -1,6 +1,7 @@
 import { Component, Input, OnInit } from '@angular/core';
 import { UntypedFormControl } from '@angular/forms';
 import { MatCheckboxChange } from '@angular/material/checkbox';
+import { Duration } from 'google-protobuf/google/protobuf/duration_pb';
 import { SetDefaultLanguageResponse, SetSecurityPolicyRequest } from 'src/app/proto/generated/zitadel/admin_pb';
 import { AdminService } from 'src/app/services/admin.service';
 import { ToastService } from 'src/app/services/toast.service';
@@ -14,6 +15,11 @@ import { InfoSectionType } from '../../info-section/info-section.component';
 export class SecurityPolicyComponent implements OnInit {
   public originsList: string[] = [];
   public enabled: boolean = false;
+  public tokenExchangeClientIdsList: string[] = [];
+  public impersonationClientIdsList: string[] = [];
+  public loginRateLimitMaxAttemptsPerIp: number = 0;
+  public loginRateLimitMaxAttemptsPerLoginName: number = 0;
+  public loginRateLimitWindowSeconds: number = 0;
 
   public loading: boolean = false;
   public InfoSectionType: any = InfoSectionType;
@@ -31,6 +37,11 @@ export class SecurityPolicyComponent implements OnInit {
       if (securityPolicy.policy) {
         this.enabled = securityPolicy.policy?.enableIframeEmbedding;
         this.originsList = securityPolicy.policy?.allowedOriginsList;
+        this.tokenExchangeClientIdsList = securityPolicy.policy.tokenExchangeClientIdsList;
+        this.impersonationClientIdsList = securityPolicy.policy.impersonationClientIdsList;
+        this.loginRateLimitMaxAttemptsPerIp = securityPolicy.policy.loginRateLimitMaxAttemptsPerIp;
+        this.loginRateLimitMaxAttemptsPerLoginName = securityPolicy.policy.loginRateLimitMaxAttemptsPerLoginName;
+        this.loginRateLimitWindowSeconds = securityPolicy.policy.loginRateLimitWindow?.seconds ?? 0;
         if (securityPolicy.policy.enableIframeEmbedding) {
           this.originsControl.enable();
         } else {
@@ -44,6 +55,11 @@ export class SecurityPolicyComponent implements OnInit {
     const req = new SetSecurityPolicyRequest();
     req.setAllowedOriginsList(this.originsList);
     req.setEnableIframeEmbedding(this.enabled);
+    req.setTokenExchangeClientIdsList(this.tokenExchangeClientIdsList);
+    req.setImpersonationClientIdsList(this.impersonationClientIdsList);
+    req.setLoginRateLimitMaxAttemptsPerIp(this.loginRateLimitMaxAttemptsPerIp);
+    req.setLoginRateLimitMaxAttemptsPerLoginName(this.loginRateLimitMaxAttemptsPerLoginName);
+    req.setLoginRateLimitWindow(new Duration().setSeconds(this.loginRateLimitWindowSeconds));
     return (this.service as AdminService).setSecurityPolicy(req);
   }
 
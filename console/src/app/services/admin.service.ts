@@ -594,6 +594,7 @@ export class AdminService {
     hasUpperCase: boolean,
     hasNumber: boolean,
     hasSymbol: boolean,
+    checkBreached: boolean,
     minLength: number,
   ): Promise<UpdatePasswordComplexityPolicyResponse.AsObject> {
     const req = new UpdatePasswordComplexityPolicyRequest();
@@ -601,6 +602,7 @@ export class AdminService {
     req.setHasUppercase(hasUpperCase);
     req.setHasNumber(hasNumber);
     req.setHasSymbol(hasSymbol);
+    req.setCheckBreached(checkBreached);
     req.setMinLength(minLength);
     return this.grpcService.admin.updatePasswordComplexityPolicy(req, null).then((resp) => resp.toObject());
   }
@@ -616,10 +618,12 @@ export class AdminService {
   public updatePasswordAgePolicy(
     maxAgeDays: number,
     expireWarnDays: number,
+    historyCount: number,
   ): Promise<UpdatePasswordAgePolicyResponse.AsObject> {
     const req = new UpdatePasswordAgePolicyRequest();
     req.setMaxAgeDays(maxAgeDays);
     req.setExpireWarnDays(expireWarnDays);
+    req.setHistoryCount(historyCount);
 
     return this.grpcService.admin.updatePasswordAgePolicy(req, null).then((resp) => resp.toObject());
   }
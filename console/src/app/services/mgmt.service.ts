@@ -1408,10 +1408,12 @@ export class ManagementService {
   public addCustomPasswordAgePolicy(
     maxAgeDays: number,
     expireWarnDays: number,
+    historyCount: number,
   ): Promise<AddCustomPasswordAgePolicyResponse.AsObject> {
     const req = new AddCustomPasswordAgePolicyRequest();
     req.setMaxAgeDays(maxAgeDays);
     req.setExpireWarnDays(expireWarnDays);
+    req.setHistoryCount(historyCount);
 
     return this.grpcService.mgmt.addCustomPasswordAgePolicy(req, null).then((resp) => resp.toObject());
   }
@@ -1424,10 +1426,12 @@ export class ManagementService {
   public updateCustomPasswordAgePolicy(
     maxAgeDays: number,
     expireWarnDays: number,
+    historyCount: number,
   ): Promise<UpdateCustomPasswordAgePolicyResponse.AsObject> {
     const req = new UpdateCustomPasswordAgePolicyRequest();
     req.setMaxAgeDays(maxAgeDays);
     req.setExpireWarnDays(expireWarnDays);
+    req.setHistoryCount(historyCount);
     return this.grpcService.mgmt.updateCustomPasswordAgePolicy(req, null).then((resp) => resp.toObject());
   }
 
@@ -1441,6 +1445,7 @@ export class ManagementService {
     hasUpperCase: boolean,
     hasNumber: boolean,
     hasSymbol: boolean,
+    checkBreached: boolean,
     minLength: number,
   ): Promise<AddCustomPasswordComplexityPolicyResponse.AsObject> {
     const req = new AddCustomPasswordComplexityPolicyRequest();
@@ -1448,6 +1453,7 @@ export class ManagementService {
     req.setHasUppercase(hasUpperCase);
     req.setHasNumber(hasNumber);
     req.setHasSymbol(hasSymbol);
+    req.setCheckBreached(checkBreached);
     req.setMinLength(minLength);
     return this.grpcService.mgmt.addCustomPasswordComplexityPolicy(req, null).then((resp) => resp.toObject());
   }
@@ -1462,6 +1468,7 @@ export class ManagementService {
     hasUpperCase: boolean,
     hasNumber: boolean,
     hasSymbol: boolean,
+    checkBreached: boolean,
     minLength: number,
   ): Promise<UpdateCustomPasswordComplexityPolicyResponse.AsObject> {
     const req = new UpdateCustomPasswordComplexityPolicyRequest();
@@ -1469,6 +1476,7 @@ export class ManagementService {
     req.setHasUppercase(hasUpperCase);
     req.setHasNumber(hasNumber);
     req.setHasSymbol(hasSymbol);
+    req.setCheckBreached(checkBreached);
     req.setMinLength(minLength);
     return this.grpcService.mgmt.updateCustomPasswordComplexityPolicy(req, null).then((resp) => resp.toObject());
   }
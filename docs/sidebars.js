@@ -364,6 +364,11 @@ module.exports = {
         "apis/actions/internal-authentication",
         "apis/actions/external-authentication",
         "apis/actions/complement-token",
+        "apis/actions/customise-saml-response",
+        "apis/actions/pre-registration",
+        "apis/actions/post-password-change",
+        "apis/actions/event-triggered",
+        "apis/actions/dry-run",
         "apis/actions/objects",
       ]
     },
@@ -414,7 +419,8 @@ module.exports = {
         "self-hosting/manage/tls_modes",
         "self-hosting/manage/database/database",
         "self-hosting/manage/updating_scaling",
-        "self-hosting/manage/quotas"
+        "self-hosting/manage/quotas",
+        "self-hosting/manage/event-exporter"
       ],
     },
   ],
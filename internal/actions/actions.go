@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/sirupsen/logrus"
 
@@ -18,7 +19,7 @@ type Config struct {
 
 var ErrHalt = errors.New("interrupt")
 
-type jsAction func(fields, fields) error
+type jsAction func(ctx, api goja.Value) error
 
 const (
 	actionStartedMessage   = "action run started"
@@ -30,7 +31,7 @@ func actionFailedMessage(err error) string {
 }
 
 func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script, name string, opts ...Option) (err error) {
-	config := newRunConfig(ctx, append(opts, withLogger(ctx))...)
+	config := newRunConfig(ctx, append(opts, withLogger(ctx), withOrgModules(ctx))...)
 	if config.functionTimeout == 0 {
 		return z_errs.ThrowInternal(nil, "ACTIO-uCpCx", "Errrors.Internal")
 	}
@@ -126,14 +127,19 @@ func executeFn(config *runConfig, fn jsAction) (err error) {
 		err = fmt.Errorf("unknown error occurred: %v", r)
 	}()
 
-	if err = fn(config.ctxParam.fields, config.apiParam.fields); err != nil {
+	api := config.vm.ToValue(config.apiParam.fields)
+	if config.dryRun != nil {
+		api = config.dryRun.api(config.vm)
+	}
+	if err = fn(config.vm.ToValue(config.ctxParam.fields), api); err != nil {
 		return err
 	}
 	return nil
 }
 
 func ActionToOptions(a *query.Action) []Option {
-	opts := make([]Option, 0, 1)
+	opts := make([]Option, 0, 3)
+	opts = append(opts, withActionID(a.ID), withResourceOwner(a.ResourceOwner))
 	if a.AllowedToFail {
 		opts = append(opts, WithAllowedToFail())
 	}
@@ -21,16 +21,66 @@ func WithAllowedToFail() Option {
 	}
 }
 
+// WithLogMetadata adds the metadata to the execution logs of the run
+func WithLogMetadata(metadata map[string]interface{}) Option {
+	return func(c *runConfig) {
+		c.logMetadata = metadata
+	}
+}
+
+func withActionID(id string) Option {
+	return func(c *runConfig) {
+		c.actionID = id
+	}
+}
+
+func withResourceOwner(resourceOwner string) Option {
+	return func(c *runConfig) {
+		c.resourceOwner = resourceOwner
+	}
+}
+
+// withOrgModules registers the modules accessing the data of the organisation owning the action,
+// they are only available if the action is known and the services are set.
+// Dry runs only get the libraries as the other modules have side effects or reveal secrets
+func withOrgModules(ctx context.Context) Option {
+	return func(c *runConfig) {
+		if c.resourceOwner == "" {
+			return
+		}
+		if libraryQuery != nil {
+			withLibraries(ctx, c)
+		}
+		if c.dryRun != nil {
+			return
+		}
+		if secretsQuery != nil && secretsDecryptor != nil {
+			c.modules["zitadel/secrets"] = func(runtime *goja.Runtime, module *goja.Object) {
+				requireSecrets(ctx, c.resourceOwner, runtime, module)
+			}
+		}
+		if storageQuery != nil && storageCommand != nil {
+			c.modules["zitadel/storage"] = func(runtime *goja.Runtime, module *goja.Object) {
+				requireStorage(ctx, c.resourceOwner, runtime, module)
+			}
+		}
+	}
+}
+
 type runConfig struct {
 	allowedToFail bool
 	functionTimeout,
 	scriptTimeout time.Duration
-	modules    map[string]require.ModuleLoader
-	logger     *logger
-	instanceID string
-	vm         *goja.Runtime
-	ctxParam   *ctxConfig
-	apiParam   *apiConfig
+	modules       map[string]require.ModuleLoader
+	logger        *logger
+	instanceID    string
+	actionID      string
+	resourceOwner string
+	logMetadata   map[string]interface{}
+	dryRun        *DryRun
+	vm            *goja.Runtime
+	ctxParam      *ctxConfig
+	apiParam      *apiConfig
 }
 
 func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
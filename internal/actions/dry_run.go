@@ -0,0 +1,188 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dop251/goja"
+	"github.com/sirupsen/logrus"
+)
+
+const dryRunDefaultResponseBody = "{}"
+
+// DryRun executes an action in a sandbox:
+// calls on the api object and http requests are recorded instead of performed
+// and the console logs are captured
+type DryRun struct {
+	// ResourceOwner is the organisation whose libraries can be required
+	ResourceOwner string
+	// HTTPResponses are returned for matching http requests
+	// requests without a matching response are answered with status 200 and an empty json object
+	HTTPResponses []*DryRunHTTPResponse
+
+	APICalls     []*DryRunAPICall
+	HTTPRequests []*DryRunHTTPRequest
+	Logs         []*DryRunLog
+}
+
+type DryRunHTTPResponse struct {
+	// Method is matched case insensitive, empty matches all methods
+	Method string
+	URL    string
+	Status int
+	Body   string
+}
+
+type DryRunAPICall struct {
+	// Function is the path of the called function (e.g. v1.claims.setClaim)
+	Function string
+	Args     []interface{}
+}
+
+type DryRunHTTPRequest struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    string
+}
+
+type DryRunLog struct {
+	Level   logrus.Level
+	Message string
+	LogDate time.Time
+}
+
+// WithDryRun executes the action in the sandbox of the dry run
+// the api fields passed to Run are ignored
+func WithDryRun(ctx context.Context, dryRun *DryRun) Option {
+	return func(c *runConfig) {
+		c.dryRun = dryRun
+		c.resourceOwner = dryRun.ResourceOwner
+		c.modules["zitadel/http"] = func(runtime *goja.Runtime, module *goja.Object) {
+			requireHTTP(ctx, &http.Client{Transport: &dryRunTransport{dryRun: dryRun}}, runtime, module)
+		}
+	}
+}
+
+// DryRunContextFields sets the mocked context of a dry run
+// fields prefixed with `get` (e.g. getUser) are provided as functions returning the mocked value
+func DryRunContextFields(mock map[string]interface{}) contextFields {
+	return func(p *ctxConfig) {
+		if p.fields == nil {
+			p.fields = fields{}
+		}
+		for key, value := range mock {
+			p.fields[key] = dryRunContextValue(key, value)
+		}
+	}
+}
+
+func dryRunContextValue(key string, value interface{}) interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		converted := make(map[string]interface{}, len(object))
+		for k, v := range object {
+			converted[k] = dryRunContextValue(k, v)
+		}
+		value = converted
+	}
+	if !isGetter(key) {
+		return value
+	}
+	return func() interface{} {
+		return value
+	}
+}
+
+func isGetter(key string) bool {
+	name := strings.TrimPrefix(key, "get")
+	return len(name) > 0 && len(name) < len(key) && unicode.IsUpper(rune(name[0]))
+}
+
+func (d *DryRun) log(msg string, level logrus.Level, ts time.Time) {
+	d.Logs = append(d.Logs, &DryRunLog{
+		Level:   level,
+		Message: msg,
+		LogDate: ts,
+	})
+}
+
+// api returns an object which records the calls on itself and its properties
+func (d *DryRun) api(runtime *goja.Runtime) goja.Value {
+	return d.recorder(runtime, "")
+}
+
+func (d *DryRun) recorder(runtime *goja.Runtime, path string) goja.Value {
+	properties := make(map[string]goja.Value)
+	target := runtime.ToValue(func(goja.FunctionCall) goja.Value { return goja.Undefined() }).ToObject(runtime)
+	proxy := runtime.NewProxy(target, &goja.ProxyTrapConfig{
+		Get: func(_ *goja.Object, property string, _ goja.Value) goja.Value {
+			if value, ok := properties[property]; ok {
+				return value
+			}
+			propertyPath := property
+			if path != "" {
+				propertyPath = path + "." + property
+			}
+			properties[property] = d.recorder(runtime, propertyPath)
+			return properties[property]
+		},
+		Apply: func(_ *goja.Object, _ goja.Value, arguments []goja.Value) goja.Value {
+			args := make([]interface{}, len(arguments))
+			for i, argument := range arguments {
+				args[i] = argument.Export()
+			}
+			d.APICalls = append(d.APICalls, &DryRunAPICall{
+				Function: path,
+				Args:     args,
+			})
+			return goja.Undefined()
+		},
+	})
+	return runtime.ToValue(proxy)
+}
+
+func (d *DryRun) response(req *http.Request) *DryRunHTTPResponse {
+	for _, response := range d.HTTPResponses {
+		if (response.Method == "" || strings.EqualFold(response.Method, req.Method)) && response.URL == req.URL.String() {
+			return response
+		}
+	}
+	return &DryRunHTTPResponse{
+		Status: http.StatusOK,
+		Body:   dryRunDefaultResponseBody,
+	}
+}
+
+type dryRunTransport struct {
+	dryRun *DryRun
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+	t.dryRun.HTTPRequests = append(t.dryRun.HTTPRequests, &DryRunHTTPRequest{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: req.Header.Clone(),
+		Body:    string(body),
+	})
+
+	response := t.dryRun.response(req)
+	return &http.Response{
+		Status:     http.StatusText(response.Status),
+		StatusCode: response.Status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString(response.Body)),
+		Request:    req,
+	}, nil
+}
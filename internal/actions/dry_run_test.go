@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/zitadel/zitadel/internal/logstore"
+)
+
+func TestRun_DryRun(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	type args struct {
+		ctx           map[string]interface{}
+		script        string
+		httpResponses []*DryRunHTTPResponse
+	}
+	type want struct {
+		err          bool
+		apiCalls     []*DryRunAPICall
+		httpRequests []*DryRunHTTPRequest
+		logs         []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "api calls recorded",
+			args: args{
+				ctx: map[string]interface{}{
+					"v1": map[string]interface{}{
+						"getUser": map[string]interface{}{
+							"id": "user1",
+						},
+						"claims": map[string]interface{}{
+							"aud": "app",
+						},
+					},
+				},
+				script: `
+function testFunc(ctx, api) {
+	api.v1.claims.setClaim("user", ctx.v1.getUser().id);
+	api.v1.claims.setClaim("aud", ctx.v1.claims.aud);
+	api.setFirstName("Gigi");
+}`,
+			},
+			want: want{
+				apiCalls: []*DryRunAPICall{
+					{Function: "v1.claims.setClaim", Args: []interface{}{"user", "user1"}},
+					{Function: "v1.claims.setClaim", Args: []interface{}{"aud", "app"}},
+					{Function: "setFirstName", Args: []interface{}{"Gigi"}},
+				},
+				logs: []string{actionStartedMessage, actionSucceededMessage},
+			},
+		},
+		{
+			name: "http requests recorded",
+			args: args{
+				script: `
+let http = require("zitadel/http");
+let logger = require("zitadel/log");
+function testFunc(ctx, api) {
+	let res = http.fetch("https://zitadel.com/hodor", {method: "POST", body: {name: "hodor"}});
+	logger.log(res.status + " " + res.json().hodor);
+	http.fetch("https://zitadel.com/default");
+}`,
+				httpResponses: []*DryRunHTTPResponse{
+					{Method: "post", URL: "https://zitadel.com/hodor", Status: http.StatusCreated, Body: `{"hodor":"hodor"}`},
+				},
+			},
+			want: want{
+				httpRequests: []*DryRunHTTPRequest{
+					{Method: http.MethodPost, URL: "https://zitadel.com/hodor", Headers: defaultFetchConfig.Headers, Body: `{"name":"hodor"}`},
+					{Method: http.MethodGet, URL: "https://zitadel.com/default", Headers: defaultFetchConfig.Headers, Body: ""},
+				},
+				logs: []string{actionStartedMessage, "201 hodor", actionSucceededMessage},
+			},
+		},
+		{
+			name: "error logged",
+			args: args{
+				script: `function testFunc(ctx, api) { throw "some error" }`,
+			},
+			want: want{
+				err:  true,
+				logs: []string{actionStartedMessage, "action run failed: some error at testFunc (<eval>:1:31(2))"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			dryRun := &DryRun{HTTPResponses: tt.args.httpResponses}
+			err := Run(ctx, DryRunContextFields(tt.args.ctx), nil, tt.args.script, "testFunc", WithDryRun(ctx, dryRun))
+			if (err != nil) != tt.want.err {
+				t.Fatalf("Run() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(dryRun.APICalls, tt.want.apiCalls) {
+				t.Errorf("api calls = %v, want %v", dryRun.APICalls, tt.want.apiCalls)
+			}
+			if !reflect.DeepEqual(dryRun.HTTPRequests, tt.want.httpRequests) {
+				t.Errorf("http requests = %v, want %v", dryRun.HTTPRequests, tt.want.httpRequests)
+			}
+			logs := make([]string, len(dryRun.Logs))
+			for i, log := range dryRun.Logs {
+				logs[i] = log.Message
+				if log.Level != logrus.InfoLevel && i != len(dryRun.Logs)-1 {
+					t.Errorf("unexpected log level %v", log.Level)
+				}
+			}
+			if !reflect.DeepEqual(logs, tt.want.logs) {
+				t.Errorf("logs = %v, want %v", logs, tt.want.logs)
+			}
+		})
+	}
+}
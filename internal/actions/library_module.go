@@ -0,0 +1,241 @@
+package actions
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/require"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type libraryQueries interface {
+	SearchActionLibraries(ctx context.Context, orgID string, withOwnerRemoved bool) ([]*query.ActionLibrary, error)
+	GetActionLibrary(ctx context.Context, name string, version uint64, orgID string) (*query.ActionLibrary, error)
+	ActionLibrariesSequence(ctx context.Context) (*query.LatestSequence, error)
+}
+
+var (
+	libraryQuery libraryQueries
+	libraries    = newLibraryCache()
+)
+
+// SetLibraryService enables the libraries of the organisations,
+// they are required with the `lib/` prefix
+func SetLibraryService(queries libraryQueries) {
+	libraryQuery = queries
+}
+
+type libraryVersion struct {
+	name    string
+	version uint64
+}
+
+func (v libraryVersion) String() string {
+	return v.name + "@" + strconv.FormatUint(v.version, 10)
+}
+
+// withLibraries registers a module for each version of the libraries of the organisation
+// and for the latest version without version suffix
+func withLibraries(ctx context.Context, c *runConfig) {
+	orgKey := authz.GetInstance(ctx).InstanceID() + "/" + c.resourceOwner
+	list, err := libraries.list(ctx, orgKey, c.resourceOwner)
+	if err != nil {
+		logging.WithError(err).Warn("unable to load action libraries")
+		return
+	}
+	if len(list) == 0 {
+		return
+	}
+	loader := &libraryLoader{
+		ctx:     ctx,
+		orgKey:  orgKey,
+		orgID:   c.resourceOwner,
+		latest:  make(map[string]uint64, len(list)),
+		loading: make(map[libraryVersion]bool),
+		exports: make(map[libraryVersion]goja.Value),
+	}
+
+	// the list is ordered by version
+	for _, library := range list {
+		loader.latest[library.Name] = library.Version
+		c.modules[domain.ActionLibraryModulePrefix+libraryVersion{library.Name, library.Version}.String()] = loader.load(library)
+	}
+	for _, library := range list {
+		if loader.latest[library.Name] == library.Version {
+			c.modules[domain.ActionLibraryModulePrefix+library.Name] = loader.load(library)
+		}
+	}
+}
+
+// libraryLoader loads the libraries of a single run
+type libraryLoader struct {
+	ctx    context.Context
+	orgKey string
+	orgID  string
+	latest map[string]uint64
+	// loading contains the libraries which are currently executed to detect cycles
+	loading map[libraryVersion]bool
+	// exports of the executed libraries, so a version is only executed once per run
+	// even if it's required with and without version
+	exports map[libraryVersion]goja.Value
+}
+
+func (l *libraryLoader) load(library *query.ActionLibrary) require.ModuleLoader {
+	key := libraryVersion{library.Name, library.Version}
+	return func(runtime *goja.Runtime, module *goja.Object) {
+		if exports, ok := l.exports[key]; ok {
+			logging.OnError(module.Set("exports", exports)).Warn("unable to set module")
+			return
+		}
+		if l.loading[key] {
+			panic(runtime.NewGoError(z_errs.ThrowPreconditionFailedf(nil, "ACTIO-Wm4ze", "dependency cycle: library %s is required while it is loaded", key)))
+		}
+		program, err := libraries.program(l.ctx, l.orgKey, l.orgID, library)
+		if err != nil {
+			panic(runtime.NewGoError(err))
+		}
+		wrapper, err := runtime.RunProgram(program)
+		if err != nil {
+			panic(err)
+		}
+		fn, ok := goja.AssertFunction(wrapper)
+		if !ok {
+			panic(runtime.NewGoError(z_errs.ThrowInternal(nil, "ACTIO-Ux8bj", "Errors.Internal")))
+		}
+
+		l.loading[key] = true
+		defer delete(l.loading, key)
+		if _, err = fn(module, module.Get("exports"), runtime.ToValue(l.require(runtime)), module); err != nil {
+			panic(err)
+		}
+		l.exports[key] = module.Get("exports")
+	}
+}
+
+// require is passed to the libraries,
+// it fails if a library is required which is currently executed
+func (l *libraryLoader) require(runtime *goja.Runtime) func(goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		name := call.Argument(0).String()
+		if strings.HasPrefix(name, domain.ActionLibraryModulePrefix) {
+			libName, version, ok := domain.ParseActionLibraryDependency(strings.TrimPrefix(name, domain.ActionLibraryModulePrefix))
+			if !ok {
+				panic(runtime.NewGoError(z_errs.ThrowInvalidArgumentf(nil, "ACTIO-Tq2vd", "invalid library dependency %s", name)))
+			}
+			if version == 0 {
+				version = l.latest[libName]
+			}
+			if key := (libraryVersion{libName, version}); l.loading[key] {
+				panic(runtime.NewGoError(z_errs.ThrowPreconditionFailedf(nil, "ACTIO-Hn6qa", "dependency cycle: library %s is required while it is loaded", key)))
+			}
+		}
+		return require.Require(runtime, name)
+	}
+}
+
+type compiledLibrary struct {
+	sequence uint64
+	program  *goja.Program
+}
+
+type libraryList struct {
+	sequence  uint64
+	libraries []*query.ActionLibrary
+}
+
+// libraryCache contains the libraries and their compiled versions per instance and organisation
+type libraryCache struct {
+	mu    sync.Mutex
+	lists map[string]*libraryList
+	orgs  map[string]map[libraryVersion]*compiledLibrary
+}
+
+func newLibraryCache() *libraryCache {
+	return &libraryCache{
+		lists: make(map[string]*libraryList),
+		orgs:  make(map[string]map[libraryVersion]*compiledLibrary),
+	}
+}
+
+// list returns the libraries of the organisation,
+// they are only searched again if the sequence of the libraries of the instance changed since they were cached
+func (c *libraryCache) list(ctx context.Context, orgKey, orgID string) ([]*query.ActionLibrary, error) {
+	sequence, err := libraryQuery.ActionLibrariesSequence(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	cached, ok := c.lists[orgKey]
+	c.mu.Unlock()
+	if ok && cached.sequence == sequence.Sequence {
+		return cached.libraries, nil
+	}
+
+	list, err := libraryQuery.SearchActionLibraries(ctx, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lists[orgKey] = &libraryList{sequence: sequence.Sequence, libraries: list}
+	c.invalidate(orgKey, list)
+	return list, nil
+}
+
+// invalidate removes the compiled versions which are removed or changed since they were compiled,
+// the caller must hold the lock
+func (c *libraryCache) invalidate(orgKey string, list []*query.ActionLibrary) {
+	compiled, ok := c.orgs[orgKey]
+	if !ok {
+		return
+	}
+	current := make(map[libraryVersion]uint64, len(list))
+	for _, library := range list {
+		current[libraryVersion{library.Name, library.Version}] = library.Sequence
+	}
+	for key, library := range compiled {
+		if sequence, ok := current[key]; !ok || sequence != library.sequence {
+			delete(compiled, key)
+		}
+	}
+	if len(compiled) == 0 {
+		delete(c.orgs, orgKey)
+	}
+}
+
+func (c *libraryCache) program(ctx context.Context, orgKey, orgID string, library *query.ActionLibrary) (*goja.Program, error) {
+	key := libraryVersion{library.Name, library.Version}
+	c.mu.Lock()
+	compiled, ok := c.orgs[orgKey][key]
+	c.mu.Unlock()
+	if ok && compiled.sequence == library.Sequence {
+		return compiled.program, nil
+	}
+
+	withScript, err := libraryQuery.GetActionLibrary(ctx, library.Name, library.Version, orgID)
+	if err != nil {
+		return nil, err
+	}
+	// wrapped like a CommonJS module
+	program, err := goja.Compile(domain.ActionLibraryModulePrefix+key.String(), "(function(exports, require, module) {"+withScript.Script+"\n})", false)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.orgs[orgKey]; !ok {
+		c.orgs[orgKey] = make(map[libraryVersion]*compiledLibrary)
+	}
+	c.orgs[orgKey][key] = &compiledLibrary{sequence: withScript.Sequence, program: program}
+	return program, nil
+}
@@ -0,0 +1,196 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockLibraries struct {
+	// libraries ordered by name and version
+	libraries []*query.ActionLibrary
+	// sequence of the libraries of the instance
+	sequence uint64
+	// searched counts the lists queried
+	searched int
+	// loaded counts the scripts queried
+	loaded int
+}
+
+func (m *mockLibraries) ActionLibrariesSequence(context.Context) (*query.LatestSequence, error) {
+	return &query.LatestSequence{Sequence: m.sequence}, nil
+}
+
+func (m *mockLibraries) SearchActionLibraries(_ context.Context, orgID string, _ bool) ([]*query.ActionLibrary, error) {
+	m.searched++
+	list := make([]*query.ActionLibrary, 0, len(m.libraries))
+	for _, library := range m.libraries {
+		if library.ResourceOwner != orgID {
+			continue
+		}
+		list = append(list, &query.ActionLibrary{
+			Name:          library.Name,
+			Version:       library.Version,
+			ResourceOwner: library.ResourceOwner,
+			Sequence:      library.Sequence,
+		})
+	}
+	return list, nil
+}
+
+func (m *mockLibraries) GetActionLibrary(_ context.Context, name string, version uint64, orgID string) (*query.ActionLibrary, error) {
+	var found *query.ActionLibrary
+	for _, library := range m.libraries {
+		if library.Name == name && library.ResourceOwner == orgID && (version == 0 || library.Version == version) {
+			found = library
+		}
+	}
+	if found == nil {
+		return nil, z_errs.ThrowNotFound(nil, "id", "not found")
+	}
+	m.loaded++
+	return found, nil
+}
+
+func Test_libraryModule(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	tests := []struct {
+		name      string
+		libraries []*query.ActionLibrary
+		script    string
+		wantErr   bool
+	}{
+		{
+			name: "latest and pinned version",
+			libraries: []*query.ActionLibrary{
+				{Name: "helpers", Version: 1, ResourceOwner: "org1", Sequence: 1, Script: "module.exports = { version: 1 }"},
+				{Name: "helpers", Version: 2, ResourceOwner: "org1", Sequence: 2, Script: "exports.version = 2"},
+			},
+			script: `function test() {
+				if (require('lib/helpers').version !== 2) {
+					throw 'latest version expected'
+				}
+				if (require('lib/helpers@1').version !== 1) {
+					throw 'pinned version expected'
+				}
+			}`,
+		},
+		{
+			name: "version executed once",
+			libraries: []*query.ActionLibrary{
+				{Name: "helpers", Version: 1, ResourceOwner: "org1", Sequence: 1, Script: "module.exports = {}"},
+			},
+			script: `function test() {
+				if (require('lib/helpers') !== require('lib/helpers@1')) {
+					throw 'same exports expected'
+				}
+			}`,
+		},
+		{
+			name: "dependency",
+			libraries: []*query.ActionLibrary{
+				{Name: "crm", Version: 1, ResourceOwner: "org1", Sequence: 2, Script: "let helpers = require('lib/helpers'); exports.userId = (user) => helpers.prefix + user.id"},
+				{Name: "helpers", Version: 1, ResourceOwner: "org1", Sequence: 1, Script: "exports.prefix = 'crm-'"},
+			},
+			script: `function test() {
+				if (require('lib/crm').userId({id: '42'}) !== 'crm-42') {
+					throw 'wrong id'
+				}
+			}`,
+		},
+		{
+			name: "dependency cycle",
+			libraries: []*query.ActionLibrary{
+				{Name: "a", Version: 1, ResourceOwner: "org1", Sequence: 1, Script: "require('lib/b')"},
+				{Name: "b", Version: 1, ResourceOwner: "org1", Sequence: 2, Script: "require('lib/a@1')"},
+			},
+			script: `function test() {
+				require('lib/a')
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "invalid dependency",
+			libraries: []*query.ActionLibrary{
+				{Name: "helpers", Version: 1, ResourceOwner: "org1", Sequence: 1, Script: "module.exports = {}"},
+			},
+			script: `function test() {
+				require('lib/helpers@latest')
+			}`,
+			wantErr: true,
+		},
+		{
+			name: "library of other organisation",
+			libraries: []*query.ActionLibrary{
+				{Name: "helpers", Version: 1, ResourceOwner: "org2", Sequence: 1, Script: "module.exports = {}"},
+			},
+			script: `function test() {
+				require('lib/helpers')
+			}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			libraries = newLibraryCache()
+			SetLibraryService(&mockLibraries{libraries: tt.libraries})
+			defer SetLibraryService(nil)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := Run(ctx, nil, nil, tt.script, "test", ActionToOptions(&query.Action{ID: "action1", ResourceOwner: "org1"})...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_libraryModule_invalidation(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	libraries = newLibraryCache()
+	mock := &mockLibraries{
+		libraries: []*query.ActionLibrary{
+			{Name: "helpers", Version: 1, ResourceOwner: "org1", Sequence: 1, Script: "exports.value = 'compiled'"},
+		},
+		sequence: 1,
+	}
+	SetLibraryService(mock)
+	defer SetLibraryService(nil)
+
+	run := func(want string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return Run(ctx, SetContextFields(SetFields("want", want)), nil,
+			`function test(ctx) {
+				if (require('lib/helpers').value !== ctx.want) {
+					throw 'unexpected value'
+				}
+			}`,
+			"test",
+			ActionToOptions(&query.Action{ID: "action1", ResourceOwner: "org1"})...,
+		)
+	}
+
+	assert.NoError(t, run("compiled"))
+	assert.NoError(t, run("compiled"))
+	assert.Equal(t, 1, mock.searched, "listed libraries should be reused")
+	assert.Equal(t, 1, mock.loaded, "compiled library should be reused")
+
+	mock.libraries[0] = &query.ActionLibrary{Name: "helpers", Version: 1, ResourceOwner: "org1", Sequence: 2, Script: "exports.value = 'changed'"}
+	mock.sequence = 2
+	assert.NoError(t, run("changed"))
+	assert.Equal(t, 2, mock.searched, "libraries should be listed again")
+	assert.Equal(t, 2, mock.loaded, "changed library should be compiled again")
+
+	mock.libraries = nil
+	mock.sequence = 3
+	assert.Error(t, run("changed"))
+	assert.Empty(t, libraries.orgs, "removed library should be dropped")
+}
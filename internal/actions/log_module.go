@@ -26,6 +26,9 @@ type logger struct {
 	ctx        context.Context
 	started    time.Time
 	instanceID string
+	actionID   string
+	metadata   map[string]interface{}
+	dryRun     *DryRun
 }
 
 // newLogger returns a *logger instance that should only be used for a single action run.
@@ -59,6 +62,8 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 	record := &execution.Record{
 		LogDate:    ts,
 		InstanceID: l.instanceID,
+		ActionID:   l.actionID,
+		Metadata:   l.metadata,
 		Message:    msg,
 		LogLevel:   level,
 	}
@@ -67,6 +72,10 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 		record.Took = ts.Sub(l.started)
 	}
 
+	if l.dryRun != nil {
+		l.dryRun.log(msg, level, ts)
+	}
+
 	logstoreService.Handle(l.ctx, record)
 }
 
@@ -75,6 +84,9 @@ func withLogger(ctx context.Context) Option {
 	instanceID := instance.InstanceID()
 	return func(c *runConfig) {
 		c.logger = newLogger(ctx, instanceID)
+		c.logger.actionID = c.actionID
+		c.logger.metadata = c.logMetadata
+		c.logger.dryRun = c.dryRun
 		c.instanceID = instanceID
 		c.modules["zitadel/log"] = func(runtime *goja.Runtime, module *goja.Object) {
 			console.RequireWithPrinter(c.logger)(runtime, module)
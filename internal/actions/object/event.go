@@ -0,0 +1,42 @@
+package object
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/eventpayload"
+)
+
+// EventField provides the event which triggered the action,
+// the payload only contains the public fields of the event (see [eventpayload.Fields])
+func EventField(event eventstore.Event) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		e := &eventObject{
+			InstanceId:    event.Aggregate().InstanceID,
+			AggregateType: string(event.Aggregate().Type),
+			AggregateId:   event.Aggregate().ID,
+			ResourceOwner: event.Aggregate().ResourceOwner,
+			Type:          string(event.Type()),
+			Sequence:      event.Sequence(),
+			CreationDate:  event.CreationDate(),
+			EditorUser:    event.EditorUser(),
+			Version:       string(event.Aggregate().Version),
+			Payload:       eventpayload.Fields(event),
+		}
+		return c.Runtime.ToValue(e)
+	}
+}
+
+type eventObject struct {
+	InstanceId    string
+	AggregateType string
+	AggregateId   string
+	ResourceOwner string
+	Type          string
+	Sequence      uint64
+	CreationDate  time.Time
+	EditorUser    string
+	Version       string
+	Payload       map[string]interface{}
+}
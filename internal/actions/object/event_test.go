@@ -0,0 +1,37 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func TestEventField(t *testing.T) {
+	event := eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   "user-id",
+		AggregateType: "user",
+		Type:          "user.human.password.changed",
+		Sequence:      15,
+		InstanceID:    "instance-id",
+		Data:          []byte(`{"secret":{"cryptoType":1,"algorithm":"argon2id","crypted":"JGFyZ29uMmlk"},"changeRequired":false,"userAgentID":"agent"}`),
+	})
+	runtime := goja.New()
+
+	value := EventField(event)(&actions.FieldConfig{Runtime: runtime}).(goja.Value)
+	object := value.ToObject(runtime)
+
+	assert.Equal(t, "user.human.password.changed", object.Get("Type").Export())
+	assert.Equal(t, map[string]interface{}{"changeRequired": false}, object.Get("Payload").Export())
+
+	stringify, ok := goja.AssertFunction(runtime.Get("JSON").ToObject(runtime).Get("stringify"))
+	if assert.True(t, ok) {
+		json, err := stringify(goja.Undefined(), value)
+		assert.NoError(t, err)
+		assert.NotContains(t, json.String(), "secret")
+	}
+}
@@ -0,0 +1,64 @@
+package object
+
+import (
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// RegistrationForm contains the values of the registration form without the password,
+// the values can be changed by the actions of the pre registration flow
+type RegistrationForm struct {
+	Email             domain.EmailAddress
+	Username          string
+	FirstName         string
+	LastName          string
+	PreferredLanguage string
+
+	rejection string
+}
+
+// RegistrationFormField accepts the form by value, so it's not mutated
+func RegistrationFormField(form *RegistrationForm) func(c *actions.FieldConfig) interface{} {
+	return func(c *actions.FieldConfig) interface{} {
+		return c.Runtime.ToValue(&RegistrationForm{
+			Email:             form.Email,
+			Username:          form.Username,
+			FirstName:         form.FirstName,
+			LastName:          form.LastName,
+			PreferredLanguage: form.PreferredLanguage,
+		})
+	}
+}
+
+// RegistrationFormAPIFields provides the functions to change and to reject the form
+func RegistrationFormAPIFields(form *RegistrationForm) actions.FieldOption {
+	return actions.SetFields("form",
+		actions.SetFields("setEmail", func(email domain.EmailAddress) {
+			form.Email = email
+		}),
+		actions.SetFields("setUsername", func(username string) {
+			form.Username = username
+		}),
+		actions.SetFields("setFirstName", func(firstName string) {
+			form.FirstName = firstName
+		}),
+		actions.SetFields("setLastName", func(lastName string) {
+			form.LastName = lastName
+		}),
+		actions.SetFields("setPreferredLanguage", func(preferredLanguage string) {
+			form.PreferredLanguage = preferredLanguage
+		}),
+		actions.SetFields("reject", func(message string) {
+			form.rejection = message
+		}),
+	)
+}
+
+// Rejection returns an error containing the message of the action which rejected the form
+func (f *RegistrationForm) Rejection() error {
+	if f.rejection == "" {
+		return nil
+	}
+	return errors.ThrowInvalidArgument(nil, "ACTIO-Rw3ji", f.rejection)
+}
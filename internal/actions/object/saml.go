@@ -0,0 +1,26 @@
+package object
+
+import (
+	"github.com/zitadel/zitadel/internal/actions"
+)
+
+// SAMLAttributeSetter sets the attributes of the assertion of a SAML response
+type SAMLAttributeSetter interface {
+	SetEmail(string)
+	SetFullName(string)
+	SetGivenName(string)
+	SetSurname(string)
+	SetUsername(string)
+}
+
+// SAMLAttributesAPIFields provides the functions to overwrite or add attributes of the assertion,
+// the user id is not changeable as it's the subject of the assertion
+func SAMLAttributesAPIFields(attributes SAMLAttributeSetter) actions.FieldOption {
+	return actions.SetFields("attributes",
+		actions.SetFields("setEmail", attributes.SetEmail),
+		actions.SetFields("setFullName", attributes.SetFullName),
+		actions.SetFields("setGivenName", attributes.SetGivenName),
+		actions.SetFields("setSurname", attributes.SetSurname),
+		actions.SetFields("setUsername", attributes.SetUsername),
+	)
+}
@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+type secretsQueries interface {
+	GetActionSecretValue(ctx context.Context, name, orgID string) (*crypto.CryptoValue, error)
+}
+
+var (
+	secretsQuery     secretsQueries
+	secretsDecryptor crypto.EncryptionAlgorithm
+)
+
+// SetSecretsService enables the zitadel/secrets module,
+// the secrets are decrypted with the algorithm they were encrypted by the commands
+func SetSecretsService(queries secretsQueries, alg crypto.EncryptionAlgorithm) {
+	secretsQuery = queries
+	secretsDecryptor = alg
+}
+
+type secretsModule struct {
+	ctx     context.Context
+	orgID   string
+	runtime *goja.Runtime
+}
+
+func requireSecrets(ctx context.Context, orgID string, runtime *goja.Runtime, module *goja.Object) {
+	s := &secretsModule{
+		ctx:     ctx,
+		orgID:   orgID,
+		runtime: runtime,
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("get", s.get)).Warn("unable to set module")
+}
+
+// get returns the decrypted value of the secret or null if the secret doesn't exist
+func (s *secretsModule) get(name string) goja.Value {
+	value, err := secretsQuery.GetActionSecretValue(s.ctx, name, s.orgID)
+	if z_errs.IsNotFound(err) {
+		return goja.Null()
+	}
+	if err != nil {
+		panic(s.runtime.NewGoError(err))
+	}
+	decrypted, err := crypto.DecryptString(value, secretsDecryptor)
+	if err != nil {
+		panic(s.runtime.NewGoError(err))
+	}
+	return s.runtime.ToValue(decrypted)
+}
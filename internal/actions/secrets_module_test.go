@@ -0,0 +1,89 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockSecrets map[string]*crypto.CryptoValue
+
+func (m mockSecrets) GetActionSecretValue(_ context.Context, name, orgID string) (*crypto.CryptoValue, error) {
+	value, ok := m[orgID+"/"+name]
+	if !ok {
+		return nil, z_errs.ThrowNotFound(nil, "id", "not found")
+	}
+	return value, nil
+}
+
+func Test_secretsModule(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	secrets := mockSecrets{
+		"org1/apiKey": {
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("secret"),
+		},
+		"org2/other": {
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("other"),
+		},
+	}
+	tests := []struct {
+		name    string
+		script  string
+		wantErr bool
+	}{
+		{
+			name: "get secret",
+			script: `function test() {
+				let secrets = require('zitadel/secrets')
+				if (secrets.get('apiKey') != 'secret') {
+					throw 'wrong secret'
+				}
+			}`,
+		},
+		{
+			name: "secret of other org",
+			script: `function test() {
+				let secrets = require('zitadel/secrets')
+				if (secrets.get('other') !== null) {
+					throw 'secret should be null'
+				}
+			}`,
+		},
+		{
+			name: "wrong secret",
+			script: `function test() {
+				let secrets = require('zitadel/secrets')
+				if (secrets.get('apiKey') != 'wrong') {
+					throw 'wrong secret'
+				}
+			}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetSecretsService(secrets, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+			defer SetSecretsService(nil, nil)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := Run(ctx, nil, nil, tt.script, "test", ActionToOptions(&query.Action{ID: "action1", ResourceOwner: "org1"})...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type storageQueries interface {
+	GetActionStorageValue(ctx context.Context, key, orgID string) (*query.ActionStorageValue, error)
+}
+
+type storageCommands interface {
+	SetActionStorageValue(ctx context.Context, key, value, resourceOwner string) (*domain.ObjectDetails, error)
+	RemoveActionStorageValue(ctx context.Context, key, resourceOwner string) (*domain.ObjectDetails, error)
+}
+
+var (
+	storageQuery   storageQueries
+	storageCommand storageCommands
+)
+
+// SetStorageService enables the zitadel/storage module
+func SetStorageService(queries storageQueries, commands storageCommands) {
+	storageQuery = queries
+	storageCommand = commands
+}
+
+type storageModule struct {
+	ctx     context.Context
+	orgID   string
+	runtime *goja.Runtime
+	// written contains the values set or removed (nil) during the run,
+	// as the values are read from a projection which is updated asynchronously
+	written map[string]*string
+}
+
+func requireStorage(ctx context.Context, orgID string, runtime *goja.Runtime, module *goja.Object) {
+	s := &storageModule{
+		ctx:     ctx,
+		orgID:   orgID,
+		runtime: runtime,
+		written: make(map[string]*string),
+	}
+	o := module.Get("exports").(*goja.Object)
+	logging.OnError(o.Set("get", s.get)).Warn("unable to set module")
+	logging.OnError(o.Set("set", s.set)).Warn("unable to set module")
+	logging.OnError(o.Set("remove", s.remove)).Warn("unable to set module")
+}
+
+// get returns the stored value or null if no value is stored for the key
+func (s *storageModule) get(key string) goja.Value {
+	if value, ok := s.written[key]; ok {
+		if value == nil {
+			return goja.Null()
+		}
+		return s.runtime.ToValue(*value)
+	}
+	value, err := storageQuery.GetActionStorageValue(s.ctx, key, s.orgID)
+	if z_errs.IsNotFound(err) {
+		return goja.Null()
+	}
+	if err != nil {
+		panic(s.runtime.NewGoError(err))
+	}
+	return s.runtime.ToValue(value.Value)
+}
+
+func (s *storageModule) set(key, value string) {
+	if _, err := storageCommand.SetActionStorageValue(s.ctx, key, value, s.orgID); err != nil {
+		panic(s.runtime.NewGoError(err))
+	}
+	s.written[key] = &value
+}
+
+// remove deletes the value of the key, removing a key without value is ignored
+func (s *storageModule) remove(key string) {
+	_, err := storageCommand.RemoveActionStorageValue(s.ctx, key, s.orgID)
+	if err != nil && !z_errs.IsNotFound(err) {
+		panic(s.runtime.NewGoError(err))
+	}
+	s.written[key] = nil
+}
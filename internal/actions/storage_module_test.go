@@ -0,0 +1,143 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockStorage struct {
+	values map[string]string
+	// limit is the max length of a value
+	limit int
+}
+
+func (m *mockStorage) GetActionStorageValue(_ context.Context, key, orgID string) (*query.ActionStorageValue, error) {
+	value, ok := m.values[orgID+"/"+key]
+	if !ok {
+		return nil, z_errs.ThrowNotFound(nil, "id", "not found")
+	}
+	return &query.ActionStorageValue{Key: key, Value: value}, nil
+}
+
+func (m *mockStorage) SetActionStorageValue(_ context.Context, key, value, resourceOwner string) (*domain.ObjectDetails, error) {
+	if len(value) > m.limit {
+		return nil, z_errs.ThrowInvalidArgument(nil, "id", "too long")
+	}
+	m.values[resourceOwner+"/"+key] = value
+	return &domain.ObjectDetails{ResourceOwner: resourceOwner}, nil
+}
+
+func (m *mockStorage) RemoveActionStorageValue(_ context.Context, key, resourceOwner string) (*domain.ObjectDetails, error) {
+	if _, ok := m.values[resourceOwner+"/"+key]; !ok {
+		return nil, z_errs.ThrowNotFound(nil, "id", "not found")
+	}
+	delete(m.values, resourceOwner+"/"+key)
+	return &domain.ObjectDetails{ResourceOwner: resourceOwner}, nil
+}
+
+func Test_storageModule(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	tests := []struct {
+		name       string
+		values     map[string]string
+		script     string
+		wantErr    bool
+		wantValues map[string]string
+	}{
+		{
+			name:   "get stored value",
+			values: map[string]string{"org1/counter": "41", "org2/counter": "1"},
+			script: `function test() {
+				let storage = require('zitadel/storage')
+				let counter = parseInt(storage.get('counter'))
+				storage.set('counter', String(counter + 1))
+				if (storage.get('counter') != '42') {
+					throw 'value not updated'
+				}
+			}`,
+			wantValues: map[string]string{"org1/counter": "42", "org2/counter": "1"},
+		},
+		{
+			name:   "missing value",
+			values: map[string]string{},
+			script: `function test() {
+				let storage = require('zitadel/storage')
+				if (storage.get('counter') !== null) {
+					throw 'value should be null'
+				}
+				storage.remove('counter')
+			}`,
+			wantValues: map[string]string{},
+		},
+		{
+			name:   "removed value",
+			values: map[string]string{"org1/counter": "41"},
+			script: `function test() {
+				let storage = require('zitadel/storage')
+				storage.remove('counter')
+				if (storage.get('counter') !== null) {
+					throw 'value should be null'
+				}
+			}`,
+			wantValues: map[string]string{},
+		},
+		{
+			name:   "limit exceeded",
+			values: map[string]string{},
+			script: `function test() {
+				let storage = require('zitadel/storage')
+				storage.set('counter', 'value exceeding the limit')
+			}`,
+			wantErr:    true,
+			wantValues: map[string]string{},
+		},
+		{
+			name:   "limit error catchable",
+			values: map[string]string{},
+			script: `function test() {
+				let storage = require('zitadel/storage')
+				try {
+					storage.set('counter', 'value exceeding the limit')
+				} catch (e) {
+					storage.set('counter', 'short')
+				}
+			}`,
+			wantValues: map[string]string{"org1/counter": "short"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &mockStorage{values: tt.values, limit: 10}
+			SetStorageService(storage, storage)
+			defer SetStorageService(nil, nil)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := Run(ctx, nil, nil, tt.script, "test", ActionToOptions(&query.Action{ID: "action1", ResourceOwner: "org1"})...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantValues, storage.values)
+		})
+	}
+}
+
+func Test_storageModule_unknownAction(t *testing.T) {
+	SetLogstoreService(logstore.New(nil, nil, nil))
+	storage := &mockStorage{values: map[string]string{}, limit: 10}
+	SetStorageService(storage, storage)
+	defer SetStorageService(nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := Run(ctx, nil, nil, `function test() { require('zitadel/storage') }`, "test")
+	assert.Error(t, err)
+}
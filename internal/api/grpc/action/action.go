@@ -1,8 +1,14 @@
 package action
 
 import (
+	"encoding/json"
+	"strings"
+
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
@@ -19,6 +25,12 @@ func FlowTypeToDomain(flowType string) domain.FlowType {
 		return domain.FlowTypeCustomiseToken
 	case domain.FlowTypeInternalAuthentication.ID():
 		return domain.FlowTypeInternalAuthentication
+	case domain.FlowTypeCustomiseSAMLResponse.ID():
+		return domain.FlowTypeCustomiseSAMLResponse
+	case domain.FlowTypePreRegistration.ID():
+		return domain.FlowTypePreRegistration
+	case domain.FlowTypePostPasswordChange.ID():
+		return domain.FlowTypePostPasswordChange
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -47,6 +59,12 @@ func TriggerTypeToDomain(triggerType string) domain.TriggerType {
 		return domain.TriggerTypePreAccessTokenCreation
 	case domain.TriggerTypePreUserinfoCreation.ID():
 		return domain.TriggerTypePreUserinfoCreation
+	case domain.TriggerTypePreSAMLResponseCreation.ID():
+		return domain.TriggerTypePreSAMLResponseCreation
+	case domain.TriggerTypePreRegistrationValidation.ID():
+		return domain.TriggerTypePreRegistrationValidation
+	case domain.TriggerTypePostPasswordChange.ID():
+		return domain.TriggerTypePostPasswordChange
 	default:
 		return domain.TriggerTypeUnspecified
 	}
@@ -93,6 +111,128 @@ func TriggerActionsToPb(triggers map[domain.TriggerType][]*query.Action) []*acti
 	return list
 }
 
+func EventTriggerActionsListToPb(triggers []*query.EventTriggerActions) []*action_pb.EventTriggerActions {
+	list := make([]*action_pb.EventTriggerActions, len(triggers))
+	for i, trigger := range triggers {
+		list[i] = EventTriggerActionsToPb(trigger)
+	}
+	return list
+}
+
+func EventTriggerActionsToPb(trigger *query.EventTriggerActions) *action_pb.EventTriggerActions {
+	return &action_pb.EventTriggerActions{
+		EventType: trigger.EventType,
+		Details:   object_grpc.ChangeToDetailsPb(trigger.Sequence, trigger.ChangeDate, trigger.ResourceOwner),
+		Actions:   ActionsToPb(trigger.Actions),
+	}
+}
+
+func ActionSecretsToPb(secrets []*query.ActionSecret) []*action_pb.ActionSecret {
+	list := make([]*action_pb.ActionSecret, len(secrets))
+	for i, secret := range secrets {
+		list[i] = &action_pb.ActionSecret{
+			Name:    secret.Name,
+			Details: object_grpc.ChangeToDetailsPb(secret.Sequence, secret.ChangeDate, secret.ResourceOwner),
+		}
+	}
+	return list
+}
+
+// ActionStorageValuesToPb returns the keys of the stored values,
+// the values themselves are only returned if withValues is set
+func ActionStorageValuesToPb(values []*query.ActionStorageValue, withValues bool) []*action_pb.ActionStorageValue {
+	list := make([]*action_pb.ActionStorageValue, len(values))
+	for i, value := range values {
+		list[i] = &action_pb.ActionStorageValue{
+			Key:     value.Key,
+			Details: object_grpc.ChangeToDetailsPb(value.Sequence, value.ChangeDate, value.ResourceOwner),
+		}
+		if withValues {
+			list[i].Value = value.Value
+		}
+	}
+	return list
+}
+
+func ActionLibrariesToPb(libraries []*query.ActionLibrary) []*action_pb.ActionLibrary {
+	list := make([]*action_pb.ActionLibrary, len(libraries))
+	for i, library := range libraries {
+		list[i] = ActionLibraryToPb(library)
+	}
+	return list
+}
+
+func ActionLibraryToPb(library *query.ActionLibrary) *action_pb.ActionLibrary {
+	return &action_pb.ActionLibrary{
+		Name:    library.Name,
+		Version: library.Version,
+		Details: object_grpc.ChangeToDetailsPb(library.Sequence, library.ChangeDate, library.ResourceOwner),
+		Script:  library.Script,
+	}
+}
+
+func DryRunHTTPResponsesToDomain(responses []*action_pb.ActionDryRunHTTPResponse) []*actions.DryRunHTTPResponse {
+	list := make([]*actions.DryRunHTTPResponse, len(responses))
+	for i, response := range responses {
+		list[i] = &actions.DryRunHTTPResponse{
+			Method: response.Method,
+			URL:    response.Url,
+			Status: int(response.Status),
+			Body:   response.Body,
+		}
+	}
+	return list
+}
+
+func DryRunAPICallsToPb(calls []*actions.DryRunAPICall) ([]*action_pb.ActionDryRunAPICall, error) {
+	list := make([]*action_pb.ActionDryRunAPICall, len(calls))
+	for i, call := range calls {
+		// the arguments are exported from javascript and therefore json serializable
+		args, err := json.Marshal(call.Args)
+		if err != nil {
+			return nil, err
+		}
+		arguments := new(structpb.ListValue)
+		if err = arguments.UnmarshalJSON(args); err != nil {
+			return nil, err
+		}
+		list[i] = &action_pb.ActionDryRunAPICall{
+			Function:  call.Function,
+			Arguments: arguments,
+		}
+	}
+	return list, nil
+}
+
+func DryRunHTTPRequestsToPb(requests []*actions.DryRunHTTPRequest) []*action_pb.ActionDryRunHTTPRequest {
+	list := make([]*action_pb.ActionDryRunHTTPRequest, len(requests))
+	for i, request := range requests {
+		headers := make(map[string]string, len(request.Headers))
+		for key, values := range request.Headers {
+			headers[key] = strings.Join(values, ", ")
+		}
+		list[i] = &action_pb.ActionDryRunHTTPRequest{
+			Method:  request.Method,
+			Url:     request.URL,
+			Headers: headers,
+			Body:    request.Body,
+		}
+	}
+	return list
+}
+
+func DryRunLogsToPb(logs []*actions.DryRunLog) []*action_pb.ActionDryRunLog {
+	list := make([]*action_pb.ActionDryRunLog, len(logs))
+	for i, log := range logs {
+		list[i] = &action_pb.ActionDryRunLog{
+			Timestamp: timestamppb.New(log.LogDate),
+			Level:     log.Level.String(),
+			Message:   log.Message,
+		}
+	}
+	return list
+}
+
 func ActionsToPb(actions []*query.Action) []*action_pb.Action {
 	list := make([]*action_pb.Action, len(actions))
 	for i, action := range actions {
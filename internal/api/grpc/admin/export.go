@@ -486,6 +486,8 @@ func (s *Server) getLockoutPolicy(ctx context.Context, orgID string) (_ *managem
 	if !queriedLockout.IsDefault {
 		return &management_pb.AddCustomLockoutPolicyRequest{
 			MaxPasswordAttempts: uint32(queriedLockout.MaxPasswordAttempts),
+			MaxOtpAttempts:      uint32(queriedLockout.MaxOTPAttempts),
+			LockoutDuration:     durationpb.New(queriedLockout.LockoutDuration),
 		}, nil
 	}
 	return nil, nil
@@ -501,11 +503,12 @@ func (s *Server) getPasswordComplexityPolicy(ctx context.Context, orgID string)
 	}
 	if !queriedPasswordComplexity.IsDefault {
 		return &management_pb.AddCustomPasswordComplexityPolicyRequest{
-			MinLength:    queriedPasswordComplexity.MinLength,
-			HasUppercase: queriedPasswordComplexity.HasUppercase,
-			HasLowercase: queriedPasswordComplexity.HasLowercase,
-			HasNumber:    queriedPasswordComplexity.HasNumber,
-			HasSymbol:    queriedPasswordComplexity.HasSymbol,
+			MinLength:     queriedPasswordComplexity.MinLength,
+			HasUppercase:  queriedPasswordComplexity.HasUppercase,
+			HasLowercase:  queriedPasswordComplexity.HasLowercase,
+			HasNumber:     queriedPasswordComplexity.HasNumber,
+			HasSymbol:     queriedPasswordComplexity.HasSymbol,
+			CheckBreached: queriedPasswordComplexity.CheckBreached,
 		}, nil
 	}
 	return nil, nil
@@ -659,7 +662,7 @@ func (s *Server) getUsers(ctx context.Context, org string, withPasswords bool, w
 func (s *Server) getTriggerActions(ctx context.Context, org string, processedActions []string) (_ []*management_pb.SetTriggerActionsRequest, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-	flowTypes := []domain.FlowType{domain.FlowTypeExternalAuthentication, domain.FlowTypeInternalAuthentication}
+	flowTypes := []domain.FlowType{domain.FlowTypeExternalAuthentication, domain.FlowTypeInternalAuthentication, domain.FlowTypeCustomiseSAMLResponse, domain.FlowTypePreRegistration, domain.FlowTypePostPasswordChange}
 	triggerActions := make([]*management_pb.SetTriggerActionsRequest, 0)
 
 	for _, flowType := range flowTypes {
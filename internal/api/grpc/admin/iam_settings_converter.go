@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -89,6 +90,10 @@ func SecretGeneratorTypeToPb(generatorType domain.SecretGeneratorType) settings_
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_PASSWORDLESS_INIT_CODE
 	case domain.SecretGeneratorTypeAppSecret:
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_APP_SECRET
+	case domain.SecretGeneratorTypeOTPSMS:
+		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_SMS
+	case domain.SecretGeneratorTypeOTPEmail:
+		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_EMAIL
 	default:
 		return settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_UNSPECIFIED
 	}
@@ -108,6 +113,10 @@ func SecretGeneratorTypeToDomain(generatorType settings_pb.SecretGeneratorType)
 		return domain.SecretGeneratorTypePasswordlessInitCode
 	case settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_APP_SECRET:
 		return domain.SecretGeneratorTypeAppSecret
+	case settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_SMS:
+		return domain.SecretGeneratorTypeOTPSMS
+	case settings_pb.SecretGeneratorType_SECRET_GENERATOR_TYPE_OTP_EMAIL:
+		return domain.SecretGeneratorTypeOTPEmail
 	default:
 		return domain.SecretGeneratorTypeUnspecified
 	}
@@ -152,8 +161,27 @@ func SMTPConfigToPb(smtp *query.SMTPConfig) *settings_pb.SMTPConfig {
 
 func SecurityPolicyToPb(policy *query.SecurityPolicy) *settings_pb.SecurityPolicy {
 	return &settings_pb.SecurityPolicy{
-		Details:               obj_grpc.ToViewDetailsPb(policy.Sequence, policy.CreationDate, policy.ChangeDate, policy.AggregateID),
-		EnableIframeEmbedding: policy.Enabled,
-		AllowedOrigins:        policy.AllowedOrigins,
+		Details:                obj_grpc.ToViewDetailsPb(policy.Sequence, policy.CreationDate, policy.ChangeDate, policy.AggregateID),
+		EnableIframeEmbedding:  policy.Enabled,
+		AllowedOrigins:         policy.AllowedOrigins,
+		TokenExchangeClientIds: policy.TokenExchangeClientIDs,
+		ImpersonationClientIds: policy.ImpersonationClientIDs,
+
+		LoginRateLimitMaxAttemptsPerIp:        policy.LoginRateLimitMaxAttemptsPerIP,
+		LoginRateLimitMaxAttemptsPerLoginName: policy.LoginRateLimitMaxAttemptsPerLoginName,
+		LoginRateLimitWindow:                  durationpb.New(policy.LoginRateLimitWindow),
+	}
+}
+
+func securityPolicyToCommand(req *admin_pb.SetSecurityPolicyRequest) *command.SecurityPolicy {
+	return &command.SecurityPolicy{
+		Enabled:                req.EnableIframeEmbedding,
+		AllowedOrigins:         req.AllowedOrigins,
+		TokenExchangeClientIDs: req.TokenExchangeClientIds,
+		ImpersonationClientIDs: req.ImpersonationClientIds,
+
+		LoginRateLimitMaxAttemptsPerIP:        req.LoginRateLimitMaxAttemptsPerIp,
+		LoginRateLimitMaxAttemptsPerLoginName: req.LoginRateLimitMaxAttemptsPerLoginName,
+		LoginRateLimitWindow:                  req.LoginRateLimitWindow.AsDuration(),
 	}
 }
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -37,3 +38,14 @@ func (s *Server) UpdateOIDCSettings(ctx context.Context, req *admin_pb.UpdateOID
 		Details: object.DomainToChangeDetailsPb(result),
 	}, nil
 }
+
+func (s *Server) ListSigningKeys(ctx context.Context, _ *admin_pb.ListSigningKeysRequest) (*admin_pb.ListSigningKeysResponse, error) {
+	result, err := s.query.SigningKeys(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListSigningKeysResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.Timestamp),
+		Result:  SigningKeysToPb(result.Keys),
+	}, nil
+}
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -17,6 +18,9 @@ func OIDCSettingsToPb(config *query.OIDCSettings) *settings_pb.OIDCSettings {
 		IdTokenLifetime:            durationpb.New(config.IdTokenLifetime),
 		RefreshTokenIdleExpiration: durationpb.New(config.RefreshTokenIdleExpiration),
 		RefreshTokenExpiration:     durationpb.New(config.RefreshTokenExpiration),
+		SigningKeyAlgorithm:        config.SigningKeyAlgorithm,
+		SigningKeyLifetime:         durationpb.New(config.SigningKeyLifetime),
+		SigningKeyOverlap:          durationpb.New(config.SigningKeyOverlap),
 	}
 }
 
@@ -26,6 +30,9 @@ func AddOIDCConfigToConfig(req *admin_pb.AddOIDCSettingsRequest) *domain.OIDCSet
 		IdTokenLifetime:            req.IdTokenLifetime.AsDuration(),
 		RefreshTokenIdleExpiration: req.RefreshTokenIdleExpiration.AsDuration(),
 		RefreshTokenExpiration:     req.RefreshTokenExpiration.AsDuration(),
+		SigningKeyAlgorithm:        req.SigningKeyAlgorithm,
+		SigningKeyLifetime:         req.SigningKeyLifetime.AsDuration(),
+		SigningKeyOverlap:          req.SigningKeyOverlap.AsDuration(),
 	}
 }
 
@@ -35,5 +42,40 @@ func UpdateOIDCConfigToConfig(req *admin_pb.UpdateOIDCSettingsRequest) *domain.O
 		IdTokenLifetime:            req.IdTokenLifetime.AsDuration(),
 		RefreshTokenIdleExpiration: req.RefreshTokenIdleExpiration.AsDuration(),
 		RefreshTokenExpiration:     req.RefreshTokenExpiration.AsDuration(),
+		SigningKeyAlgorithm:        req.SigningKeyAlgorithm,
+		SigningKeyLifetime:         req.SigningKeyLifetime.AsDuration(),
+		SigningKeyOverlap:          req.SigningKeyOverlap.AsDuration(),
+	}
+}
+
+func SigningKeysToPb(keys []*query.SigningKey) []*settings_pb.SigningKey {
+	result := make([]*settings_pb.SigningKey, len(keys))
+	for i, key := range keys {
+		result[i] = SigningKeyToPb(key)
+	}
+	return result
+}
+
+func SigningKeyToPb(key *query.SigningKey) *settings_pb.SigningKey {
+	return &settings_pb.SigningKey{
+		Id:               key.ID,
+		Algorithm:        key.Algorithm,
+		State:            SigningKeyStateToPb(key.State),
+		CreationDate:     timestamppb.New(key.CreationDate),
+		PrivateKeyExpiry: timestamppb.New(key.PrivateKeyExpiry),
+		PublicKeyExpiry:  timestamppb.New(key.PublicKeyExpiry),
+	}
+}
+
+func SigningKeyStateToPb(state domain.KeyState) settings_pb.SigningKeyState {
+	switch state {
+	case domain.KeyStateNext:
+		return settings_pb.SigningKeyState_SIGNING_KEY_STATE_NEXT
+	case domain.KeyStateActive:
+		return settings_pb.SigningKeyState_SIGNING_KEY_STATE_ACTIVE
+	case domain.KeyStateRetired:
+		return settings_pb.SigningKeyState_SIGNING_KEY_STATE_RETIRED
+	default:
+		return settings_pb.SigningKeyState_SIGNING_KEY_STATE_UNSPECIFIED
 	}
 }
@@ -65,6 +65,68 @@ func (s *Server) UpdateSMSProviderTwilioToken(ctx context.Context, req *admin_pb
 	}, nil
 }
 
+func (s *Server) AddSMSProviderHTTP(ctx context.Context, req *admin_pb.AddSMSProviderHTTPRequest) (*admin_pb.AddSMSProviderHTTPResponse, error) {
+	id, result, err := s.command.AddSMSConfigHTTP(ctx, authz.GetInstance(ctx).InstanceID(), AddSMSConfigHTTPToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddSMSProviderHTTPResponse{
+		Details: object.DomainToAddDetailsPb(result),
+		Id:      id,
+	}, nil
+}
+
+func (s *Server) UpdateSMSProviderHTTP(ctx context.Context, req *admin_pb.UpdateSMSProviderHTTPRequest) (*admin_pb.UpdateSMSProviderHTTPResponse, error) {
+	result, err := s.command.ChangeSMSConfigHTTP(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, UpdateSMSConfigHTTPToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMSProviderHTTPResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) UpdateSMSProviderHTTPHeaders(ctx context.Context, req *admin_pb.UpdateSMSProviderHTTPHeadersRequest) (*admin_pb.UpdateSMSProviderHTTPHeadersResponse, error) {
+	result, err := s.command.ChangeSMSConfigHTTPHeaders(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, req.Headers)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMSProviderHTTPHeadersResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) AddSMSProviderVonage(ctx context.Context, req *admin_pb.AddSMSProviderVonageRequest) (*admin_pb.AddSMSProviderVonageResponse, error) {
+	id, result, err := s.command.AddSMSConfigVonage(ctx, authz.GetInstance(ctx).InstanceID(), AddSMSConfigVonageToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddSMSProviderVonageResponse{
+		Details: object.DomainToAddDetailsPb(result),
+		Id:      id,
+	}, nil
+}
+
+func (s *Server) UpdateSMSProviderVonage(ctx context.Context, req *admin_pb.UpdateSMSProviderVonageRequest) (*admin_pb.UpdateSMSProviderVonageResponse, error) {
+	result, err := s.command.ChangeSMSConfigVonage(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, UpdateSMSConfigVonageToConfig(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMSProviderVonageResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) UpdateSMSProviderVonageAPISecret(ctx context.Context, req *admin_pb.UpdateSMSProviderVonageAPISecretRequest) (*admin_pb.UpdateSMSProviderVonageAPISecretResponse, error) {
+	result, err := s.command.ChangeSMSConfigVonageAPISecret(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, req.ApiSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMSProviderVonageAPISecretResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
 func (s *Server) ActivateSMSProvider(ctx context.Context, req *admin_pb.ActivateSMSProviderRequest) (*admin_pb.ActivateSMSProviderResponse, error) {
 	result, err := s.command.ActivateSMSConfig(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
 	if err != nil {
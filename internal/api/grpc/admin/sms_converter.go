@@ -4,6 +4,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/vonage"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 	settings_pb "github.com/zitadel/zitadel/pkg/grpc/settings"
@@ -38,8 +40,13 @@ func SMSConfigToProviderPb(config *query.SMSConfig) *settings_pb.SMSProvider {
 }
 
 func SMSConfigToPb(config *query.SMSConfig) settings_pb.SMSConfig {
-	if config.TwilioConfig != nil {
+	switch {
+	case config.TwilioConfig != nil:
 		return TwilioConfigToPb(config.TwilioConfig)
+	case config.HTTPConfig != nil:
+		return HTTPConfigToPb(config.HTTPConfig)
+	case config.VonageConfig != nil:
+		return VonageConfigToPb(config.VonageConfig)
 	}
 	return nil
 }
@@ -53,6 +60,25 @@ func TwilioConfigToPb(twilio *query.Twilio) *settings_pb.SMSProvider_Twilio {
 	}
 }
 
+func HTTPConfigToPb(http *query.HTTP) *settings_pb.SMSProvider_Http {
+	return &settings_pb.SMSProvider_Http{
+		Http: &settings_pb.HTTPConfig{
+			Endpoint:     http.Endpoint,
+			BodyTemplate: http.BodyTemplate,
+			SenderNumber: http.SenderNumber,
+		},
+	}
+}
+
+func VonageConfigToPb(vonage *query.Vonage) *settings_pb.SMSProvider_Vonage {
+	return &settings_pb.SMSProvider_Vonage{
+		Vonage: &settings_pb.VonageConfig{
+			ApiKey:       vonage.APIKey,
+			SenderNumber: vonage.SenderNumber,
+		},
+	}
+}
+
 func smsStateToPb(state domain.SMSConfigState) settings_pb.SMSProviderConfigState {
 	switch state {
 	case domain.SMSConfigStateInactive:
@@ -78,3 +104,35 @@ func UpdateSMSConfigTwilioToConfig(req *admin_pb.UpdateSMSProviderTwilioRequest)
 		SenderNumber: req.SenderNumber,
 	}
 }
+
+func AddSMSConfigHTTPToConfig(req *admin_pb.AddSMSProviderHTTPRequest) *webhook.Config {
+	return &webhook.Config{
+		Endpoint:     req.Endpoint,
+		Headers:      req.Headers,
+		BodyTemplate: req.BodyTemplate,
+		SenderNumber: req.SenderNumber,
+	}
+}
+
+func UpdateSMSConfigHTTPToConfig(req *admin_pb.UpdateSMSProviderHTTPRequest) *webhook.Config {
+	return &webhook.Config{
+		Endpoint:     req.Endpoint,
+		BodyTemplate: req.BodyTemplate,
+		SenderNumber: req.SenderNumber,
+	}
+}
+
+func AddSMSConfigVonageToConfig(req *admin_pb.AddSMSProviderVonageRequest) *vonage.Config {
+	return &vonage.Config{
+		APIKey:       req.ApiKey,
+		APISecret:    req.ApiSecret,
+		SenderNumber: req.SenderNumber,
+	}
+}
+
+func UpdateSMSConfigVonageToConfig(req *admin_pb.UpdateSMSProviderVonageRequest) *vonage.Config {
+	return &vonage.Config{
+		APIKey:       req.ApiKey,
+		SenderNumber: req.SenderNumber,
+	}
+}
@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	webhook_grpc "github.com/zitadel/zitadel/internal/api/grpc/webhook"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListWebhooks(ctx context.Context, req *admin_pb.ListWebhooksRequest) (*admin_pb.ListWebhooksResponse, error) {
+	query, err := webhook_grpc.ListWebhooksToQuery(authz.GetInstance(ctx).InstanceID(), req.Query, req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := s.query.SearchWebhooks(ctx, query, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListWebhooksResponse{
+		Details: obj_grpc.ToListDetails(webhooks.Count, webhooks.Sequence, webhooks.Timestamp),
+		Result:  webhook_grpc.WebhooksToPb(webhooks.Webhooks),
+	}, nil
+}
+
+func (s *Server) GetWebhook(ctx context.Context, req *admin_pb.GetWebhookRequest) (*admin_pb.GetWebhookResponse, error) {
+	webhook, err := s.query.GetWebhookByID(ctx, req.Id, authz.GetInstance(ctx).InstanceID(), false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetWebhookResponse{
+		Webhook: webhook_grpc.WebhookToPb(webhook),
+	}, nil
+}
+
+func (s *Server) AddWebhook(ctx context.Context, req *admin_pb.AddWebhookRequest) (*admin_pb.AddWebhookResponse, error) {
+	id, signingKey, details, err := s.command.AddWebhook(ctx, addWebhookRequestToDomain(req), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddWebhookResponse{
+		Details:    obj_grpc.DomainToAddDetailsPb(details),
+		Id:         id,
+		SigningKey: signingKey,
+	}, nil
+}
+
+func (s *Server) UpdateWebhook(ctx context.Context, req *admin_pb.UpdateWebhookRequest) (*admin_pb.UpdateWebhookResponse, error) {
+	details, err := s.command.ChangeWebhook(ctx, updateWebhookRequestToDomain(req), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RegenerateWebhookSigningKey(ctx context.Context, req *admin_pb.RegenerateWebhookSigningKeyRequest) (*admin_pb.RegenerateWebhookSigningKeyResponse, error) {
+	signingKey, details, err := s.command.RegenerateWebhookSigningKey(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RegenerateWebhookSigningKeyResponse{
+		Details:    obj_grpc.DomainToChangeDetailsPb(details),
+		SigningKey: signingKey,
+	}, nil
+}
+
+func (s *Server) DeactivateWebhook(ctx context.Context, req *admin_pb.DeactivateWebhookRequest) (*admin_pb.DeactivateWebhookResponse, error) {
+	details, err := s.command.DeactivateWebhook(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.DeactivateWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ReactivateWebhook(ctx context.Context, req *admin_pb.ReactivateWebhookRequest) (*admin_pb.ReactivateWebhookResponse, error) {
+	details, err := s.command.ReactivateWebhook(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ReactivateWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveWebhook(ctx context.Context, req *admin_pb.RemoveWebhookRequest) (*admin_pb.RemoveWebhookResponse, error) {
+	details, err := s.command.RemoveWebhook(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveWebhookResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListWebhookDeliveries(ctx context.Context, req *admin_pb.ListWebhookDeliveriesRequest) (*admin_pb.ListWebhookDeliveriesResponse, error) {
+	// ensures the webhook belongs to the instance
+	if _, err := s.query.GetWebhookByID(ctx, req.Id, authz.GetInstance(ctx).InstanceID(), false); err != nil {
+		return nil, err
+	}
+	query, err := webhook_grpc.ListWebhookDeliveriesToQuery(req.Query, req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.query.SearchWebhookDeliveries(ctx, req.Id, query)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListWebhookDeliveriesResponse{
+		Details: obj_grpc.ToListDetails(deliveries.Count, deliveries.Sequence, deliveries.Timestamp),
+		Result:  webhook_grpc.WebhookDeliveriesToPb(deliveries.Deliveries),
+	}, nil
+}
+
+func (s *Server) RetryWebhookDelivery(ctx context.Context, req *admin_pb.RetryWebhookDeliveryRequest) (*admin_pb.RetryWebhookDeliveryResponse, error) {
+	// ensures the webhook belongs to the instance
+	if _, err := s.query.GetWebhookByID(ctx, req.Id, authz.GetInstance(ctx).InstanceID(), false); err != nil {
+		return nil, err
+	}
+	if err := s.query.RetryWebhookDelivery(ctx, req.Id, req.EventSequence); err != nil {
+		return nil, err
+	}
+	return &admin_pb.RetryWebhookDeliveryResponse{}, nil
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func addWebhookRequestToDomain(req *admin_pb.AddWebhookRequest) *domain.Webhook {
+	return &domain.Webhook{
+		Name:           req.Name,
+		URL:            req.Url,
+		AggregateTypes: req.AggregateTypes,
+		EventTypes:     req.EventTypes,
+	}
+}
+
+func updateWebhookRequestToDomain(req *admin_pb.UpdateWebhookRequest) *domain.Webhook {
+	return &domain.Webhook{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		Name:           req.Name,
+		URL:            req.Url,
+		AggregateTypes: req.AggregateTypes,
+		EventTypes:     req.EventTypes,
+	}
+}
@@ -412,7 +412,9 @@ func providerTypeToPb(idpType domain.IDPType) idp_pb.ProviderType {
 		return idp_pb.ProviderType_PROVIDER_TYPE_GITLAB_SELF_HOSTED
 	case domain.IDPTypeGoogle:
 		return idp_pb.ProviderType_PROVIDER_TYPE_GOOGLE
-	case domain.IDPTypeUnspecified:
+	case domain.IDPTypeSAML,
+		domain.IDPTypeApple,
+		domain.IDPTypeUnspecified:
 		return idp_pb.ProviderType_PROVIDER_TYPE_UNSPECIFIED
 	default:
 		return idp_pb.ProviderType_PROVIDER_TYPE_UNSPECIFIED
@@ -2,7 +2,11 @@ package management
 
 import (
 	"context"
+	"time"
 
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -96,3 +100,39 @@ func (s *Server) DeleteAction(ctx context.Context, req *mgmt_pb.DeleteActionRequ
 	_, err = s.command.DeleteAction(ctx, req.Id, authz.GetCtxData(ctx).OrgID, flowTypes...)
 	return &mgmt_pb.DeleteActionResponse{}, err
 }
+
+func (s *Server) DryRunAction(ctx context.Context, req *mgmt_pb.DryRunActionRequest) (*mgmt_pb.DryRunActionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, dryRunActionTimeout(req.Timeout.AsDuration()))
+	defer cancel()
+
+	dryRun := &actions.DryRun{
+		ResourceOwner: authz.GetCtxData(ctx).OrgID,
+		HTTPResponses: action_grpc.DryRunHTTPResponsesToDomain(req.HttpResponses),
+	}
+	started := time.Now()
+	runErr := actions.Run(
+		ctx,
+		actions.DryRunContextFields(req.Context.AsMap()),
+		nil,
+		req.Script,
+		req.Name,
+		actions.WithDryRun(ctx, dryRun),
+		actions.WithLogMetadata(map[string]interface{}{"dryRun": true}),
+	)
+	took := time.Since(started)
+
+	apiCalls, err := action_grpc.DryRunAPICallsToPb(dryRun.APICalls)
+	if err != nil {
+		return nil, err
+	}
+	res := &mgmt_pb.DryRunActionResponse{
+		ApiCalls:     apiCalls,
+		HttpRequests: action_grpc.DryRunHTTPRequestsToPb(dryRun.HTTPRequests),
+		Logs:         action_grpc.DryRunLogsToPb(dryRun.Logs),
+		Took:         durationpb.New(took),
+	}
+	if runErr != nil {
+		res.Error = runErr.Error()
+	}
+	return res, nil
+}
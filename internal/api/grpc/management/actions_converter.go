@@ -1,6 +1,8 @@
 package management
 
 import (
+	"time"
+
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -10,6 +12,16 @@ import (
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
 
+const maxDryRunActionTimeout = 20 * time.Second
+
+// dryRunActionTimeout limits the run like the timeout of a stored action
+func dryRunActionTimeout(timeout time.Duration) time.Duration {
+	if timeout > 0 && timeout < maxDryRunActionTimeout {
+		return timeout
+	}
+	return maxDryRunActionTimeout
+}
+
 func CreateActionRequestToDomain(req *mgmt_pb.CreateActionRequest) *domain.Action {
 	return &domain.Action{
 		Name:          req.Name,
@@ -0,0 +1,116 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+const permissionActionWrite = "org.action.write"
+
+func (s *Server) ListActionSecrets(ctx context.Context, _ *mgmt_pb.ListActionSecretsRequest) (*mgmt_pb.ListActionSecretsResponse, error) {
+	secrets, err := s.query.SearchActionSecrets(ctx, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionSecretsResponse{
+		Result: action_grpc.ActionSecretsToPb(secrets),
+	}, nil
+}
+
+func (s *Server) SetActionSecret(ctx context.Context, req *mgmt_pb.SetActionSecretRequest) (*mgmt_pb.SetActionSecretResponse, error) {
+	details, err := s.command.SetActionSecret(ctx, req.Name, req.Value, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetActionSecretResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveActionSecret(ctx context.Context, req *mgmt_pb.RemoveActionSecretRequest) (*mgmt_pb.RemoveActionSecretResponse, error) {
+	details, err := s.command.RemoveActionSecret(ctx, req.Name, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveActionSecretResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+// ListActionStorage returns the keys of the stored values,
+// the values can contain sensitive data (e.g. tokens of external systems), so they are only returned to users allowed to change them
+func (s *Server) ListActionStorage(ctx context.Context, _ *mgmt_pb.ListActionStorageRequest) (*mgmt_pb.ListActionStorageResponse, error) {
+	values, err := s.query.SearchActionStorage(ctx, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	withValues := authz.HasGlobalExplicitPermission(authz.GetAllPermissionsFromCtx(ctx), permissionActionWrite)
+	return &mgmt_pb.ListActionStorageResponse{
+		Result: action_grpc.ActionStorageValuesToPb(values, withValues),
+	}, nil
+}
+
+func (s *Server) SetActionStorageValue(ctx context.Context, req *mgmt_pb.SetActionStorageValueRequest) (*mgmt_pb.SetActionStorageValueResponse, error) {
+	details, err := s.command.SetActionStorageValue(ctx, req.Key, req.Value, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetActionStorageValueResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveActionStorageValue(ctx context.Context, req *mgmt_pb.RemoveActionStorageValueRequest) (*mgmt_pb.RemoveActionStorageValueResponse, error) {
+	details, err := s.command.RemoveActionStorageValue(ctx, req.Key, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveActionStorageValueResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListActionLibraries(ctx context.Context, _ *mgmt_pb.ListActionLibrariesRequest) (*mgmt_pb.ListActionLibrariesResponse, error) {
+	libraries, err := s.query.SearchActionLibraries(ctx, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionLibrariesResponse{
+		Result: action_grpc.ActionLibrariesToPb(libraries),
+	}, nil
+}
+
+func (s *Server) GetActionLibrary(ctx context.Context, req *mgmt_pb.GetActionLibraryRequest) (*mgmt_pb.GetActionLibraryResponse, error) {
+	library, err := s.query.GetActionLibrary(ctx, req.Name, req.Version, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetActionLibraryResponse{
+		Library: action_grpc.ActionLibraryToPb(library),
+	}, nil
+}
+
+func (s *Server) SetActionLibrary(ctx context.Context, req *mgmt_pb.SetActionLibraryRequest) (*mgmt_pb.SetActionLibraryResponse, error) {
+	version, details, err := s.command.SetActionLibrary(ctx, req.Name, req.Script, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetActionLibraryResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+		Version: version,
+	}, nil
+}
+
+func (s *Server) RemoveActionLibrary(ctx context.Context, req *mgmt_pb.RemoveActionLibraryRequest) (*mgmt_pb.RemoveActionLibraryResponse, error) {
+	details, err := s.command.RemoveActionLibrary(ctx, req.Name, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveActionLibraryResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
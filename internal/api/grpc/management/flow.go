@@ -18,6 +18,9 @@ func (s *Server) ListFlowTypes(ctx context.Context, _ *mgmt_pb.ListFlowTypesRequ
 			action_grpc.FlowTypeToPb(domain.FlowTypeExternalAuthentication),
 			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseToken),
 			action_grpc.FlowTypeToPb(domain.FlowTypeInternalAuthentication),
+			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseSAMLResponse),
+			action_grpc.FlowTypeToPb(domain.FlowTypePreRegistration),
+			action_grpc.FlowTypeToPb(domain.FlowTypePostPasswordChange),
 		},
 	}, nil
 }
@@ -71,3 +74,23 @@ func (s *Server) SetTriggerActions(ctx context.Context, req *mgmt_pb.SetTriggerA
 		),
 	}, nil
 }
+
+func (s *Server) ListEventTriggerActions(ctx context.Context, _ *mgmt_pb.ListEventTriggerActionsRequest) (*mgmt_pb.ListEventTriggerActionsResponse, error) {
+	triggers, err := s.query.SearchEventTriggerActions(ctx, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListEventTriggerActionsResponse{
+		Result: action_grpc.EventTriggerActionsListToPb(triggers),
+	}, nil
+}
+
+func (s *Server) SetEventTriggerActions(ctx context.Context, req *mgmt_pb.SetEventTriggerActionsRequest) (*mgmt_pb.SetEventTriggerActionsResponse, error) {
+	details, err := s.command.SetEventTriggerActions(ctx, req.EventType, req.ActionIds, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetEventTriggerActionsResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
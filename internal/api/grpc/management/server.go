@@ -39,6 +39,7 @@ func CreateServer(
 	query *query.Queries,
 	sd systemdefaults.SystemDefaults,
 	userCodeAlg crypto.EncryptionAlgorithm,
+	passwordHashAlg crypto.HashAlgorithm,
 	externalSecure bool,
 	auditLogRetention time.Duration,
 ) *Server {
@@ -47,7 +48,7 @@ func CreateServer(
 		query:             query,
 		systemDefaults:    sd,
 		assetAPIPrefix:    assets.AssetAPI(externalSecure),
-		passwordHashAlg:   crypto.NewBCrypt(sd.SecretGenerators.PasswordSaltCost),
+		passwordHashAlg:   passwordHashAlg,
 		userCodeAlg:       userCodeAlg,
 		externalSecure:    externalSecure,
 		auditLogRetention: auditLogRetention,
@@ -0,0 +1,28 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func addWebhookRequestToDomain(req *mgmt_pb.AddWebhookRequest) *domain.Webhook {
+	return &domain.Webhook{
+		Name:           req.Name,
+		URL:            req.Url,
+		AggregateTypes: req.AggregateTypes,
+		EventTypes:     req.EventTypes,
+	}
+}
+
+func updateWebhookRequestToDomain(req *mgmt_pb.UpdateWebhookRequest) *domain.Webhook {
+	return &domain.Webhook{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		Name:           req.Name,
+		URL:            req.Url,
+		AggregateTypes: req.AggregateTypes,
+		EventTypes:     req.EventTypes,
+	}
+}
@@ -11,6 +11,7 @@ func ModelPasswordAgePolicyToPb(policy *query.PasswordAgePolicy) *policy_pb.Pass
 		IsDefault:      policy.IsDefault,
 		MaxAgeDays:     policy.MaxAgeDays,
 		ExpireWarnDays: policy.ExpireWarnDays,
+		HistoryCount:   policy.HistoryCount,
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
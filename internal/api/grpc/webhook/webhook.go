@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object"
+	webhook_pb "github.com/zitadel/zitadel/pkg/grpc/webhook"
+)
+
+func WebhooksToPb(webhooks []*query.Webhook) []*webhook_pb.Webhook {
+	list := make([]*webhook_pb.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		list[i] = WebhookToPb(webhook)
+	}
+	return list
+}
+
+// WebhookToPb maps the webhook without its signing key, which is only returned on creation
+func WebhookToPb(webhook *query.Webhook) *webhook_pb.Webhook {
+	return &webhook_pb.Webhook{
+		Id:             webhook.ID,
+		Details:        object_grpc.ChangeToDetailsPb(webhook.Sequence, webhook.ChangeDate, webhook.ResourceOwner),
+		State:          WebhookStateToPb(webhook.State),
+		Name:           webhook.Name,
+		Url:            webhook.URL,
+		AggregateTypes: webhook.AggregateTypes,
+		EventTypes:     webhook.EventTypes,
+	}
+}
+
+func WebhookStateToPb(state domain.WebhookState) webhook_pb.WebhookState {
+	switch state {
+	case domain.WebhookStateActive:
+		return webhook_pb.WebhookState_WEBHOOK_STATE_ACTIVE
+	case domain.WebhookStateInactive:
+		return webhook_pb.WebhookState_WEBHOOK_STATE_INACTIVE
+	default:
+		return webhook_pb.WebhookState_WEBHOOK_STATE_UNSPECIFIED
+	}
+}
+
+func WebhookStateToDomain(state webhook_pb.WebhookState) domain.WebhookState {
+	switch state {
+	case webhook_pb.WebhookState_WEBHOOK_STATE_ACTIVE:
+		return domain.WebhookStateActive
+	case webhook_pb.WebhookState_WEBHOOK_STATE_INACTIVE:
+		return domain.WebhookStateInactive
+	default:
+		return domain.WebhookStateUnspecified
+	}
+}
+
+func ListWebhooksToQuery(resourceOwner string, listQuery *object_pb.ListQuery, webhookQueries []*webhook_pb.WebhookQuery) (_ *query.WebhookSearchQueries, err error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(listQuery)
+	queries := make([]query.SearchQuery, len(webhookQueries)+1)
+	queries[0], err = query.NewWebhookResourceOwnerQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	for i, webhookQuery := range webhookQueries {
+		queries[i+1], err = WebhookQueryToQuery(webhookQuery.Query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.WebhookSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func WebhookQueryToQuery(webhookQuery interface{}) (query.SearchQuery, error) {
+	switch q := webhookQuery.(type) {
+	case *webhook_pb.WebhookQuery_WebhookIdQuery:
+		return query.NewWebhookIDSearchQuery(q.WebhookIdQuery.Id)
+	case *webhook_pb.WebhookQuery_WebhookNameQuery:
+		return query.NewWebhookNameSearchQuery(object_grpc.TextMethodToQuery(q.WebhookNameQuery.Method), q.WebhookNameQuery.Name)
+	case *webhook_pb.WebhookQuery_WebhookStateQuery:
+		return query.NewWebhookStateSearchQuery(WebhookStateToDomain(q.WebhookStateQuery.State))
+	}
+	return nil, errors.ThrowInvalidArgument(nil, "WEBHOOK-Fb2qa", "Errors.Query.InvalidRequest")
+}
+
+func WebhookDeliveriesToPb(deliveries []*query.WebhookDelivery) []*webhook_pb.WebhookDelivery {
+	list := make([]*webhook_pb.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		list[i] = WebhookDeliveryToPb(delivery)
+	}
+	return list
+}
+
+func WebhookDeliveryToPb(delivery *query.WebhookDelivery) *webhook_pb.WebhookDelivery {
+	return &webhook_pb.WebhookDelivery{
+		WebhookId:     delivery.WebhookID,
+		CreationDate:  timestamppb.New(delivery.CreationDate),
+		AggregateType: delivery.AggregateType,
+		AggregateId:   delivery.AggregateID,
+		EventType:     delivery.EventType,
+		EventSequence: delivery.EventSequence,
+		State:         WebhookDeliveryStateToPb(delivery.State),
+		Attempts:      delivery.Attempts,
+		StatusCode:    uint32(delivery.StatusCode),
+		Error:         delivery.Error,
+	}
+}
+
+func WebhookDeliveryStateToPb(state domain.WebhookDeliveryState) webhook_pb.WebhookDeliveryState {
+	switch state {
+	case domain.WebhookDeliveryStateSucceeded:
+		return webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_SUCCEEDED
+	case domain.WebhookDeliveryStateFailed:
+		return webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_FAILED
+	case domain.WebhookDeliveryStatePending:
+		return webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING
+	default:
+		return webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_UNSPECIFIED
+	}
+}
+
+func WebhookDeliveryStateToDomain(state webhook_pb.WebhookDeliveryState) domain.WebhookDeliveryState {
+	switch state {
+	case webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_SUCCEEDED:
+		return domain.WebhookDeliveryStateSucceeded
+	case webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_FAILED:
+		return domain.WebhookDeliveryStateFailed
+	case webhook_pb.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING:
+		return domain.WebhookDeliveryStatePending
+	default:
+		return domain.WebhookDeliveryStateUnspecified
+	}
+}
+
+func ListWebhookDeliveriesToQuery(listQuery *object_pb.ListQuery, deliveryQueries []*webhook_pb.WebhookDeliveryQuery) (_ *query.WebhookDeliverySearchQueries, err error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(listQuery)
+	queries := make([]query.SearchQuery, len(deliveryQueries))
+	for i, deliveryQuery := range deliveryQueries {
+		switch q := deliveryQuery.Query.(type) {
+		case *webhook_pb.WebhookDeliveryQuery_StateQuery:
+			queries[i], err = query.NewWebhookDeliveryStateSearchQuery(WebhookDeliveryStateToDomain(q.StateQuery.State))
+		default:
+			err = errors.ThrowInvalidArgument(nil, "WEBHOOK-Kd8sm", "Errors.Query.InvalidRequest")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &query.WebhookDeliverySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
@@ -93,6 +93,50 @@ func RemoteIPStringFromRequest(r *http.Request) string {
 	return host
 }
 
+// ClientIP returns the ip of the client, which connected to the outermost of the trusted proxies.
+// Every proxy appends the address of its peer to the X-Forwarded-For header,
+// so only the last trustedProxies entries are set by trusted proxies, the entries before them can be set by the client.
+// Without trusted proxies, the address of the peer of the connection is returned
+func ClientIP(remoteAddr string, headers http.Header, trustedProxies uint) string {
+	if trustedProxies > 0 {
+		forwarded := forwardedFor(headers)
+		if uint(len(forwarded)) >= trustedProxies {
+			return forwarded[uint(len(forwarded))-trustedProxies]
+		}
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+func ClientIPFromRequest(r *http.Request, trustedProxies uint) string {
+	return ClientIP(r.RemoteAddr, r.Header, trustedProxies)
+}
+
+func ClientIPFromCtx(ctx context.Context, trustedProxies uint) string {
+	headers, _ := HeadersFromCtx(ctx)
+	return ClientIP(RemoteAddrFromCtx(ctx), headers, trustedProxies)
+}
+
+// forwardedFor returns the entries of all X-Forwarded-For headers in the order they were appended
+func forwardedFor(headers http.Header) []string {
+	values := headers.Values(ForwardedFor)
+	if len(values) == 0 {
+		values = headers[ForwardedFor]
+	}
+	forwarded := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, ip := range strings.Split(value, ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				forwarded = append(forwarded, ip)
+			}
+		}
+	}
+	return forwarded
+}
+
 func GetAuthorization(r *http.Request) string {
 	return r.Header.Get(Authorization)
 }
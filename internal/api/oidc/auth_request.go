@@ -81,13 +81,7 @@ func (o *OPStorage) DeleteAuthRequest(ctx context.Context, id string) (err error
 func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest) (_ string, _ time.Time, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-	var userAgentID, applicationID, userOrgID string
-	authReq, ok := req.(*AuthRequest)
-	if ok {
-		userAgentID = authReq.AgentID
-		applicationID = authReq.ApplicationID
-		userOrgID = authReq.UserOrgID
-	}
+	userAgentID, applicationID, userOrgID, _, _ := getInfoFromRequest(req)
 
 	accessTokenLifetime, _, _, _, err := o.getOIDCSettings(ctx)
 	if err != nil {
@@ -139,6 +133,10 @@ func getInfoFromRequest(req op.TokenRequest) (string, string, string, time.Time,
 	if ok {
 		return refreshReq.UserAgentID, refreshReq.ClientID, "", refreshReq.AuthTime, refreshReq.AuthMethodsReferences
 	}
+	deviceReq, ok := req.(*deviceAuthRequest)
+	if ok {
+		return deviceReq.UserAgentID, deviceReq.ClientID, deviceReq.UserOrgID, deviceReq.AuthTime, deviceReq.AuthMethodsReferences
+	}
 	return "", "", "", time.Time{}, nil
 }
 
@@ -17,15 +17,6 @@ import (
 	"github.com/zitadel/zitadel/internal/user/model"
 )
 
-const (
-	// DEPRECATED: use `amrPWD` instead
-	amrPassword     = "password"
-	amrPWD          = "pwd"
-	amrMFA          = "mfa"
-	amrOTP          = "otp"
-	amrUserPresence = "user"
-)
-
 type AuthRequest struct {
 	*domain.AuthRequest
 }
@@ -40,19 +31,7 @@ func (a *AuthRequest) GetACR() string {
 }
 
 func (a *AuthRequest) GetAMR() []string {
-	amr := make([]string, 0)
-	if a.PasswordVerified {
-		amr = append(amr, amrPassword, amrPWD)
-	}
-	if len(a.MFAsVerified) > 0 {
-		amr = append(amr, amrMFA)
-		for _, mfa := range a.MFAsVerified {
-			if amrMFA := AMRFromMFAType(mfa); amrMFA != "" {
-				amr = append(amr, amrMFA)
-			}
-		}
-	}
-	return amr
+	return a.AuthMethodsReferences()
 }
 
 func (a *AuthRequest) GetAudience() []string {
@@ -277,18 +256,6 @@ func CodeChallengeToOIDC(challenge *domain.OIDCCodeChallenge) *oidc.CodeChalleng
 	}
 }
 
-func AMRFromMFAType(mfaType domain.MFAType) string {
-	switch mfaType {
-	case domain.MFATypeOTP:
-		return amrOTP
-	case domain.MFATypeU2F,
-		domain.MFATypeU2FUserVerification:
-		return amrUserPresence
-	default:
-		return ""
-	}
-}
-
 func RefreshTokenRequestFromBusiness(tokenView *model.RefreshTokenView) op.RefreshTokenRequest {
 	return &RefreshTokenRequest{tokenView}
 }
@@ -104,6 +104,16 @@ func (o *OPStorage) AuthorizeClientIDSecret(ctx context.Context, id string, secr
 		UserID: oidcCtx,
 		OrgID:  oidcCtx,
 	})
+	limitKeys := o.clientRateLimitKeys(ctx, id)
+	if err = o.rateLimiter.Check(ctx, limitKeys...); err != nil {
+		return err
+	}
+	err = o.authorizeClientIDSecret(ctx, id, secret)
+	o.countClientAuthentication(ctx, id, limitKeys, err)
+	return err
+}
+
+func (o *OPStorage) authorizeClientIDSecret(ctx context.Context, id string, secret string) error {
 	app, err := o.query.AppByClientID(ctx, id, false)
 	if err != nil {
 		return err
@@ -181,6 +191,12 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			introspection.SetAudience(token.Audience)
 			introspection.SetIssuer(op.IssuerFromContext(ctx))
 			introspection.SetJWTID(token.ID)
+			if token.ActorUserID != "" {
+				introspection.AppendClaims(claimActor, &actorClaim{
+					Issuer:  op.IssuerFromContext(ctx),
+					Subject: token.ActorUserID,
+				})
+			}
 			return nil
 		}
 	}
@@ -213,11 +229,16 @@ func (o *OPStorage) ClientCredentials(ctx context.Context, clientID, clientSecre
 	if err != nil {
 		return nil, err
 	}
-	user, err := o.query.GetUser(ctx, false, false, loginname)
-	if err != nil {
+	limitKeys := o.clientRateLimitKeys(ctx, clientID)
+	if err = o.rateLimiter.Check(ctx, limitKeys...); err != nil {
 		return nil, err
 	}
-	if _, err := o.command.VerifyMachineSecret(ctx, user.ID, user.ResourceOwner, clientSecret); err != nil {
+	user, err := o.query.GetUser(ctx, false, false, loginname)
+	if err == nil {
+		_, err = o.command.VerifyMachineSecret(ctx, user.ID, user.ResourceOwner, clientSecret)
+	}
+	o.countClientAuthentication(ctx, clientID, limitKeys, err)
+	if err != nil {
 		return nil, err
 	}
 	return &clientCredentialsClient{
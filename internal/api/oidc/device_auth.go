@@ -0,0 +1,406 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// GrantTypeDeviceCode is the grant type of the OAuth 2.0 Device Authorization Grant (RFC 8628)
+	GrantTypeDeviceCode oidc.GrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	DeviceAuthorizationPath = "/oauth/v2/device_authorization"
+
+	deviceCodeLength = 32
+	// pollIntervalTolerance allows polls slightly faster than the interval (e.g. because of network latency)
+	// before the device is asked to slow down
+	pollIntervalTolerance = time.Second
+)
+
+var (
+	deviceCodeChars = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+	errAuthorizationPending = func() *oidc.Error {
+		return &oidc.Error{ErrorType: "authorization_pending"}
+	}
+	errSlowDown = func() *oidc.Error {
+		return &oidc.Error{ErrorType: "slow_down"}
+	}
+	errAccessDenied = func() *oidc.Error {
+		return &oidc.Error{ErrorType: "access_denied"}
+	}
+	errExpiredToken = func() *oidc.Error {
+		return &oidc.Error{ErrorType: "expired_token"}
+	}
+)
+
+type DeviceAuthorizationConfig struct {
+	Lifetime     time.Duration
+	PollInterval time.Duration
+	UserCode     *UserCodeConfig
+}
+
+type UserCodeConfig struct {
+	CharSet      string
+	CharAmount   int
+	DashInterval int
+}
+
+// deviceAuthorizationRequest is the request of the device to the device authorization endpoint
+// https://www.rfc-editor.org/rfc/rfc8628#section-3.1
+type deviceAuthorizationRequest struct {
+	Scopes              oidc.SpaceDelimitedArray `schema:"scope"`
+	ClientID            string                   `schema:"client_id"`
+	ClientSecret        string                   `schema:"client_secret"`
+	ClientAssertion     string                   `schema:"client_assertion"`
+	ClientAssertionType string                   `schema:"client_assertion_type"`
+}
+
+func (r *deviceAuthorizationRequest) SetClientID(clientID string) {
+	r.ClientID = clientID
+}
+
+func (r *deviceAuthorizationRequest) SetClientSecret(clientSecret string) {
+	r.ClientSecret = clientSecret
+}
+
+// deviceAuthorizationResponse is returned to the device, so it can show the user code and verification uri to the user
+// https://www.rfc-editor.org/rfc/rfc8628#section-3.2
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// deviceAccessTokenRequest is the request of the device to the token endpoint, which is polled until the user approved or denied the device
+// https://www.rfc-editor.org/rfc/rfc8628#section-3.4
+type deviceAccessTokenRequest struct {
+	DeviceCode          string `schema:"device_code"`
+	ClientID            string `schema:"client_id"`
+	ClientSecret        string `schema:"client_secret"`
+	ClientAssertion     string `schema:"client_assertion"`
+	ClientAssertionType string `schema:"client_assertion_type"`
+}
+
+func (r *deviceAccessTokenRequest) SetClientID(clientID string) {
+	r.ClientID = clientID
+}
+
+func (r *deviceAccessTokenRequest) SetClientSecret(clientSecret string) {
+	r.ClientSecret = clientSecret
+}
+
+// provider wraps the [op.Provider] to handle the device authorization grant,
+// which is not supported by the oidc library
+type provider struct {
+	*op.Provider
+	storage     *OPStorage
+	config      *DeviceAuthorizationConfig
+	devicePolls *devicePolls
+	httpHandler http.Handler
+}
+
+func newProvider(p *op.Provider, storage *OPStorage, config *DeviceAuthorizationConfig, interceptors ...op.HttpInterceptor) *provider {
+	wrapped := &provider{
+		Provider:    p,
+		storage:     storage,
+		config:      config,
+		devicePolls: newDevicePolls(config.PollInterval-pollIntervalTolerance, config.Lifetime),
+	}
+	interceptors = append([]op.HttpInterceptor{op.NewIssuerInterceptor(p.IssuerFromRequest).Handler}, interceptors...)
+	intercept := func(handler http.HandlerFunc) http.Handler {
+		var h http.Handler = handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			h = interceptors[i](h)
+		}
+		return h
+	}
+
+	router := mux.NewRouter()
+	router.Handle(oidc.DiscoveryEndpoint, intercept(wrapped.discoveryHandler))
+	router.Handle(DeviceAuthorizationPath, intercept(wrapped.deviceAuthorizationHandler)).Methods(http.MethodPost)
+	router.Handle(p.TokenEndpoint().Relative(), intercept(wrapped.deviceAccessTokenHandler)).
+		Methods(http.MethodPost).
+		MatcherFunc(isDeviceCodeGrant)
+	router.Handle(p.TokenEndpoint().Relative(), intercept(wrapped.tokenExchangeHandler)).
+		Methods(http.MethodPost).
+		MatcherFunc(isTokenExchangeGrant)
+	router.NotFoundHandler = p.HttpHandler()
+	wrapped.httpHandler = router
+	return wrapped
+}
+
+func (p *provider) HttpHandler() http.Handler {
+	return p.httpHandler
+}
+
+// discoveryConfiguration extends the discovery of the oidc library with the device authorization endpoint
+// (the device code and token exchange grant types are added to the supported grant types)
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
+}
+
+func (p *provider) discoveryHandler(w http.ResponseWriter, r *http.Request) {
+	config := op.CreateDiscoveryConfig(r, p, p.Storage())
+	config.GrantTypesSupported = append(config.GrantTypesSupported, GrantTypeDeviceCode, oidc.GrantTypeTokenExchange)
+	httphelper.MarshalJSON(w, &discoveryConfiguration{
+		DiscoveryConfiguration:      config,
+		DeviceAuthorizationEndpoint: op.IssuerFromContext(r.Context()) + DeviceAuthorizationPath,
+	})
+}
+
+func isDeviceCodeGrant(r *http.Request, _ *mux.RouteMatch) bool {
+	return r.FormValue("grant_type") == string(GrantTypeDeviceCode)
+}
+
+func (p *provider) deviceAuthorizationHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := p.deviceAuthorization(r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+func (p *provider) deviceAuthorization(r *http.Request) (_ *deviceAuthorizationResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	req := new(deviceAuthorizationRequest)
+	if err = op.ParseAuthenticatedTokenRequest(r, p.Decoder(), req); err != nil {
+		return nil, err
+	}
+	client, err := p.authorizeClient(ctx, req.ClientID, req.ClientSecret, req.ClientAssertion, req.ClientAssertionType, GrantTypeDeviceCode)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := op.ValidateAuthReqScopes(client, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err = p.storage.assertProjectRoleScopes(ctx, client.GetID(), scopes)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	deviceCode, err := crypto.GenerateRandomString(deviceCodeLength, deviceCodeChars)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	userCode, err := generateUserCode(p.config.UserCode)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	expires := time.Now().UTC().Add(p.config.Lifetime)
+	_, _, err = p.storage.command.AddDeviceAuth(setContextUserSystem(ctx), client.GetID(), deviceCode, userCode, expires, scopes)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	verificationURI := op.IssuerFromContext(ctx) + login.HandlerPrefix + login.EndpointDeviceAuth
+	return &deviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?" + login.QueryUserCode + "=" + userCode,
+		ExpiresIn:               int(p.config.Lifetime / time.Second),
+		Interval:                int(p.config.PollInterval / time.Second),
+	}, nil
+}
+
+func (p *provider) deviceAccessTokenHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := p.deviceAccessToken(r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+func (p *provider) deviceAccessToken(r *http.Request) (_ *oidc.AccessTokenResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	req := new(deviceAccessTokenRequest)
+	if err = op.ParseAuthenticatedTokenRequest(r, p.Decoder(), req); err != nil {
+		return nil, err
+	}
+	if req.DeviceCode == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("device_code missing")
+	}
+	client, err := p.authorizeClient(ctx, req.ClientID, req.ClientSecret, req.ClientAssertion, req.ClientAssertionType, GrantTypeDeviceCode)
+	if err != nil {
+		return nil, err
+	}
+	deviceAuth, err := p.storage.query.DeviceAuthByDeviceCode(ctx, true, client.GetID(), req.DeviceCode)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code").WithParent(err)
+		}
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	if deviceAuth.Expired() {
+		return nil, errExpiredToken()
+	}
+	switch deviceAuth.State {
+	case domain.DeviceAuthStateInitiated:
+		if p.devicePolls.poll(authz.GetInstance(ctx).InstanceID(), deviceAuth.ID, time.Now()) {
+			return nil, errSlowDown()
+		}
+		return nil, errAuthorizationPending()
+	case domain.DeviceAuthStateDenied:
+		return nil, errAccessDenied()
+	case domain.DeviceAuthStateApproved:
+		// the tokens are created below
+	default:
+		return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code")
+	}
+	// the device code must only be used once, so it is removed before the tokens are created
+	_, err = p.storage.command.RemoveDeviceAuth(setContextUserSystem(ctx), deviceAuth.ID)
+	if err != nil {
+		return nil, oidc.ErrInvalidGrant().WithParent(err)
+	}
+	return op.CreateTokenResponse(ctx, &deviceAuthRequest{deviceAuth}, client, p, true, "", "")
+}
+
+// authorizeClient authenticates the client the same way as the token endpoint does for the authorization code flow
+func (p *provider) authorizeClient(ctx context.Context, clientID, clientSecret, clientAssertion, clientAssertionType string, grantType oidc.GrantType) (op.Client, error) {
+	var client op.Client
+	if clientAssertionType == oidc.ClientAssertionTypeJWTAssertion {
+		if !p.AuthMethodPrivateKeyJWTSupported() {
+			return nil, oidc.ErrInvalidClient().WithDescription("auth_method private_key_jwt not supported")
+		}
+		var err error
+		client, err = op.AuthorizePrivateJWTKey(ctx, clientAssertion, p)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		client, err = p.storage.GetClientByClientID(ctx, clientID)
+		if err != nil {
+			return nil, oidc.ErrInvalidClient().WithParent(err)
+		}
+		switch client.AuthMethod() {
+		case oidc.AuthMethodNone:
+		case oidc.AuthMethodPrivateKeyJWT:
+			return nil, oidc.ErrInvalidClient().WithDescription("private_key_jwt not allowed for this client")
+		case oidc.AuthMethodPost:
+			if !p.AuthMethodPostSupported() {
+				return nil, oidc.ErrInvalidClient().WithDescription("auth_method post not supported")
+			}
+			fallthrough
+		default:
+			if err = op.AuthorizeClientIDSecret(ctx, clientID, clientSecret, p.Storage()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !op.ValidateGrantType(client, grantType) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("client is not allowed to use the %s grant", grantType)
+	}
+	return client, nil
+}
+
+// generateUserCode generates the code the user has to enter on the login UI
+// e.g. WDJB-MJHT for 8 characters with a dash after every 4
+func generateUserCode(config *UserCodeConfig) (string, error) {
+	code, err := crypto.GenerateRandomString(uint(config.CharAmount), []rune(config.CharSet))
+	if err != nil {
+		return "", err
+	}
+	if config.DashInterval <= 0 {
+		return code, nil
+	}
+	var b strings.Builder
+	for i, char := range code {
+		if i > 0 && i%config.DashInterval == 0 {
+			b.WriteRune('-')
+		}
+		b.WriteRune(char)
+	}
+	return b.String(), nil
+}
+
+// deviceAuthRequest implements [op.AuthRequest] for an approved device authorization,
+// so the tokens (including a refresh token if offline_access was requested) are created as for the authorization code flow
+type deviceAuthRequest struct {
+	*query.DeviceAuth
+}
+
+func (r *deviceAuthRequest) GetID() string {
+	return r.ID
+}
+
+func (r *deviceAuthRequest) GetACR() string {
+	return "" //PLANNED: impl
+}
+
+func (r *deviceAuthRequest) GetAMR() []string {
+	return r.AuthMethodsReferences
+}
+
+func (r *deviceAuthRequest) GetAudience() []string {
+	return r.Audience
+}
+
+func (r *deviceAuthRequest) GetAuthTime() time.Time {
+	return r.AuthTime
+}
+
+func (r *deviceAuthRequest) GetClientID() string {
+	return r.ClientID
+}
+
+func (r *deviceAuthRequest) GetCodeChallenge() *oidc.CodeChallenge {
+	return nil
+}
+
+func (r *deviceAuthRequest) GetNonce() string {
+	return ""
+}
+
+func (r *deviceAuthRequest) GetRedirectURI() string {
+	return ""
+}
+
+func (r *deviceAuthRequest) GetResponseType() oidc.ResponseType {
+	return oidc.ResponseTypeCode
+}
+
+func (r *deviceAuthRequest) GetResponseMode() oidc.ResponseMode {
+	return ""
+}
+
+func (r *deviceAuthRequest) GetScopes() []string {
+	return r.Scopes
+}
+
+func (r *deviceAuthRequest) GetState() string {
+	return ""
+}
+
+func (r *deviceAuthRequest) GetSubject() string {
+	return r.UserID
+}
+
+func (r *deviceAuthRequest) Done() bool {
+	return true
+}
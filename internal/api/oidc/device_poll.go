@@ -0,0 +1,55 @@
+package oidc
+
+import (
+	"sync"
+	"time"
+)
+
+// devicePolls remembers the last poll of the devices on the token endpoint,
+// so devices polling faster than the interval can be asked to slow down (RFC 8628 3.5).
+// The polls are only kept in memory of the process, as they are short-lived
+// and a missed slow_down (e.g. polls distributed to different processes) is not critical.
+type devicePolls struct {
+	mutex    sync.Mutex
+	lastPoll map[string]time.Time
+	interval time.Duration
+	lifetime time.Duration
+	pruned   time.Time
+}
+
+func newDevicePolls(interval, lifetime time.Duration) *devicePolls {
+	return &devicePolls{
+		lastPoll: make(map[string]time.Time),
+		interval: interval,
+		lifetime: lifetime,
+	}
+}
+
+// poll records the poll of the device authorization
+// and returns if the device has to slow down, because the previous poll is less than the interval ago
+func (p *devicePolls) poll(instanceID, id string, now time.Time) (slowDown bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.prune(now)
+	key := instanceID + ":" + id
+	if last, ok := p.lastPoll[key]; ok && now.Sub(last) < p.interval {
+		return true
+	}
+	p.lastPoll[key] = now
+	return false
+}
+
+// prune removes the polls of device authorizations which are expired by now,
+// it's done at most once per lifetime
+func (p *devicePolls) prune(now time.Time) {
+	if now.Sub(p.pruned) < p.lifetime {
+		return
+	}
+	for key, last := range p.lastPoll {
+		if now.Sub(last) >= p.lifetime {
+			delete(p.lastPoll, key)
+		}
+	}
+	p.pruned = now
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/query"
@@ -27,7 +28,7 @@ const (
 	retryBackoff   = 500 * time.Millisecond
 	retryCount     = 3
 	lockDuration   = retryCount * retryBackoff * 5
-	gracefulPeriod = 10 * time.Minute
+	gracefulPeriod = domain.SigningKeyGracefulPeriod
 )
 
 // SigningKey wraps the query.PrivateKey to implement the op.SigningKey interface
@@ -119,25 +120,66 @@ func (o *OPStorage) getSigningKey(ctx context.Context) (op.SigningKey, error) {
 		return nil, err
 	}
 	if len(keys.Keys) > 0 {
+		logging.OnError(o.rotateSigningKey(ctx, keys)).Warn("unable to generate next signing key")
 		return o.privateKeyToSigningKey(selectSigningKey(keys.Keys))
 	}
+	return nil, o.refreshSigningKey(ctx, keys)
+}
+
+func (o *OPStorage) refreshSigningKey(ctx context.Context, keys *query.PrivateKeys) error {
+	if err := o.rotateSigningKey(ctx, keys); err != nil {
+		return err
+	}
+	return errors.ThrowInternal(nil, "OIDC-Df1bh", "")
+}
+
+// rotateSigningKey generates the next signing key if it's due,
+// so it's published in the key set before it's used for signing
+func (o *OPStorage) rotateSigningKey(ctx context.Context, keys *query.PrivateKeys) error {
+	rotation, err := o.signingKeyRotation(ctx)
+	if err != nil {
+		return err
+	}
+	expiries := make([]time.Time, len(keys.Keys))
+	for i, key := range keys.Keys {
+		expiries[i] = key.Expiry()
+	}
+	due, privateKeyExp, publicKeyExp := rotation.NextSigningKey(expiries, time.Now())
+	if !due {
+		return nil
+	}
 	var sequence uint64
 	if keys.LatestSequence != nil {
 		sequence = keys.LatestSequence.Sequence
 	}
-	return nil, o.refreshSigningKey(ctx, o.signingKeyAlgorithm, sequence)
-}
-
-func (o *OPStorage) refreshSigningKey(ctx context.Context, algorithm string, sequence uint64) error {
 	ok, err := o.ensureIsLatestKey(ctx, sequence)
 	if err != nil || !ok {
 		return errors.ThrowInternal(err, "OIDC-ASfh3", "cannot ensure that projection is up to date")
 	}
-	err = o.lockAndGenerateSigningKeyPair(ctx, algorithm)
+	err = o.lockAndGenerateSigningKeyPair(ctx, rotation.Algorithm, privateKeyExp, publicKeyExp)
 	if err != nil {
 		return errors.ThrowInternal(err, "OIDC-ADh31", "could not create signing key")
 	}
-	return errors.ThrowInternal(nil, "OIDC-Df1bh", "")
+	return nil
+}
+
+// signingKeyRotation returns the rotation defined in the oidc settings of the instance,
+// missing settings use the system defaults
+func (o *OPStorage) signingKeyRotation(ctx context.Context) (*domain.SigningKeyRotation, error) {
+	settings, err := o.query.OIDCSettingsByAggID(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	algorithm := o.signingKeyAlgorithm
+	var lifetime, overlap time.Duration
+	if settings != nil {
+		if settings.SigningKeyAlgorithm != "" {
+			algorithm = settings.SigningKeyAlgorithm
+		}
+		lifetime = settings.SigningKeyLifetime
+		overlap = settings.SigningKeyOverlap
+	}
+	return o.command.SigningKeyRotation(algorithm, lifetime, overlap), nil
 }
 
 func (o *OPStorage) ensureIsLatestKey(ctx context.Context, sequence uint64) (bool, error) {
@@ -153,7 +195,7 @@ func (o *OPStorage) privateKeyToSigningKey(key query.PrivateKey) (_ op.SigningKe
 	if err != nil {
 		return nil, err
 	}
-	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	privateKey, err := crypto.BytesToSigningKey(keyData)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +206,7 @@ func (o *OPStorage) privateKeyToSigningKey(key query.PrivateKey) (_ op.SigningKe
 	}, nil
 }
 
-func (o *OPStorage) lockAndGenerateSigningKeyPair(ctx context.Context, algorithm string) error {
+func (o *OPStorage) lockAndGenerateSigningKeyPair(ctx context.Context, algorithm string, privateKeyExp, publicKeyExp time.Time) error {
 	logging.Info("lock and generate signing key pair")
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -180,7 +222,7 @@ func (o *OPStorage) lockAndGenerateSigningKeyPair(ctx context.Context, algorithm
 		return err
 	}
 
-	return o.command.GenerateSigningKeyPair(setOIDCCtx(ctx), algorithm)
+	return o.command.GenerateSigningKeyPair(setOIDCCtx(ctx), algorithm, privateKeyExp, publicKeyExp)
 }
 
 func (o *OPStorage) getMaxKeySequence(ctx context.Context) (uint64, error) {
@@ -194,8 +236,10 @@ func (o *OPStorage) getMaxKeySequence(ctx context.Context) (uint64, error) {
 	)
 }
 
+// selectSigningKey returns the key expiring first,
+// later keys are only published until the active one is retired
 func selectSigningKey(keys []query.PrivateKey) query.PrivateKey {
-	return keys[len(keys)-1]
+	return keys[0]
 }
 
 func setOIDCCtx(ctx context.Context) context.Context {
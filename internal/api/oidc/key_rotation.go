@@ -0,0 +1,60 @@
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const keyRotationPageSize = 100
+
+// startKeyRotation checks the signing keys of all instances in the interval,
+// so the next keys are published ahead of their use even if an instance doesn't sign any tokens
+func (o *OPStorage) startKeyRotation(ctx context.Context, interval time.Duration) {
+	if interval == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				o.rotateSigningKeys(ctx)
+			}
+		}
+	}()
+}
+
+func (o *OPStorage) rotateSigningKeys(ctx context.Context) {
+	queries := &query.InstanceSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Limit: keyRotationPageSize,
+		},
+	}
+	for {
+		instances, err := o.query.SearchInstances(ctx, queries)
+		if err != nil {
+			logging.WithError(err).Warn("unable to search instances for signing key rotation")
+			return
+		}
+		for _, instance := range instances.Instances {
+			instanceCtx := authz.WithInstanceID(ctx, instance.ID)
+			keys, err := o.query.ActivePrivateSigningKey(instanceCtx, time.Now().Add(gracefulPeriod))
+			if err == nil {
+				err = o.rotateSigningKey(instanceCtx, keys)
+			}
+			logging.WithFields("instance", instance.ID).OnError(err).Warn("unable to rotate signing key")
+		}
+		if len(instances.Instances) < keyRotationPageSize {
+			return
+		}
+		queries.Offset += keyRotationPageSize
+	}
+}
@@ -0,0 +1,25 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/ratelimit"
+)
+
+// clientRateLimitKeys returns the keys of the login rate limit for the client ip and the client id
+func (o *OPStorage) clientRateLimitKeys(ctx context.Context, clientID string) []ratelimit.Key {
+	return []ratelimit.Key{
+		o.rateLimiter.ClientIPFromCtx(ctx),
+		ratelimit.ClientID(clientID),
+	}
+}
+
+// countClientAuthentication counts a failed client authentication for all keys
+// or resets the failed attempts of the client id on success
+func (o *OPStorage) countClientAuthentication(ctx context.Context, clientID string, keys []ratelimit.Key, err error) {
+	if err != nil {
+		o.rateLimiter.Failed(ctx, keys...)
+		return
+	}
+	o.rateLimiter.Succeeded(ctx, ratelimit.ClientID(clientID))
+}
@@ -0,0 +1,377 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	oidc_crypto "github.com/zitadel/oidc/v2/pkg/crypto"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	usr_model "github.com/zitadel/zitadel/internal/user/model"
+)
+
+const (
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+	// TokenTypeUserID is a ZITADEL specific subject_token_type,
+	// which allows the actor to impersonate the user with the provided id
+	TokenTypeUserID = "urn:zitadel:params:oauth:token-type:user_id"
+
+	claimActor = "act"
+
+	permissionImpersonation = "impersonation"
+)
+
+var (
+	errInvalidTarget = func() *oidc.Error {
+		return &oidc.Error{ErrorType: "invalid_target"}
+	}
+)
+
+// tokenExchangeRequest is the request of the client to the token endpoint for the token exchange grant
+// https://www.rfc-editor.org/rfc/rfc8693#section-2.1
+type tokenExchangeRequest struct {
+	SubjectToken        string                   `schema:"subject_token"`
+	SubjectTokenType    string                   `schema:"subject_token_type"`
+	ActorToken          string                   `schema:"actor_token"`
+	ActorTokenType      string                   `schema:"actor_token_type"`
+	RequestedTokenType  string                   `schema:"requested_token_type"`
+	Audience            []string                 `schema:"audience"`
+	Scopes              oidc.SpaceDelimitedArray `schema:"scope"`
+	ClientID            string                   `schema:"client_id"`
+	ClientSecret        string                   `schema:"client_secret"`
+	ClientAssertion     string                   `schema:"client_assertion"`
+	ClientAssertionType string                   `schema:"client_assertion_type"`
+}
+
+func (r *tokenExchangeRequest) SetClientID(clientID string) {
+	r.ClientID = clientID
+}
+
+func (r *tokenExchangeRequest) SetClientSecret(clientSecret string) {
+	r.ClientSecret = clientSecret
+}
+
+// tokenExchangeResponse is returned to the client on a successful token exchange
+// https://www.rfc-editor.org/rfc/rfc8693#section-2.2.1
+type tokenExchangeResponse struct {
+	AccessToken     string                   `json:"access_token"`
+	IssuedTokenType string                   `json:"issued_token_type"`
+	TokenType       string                   `json:"token_type"`
+	ExpiresIn       uint64                   `json:"expires_in,omitempty"`
+	Scopes          oidc.SpaceDelimitedArray `json:"scope,omitempty"`
+}
+
+// actorClaim is the act claim of the exchanged token, identifying the user acting in the name of the subject
+// https://www.rfc-editor.org/rfc/rfc8693#section-4.1
+type actorClaim struct {
+	Issuer  string `json:"iss,omitempty"`
+	Subject string `json:"sub"`
+}
+
+func isTokenExchangeGrant(r *http.Request, _ *mux.RouteMatch) bool {
+	return r.FormValue("grant_type") == string(oidc.GrantTypeTokenExchange)
+}
+
+func (p *provider) tokenExchangeHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := p.tokenExchange(r)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+func (p *provider) tokenExchange(r *http.Request) (_ *tokenExchangeResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	req := new(tokenExchangeRequest)
+	if err = op.ParseAuthenticatedTokenRequest(r, p.Decoder(), req); err != nil {
+		return nil, err
+	}
+	if req.SubjectToken == "" || req.SubjectTokenType == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token or subject_token_type missing")
+	}
+	if req.RequestedTokenType != "" && req.RequestedTokenType != TokenTypeAccessToken && req.RequestedTokenType != TokenTypeJWT {
+		return nil, oidc.ErrInvalidRequest().WithDescription("requested_token_type not supported")
+	}
+	client, err := p.authorizeClient(ctx, req.ClientID, req.ClientSecret, req.ClientAssertion, req.ClientAssertionType, oidc.GrantTypeTokenExchange)
+	if err != nil {
+		return nil, err
+	}
+	// public clients cannot prove their identity and must therefore not be able to exchange (foreign) tokens
+	if client.AuthMethod() == oidc.AuthMethodNone {
+		return nil, oidc.ErrInvalidClient().WithDescription("client must authenticate for the token exchange")
+	}
+	policy, err := p.storage.query.SecurityPolicy(ctx)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	if !policy.TokenExchangeAllowed(client.GetID()) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("client is not allowed to exchange tokens")
+	}
+
+	var actor *usr_model.TokenView
+	if req.ActorToken != "" {
+		if !isAccessTokenType(req.ActorTokenType) {
+			return nil, oidc.ErrInvalidRequest().WithDescription("actor_token_type not supported")
+		}
+		actor, err = p.verifiedAccessToken(ctx, req.ActorToken)
+		if err != nil {
+			return nil, err
+		}
+		if !containsValue(actor.Audience, client.GetID()) {
+			return nil, oidc.ErrInvalidGrant().WithDescription("actor_token was not issued for the client")
+		}
+	}
+
+	var (
+		userID, orgID     string
+		allowedScopes     []string
+		allowedAudience   []string
+		subjectExpiration time.Time
+	)
+	switch {
+	case isAccessTokenType(req.SubjectTokenType):
+		subject, err := p.verifiedAccessToken(ctx, req.SubjectToken)
+		if err != nil {
+			return nil, err
+		}
+		userID, orgID = subject.UserID, subject.ResourceOwner
+		allowedScopes, allowedAudience = subject.Scopes, subject.Audience
+		subjectExpiration = subject.Expiration
+	case req.SubjectTokenType == TokenTypeUserID:
+		if actor == nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("actor_token is required for impersonation")
+		}
+		if !policy.ImpersonationAllowed(client.GetID()) {
+			return nil, oidc.ErrUnauthorizedClient().WithDescription("client is not allowed to impersonate users")
+		}
+		user, err := p.storage.query.GetUserByID(ctx, true, req.SubjectToken, false)
+		if err != nil {
+			return nil, oidc.ErrInvalidGrant().WithDescription("invalid subject_token").WithParent(err)
+		}
+		userID, orgID = user.ID, user.ResourceOwner
+		if err = p.checkImpersonationPermission(ctx, orgID, actor.UserID, userID); err != nil {
+			return nil, err
+		}
+		allowedScopes, allowedAudience = actor.Scopes, actor.Audience
+		subjectExpiration = actor.Expiration
+	default:
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token_type not supported")
+	}
+
+	scopes, err := downscope(req.Scopes, allowedScopes)
+	if err != nil {
+		return nil, oidc.ErrInvalidScope().WithDescription("%s", err)
+	}
+	audience, err := downscope(req.Audience, allowedAudience)
+	if err != nil {
+		return nil, errInvalidTarget().WithDescription("%s", err)
+	}
+	lifetime, _, _, _, err := p.storage.getOIDCSettings(ctx)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	// the exchanged token must not outlive the token it was exchanged for
+	if remaining := time.Until(subjectExpiration); remaining < lifetime {
+		lifetime = remaining
+	}
+
+	var actorUserID, actorOrgID string
+	if actor != nil {
+		actorUserID, actorOrgID = actor.UserID, actor.ResourceOwner
+	}
+	token, err := p.storage.command.ExchangeUserToken(setContextUserSystem(ctx), orgID, client.GetID(), userID, actorUserID, actorOrgID, audience, scopes, lifetime)
+	if err != nil {
+		return nil, oidc.ErrInvalidGrant().WithParent(err)
+	}
+
+	resp := &tokenExchangeResponse{
+		IssuedTokenType: TokenTypeAccessToken,
+		TokenType:       oidc.BearerToken,
+		ExpiresIn:       uint64(time.Until(token.Expiration).Seconds()),
+		Scopes:          token.Scopes,
+	}
+	if req.RequestedTokenType == TokenTypeJWT || client.AccessTokenType() == op.AccessTokenTypeJWT {
+		resp.IssuedTokenType = TokenTypeJWT
+		resp.AccessToken, err = p.createExchangedJWT(ctx, client, userID, actorUserID, token)
+	} else {
+		resp.AccessToken, err = op.CreateBearerToken(token.TokenID, userID, p.Crypto())
+	}
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	return resp, nil
+}
+
+// checkImpersonationPermission checks if the actor is allowed to impersonate the subject (see [impersonationAllowed]),
+// the permissions are granted by memberships on the organisation of the subject or the instance
+func (p *provider) checkImpersonationPermission(ctx context.Context, orgID, actorUserID, subjectUserID string) error {
+	actorPermissions, err := p.storage.query.MyZitadelPermissions(ctx, orgID, actorUserID)
+	if err != nil {
+		return oidc.ErrServerError().WithParent(err)
+	}
+	subjectPermissions, err := p.storage.query.MyZitadelPermissions(ctx, orgID, subjectUserID)
+	if err != nil {
+		return oidc.ErrServerError().WithParent(err)
+	}
+	subjectIsManager, err := p.isManager(ctx, subjectUserID)
+	if err != nil {
+		return oidc.ErrServerError().WithParent(err)
+	}
+	var actorInstancePermissions []string
+	if subjectIsManager {
+		permissions, err := p.storage.query.MyZitadelPermissions(ctx, authz.GetInstance(ctx).InstanceID(), actorUserID)
+		if err != nil {
+			return oidc.ErrServerError().WithParent(err)
+		}
+		actorInstancePermissions = permissions.Permissions
+	}
+	if !impersonationAllowed(actorPermissions.Permissions, actorInstancePermissions, subjectPermissions.Permissions, subjectIsManager) {
+		return oidc.ErrInvalidGrant().WithDescription("actor is not allowed to impersonate the user")
+	}
+	return nil
+}
+
+// isManager returns if the user is a member of the instance, an organisation, a project or a project grant
+func (p *provider) isManager(ctx context.Context, userID string) (bool, error) {
+	userIDQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return false, err
+	}
+	memberships, err := p.storage.query.Memberships(ctx, &query.MembershipSearchQuery{
+		SearchRequest: query.SearchRequest{Limit: 1},
+		Queries:       []query.SearchQuery{userIDQuery},
+	}, false)
+	if err != nil {
+		return false, err
+	}
+	return len(memberships.Memberships) > 0, nil
+}
+
+// impersonationAllowed returns if the actor may impersonate the subject,
+// so no permissions can be gained by impersonation:
+// end users can be impersonated with the impersonation permission on their organisation,
+// managers only with the impersonation permission on the instance.
+// In both cases the actor must hold every permission the subject holds on its organisation
+func impersonationAllowed(actorPermissions, actorInstancePermissions, subjectPermissions []string, subjectIsManager bool) bool {
+	if !authz.ExistsPerm(actorPermissions, permissionImpersonation) {
+		return false
+	}
+	if subjectIsManager && !authz.ExistsPerm(actorInstancePermissions, permissionImpersonation) {
+		return false
+	}
+	for _, permission := range subjectPermissions {
+		if !authz.ExistsPerm(actorPermissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// verifiedAccessToken returns the (active) token of the provided opaque or JWT access token
+func (p *provider) verifiedAccessToken(ctx context.Context, accessToken string) (*usr_model.TokenView, error) {
+	tokenID, subject, ok := p.tokenIDAndSubject(ctx, accessToken)
+	if !ok {
+		return nil, oidc.ErrInvalidGrant().WithDescription("token is not valid or has expired")
+	}
+	token, err := p.storage.repo.TokenByIDs(ctx, subject, tokenID)
+	if err != nil {
+		return nil, oidc.ErrInvalidGrant().WithDescription("token is not valid or has expired").WithParent(err)
+	}
+	if token.Expiration.Before(time.Now()) {
+		return nil, oidc.ErrInvalidGrant().WithDescription("token is not valid or has expired")
+	}
+	return token, nil
+}
+
+// tokenIDAndSubject extracts the token id and subject the same way the oidc library does for the userinfo endpoint
+func (p *provider) tokenIDAndSubject(ctx context.Context, accessToken string) (tokenID, subject string, ok bool) {
+	tokenIDSubject, err := p.Crypto().Decrypt(accessToken)
+	if err == nil {
+		splitToken := strings.Split(tokenIDSubject, ":")
+		if len(splitToken) != 2 {
+			return "", "", false
+		}
+		return splitToken[0], splitToken[1], true
+	}
+	claims, err := op.VerifyAccessToken(ctx, accessToken, p.AccessTokenVerifier(ctx))
+	if err != nil {
+		return "", "", false
+	}
+	return claims.GetTokenID(), claims.GetSubject(), true
+}
+
+// createExchangedJWT creates a JWT access token as [op.CreateJWT] does,
+// but with the act claim if the token was issued to an actor
+func (p *provider) createExchangedJWT(ctx context.Context, client op.Client, userID, actorUserID string, token *domain.Token) (string, error) {
+	issuer := op.IssuerFromContext(ctx)
+	claims := oidc.NewAccessTokenClaims(issuer, userID, token.Audience, token.Expiration, token.TokenID, client.GetID(), client.ClockSkew())
+	privateClaims, err := p.storage.GetPrivateClaimsFromScopes(ctx, userID, client.GetID(), client.RestrictAdditionalAccessTokenScopes()(token.Scopes))
+	if err != nil {
+		return "", err
+	}
+	if actorUserID != "" {
+		if privateClaims == nil {
+			privateClaims = make(map[string]interface{}, 1)
+		}
+		privateClaims[claimActor] = &actorClaim{
+			Issuer:  issuer,
+			Subject: actorUserID,
+		}
+	}
+	claims.SetPrivateClaims(privateClaims)
+	signingKey, err := p.storage.SigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	signer, err := op.SignerFromKey(signingKey)
+	if err != nil {
+		return "", err
+	}
+	return oidc_crypto.Sign(claims, signer)
+}
+
+func isAccessTokenType(tokenType string) bool {
+	return tokenType == TokenTypeAccessToken || tokenType == TokenTypeJWT
+}
+
+// downscope returns the requested values if all of them are allowed,
+// if none are requested, all allowed values are returned
+func downscope(requested, allowed []string) ([]string, error) {
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+	for _, value := range requested {
+		if !containsValue(allowed, value) {
+			return nil, downscopeError(value)
+		}
+	}
+	return requested, nil
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type downscopeError string
+
+func (e downscopeError) Error() string {
+	return string(e) + " exceeds the subject token"
+}
@@ -0,0 +1,91 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_impersonationAllowed(t *testing.T) {
+	orgImpersonator := []string{"impersonation"}
+	orgOwner := []string{"org.read", "org.write", "user.read", "user.write", "impersonation"}
+	iamOwner := []string{"iam.read", "iam.write", "org.read", "org.write", "user.read", "user.write", "impersonation"}
+	type args struct {
+		actorPermissions         []string
+		actorInstancePermissions []string
+		subjectPermissions       []string
+		subjectIsManager         bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "no impersonation permission",
+			args: args{
+				actorPermissions: []string{"user.read"},
+			},
+			want: false,
+		},
+		{
+			name: "org impersonator, end user",
+			args: args{
+				actorPermissions: orgImpersonator,
+			},
+			want: true,
+		},
+		{
+			name: "org impersonator, org owner",
+			args: args{
+				actorPermissions:   orgImpersonator,
+				subjectPermissions: orgOwner,
+				subjectIsManager:   true,
+			},
+			want: false,
+		},
+		{
+			name: "org impersonator, iam owner of the organisation",
+			args: args{
+				actorPermissions:   orgImpersonator,
+				subjectPermissions: iamOwner,
+				subjectIsManager:   true,
+			},
+			want: false,
+		},
+		{
+			name: "org owner, manager of other organisation",
+			args: args{
+				actorPermissions: orgOwner,
+				subjectIsManager: true,
+			},
+			want: false,
+		},
+		{
+			name: "instance impersonator, iam owner",
+			args: args{
+				actorPermissions:         orgImpersonator,
+				actorInstancePermissions: orgImpersonator,
+				subjectPermissions:       iamOwner,
+				subjectIsManager:         true,
+			},
+			want: false,
+		},
+		{
+			name: "iam owner, org owner",
+			args: args{
+				actorPermissions:         iamOwner,
+				actorInstancePermissions: iamOwner,
+				subjectPermissions:       orgOwner,
+				subjectIsManager:         true,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := impersonationAllowed(tt.args.actorPermissions, tt.args.actorInstancePermissions, tt.args.subjectPermissions, tt.args.subjectIsManager)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
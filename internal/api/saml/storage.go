@@ -4,12 +4,17 @@ import (
 	"context"
 	"time"
 
+	"github.com/dop251/goja"
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/saml/pkg/provider"
 	"github.com/zitadel/saml/pkg/provider/key"
 	"github.com/zitadel/saml/pkg/provider/models"
 	"github.com/zitadel/saml/pkg/provider/serviceprovider"
 	"github.com/zitadel/saml/pkg/provider/xml/samlp"
 
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/command"
@@ -127,7 +132,7 @@ func (p *Storage) SetUserinfoWithUserID(ctx context.Context, userinfo models.Att
 	}
 
 	setUserinfo(user, userinfo, attributes)
-	return nil
+	return p.samlResponseFlows(ctx, user, userinfo)
 }
 
 func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.AttributeSetter, loginName string, attributes []int) (err error) {
@@ -144,6 +149,70 @@ func (p *Storage) SetUserinfoWithLoginName(ctx context.Context, userinfo models.
 	}
 
 	setUserinfo(user, userinfo, attributes)
+	return p.samlResponseFlows(ctx, user, userinfo)
+}
+
+// samlResponseFlows runs the actions of the customise SAML response flow,
+// which are able to change the attributes of the assertion
+func (p *Storage) samlResponseFlows(ctx context.Context, user *query.User, userinfo models.AttributeSetter) error {
+	queriedActions, err := p.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeCustomiseSAMLResponse, domain.TriggerTypePreSAMLResponseCreation, user.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(call goja.FunctionCall) goja.Value {
+					return object.UserFromQuery(c, user)
+				}
+			}),
+			actions.SetFields("user",
+				actions.SetFields("getMetadata", func(c *actions.FieldConfig) interface{} {
+					return func(goja.FunctionCall) goja.Value {
+						resourceOwnerQuery, err := query.NewUserMetadataResourceOwnerSearchQuery(user.ResourceOwner)
+						if err != nil {
+							logging.WithError(err).Debug("unable to create search query")
+							panic(err)
+						}
+						metadata, err := p.query.SearchUserMetadata(
+							ctx,
+							true,
+							user.ID,
+							&query.UserMetadataSearchQueries{Queries: []query.SearchQuery{resourceOwnerQuery}},
+							false,
+						)
+						if err != nil {
+							logging.WithError(err).Info("unable to get md in action")
+							panic(err)
+						}
+						return object.UserMetadataListFromQuery(c, metadata)
+					}
+				}),
+			),
+		),
+	)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			object.SAMLAttributesAPIFields(userinfo),
+		),
+	)
+
+	for _, action := range queriedActions {
+		actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			action.Script,
+			action.Name,
+			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,201 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const bulkIDPrefix = "bulkId:"
+
+func (h *Handler) bulkHandler(w http.ResponseWriter, r *http.Request) {
+	req := new(BulkRequest)
+	if err := decodeBody(w, r, h.config.MaxBulkPayloadSize, req, "invalid bulk request"); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if h.config.MaxBulkOperations > 0 && len(req.Operations) > h.config.MaxBulkOperations {
+		writeError(w, r, newSCIMError(http.StatusRequestEntityTooLarge, scimTypeTooMany, "maximum operations exceeded"))
+		return
+	}
+	writeResponse(w, h.bulk(r.Context(), req), http.StatusOK)
+}
+
+// bulk executes the operations in the order of the request.
+// The processing stops after failOnErrors failed operations, if provided.
+func (h *Handler) bulk(ctx context.Context, req *BulkRequest) *BulkResponse {
+	resp := &BulkResponse{
+		Schemas:    []string{schemaBulkResponse},
+		Operations: make([]*BulkOperation, 0, len(req.Operations)),
+	}
+	bulkIDs := make(map[string]string)
+	errorCount := 0
+	for _, operation := range req.Operations {
+		result := h.bulkOperation(ctx, operation, bulkIDs)
+		resp.Operations = append(resp.Operations, result)
+		if result.Response == nil {
+			continue
+		}
+		errorCount++
+		if req.FailOnErrors > 0 && errorCount >= req.FailOnErrors {
+			break
+		}
+	}
+	return resp
+}
+
+// bulkOperation executes the operation and returns its result,
+// the response is only set if the operation failed
+func (h *Handler) bulkOperation(ctx context.Context, operation *BulkOperation, bulkIDs map[string]string) *BulkOperation {
+	result := &BulkOperation{
+		Method:  operation.Method,
+		BulkID:  operation.BulkID,
+		Version: operation.Version,
+	}
+	resource, status, err := h.executeBulkOperation(ctx, operation, bulkIDs)
+	if err != nil {
+		status, result.Response = errorResponse(err)
+		result.Status = strconv.Itoa(status)
+		return result
+	}
+	result.Status = strconv.Itoa(status)
+	if resource != nil {
+		result.Location = resource.Location
+		if operation.BulkID != "" {
+			bulkIDs[operation.BulkID] = resource.ID
+		}
+	}
+	return result
+}
+
+// bulkResource is the created or changed resource of a bulk operation
+type bulkResource struct {
+	ID       string
+	Location string
+}
+
+func (h *Handler) executeBulkOperation(ctx context.Context, operation *BulkOperation, bulkIDs map[string]string) (_ *bulkResource, status int, err error) {
+	endpoint, id, err := bulkOperationResource(operation.Path, bulkIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	method := strings.ToUpper(operation.Method)
+	if (method == http.MethodPost) != (id == "") {
+		return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidPath, "invalid path "+operation.Path+" for method "+operation.Method)
+	}
+	if endpoint == "/Groups" {
+		return h.executeBulkGroupOperation(ctx, method, id, operation.Data)
+	}
+	switch method {
+	case http.MethodPost:
+		user := new(User)
+		if err = json.Unmarshal(operation.Data, user); err != nil {
+			return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "invalid user")
+		}
+		user, err = h.createUser(ctx, user)
+		return userBulkResource(user), http.StatusCreated, err
+	case http.MethodPut:
+		user := new(User)
+		if err = json.Unmarshal(operation.Data, user); err != nil {
+			return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "invalid user")
+		}
+		user, err = h.replaceUser(ctx, id, user)
+		return userBulkResource(user), http.StatusOK, err
+	case http.MethodPatch:
+		patch := new(PatchRequest)
+		if err = json.Unmarshal(operation.Data, patch); err != nil {
+			return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "invalid patch request")
+		}
+		user, err := h.patchUser(ctx, id, patch)
+		return userBulkResource(user), http.StatusOK, err
+	case http.MethodDelete:
+		if !hasPermission(ctx, permissionUserDelete) {
+			return nil, 0, caos_errs.ThrowPermissionDenied(nil, "SCIM-Oa8sd", "No matching permissions found")
+		}
+		return nil, http.StatusNoContent, h.deleteUser(ctx, id)
+	}
+	return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "unsupported method "+operation.Method)
+}
+
+// executeBulkGroupOperation checks the permissions on the projects,
+// as the bulk request itself is only authorized for [permissionUserWrite]
+func (h *Handler) executeBulkGroupOperation(ctx context.Context, method, id string, data json.RawMessage) (_ *bulkResource, status int, err error) {
+	permission := permissionGroupWrite
+	if method == http.MethodDelete {
+		permission = permissionGroupDelete
+	}
+	if !hasPermission(ctx, permission) {
+		return nil, 0, caos_errs.ThrowPermissionDenied(nil, "SCIM-Ba8sf", "No matching permissions found")
+	}
+	switch method {
+	case http.MethodPost:
+		group := new(Group)
+		if err = json.Unmarshal(data, group); err != nil {
+			return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "invalid group")
+		}
+		group, err = h.createGroup(ctx, group)
+		return groupBulkResource(group), http.StatusCreated, err
+	case http.MethodPut:
+		group := new(Group)
+		if err = json.Unmarshal(data, group); err != nil {
+			return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "invalid group")
+		}
+		group, err = h.replaceGroup(ctx, id, group)
+		return groupBulkResource(group), http.StatusOK, err
+	case http.MethodPatch:
+		patch := new(PatchRequest)
+		if err = json.Unmarshal(data, patch); err != nil {
+			return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "invalid patch request")
+		}
+		group, err := h.patchGroup(ctx, id, patch)
+		return groupBulkResource(group), http.StatusOK, err
+	case http.MethodDelete:
+		return nil, http.StatusNoContent, h.deleteGroup(ctx, id)
+	}
+	return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "unsupported method "+method)
+}
+
+func userBulkResource(user *User) *bulkResource {
+	if user == nil {
+		return nil
+	}
+	return &bulkResource{ID: user.ID, Location: user.Meta.Location}
+}
+
+func groupBulkResource(group *Group) *bulkResource {
+	if group == nil {
+		return nil
+	}
+	return &bulkResource{ID: group.ID, Location: group.Meta.Location}
+}
+
+// bulkOperationResource returns the endpoint and the id of the resource in the path of the operation,
+// references to resources created in the same request (bulkId:<id>) are resolved
+func bulkOperationResource(path string, bulkIDs map[string]string) (endpoint, id string, err error) {
+	for _, resourceEndpoint := range []string{"/Users", "/Groups"} {
+		if strings.HasPrefix(path, resourceEndpoint) {
+			endpoint = resourceEndpoint
+			break
+		}
+	}
+	if endpoint == "" {
+		return "", "", newSCIMError(http.StatusBadRequest, scimTypeInvalidPath, "unsupported path "+path)
+	}
+	id = strings.TrimPrefix(path, endpoint)
+	if id != "" && !strings.HasPrefix(id, "/") {
+		return "", "", newSCIMError(http.StatusBadRequest, scimTypeInvalidPath, "unsupported path "+path)
+	}
+	id = strings.TrimPrefix(id, "/")
+	if !strings.HasPrefix(id, bulkIDPrefix) {
+		return endpoint, id, nil
+	}
+	resolved, ok := bulkIDs[strings.TrimPrefix(id, bulkIDPrefix)]
+	if !ok {
+		return "", "", newSCIMError(http.StatusConflict, scimTypeInvalidValue, "unresolved "+id)
+	}
+	return endpoint, resolved, nil
+}
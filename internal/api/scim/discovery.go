@@ -0,0 +1,234 @@
+package scim
+
+import (
+	"net/http"
+)
+
+type serviceProviderConfig struct {
+	Schemas               []string                `json:"schemas"`
+	DocumentationURI      string                  `json:"documentationUri,omitempty"`
+	Patch                 supported               `json:"patch"`
+	Bulk                  bulkConfig              `json:"bulk"`
+	Filter                filterConfig            `json:"filter"`
+	ChangePassword        supported               `json:"changePassword"`
+	Sort                  supported               `json:"sort"`
+	ETag                  supported               `json:"etag"`
+	AuthenticationSchemes []*authenticationScheme `json:"authenticationSchemes"`
+}
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type bulkConfig struct {
+	Supported      bool  `json:"supported"`
+	MaxOperations  int   `json:"maxOperations"`
+	MaxPayloadSize int64 `json:"maxPayloadSize"`
+}
+
+type filterConfig struct {
+	Supported  bool   `json:"supported"`
+	MaxResults uint64 `json:"maxResults"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type resourceType struct {
+	Schemas  []string `json:"schemas"`
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Endpoint string   `json:"endpoint"`
+	Schema   string   `json:"schema"`
+}
+
+type schema struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Attributes  []*attribute `json:"attributes"`
+}
+
+type attribute struct {
+	Name          string       `json:"name"`
+	Type          string       `json:"type"`
+	MultiValued   bool         `json:"multiValued"`
+	Required      bool         `json:"required"`
+	CaseExact     bool         `json:"caseExact"`
+	Mutability    string       `json:"mutability"`
+	Returned      string       `json:"returned"`
+	Uniqueness    string       `json:"uniqueness"`
+	SubAttributes []*attribute `json:"subAttributes,omitempty"`
+}
+
+func (h *Handler) serviceProviderConfig(w http.ResponseWriter, _ *http.Request) {
+	writeResponse(w, &serviceProviderConfig{
+		Schemas:          []string{schemaServiceProviderConfig},
+		DocumentationURI: "https://zitadel.com/docs",
+		Patch:            supported{Supported: true},
+		Bulk: bulkConfig{
+			Supported:      true,
+			MaxOperations:  h.config.MaxBulkOperations,
+			MaxPayloadSize: h.config.MaxBulkPayloadSize,
+		},
+		Filter: filterConfig{
+			Supported:  true,
+			MaxResults: h.config.MaxResults,
+		},
+		AuthenticationSchemes: []*authenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "OAuth Bearer Token",
+				Description: "Personal access token of a machine user with the permissions to manage the users of the organisation",
+				Primary:     true,
+			},
+		},
+	}, http.StatusOK)
+}
+
+func (h *Handler) resourceTypes(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: 2,
+		StartIndex:   1,
+		ItemsPerPage: 2,
+		Resources: []*resourceType{
+			{
+				Schemas:  []string{schemaResourceType},
+				ID:       resourceTypeUser,
+				Name:     resourceTypeUser,
+				Endpoint: "/Users",
+				Schema:   schemaUser,
+			},
+			{
+				Schemas:  []string{schemaResourceType},
+				ID:       resourceTypeGroup,
+				Name:     resourceTypeGroup,
+				Endpoint: "/Groups",
+				Schema:   schemaGroup,
+			},
+		},
+	}, http.StatusOK)
+}
+
+func (h *Handler) schemas(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: 2,
+		StartIndex:   1,
+		ItemsPerPage: 2,
+		Resources:    []*schema{userSchema, groupSchema},
+	}, http.StatusOK)
+}
+
+var userSchema = &schema{
+	Schemas:     []string{schemaSchema},
+	ID:          schemaUser,
+	Name:        resourceTypeUser,
+	Description: "User Account",
+	Attributes: []*attribute{
+		stringAttribute("userName", true, "readWrite", "server"),
+		stringAttribute("externalId", false, "readWrite", "none"),
+		{
+			Name:       "name",
+			Type:       "complex",
+			Required:   true,
+			Mutability: "readWrite",
+			Returned:   "default",
+			Uniqueness: "none",
+			SubAttributes: []*attribute{
+				stringAttribute("formatted", false, "readWrite", "none"),
+				stringAttribute("familyName", true, "readWrite", "none"),
+				stringAttribute("givenName", true, "readWrite", "none"),
+			},
+		},
+		stringAttribute("displayName", false, "readWrite", "none"),
+		stringAttribute("nickName", false, "readWrite", "none"),
+		stringAttribute("preferredLanguage", false, "readWrite", "none"),
+		{
+			Name:       "active",
+			Type:       "boolean",
+			Mutability: "readWrite",
+			Returned:   "default",
+			Uniqueness: "none",
+		},
+		{
+			Name:       "password",
+			Type:       "string",
+			Mutability: "writeOnly",
+			Returned:   "never",
+			Uniqueness: "none",
+		},
+		multiValuedAttribute("emails", true),
+		multiValuedAttribute("phoneNumbers", false),
+	},
+}
+
+var groupSchema = &schema{
+	Schemas:     []string{schemaSchema},
+	ID:          schemaGroup,
+	Name:        resourceTypeGroup,
+	Description: "Project of the organisation, the members are the users granted on the project",
+	Attributes: []*attribute{
+		stringAttribute("displayName", true, "readWrite", "none"),
+		{
+			Name:        "members",
+			Type:        "complex",
+			MultiValued: true,
+			Mutability:  "readWrite",
+			Returned:    "default",
+			Uniqueness:  "none",
+			SubAttributes: []*attribute{
+				stringAttribute("value", true, "immutable", "none"),
+				{
+					Name:       "$ref",
+					Type:       "reference",
+					Mutability: "immutable",
+					Returned:   "default",
+					Uniqueness: "none",
+				},
+				stringAttribute("display", false, "readOnly", "none"),
+				stringAttribute("type", false, "immutable", "none"),
+			},
+		},
+	},
+}
+
+func stringAttribute(name string, required bool, mutability, uniqueness string) *attribute {
+	return &attribute{
+		Name:       name,
+		Type:       "string",
+		Required:   required,
+		Mutability: mutability,
+		Returned:   "default",
+		Uniqueness: uniqueness,
+	}
+}
+
+func multiValuedAttribute(name string, required bool) *attribute {
+	return &attribute{
+		Name:        name,
+		Type:        "complex",
+		MultiValued: true,
+		Required:    required,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+		SubAttributes: []*attribute{
+			stringAttribute("value", true, "readWrite", "none"),
+			stringAttribute("type", false, "readWrite", "none"),
+			{
+				Name:       "primary",
+				Type:       "boolean",
+				Mutability: "readWrite",
+				Returned:   "default",
+				Uniqueness: "none",
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeNoTarget      = "noTarget"
+	scimTypeUniqueness    = "uniqueness"
+	scimTypeTooMany       = "tooMany"
+)
+
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Status   string   `json:"status"`
+}
+
+// scimError is returned by the parsers of the request
+// if the violation has a specific SCIM error type
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (err *scimError) Error() string {
+	return err.detail
+}
+
+func newSCIMError(status int, scimType, detail string) error {
+	return &scimError{status: status, scimType: scimType, detail: detail}
+}
+
+func errorResponse(err error) (int, *Error) {
+	status, scimType, detail := mapError(err)
+	return status, &Error{
+		Schemas:  []string{schemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+	}
+}
+
+func mapError(err error) (status int, scimType, detail string) {
+	scimErr := new(scimError)
+	if errors.As(err, &scimErr) {
+		return scimErr.status, scimErr.scimType, scimErr.detail
+	}
+	caosErr := new(caos_errs.CaosError)
+	if errors.As(err, &caosErr) {
+		detail = caosErr.GetMessage()
+	}
+	switch {
+	case caos_errs.IsErrorInvalidArgument(err):
+		return http.StatusBadRequest, scimTypeInvalidValue, detail
+	case caos_errs.IsPreconditionFailed(err):
+		return http.StatusBadRequest, "", detail
+	case caos_errs.IsErrorAlreadyExists(err):
+		return http.StatusConflict, scimTypeUniqueness, detail
+	case caos_errs.IsNotFound(err):
+		return http.StatusNotFound, "", detail
+	case caos_errs.IsUnauthenticated(err):
+		return http.StatusUnauthorized, "", detail
+	case caos_errs.IsPermissionDenied(err):
+		return http.StatusForbidden, "", detail
+	case caos_errs.IsResourceExhausted(err):
+		return http.StatusTooManyRequests, "", detail
+	case caos_errs.IsUnimplemented(err):
+		return http.StatusNotImplemented, "", detail
+	}
+	return http.StatusInternalServerError, "", detail
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status, resp := errorResponse(err)
+	if status == http.StatusInternalServerError {
+		logging.WithFields("uri", r.RequestURI).WithError(err).Warn("error occurred on scim api")
+	}
+	writeResponse(w, resp, status)
+}
+
+func writeResponse(w http.ResponseWriter, resp interface{}, status int) {
+	w.Header().Set("Content-Type", contentTypeSCIM)
+	w.WriteHeader(status)
+	if resp == nil {
+		return
+	}
+	err := json.NewEncoder(w).Encode(resp)
+	logging.OnError(err).Warn("unable to write scim response")
+}
@@ -0,0 +1,153 @@
+package scim
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// filter is a single comparison of a SCIM filter expression, e.g. `userName eq "gigi"`
+type filter struct {
+	attribute string
+	operator  string
+	value     string
+}
+
+// parseFilter parses the SCIM filter expression (RFC 7644 3.4.2.2).
+// Only comparisons combined by `and` are supported
+// as the searches of ZITADEL are not able to combine queries by `or` and `not`.
+func parseFilter(expression string) ([]*filter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	filters := make([]*filter, 0, (len(tokens)+1)/4)
+	for i := 0; i < len(tokens); {
+		if i > 0 {
+			if !strings.EqualFold(tokens[i], "and") {
+				return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "only the logical operator and is supported")
+			}
+			i++
+		}
+		if len(tokens) < i+3 {
+			return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "incomplete filter expression")
+		}
+		filters = append(filters, &filter{
+			attribute: strings.ToLower(tokens[i]),
+			operator:  strings.ToLower(tokens[i+1]),
+			value:     tokens[i+2],
+		})
+		i += 3
+	}
+	return filters, nil
+}
+
+func tokenizeFilter(expression string) (tokens []string, err error) {
+	var token strings.Builder
+	quoted := false
+	escaped := false
+	for _, r := range expression {
+		switch {
+		case escaped:
+			token.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			if quoted {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+			quoted = !quoted
+		case quoted:
+			token.WriteRune(r)
+		case r == ' ':
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		case r == '(' || r == ')':
+			return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "grouping is not supported")
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "unterminated string")
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens, nil
+}
+
+func (f *filter) toQuery() (query.SearchQuery, error) {
+	if f.attribute == "active" {
+		return f.activeQuery()
+	}
+	comparison, err := f.textComparison()
+	if err != nil {
+		return nil, err
+	}
+	switch f.attribute {
+	case "username":
+		return query.NewUserUsernameSearchQuery(f.value, comparison)
+	case "name.givenname":
+		return query.NewUserFirstNameSearchQuery(f.value, comparison)
+	case "name.familyname":
+		return query.NewUserLastNameSearchQuery(f.value, comparison)
+	case "displayname":
+		return query.NewUserDisplayNameSearchQuery(f.value, comparison)
+	case "nickname":
+		return query.NewUserNickNameSearchQuery(f.value, comparison)
+	case "emails", "emails.value":
+		return query.NewUserEmailSearchQuery(f.value, comparison)
+	case "phonenumbers", "phonenumbers.value":
+		return query.NewUserPhoneSearchQuery(f.value, comparison)
+	}
+	return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "filter on attribute "+f.attribute+" is not supported")
+}
+
+// toGroupQuery maps the filter on a group to the query of the projects,
+// filtering by members is not supported
+func (f *filter) toGroupQuery() (query.SearchQuery, error) {
+	comparison, err := f.textComparison()
+	if err != nil {
+		return nil, err
+	}
+	if f.attribute == "displayname" {
+		return query.NewProjectNameSearchQuery(comparison, f.value)
+	}
+	return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "filter on attribute "+f.attribute+" is not supported")
+}
+
+func (f *filter) textComparison() (query.TextComparison, error) {
+	switch f.operator {
+	case "eq":
+		return query.TextEqualsIgnoreCase, nil
+	case "sw":
+		return query.TextStartsWithIgnoreCase, nil
+	case "ew":
+		return query.TextEndsWithIgnoreCase, nil
+	case "co":
+		return query.TextContainsIgnoreCase, nil
+	}
+	return 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "operator "+f.operator+" is not supported")
+}
+
+func (f *filter) activeQuery() (query.SearchQuery, error) {
+	if f.operator != "eq" {
+		return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "operator "+f.operator+" is not supported for active")
+	}
+	active, err := strconv.ParseBool(f.value)
+	if err != nil {
+		return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidFilter, "active must be a boolean")
+	}
+	if active {
+		return query.NewUserStateSearchQuery(int32(domain.UserStateActive))
+	}
+	return query.NewUserStateSearchQuery(int32(domain.UserStateInactive))
+}
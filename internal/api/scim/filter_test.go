@@ -0,0 +1,127 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_parseFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []*filter
+		wantErr    bool
+	}{
+		{
+			name:       "single comparison",
+			expression: `userName eq "gigi@zitadel.com"`,
+			want: []*filter{
+				{attribute: "username", operator: "eq", value: "gigi@zitadel.com"},
+			},
+		},
+		{
+			name:       "combined by and",
+			expression: `name.givenName sw "Gi" AND active eq true`,
+			want: []*filter{
+				{attribute: "name.givenname", operator: "sw", value: "Gi"},
+				{attribute: "active", operator: "eq", value: "true"},
+			},
+		},
+		{
+			name:       "quoted value with spaces and escaped quote",
+			expression: `displayName co "Gigi \"the\" Giraffe"`,
+			want: []*filter{
+				{attribute: "displayname", operator: "co", value: `Gigi "the" Giraffe`},
+			},
+		},
+		{
+			name:       "or not supported",
+			expression: `userName eq "gigi" or userName eq "gaga"`,
+			wantErr:    true,
+		},
+		{
+			name:       "grouping not supported",
+			expression: `(userName eq "gigi")`,
+			wantErr:    true,
+		},
+		{
+			name:       "incomplete",
+			expression: `userName eq`,
+			wantErr:    true,
+		},
+		{
+			name:       "unterminated string",
+			expression: `userName eq "gigi`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.expression)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_filter_toQuery(t *testing.T) {
+	userNameQuery, _ := query.NewUserUsernameSearchQuery("gigi", query.TextEqualsIgnoreCase)
+	emailQuery, _ := query.NewUserEmailSearchQuery("zitadel.com", query.TextEndsWithIgnoreCase)
+	inactiveQuery, _ := query.NewUserStateSearchQuery(int32(domain.UserStateInactive))
+	tests := []struct {
+		name    string
+		filter  *filter
+		want    query.SearchQuery
+		wantErr bool
+	}{
+		{
+			name:   "userName",
+			filter: &filter{attribute: "username", operator: "eq", value: "gigi"},
+			want:   userNameQuery,
+		},
+		{
+			name:   "email value",
+			filter: &filter{attribute: "emails.value", operator: "ew", value: "zitadel.com"},
+			want:   emailQuery,
+		},
+		{
+			name:   "inactive",
+			filter: &filter{attribute: "active", operator: "eq", value: "false"},
+			want:   inactiveQuery,
+		},
+		{
+			name:    "unsupported operator",
+			filter:  &filter{attribute: "username", operator: "gt", value: "gigi"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported attribute",
+			filter:  &filter{attribute: "title", operator: "eq", value: "boss"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid active",
+			filter:  &filter{attribute: "active", operator: "eq", value: "yes"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.toQuery()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
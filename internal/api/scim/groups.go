@@ -0,0 +1,379 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// The groups of SCIM are mapped onto the projects of the organisation.
+// The members of a group are the users with a grant on the project,
+// adding a member creates a user grant (without roles), removing a member removes its grants on the project.
+
+func (h *Handler) listGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.listGroups(r.Context(), r.URL.Query())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, resp, http.StatusOK)
+}
+
+func (h *Handler) getGroupHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.getGroup(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, resp, http.StatusOK)
+}
+
+func (h *Handler) createGroupHandler(w http.ResponseWriter, r *http.Request) {
+	group := new(Group)
+	if err := decodeBody(w, r, h.config.MaxPayloadSize, group, "invalid group"); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resp, err := h.createGroup(r.Context(), group)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", resp.Meta.Location)
+	writeResponse(w, resp, http.StatusCreated)
+}
+
+func (h *Handler) replaceGroupHandler(w http.ResponseWriter, r *http.Request) {
+	group := new(Group)
+	if err := decodeBody(w, r, h.config.MaxPayloadSize, group, "invalid group"); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resp, err := h.replaceGroup(r.Context(), mux.Vars(r)["id"], group)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, resp, http.StatusOK)
+}
+
+func (h *Handler) patchGroupHandler(w http.ResponseWriter, r *http.Request) {
+	patch := new(PatchRequest)
+	if err := decodeBody(w, r, h.config.MaxPayloadSize, patch, "invalid patch request"); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resp, err := h.patchGroup(r.Context(), mux.Vars(r)["id"], patch)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, resp, http.StatusOK)
+}
+
+func (h *Handler) deleteGroupHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.deleteGroup(r.Context(), mux.Vars(r)["id"]); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listGroups(ctx context.Context, params url.Values) (*ListResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	searchQuery, startIndex, err := listGroupsToQuery(orgID, params, h.config.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := h.queries.SearchProjects(ctx, searchQuery, false)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*Group, 0, len(projects.Projects))
+	// a count of 0 only requests the total results
+	if params.Get(paramCount) != "0" {
+		for _, project := range projects.Projects {
+			resource, err := h.groupToResource(ctx, project, false)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: projects.Count,
+		StartIndex:   startIndex,
+		ItemsPerPage: uint64(len(resources)),
+		Resources:    resources,
+	}, nil
+}
+
+func (h *Handler) getGroup(ctx context.Context, id string) (*Group, error) {
+	project, err := h.projectByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	return h.groupToResource(ctx, project, false)
+}
+
+func (h *Handler) createGroup(ctx context.Context, resource *Group) (*Group, error) {
+	ctxData := authz.GetCtxData(ctx)
+	if len(resource.Members) > 0 && !hasPermission(ctx, permissionUserGrantWrite) {
+		return nil, caos_errs.ThrowPermissionDenied(nil, "SCIM-Hs7ma", "No matching permissions found")
+	}
+	project, err := h.commands.AddProject(ctx, &domain.Project{Name: strings.TrimSpace(resource.DisplayName)}, ctxData.OrgID, ctxData.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range resource.Members {
+		if err = h.addMember(ctx, project.AggregateID, member.Value); err != nil {
+			return nil, err
+		}
+	}
+	return h.getGroupTriggered(ctx, project.AggregateID)
+}
+
+func (h *Handler) replaceGroup(ctx context.Context, id string, resource *Group) (*Group, error) {
+	project, err := h.projectByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := h.projectUserGrants(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = h.updateGroup(ctx, project, grants, resource); err != nil {
+		return nil, err
+	}
+	return h.getGroupTriggered(ctx, id)
+}
+
+func (h *Handler) patchGroup(ctx context.Context, id string, patch *PatchRequest) (*Group, error) {
+	project, err := h.projectByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := h.projectUserGrants(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	resource := groupToResource(project, grants, "")
+	if err = applyGroupPatch(resource, patch.Operations); err != nil {
+		return nil, err
+	}
+	if err = h.updateGroup(ctx, project, grants, resource); err != nil {
+		return nil, err
+	}
+	return h.getGroupTriggered(ctx, id)
+}
+
+func (h *Handler) deleteGroup(ctx context.Context, id string) error {
+	if _, err := h.projectByID(ctx, id, false); err != nil {
+		return err
+	}
+	grants, err := h.projectUserGrants(ctx, id, true)
+	if err != nil {
+		return err
+	}
+	_, err = h.commands.RemoveProject(ctx, id, authz.GetCtxData(ctx).OrgID, userGrantsToIDs(grants)...)
+	return err
+}
+
+// updateGroup executes the commands for the differences between the existing project (and its grants) and the resource
+func (h *Handler) updateGroup(ctx context.Context, project *query.Project, grants []*query.UserGrant, resource *Group) (err error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	if name := strings.TrimSpace(resource.DisplayName); name != project.Name {
+		_, err = h.commands.ChangeProject(ctx, &domain.Project{
+			ObjectRoot:             models.ObjectRoot{AggregateID: project.ID, ResourceOwner: orgID},
+			Name:                   name,
+			ProjectRoleAssertion:   project.ProjectRoleAssertion,
+			ProjectRoleCheck:       project.ProjectRoleCheck,
+			HasProjectCheck:        project.HasProjectCheck,
+			PrivateLabelingSetting: project.PrivateLabelingSetting,
+		}, orgID)
+		if err != nil {
+			return err
+		}
+	}
+	added, removed := memberChanges(grants, resource.Members)
+	if len(added) > 0 && !hasPermission(ctx, permissionUserGrantWrite) {
+		return caos_errs.ThrowPermissionDenied(nil, "SCIM-Pa0sm", "No matching permissions found")
+	}
+	if len(removed) > 0 && !hasPermission(ctx, permissionUserGrantDelete) {
+		return caos_errs.ThrowPermissionDenied(nil, "SCIM-Pa0sn", "No matching permissions found")
+	}
+	for _, userID := range added {
+		if err = h.addMember(ctx, project.ID, userID); err != nil {
+			return err
+		}
+	}
+	if len(removed) > 0 {
+		return h.commands.BulkRemoveUserGrant(ctx, removed, orgID)
+	}
+	return nil
+}
+
+func (h *Handler) addMember(ctx context.Context, projectID, userID string) error {
+	_, err := h.commands.AddUserGrant(ctx, &domain.UserGrant{
+		UserID:    userID,
+		ProjectID: projectID,
+	}, authz.GetCtxData(ctx).OrgID)
+	return err
+}
+
+// projectByID returns the project of the organisation
+func (h *Handler) projectByID(ctx context.Context, id string, triggerBulk bool) (*query.Project, error) {
+	project, err := h.queries.ProjectByID(ctx, triggerBulk, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if project.ResourceOwner != authz.GetCtxData(ctx).OrgID {
+		return nil, caos_errs.ThrowNotFound(nil, "SCIM-Gk2ma", "Errors.Project.NotFound")
+	}
+	return project, nil
+}
+
+// projectUserGrants returns the user grants of the organisation on the project
+func (h *Handler) projectUserGrants(ctx context.Context, projectID string, triggerBulk bool) ([]*query.UserGrant, error) {
+	projectQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewUserGrantResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := h.queries.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{projectQuery, ownerQuery},
+	}, triggerBulk, false)
+	if err != nil {
+		return nil, err
+	}
+	return grants.UserGrants, nil
+}
+
+// getGroupTriggered returns the group after a change, the projections are triggered to include the change
+func (h *Handler) getGroupTriggered(ctx context.Context, id string) (*Group, error) {
+	project, err := h.projectByID(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	return h.groupToResource(ctx, project, true)
+}
+
+func (h *Handler) groupToResource(ctx context.Context, project *query.Project, triggerBulk bool) (*Group, error) {
+	grants, err := h.projectUserGrants(ctx, project.ID, triggerBulk)
+	if err != nil {
+		return nil, err
+	}
+	resource := groupToResource(project, grants, h.groupLocation(ctx, project.ID))
+	for _, member := range resource.Members {
+		member.Ref = h.userLocation(ctx, member.Value)
+	}
+	return resource, nil
+}
+
+func listGroupsToQuery(orgID string, params url.Values, maxResults uint64) (_ *query.ProjectSearchQueries, startIndex uint64, err error) {
+	startIndex, count, err := paginationParams(params, maxResults)
+	if err != nil {
+		return nil, 0, err
+	}
+	queries := make([]query.SearchQuery, 1)
+	if queries[0], err = query.NewProjectResourceOwnerSearchQuery(orgID); err != nil {
+		return nil, 0, err
+	}
+	if expression := params.Get(paramFilter); expression != "" {
+		filters, err := parseFilter(expression)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, filter := range filters {
+			filterQuery, err := filter.toGroupQuery()
+			if err != nil {
+				return nil, 0, err
+			}
+			queries = append(queries, filterQuery)
+		}
+	}
+	searchQuery := &query.ProjectSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: startIndex - 1,
+			Limit:  count,
+			Asc:    !strings.EqualFold(params.Get(paramSortOrder), "descending"),
+		},
+		Queries: queries,
+	}
+	switch strings.ToLower(params.Get(paramSortBy)) {
+	case "":
+	case "displayname":
+		searchQuery.SortingColumn = query.ProjectColumnName
+	default:
+		return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "sorting by "+params.Get(paramSortBy)+" is not supported")
+	}
+	return searchQuery, startIndex, nil
+}
+
+func groupToResource(project *query.Project, grants []*query.UserGrant, location string) *Group {
+	resource := &Group{
+		Schemas:     []string{schemaGroup},
+		ID:          project.ID,
+		DisplayName: project.Name,
+		Members:     make([]*GroupMember, 0, len(grants)),
+		Meta: &Meta{
+			ResourceType: resourceTypeGroup,
+			Created:      project.CreationDate,
+			LastModified: project.ChangeDate,
+			Location:     location,
+			Version:      `W/"` + strconv.FormatUint(project.Sequence, 10) + `"`,
+		},
+	}
+	// a user can have multiple grants on the project (e.g. of project grants), but is a member only once
+	members := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		if members[grant.UserID] {
+			continue
+		}
+		members[grant.UserID] = true
+		resource.Members = append(resource.Members, &GroupMember{
+			Value:   grant.UserID,
+			Display: grant.DisplayName,
+			Type:    resourceTypeUser,
+		})
+	}
+	return resource
+}
+
+// memberChanges returns the ids of the users to be added
+// and the ids of the user grants to be removed to get the requested members
+func memberChanges(grants []*query.UserGrant, members []*GroupMember) (addedUserIDs, removedGrantIDs []string) {
+	requested := make(map[string]bool, len(members))
+	for _, member := range members {
+		requested[member.Value] = true
+	}
+	existing := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		existing[grant.UserID] = true
+		if !requested[grant.UserID] {
+			removedGrantIDs = append(removedGrantIDs, grant.ID)
+		}
+	}
+	for _, member := range members {
+		if existing[member.Value] {
+			continue
+		}
+		existing[member.Value] = true
+		addedUserIDs = append(addedUserIDs, member.Value)
+	}
+	return addedUserIDs, removedGrantIDs
+}
@@ -0,0 +1,231 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_listGroupsToQuery(t *testing.T) {
+	ownerQuery, _ := query.NewProjectResourceOwnerSearchQuery("org-id")
+	nameQuery, _ := query.NewProjectNameSearchQuery(query.TextStartsWithIgnoreCase, "app")
+	type res struct {
+		query      *query.ProjectSearchQueries
+		startIndex uint64
+		err        bool
+	}
+	tests := []struct {
+		name   string
+		params url.Values
+		res    res
+	}{
+		{
+			name:   "defaults",
+			params: url.Values{},
+			res: res{
+				query: &query.ProjectSearchQueries{
+					SearchRequest: query.SearchRequest{Limit: 100, Asc: true},
+					Queries:       []query.SearchQuery{ownerQuery},
+				},
+				startIndex: 1,
+			},
+		},
+		{
+			name: "pagination, sorting and filter",
+			params: url.Values{
+				paramStartIndex: []string{"3"},
+				paramCount:      []string{"20"},
+				paramSortBy:     []string{"displayName"},
+				paramFilter:     []string{`displayName sw "app"`},
+			},
+			res: res{
+				query: &query.ProjectSearchQueries{
+					SearchRequest: query.SearchRequest{Offset: 2, Limit: 20, Asc: true, SortingColumn: query.ProjectColumnName},
+					Queries:       []query.SearchQuery{ownerQuery, nameQuery},
+				},
+				startIndex: 3,
+			},
+		},
+		{
+			name: "filter on members",
+			params: url.Values{
+				paramFilter: []string{`members.value eq "user-id"`},
+			},
+			res: res{
+				err: true,
+			},
+		},
+		{
+			name: "unsupported sorting",
+			params: url.Values{
+				paramSortBy: []string{"members"},
+			},
+			res: res{
+				err: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, startIndex, err := listGroupsToQuery("org-id", tt.params, 100)
+			if tt.res.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.res.query, got)
+			assert.Equal(t, tt.res.startIndex, startIndex)
+		})
+	}
+}
+
+func Test_groupToResource(t *testing.T) {
+	date := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := groupToResource(&query.Project{
+		ID:           "project-id",
+		CreationDate: date,
+		ChangeDate:   date,
+		Sequence:     5,
+		Name:         "App",
+	}, []*query.UserGrant{
+		{ID: "grant1", UserID: "user1", DisplayName: "Gigi Giraffe"},
+		{ID: "grant2", UserID: "user2", DisplayName: "Gaga Gazelle"},
+		{ID: "grant3", UserID: "user1", DisplayName: "Gigi Giraffe"},
+	}, "https://zitadel.cloud/scim/v2/Groups/project-id")
+	assert.Equal(t, &Group{
+		Schemas:     []string{schemaGroup},
+		ID:          "project-id",
+		DisplayName: "App",
+		Members: []*GroupMember{
+			{Value: "user1", Display: "Gigi Giraffe", Type: resourceTypeUser},
+			{Value: "user2", Display: "Gaga Gazelle", Type: resourceTypeUser},
+		},
+		Meta: &Meta{
+			ResourceType: resourceTypeGroup,
+			Created:      date,
+			LastModified: date,
+			Location:     "https://zitadel.cloud/scim/v2/Groups/project-id",
+			Version:      `W/"5"`,
+		},
+	}, got)
+}
+
+func Test_memberChanges(t *testing.T) {
+	grants := []*query.UserGrant{
+		{ID: "grant1", UserID: "user1"},
+		{ID: "grant2", UserID: "user2"},
+		{ID: "grant3", UserID: "user2"},
+	}
+	added, removed := memberChanges(grants, []*GroupMember{
+		{Value: "user1"},
+		{Value: "user3"},
+		{Value: "user3"},
+	})
+	assert.Equal(t, []string{"user3"}, added)
+	assert.Equal(t, []string{"grant2", "grant3"}, removed)
+
+	added, removed = memberChanges(grants, []*GroupMember{{Value: "user1"}, {Value: "user2"}})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func Test_applyGroupPatch(t *testing.T) {
+	newGroup := func() *Group {
+		return &Group{
+			DisplayName: "App",
+			Members:     []*GroupMember{{Value: "user1"}, {Value: "user2"}},
+		}
+	}
+	tests := []struct {
+		name       string
+		operations string
+		want       func(*Group)
+		wantErr    bool
+	}{
+		{
+			name:       "replace display name",
+			operations: `[{"op":"replace","path":"displayName","value":"Portal"}]`,
+			want: func(g *Group) {
+				g.DisplayName = "Portal"
+			},
+		},
+		{
+			name:       "replace without path, id ignored",
+			operations: `[{"op":"Replace","value":{"id":"project-id","displayName":"Portal"}}]`,
+			want: func(g *Group) {
+				g.DisplayName = "Portal"
+			},
+		},
+		{
+			name:       "add members",
+			operations: `[{"op":"Add","path":"members","value":[{"value":"user3"}]}]`,
+			want: func(g *Group) {
+				g.Members = append(g.Members, &GroupMember{Value: "user3"})
+			},
+		},
+		{
+			name:       "replace members",
+			operations: `[{"op":"replace","path":"members","value":[{"value":"user3"}]}]`,
+			want: func(g *Group) {
+				g.Members = []*GroupMember{{Value: "user3"}}
+			},
+		},
+		{
+			name:       "remove member by value filter",
+			operations: `[{"op":"remove","path":"members[value eq \"user1\"]"}]`,
+			want: func(g *Group) {
+				g.Members = []*GroupMember{{Value: "user2"}}
+			},
+		},
+		{
+			name:       "remove members by value",
+			operations: `[{"op":"remove","path":"urn:ietf:params:scim:schemas:core:2.0:Group:members","value":[{"value":"user2"}]}]`,
+			want: func(g *Group) {
+				g.Members = []*GroupMember{{Value: "user1"}}
+			},
+		},
+		{
+			name:       "remove all members",
+			operations: `[{"op":"remove","path":"members"}]`,
+			want: func(g *Group) {
+				g.Members = nil
+			},
+		},
+		{
+			name:       "remove display name",
+			operations: `[{"op":"remove","path":"displayName"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported member filter",
+			operations: `[{"op":"remove","path":"members[display eq \"Gigi\"]"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid members",
+			operations: `[{"op":"add","path":"members","value":"user3"}]`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var operations []*PatchOperation
+			assert.NoError(t, json.Unmarshal([]byte(tt.operations), &operations))
+			group := newGroup()
+			err := applyGroupPatch(group, operations)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			want := newGroup()
+			tt.want(want)
+			assert.Equal(t, want, group)
+		})
+	}
+}
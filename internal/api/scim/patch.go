@@ -0,0 +1,336 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	patchOpAdd     = "add"
+	patchOpReplace = "replace"
+	patchOpRemove  = "remove"
+)
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// applyPatch applies the operations (RFC 7644 3.5.2) on the user.
+// As the user has a single email and phone number, value filters on
+// multi-valued attributes (e.g. `emails[type eq "work"].value`) always target these values.
+func applyPatch(user *User, operations []*PatchOperation) error {
+	for _, operation := range operations {
+		if err := applyPatchOperation(user, operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyPatchOperation(user *User, operation *PatchOperation) error {
+	switch strings.ToLower(operation.Op) {
+	case patchOpAdd, patchOpReplace:
+		if operation.Path != "" {
+			return setAttribute(user, operation.Path, operation.Value)
+		}
+		attributes := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "value must be an object if no path is provided")
+		}
+		for path, value := range attributes {
+			if err := setAttribute(user, path, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	case patchOpRemove:
+		if operation.Path == "" {
+			return newSCIMError(http.StatusBadRequest, scimTypeNoTarget, "path is required for remove operations")
+		}
+		return removeAttribute(user, operation.Path)
+	}
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "unsupported operation "+operation.Op)
+}
+
+// normalizePath removes the schema prefix and value filters and lowers the path,
+// as attribute names are case insensitive
+func normalizePath(path string) string {
+	path = strings.ToLower(path)
+	path = strings.TrimPrefix(path, strings.ToLower(schemaUser)+":")
+	if start := strings.Index(path, "["); start > 0 {
+		if end := strings.Index(path, "]"); end > start {
+			path = path[:start] + path[end+1:]
+		}
+	}
+	return path
+}
+
+func setAttribute(user *User, path string, value json.RawMessage) (err error) {
+	switch normalizePath(path) {
+	case "username":
+		return unmarshalString(value, &user.UserName)
+	case "externalid":
+		return unmarshalString(value, &user.ExternalID)
+	case "displayname":
+		return unmarshalString(value, &user.DisplayName)
+	case "nickname":
+		return unmarshalString(value, &user.NickName)
+	case "preferredlanguage":
+		return unmarshalString(value, &user.PreferredLanguage)
+	case "password":
+		return unmarshalString(value, &user.Password)
+	case "active":
+		active, err := unmarshalBool(value)
+		if err != nil {
+			return err
+		}
+		user.Active = &active
+		return nil
+	case "name":
+		name := new(Name)
+		if err := json.Unmarshal(value, name); err != nil {
+			return newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "name must be an object")
+		}
+		if user.Name == nil {
+			user.Name = new(Name)
+		}
+		if name.GivenName != "" {
+			user.Name.GivenName = name.GivenName
+		}
+		if name.FamilyName != "" {
+			user.Name.FamilyName = name.FamilyName
+		}
+		if name.Formatted != "" {
+			user.Name.Formatted = name.Formatted
+		}
+		return nil
+	case "name.givenname":
+		if user.Name == nil {
+			user.Name = new(Name)
+		}
+		return unmarshalString(value, &user.Name.GivenName)
+	case "name.familyname":
+		if user.Name == nil {
+			user.Name = new(Name)
+		}
+		return unmarshalString(value, &user.Name.FamilyName)
+	case "name.formatted":
+		if user.Name == nil {
+			user.Name = new(Name)
+		}
+		return unmarshalString(value, &user.Name.Formatted)
+	case "emails":
+		user.Emails, err = unmarshalMultiValues(value)
+		return err
+	case "emails.value":
+		user.Emails, err = unmarshalSingleValue(value)
+		return err
+	case "phonenumbers":
+		user.PhoneNumbers, err = unmarshalMultiValues(value)
+		return err
+	case "phonenumbers.value":
+		user.PhoneNumbers, err = unmarshalSingleValue(value)
+		return err
+	}
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidPath, "attribute "+path+" is not supported")
+}
+
+func removeAttribute(user *User, path string) error {
+	switch normalizePath(path) {
+	case "externalid":
+		user.ExternalID = ""
+	case "displayname":
+		user.DisplayName = ""
+	case "nickname":
+		user.NickName = ""
+	case "preferredlanguage":
+		user.PreferredLanguage = ""
+	case "name.formatted":
+		if user.Name != nil {
+			user.Name.Formatted = ""
+		}
+	case "phonenumbers", "phonenumbers.value":
+		user.PhoneNumbers = nil
+	case "username", "name", "name.givenname", "name.familyname", "emails", "emails.value", "active", "password":
+		return newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "attribute "+path+" is required")
+	default:
+		return newSCIMError(http.StatusBadRequest, scimTypeInvalidPath, "attribute "+path+" is not supported")
+	}
+	return nil
+}
+
+func unmarshalString(value json.RawMessage, target *string) error {
+	if err := json.Unmarshal(value, target); err != nil {
+		return newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "value must be a string")
+	}
+	return nil
+}
+
+// unmarshalBool also accepts booleans as strings (e.g. "False") which are sent by some clients
+func unmarshalBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err = strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "value must be a boolean")
+}
+
+func unmarshalMultiValues(value json.RawMessage) ([]*MultiValue, error) {
+	var values []*MultiValue
+	if err := json.Unmarshal(value, &values); err != nil {
+		return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "value must be a list")
+	}
+	return values, nil
+}
+
+func unmarshalSingleValue(value json.RawMessage) ([]*MultiValue, error) {
+	multiValue := &MultiValue{Primary: true}
+	if err := unmarshalString(value, &multiValue.Value); err != nil {
+		return nil, err
+	}
+	return []*MultiValue{multiValue}, nil
+}
+
+// applyGroupPatch applies the operations (RFC 7644 3.5.2) on the group.
+// Members can be removed either by a value filter on their id (e.g. `members[value eq "id"]`)
+// or by a list of members provided as value.
+func applyGroupPatch(group *Group, operations []*PatchOperation) error {
+	for _, operation := range operations {
+		if err := applyGroupPatchOperation(group, operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyGroupPatchOperation(group *Group, operation *PatchOperation) error {
+	switch strings.ToLower(operation.Op) {
+	case patchOpAdd, patchOpReplace:
+		replace := strings.EqualFold(operation.Op, patchOpReplace)
+		if operation.Path != "" {
+			return setGroupAttribute(group, operation.Path, operation.Value, replace)
+		}
+		attributes := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "value must be an object if no path is provided")
+		}
+		for path, value := range attributes {
+			// some clients send the id of the group with the changed attributes
+			if strings.EqualFold(path, "id") {
+				continue
+			}
+			if err := setGroupAttribute(group, path, value, replace); err != nil {
+				return err
+			}
+		}
+		return nil
+	case patchOpRemove:
+		if operation.Path == "" {
+			return newSCIMError(http.StatusBadRequest, scimTypeNoTarget, "path is required for remove operations")
+		}
+		return removeGroupAttribute(group, operation.Path, operation.Value)
+	}
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, "unsupported operation "+operation.Op)
+}
+
+func setGroupAttribute(group *Group, path string, value json.RawMessage, replace bool) error {
+	switch attribute, memberID := groupPath(path); attribute {
+	case "displayname":
+		return unmarshalString(value, &group.DisplayName)
+	case "members":
+		members, err := unmarshalMembers(value)
+		if err != nil {
+			return err
+		}
+		if memberID != "" {
+			group.Members = removeMembers(group.Members, []*GroupMember{{Value: memberID}})
+		} else if replace {
+			group.Members = nil
+		}
+		group.Members = append(group.Members, members...)
+		return nil
+	}
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidPath, "attribute "+path+" is not supported")
+}
+
+func removeGroupAttribute(group *Group, path string, value json.RawMessage) error {
+	switch attribute, memberID := groupPath(path); attribute {
+	case "members":
+		switch {
+		case memberID != "":
+			group.Members = removeMembers(group.Members, []*GroupMember{{Value: memberID}})
+		case len(value) > 0:
+			members, err := unmarshalMembers(value)
+			if err != nil {
+				return err
+			}
+			group.Members = removeMembers(group.Members, members)
+		default:
+			group.Members = nil
+		}
+		return nil
+	case "displayname":
+		return newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "attribute "+path+" is required")
+	}
+	return newSCIMError(http.StatusBadRequest, scimTypeInvalidPath, "attribute "+path+" is not supported")
+}
+
+// groupPath returns the lowered attribute of the path and the id of the member of a value filter (`members[value eq "id"]`)
+func groupPath(path string) (attribute, memberID string) {
+	if prefix := schemaGroup + ":"; len(path) > len(prefix) && strings.EqualFold(path[:len(prefix)], prefix) {
+		path = path[len(prefix):]
+	}
+	start := strings.Index(path, "[")
+	end := strings.LastIndex(path, "]")
+	if start < 0 || end < start {
+		return strings.ToLower(path), ""
+	}
+	filters, err := parseFilter(path[start+1 : end])
+	if err != nil || len(filters) != 1 || filters[0].attribute != "value" || filters[0].operator != "eq" {
+		// an unsupported filter results in an unsupported path
+		return strings.ToLower(path), ""
+	}
+	return strings.ToLower(path[:start] + path[end+1:]), filters[0].value
+}
+
+// unmarshalMembers accepts a list of members or a single member
+func unmarshalMembers(value json.RawMessage) ([]*GroupMember, error) {
+	var members []*GroupMember
+	if err := json.Unmarshal(value, &members); err == nil {
+		return members, nil
+	}
+	member := new(GroupMember)
+	if err := json.Unmarshal(value, member); err != nil || member.Value == "" {
+		return nil, newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "value must be a list of members")
+	}
+	return []*GroupMember{member}, nil
+}
+
+func removeMembers(members, removed []*GroupMember) []*GroupMember {
+	remaining := make([]*GroupMember, 0, len(members))
+	for _, member := range members {
+		if !containsMember(removed, member.Value) {
+			remaining = append(remaining, member)
+		}
+	}
+	return remaining
+}
+
+func containsMember(members []*GroupMember, id string) bool {
+	for _, member := range members {
+		if member.Value == id {
+			return true
+		}
+	}
+	return false
+}
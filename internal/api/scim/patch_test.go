@@ -0,0 +1,118 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyPatch(t *testing.T) {
+	active := true
+	inactive := false
+	newUser := func() *User {
+		return &User{
+			UserName: "gigi",
+			Name: &Name{
+				GivenName:  "Gigi",
+				FamilyName: "Giraffe",
+			},
+			DisplayName:  "Gigi Giraffe",
+			Active:       &active,
+			Emails:       []*MultiValue{{Value: "gigi@zitadel.com", Primary: true}},
+			PhoneNumbers: []*MultiValue{{Value: "+41791234567", Primary: true}},
+		}
+	}
+	tests := []struct {
+		name       string
+		operations string
+		want       func(*User)
+		wantErr    bool
+	}{
+		{
+			name:       "replace with path",
+			operations: `[{"op":"replace","path":"name.givenName","value":"Gaga"}]`,
+			want: func(u *User) {
+				u.Name.GivenName = "Gaga"
+			},
+		},
+		{
+			name:       "replace without path",
+			operations: `[{"op":"Replace","value":{"userName":"gaga","name":{"familyName":"Gazelle"},"externalId":"ext"}}]`,
+			want: func(u *User) {
+				u.UserName = "gaga"
+				u.Name.FamilyName = "Gazelle"
+				u.ExternalID = "ext"
+			},
+		},
+		{
+			name:       "active as string",
+			operations: `[{"op":"Replace","path":"active","value":"False"}]`,
+			want: func(u *User) {
+				u.Active = &inactive
+			},
+		},
+		{
+			name:       "value filter on email",
+			operations: `[{"op":"replace","path":"emails[type eq \"work\"].value","value":"gaga@zitadel.com"}]`,
+			want: func(u *User) {
+				u.Emails = []*MultiValue{{Value: "gaga@zitadel.com", Primary: true}}
+			},
+		},
+		{
+			name:       "schema prefixed path",
+			operations: `[{"op":"add","path":"urn:ietf:params:scim:schemas:core:2.0:User:nickName","value":"gg"}]`,
+			want: func(u *User) {
+				u.NickName = "gg"
+			},
+		},
+		{
+			name:       "remove phone",
+			operations: `[{"op":"remove","path":"phoneNumbers"}]`,
+			want: func(u *User) {
+				u.PhoneNumbers = nil
+			},
+		},
+		{
+			name:       "remove required attribute",
+			operations: `[{"op":"remove","path":"userName"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "remove without path",
+			operations: `[{"op":"remove"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported attribute",
+			operations: `[{"op":"add","path":"title","value":"boss"}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid value",
+			operations: `[{"op":"replace","path":"userName","value":1}]`,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported operation",
+			operations: `[{"op":"move","path":"userName","value":"gaga"}]`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var operations []*PatchOperation
+			assert.NoError(t, json.Unmarshal([]byte(tt.operations), &operations))
+			user := newUser()
+			err := applyPatch(user, operations)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			want := newUser()
+			tt.want(want)
+			assert.Equal(t, want, user)
+		})
+	}
+}
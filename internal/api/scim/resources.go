@@ -0,0 +1,127 @@
+package scim
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaBulkRequest           = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	schemaBulkResponse          = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	resourceTypeUser  = "User"
+	resourceTypeGroup = "Group"
+
+	contentTypeSCIM = "application/scim+json"
+)
+
+// User is the SCIM representation of a human user.
+// ZITADEL users have a single email address and phone number,
+// multi-valued attributes therefore contain at most one value.
+type User struct {
+	Schemas           []string      `json:"schemas"`
+	ID                string        `json:"id,omitempty"`
+	ExternalID        string        `json:"externalId,omitempty"`
+	UserName          string        `json:"userName"`
+	Name              *Name         `json:"name,omitempty"`
+	DisplayName       string        `json:"displayName,omitempty"`
+	NickName          string        `json:"nickName,omitempty"`
+	PreferredLanguage string        `json:"preferredLanguage,omitempty"`
+	Active            *bool         `json:"active,omitempty"`
+	Password          string        `json:"password,omitempty"`
+	Emails            []*MultiValue `json:"emails,omitempty"`
+	PhoneNumbers      []*MultiValue `json:"phoneNumbers,omitempty"`
+	Meta              *Meta         `json:"meta,omitempty"`
+}
+
+// Group is the SCIM representation of a project of the organisation.
+// The members are the users with a grant on the project.
+type Group struct {
+	Schemas     []string       `json:"schemas"`
+	ID          string         `json:"id,omitempty"`
+	DisplayName string         `json:"displayName"`
+	Members     []*GroupMember `json:"members,omitempty"`
+	Meta        *Meta          `json:"meta,omitempty"`
+}
+
+type GroupMember struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+type MultiValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+	Version      string    `json:"version,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults uint64      `json:"totalResults"`
+	StartIndex   uint64      `json:"startIndex"`
+	ItemsPerPage uint64      `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+type BulkRequest struct {
+	Schemas      []string         `json:"schemas"`
+	FailOnErrors int              `json:"failOnErrors,omitempty"`
+	Operations   []*BulkOperation `json:"Operations"`
+}
+
+type BulkOperation struct {
+	Method   string          `json:"method"`
+	BulkID   string          `json:"bulkId,omitempty"`
+	Version  string          `json:"version,omitempty"`
+	Path     string          `json:"path"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Location string          `json:"location,omitempty"`
+	Status   string          `json:"status,omitempty"`
+	Response interface{}     `json:"response,omitempty"`
+}
+
+type BulkResponse struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []*BulkOperation `json:"Operations"`
+}
+
+func primaryValue(values []*MultiValue) string {
+	for _, value := range values {
+		if value.Primary {
+			return value.Value
+		}
+	}
+	if len(values) > 0 {
+		return values[0].Value
+	}
+	return ""
+}
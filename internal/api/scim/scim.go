@@ -0,0 +1,179 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	errs "errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	HandlerPrefix = "/scim/v2"
+
+	permissionUserRead   = "user.read"
+	permissionUserWrite  = "user.write"
+	permissionUserDelete = "user.delete"
+
+	permissionGroupRead   = "project.read"
+	permissionGroupWrite  = "project.write"
+	permissionGroupDelete = "project.delete"
+
+	permissionUserGrantWrite  = "user.grant.write"
+	permissionUserGrantDelete = "user.grant.delete"
+)
+
+type Config struct {
+	// MaxResults is the maximum of users or groups returned in a single list response
+	MaxResults uint64
+	// MaxPayloadSize is the maximum size of a user or group request in bytes
+	MaxPayloadSize int64
+	// MaxBulkOperations is the maximum of operations in a single bulk request
+	MaxBulkOperations int
+	// MaxBulkPayloadSize is the maximum size of a bulk request in bytes
+	MaxBulkPayloadSize int64
+}
+
+type Handler struct {
+	config         *Config
+	commands       *command.Commands
+	queries        *query.Queries
+	verifier       *authz.TokenVerifier
+	authConfig     authz.Config
+	externalSecure bool
+}
+
+// NewHandler returns the SCIM 2.0 (RFC 7643, RFC 7644) API for the human users of an organisation
+// and its projects as groups.
+// The requests are authenticated with the tokens (e.g. personal access tokens) of machine users
+// with the permissions to manage the users of the organisation, which is either
+// the organisation of the authenticated user or the one provided in the x-zitadel-orgid header.
+func NewHandler(
+	config *Config,
+	commands *command.Commands,
+	queries *query.Queries,
+	verifier *authz.TokenVerifier,
+	authConfig authz.Config,
+	externalSecure bool,
+	interceptors ...func(http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		config:         config,
+		commands:       commands,
+		queries:        queries,
+		verifier:       verifier,
+		authConfig:     authConfig,
+		externalSecure: externalSecure,
+	}
+	router := mux.NewRouter()
+	for _, interceptor := range interceptors {
+		router.Use(interceptor)
+	}
+	router.HandleFunc("/ServiceProviderConfig", h.serviceProviderConfig).Methods(http.MethodGet)
+	router.HandleFunc("/ResourceTypes", h.resourceTypes).Methods(http.MethodGet)
+	router.HandleFunc("/Schemas", h.schemas).Methods(http.MethodGet)
+	router.HandleFunc("/Users", h.authorize(permissionUserRead, h.listUsersHandler)).Methods(http.MethodGet)
+	router.HandleFunc("/Users", h.authorize(permissionUserWrite, h.createUserHandler)).Methods(http.MethodPost)
+	router.HandleFunc("/Users/{id}", h.authorize(permissionUserRead, h.getUserHandler)).Methods(http.MethodGet)
+	router.HandleFunc("/Users/{id}", h.authorize(permissionUserWrite, h.replaceUserHandler)).Methods(http.MethodPut)
+	router.HandleFunc("/Users/{id}", h.authorize(permissionUserWrite, h.patchUserHandler)).Methods(http.MethodPatch)
+	router.HandleFunc("/Users/{id}", h.authorize(permissionUserDelete, h.deleteUserHandler)).Methods(http.MethodDelete)
+	router.HandleFunc("/Groups", h.authorize(permissionGroupRead, h.listGroupsHandler)).Methods(http.MethodGet)
+	router.HandleFunc("/Groups", h.authorize(permissionGroupWrite, h.createGroupHandler)).Methods(http.MethodPost)
+	router.HandleFunc("/Groups/{id}", h.authorize(permissionGroupRead, h.getGroupHandler)).Methods(http.MethodGet)
+	router.HandleFunc("/Groups/{id}", h.authorize(permissionGroupWrite, h.replaceGroupHandler)).Methods(http.MethodPut)
+	router.HandleFunc("/Groups/{id}", h.authorize(permissionGroupWrite, h.patchGroupHandler)).Methods(http.MethodPatch)
+	router.HandleFunc("/Groups/{id}", h.authorize(permissionGroupDelete, h.deleteGroupHandler)).Methods(http.MethodDelete)
+	router.HandleFunc("/Bulk", h.authorize(permissionUserWrite, h.bulkHandler)).Methods(http.MethodPost)
+	return http_util.CopyHeadersToContext(router)
+}
+
+// authorize checks the token of the request and the permission in the organisation
+// the permissions for delete and group operations in bulk requests are checked by the bulk handler
+func (h *Handler) authorize(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := h.authorizeRequest(r, permission)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func (h *Handler) authorizeRequest(r *http.Request, permission string) (_ context.Context, err error) {
+	ctx := r.Context()
+	authCtx, span := tracing.NewServerInterceptorSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	authToken := http_util.GetAuthorization(r)
+	if authToken == "" {
+		return nil, errors.ThrowUnauthenticated(nil, "SCIM-Kd9sj", "auth header missing")
+	}
+	ctxSetter, err := authz.CheckUserAuthorization(authCtx, r, authToken, http_util.GetOrgID(r), h.verifier, h.authConfig, authz.Option{Permission: permission}, r.RequestURI)
+	if err != nil {
+		return nil, err
+	}
+	ctx = ctxSetter(ctx)
+	client, err := h.queries.GetUserByID(ctx, false, authz.GetCtxData(ctx).UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkProvisioningClient(client.Type, authz.GetAllPermissionsFromCtx(ctx)); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// checkProvisioningClient only allows machine users (e.g. with a personal access token) to provision the users,
+// which are able to manage the users of the organisation, independent of the permission of the operation.
+// The tokens of human users are refused, as they could be used by any application the user signed in to
+func checkProvisioningClient(userType domain.UserType, permissions []string) error {
+	if userType != domain.UserTypeMachine {
+		return errors.ThrowPermissionDenied(nil, "SCIM-Hs8kq", "only machine users are allowed to provision users")
+	}
+	if !authz.ExistsPerm(permissions, permissionUserWrite) {
+		return errors.ThrowPermissionDenied(nil, "SCIM-Ow2nd", "missing permission to manage the users of the organisation")
+	}
+	return nil
+}
+
+// hasPermission checks a further permission of the already authorized user
+func hasPermission(ctx context.Context, permission string) bool {
+	return authz.ExistsPerm(authz.GetAllPermissionsFromCtx(ctx), permission)
+}
+
+// decodeBody decodes the JSON body of the request, which must not exceed the max size (if it's set)
+func decodeBody(w http.ResponseWriter, r *http.Request, maxSize int64, v interface{}, invalidDetail string) error {
+	if maxSize > 0 {
+		if r.ContentLength > maxSize {
+			return newSCIMError(http.StatusRequestEntityTooLarge, "", "maximum payload size exceeded")
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		maxBytesErr := new(http.MaxBytesError)
+		if errs.As(err, &maxBytesErr) {
+			return newSCIMError(http.StatusRequestEntityTooLarge, "", "maximum payload size exceeded")
+		}
+		return newSCIMError(http.StatusBadRequest, scimTypeInvalidSyntax, invalidDetail)
+	}
+	return nil
+}
+
+func (h *Handler) userLocation(ctx context.Context, userID string) string {
+	return http_util.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), h.externalSecure) + HandlerPrefix + "/Users/" + userID
+}
+
+func (h *Handler) groupLocation(ctx context.Context, projectID string) string {
+	return http_util.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), h.externalSecure) + HandlerPrefix + "/Groups/" + projectID
+}
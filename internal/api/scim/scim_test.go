@@ -0,0 +1,100 @@
+package scim
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_checkProvisioningClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		userType    domain.UserType
+		permissions []string
+		wantErr     bool
+	}{
+		{
+			name:        "machine with user write, ok",
+			userType:    domain.UserTypeMachine,
+			permissions: []string{"user.read", "user.write"},
+		},
+		{
+			name:        "human with user write, denied",
+			userType:    domain.UserTypeHuman,
+			permissions: []string{"user.read", "user.write"},
+			wantErr:     true,
+		},
+		{
+			name:        "machine with user read, denied",
+			userType:    domain.UserTypeMachine,
+			permissions: []string{"user.read"},
+			wantErr:     true,
+		},
+		{
+			name:        "machine with user write of a project, denied",
+			userType:    domain.UserTypeMachine,
+			permissions: []string{"user.write:project-id"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkProvisioningClient(tt.userType, tt.permissions)
+			if tt.wantErr {
+				assert.True(t, errors.IsPermissionDenied(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_decodeBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		wantStatus    int
+	}{
+		{
+			name: "ok",
+			body: `{"userName":"gigi"}`,
+		},
+		{
+			name:       "invalid json",
+			body:       `{"userName":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "body exceeds max size",
+			body:       `{"userName":"` + strings.Repeat("a", 64) + `"}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:          "content length exceeds max size",
+			body:          `{"userName":"gigi"}`,
+			contentLength: 65,
+			wantStatus:    http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/Users", strings.NewReader(tt.body))
+			// an unknown content length (0) is only limited while reading the body
+			r.ContentLength = tt.contentLength
+			err := decodeBody(httptest.NewRecorder(), r, 64, new(User), "invalid user")
+			if tt.wantStatus == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			status, _, _ := mapError(err)
+			assert.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
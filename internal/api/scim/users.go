@@ -0,0 +1,577 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// metadataKeyExternalID is the key of the user metadata containing the externalId of the SCIM client
+	metadataKeyExternalID = "scim.externalId"
+
+	paramFilter     = "filter"
+	paramStartIndex = "startIndex"
+	paramCount      = "count"
+	paramSortBy     = "sortBy"
+	paramSortOrder  = "sortOrder"
+)
+
+func (h *Handler) listUsersHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.listUsers(r.Context(), r.URL.Query())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, resp, http.StatusOK)
+}
+
+func (h *Handler) getUserHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.getUser(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, resp, http.StatusOK)
+}
+
+func (h *Handler) createUserHandler(w http.ResponseWriter, r *http.Request) {
+	user := new(User)
+	if err := decodeBody(w, r, h.config.MaxPayloadSize, user, "invalid user"); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resp, err := h.createUser(r.Context(), user)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", resp.Meta.Location)
+	writeResponse(w, resp, http.StatusCreated)
+}
+
+func (h *Handler) replaceUserHandler(w http.ResponseWriter, r *http.Request) {
+	user := new(User)
+	if err := decodeBody(w, r, h.config.MaxPayloadSize, user, "invalid user"); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resp, err := h.replaceUser(r.Context(), mux.Vars(r)["id"], user)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, resp, http.StatusOK)
+}
+
+func (h *Handler) patchUserHandler(w http.ResponseWriter, r *http.Request) {
+	patch := new(PatchRequest)
+	if err := decodeBody(w, r, h.config.MaxPayloadSize, patch, "invalid patch request"); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	resp, err := h.patchUser(r.Context(), mux.Vars(r)["id"], patch)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeResponse(w, resp, http.StatusOK)
+}
+
+func (h *Handler) deleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.deleteUser(r.Context(), mux.Vars(r)["id"]); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listUsers(ctx context.Context, params url.Values) (*ListResponse, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	searchQuery, startIndex, err := listUsersToQuery(orgID, params, h.config.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	users, err := h.queries.SearchUsers(ctx, searchQuery, false)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*User, 0, len(users.Users))
+	// a count of 0 only requests the total results
+	if params.Get(paramCount) != "0" {
+		for _, user := range users.Users {
+			resource, err := h.userToResource(ctx, user)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: users.Count,
+		StartIndex:   startIndex,
+		ItemsPerPage: uint64(len(resources)),
+		Resources:    resources,
+	}, nil
+}
+
+func (h *Handler) getUser(ctx context.Context, id string) (*User, error) {
+	user, err := h.humanByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	return h.userToResource(ctx, user)
+}
+
+func (h *Handler) createUser(ctx context.Context, resource *User) (*User, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	details, err := h.commands.AddHuman(ctx, orgID, resourceToAddHuman(resource))
+	if err != nil {
+		return nil, err
+	}
+	if resource.ExternalID != "" {
+		if _, err = h.commands.SetUserMetadata(ctx, &domain.Metadata{Key: metadataKeyExternalID, Value: []byte(resource.ExternalID)}, details.ID, orgID); err != nil {
+			return nil, err
+		}
+	}
+	if resource.Active != nil && !*resource.Active {
+		if _, err = h.commands.DeactivateUser(ctx, details.ID, orgID); err != nil {
+			return nil, err
+		}
+	}
+	return h.getUserTriggered(ctx, details.ID)
+}
+
+func (h *Handler) replaceUser(ctx context.Context, id string, resource *User) (*User, error) {
+	user, err := h.humanByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	externalID, err := h.externalID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = h.updateUser(ctx, user, externalID, resource); err != nil {
+		return nil, err
+	}
+	return h.getUserTriggered(ctx, id)
+}
+
+func (h *Handler) patchUser(ctx context.Context, id string, patch *PatchRequest) (*User, error) {
+	user, err := h.humanByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	externalID, err := h.externalID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resource := userToResource(user, externalID, "")
+	if err = applyPatch(resource, patch.Operations); err != nil {
+		return nil, err
+	}
+	if err = h.updateUser(ctx, user, externalID, resource); err != nil {
+		return nil, err
+	}
+	return h.getUserTriggered(ctx, id)
+}
+
+func (h *Handler) deleteUser(ctx context.Context, id string) error {
+	if _, err := h.humanByID(ctx, id, false); err != nil {
+		return err
+	}
+	memberships, grants, err := h.removeUserDependencies(ctx, id)
+	if err != nil {
+		return err
+	}
+	_, err = h.commands.RemoveUser(ctx, id, authz.GetCtxData(ctx).OrgID, memberships, grants...)
+	return err
+}
+
+// updateUser executes the commands for the differences between the existing user and the resource
+func (h *Handler) updateUser(ctx context.Context, user *query.User, externalID string, resource *User) (err error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	if userName := strings.TrimSpace(resource.UserName); userName != user.Username {
+		if _, err = h.commands.ChangeUsername(ctx, orgID, user.ID, userName); err != nil {
+			return err
+		}
+	}
+	if profile := resourceToProfile(resource, user, orgID); profileChanged(profile, user.Human) {
+		if _, err = h.commands.ChangeHumanProfile(ctx, profile); err != nil {
+			return err
+		}
+	}
+	if email := domain.EmailAddress(strings.TrimSpace(primaryValue(resource.Emails))); email != user.Human.Email {
+		// the email is managed by the SCIM client and therefore verified
+		_, err = h.commands.ChangeHumanEmail(ctx, &domain.Email{
+			ObjectRoot:      models.ObjectRoot{AggregateID: user.ID, ResourceOwner: orgID},
+			EmailAddress:    email,
+			IsEmailVerified: true,
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if err = h.updatePhone(ctx, user, primaryValue(resource.PhoneNumbers)); err != nil {
+		return err
+	}
+	if resource.Password != "" {
+		if _, err = h.commands.SetPassword(ctx, orgID, user.ID, resource.Password, false); err != nil {
+			return err
+		}
+	}
+	if err = h.updateExternalID(ctx, user.ID, externalID, resource.ExternalID); err != nil {
+		return err
+	}
+	if resource.Active == nil {
+		return nil
+	}
+	if *resource.Active && user.State == domain.UserStateInactive {
+		_, err = h.commands.ReactivateUser(ctx, user.ID, orgID)
+	}
+	if !*resource.Active && user.State == domain.UserStateActive {
+		_, err = h.commands.DeactivateUser(ctx, user.ID, orgID)
+	}
+	return err
+}
+
+func (h *Handler) updatePhone(ctx context.Context, user *query.User, phone string) (err error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	if phone == "" {
+		if user.Human.Phone == "" {
+			return nil
+		}
+		_, err = h.commands.RemoveHumanPhone(ctx, user.ID, orgID)
+		return err
+	}
+	number, err := domain.PhoneNumber(phone).Normalize()
+	if err != nil {
+		return err
+	}
+	if number == user.Human.Phone {
+		return nil
+	}
+	// the phone is managed by the SCIM client and therefore verified
+	_, err = h.commands.ChangeHumanPhone(ctx, &domain.Phone{
+		ObjectRoot:      models.ObjectRoot{AggregateID: user.ID},
+		PhoneNumber:     number,
+		IsPhoneVerified: true,
+	}, orgID, nil)
+	return err
+}
+
+func (h *Handler) updateExternalID(ctx context.Context, userID, existing, externalID string) (err error) {
+	if existing == externalID {
+		return nil
+	}
+	orgID := authz.GetCtxData(ctx).OrgID
+	if externalID == "" {
+		_, err = h.commands.RemoveUserMetadata(ctx, metadataKeyExternalID, userID, orgID)
+		return err
+	}
+	_, err = h.commands.SetUserMetadata(ctx, &domain.Metadata{Key: metadataKeyExternalID, Value: []byte(externalID)}, userID, orgID)
+	return err
+}
+
+// humanByID returns the human user of the organisation
+func (h *Handler) humanByID(ctx context.Context, id string, triggerBulk bool) (*query.User, error) {
+	owner, err := query.NewUserResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.queries.GetUserByID(ctx, triggerBulk, id, false, owner)
+	if err != nil {
+		return nil, err
+	}
+	if user.Human == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "SCIM-Ls9fm", "Errors.User.NotHuman")
+	}
+	return user, nil
+}
+
+// getUserTriggered returns the user after a change, the projection is triggered to include the change
+func (h *Handler) getUserTriggered(ctx context.Context, id string) (*User, error) {
+	user, err := h.humanByID(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	return h.userToResource(ctx, user)
+}
+
+func (h *Handler) externalID(ctx context.Context, userID string) (string, error) {
+	metadata, err := h.queries.GetUserMetadataByKey(ctx, false, userID, metadataKeyExternalID, false)
+	if caos_errs.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(metadata.Value), nil
+}
+
+func (h *Handler) userToResource(ctx context.Context, user *query.User) (*User, error) {
+	externalID, err := h.externalID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return userToResource(user, externalID, h.userLocation(ctx, user.ID)), nil
+}
+
+func (h *Handler) removeUserDependencies(ctx context.Context, userID string) ([]*command.CascadingMembership, []string, error) {
+	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grants, err := h.queries.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userGrantUserQuery},
+	}, true, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	membershipsUserQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberships, err := h.queries.Memberships(ctx, &query.MembershipSearchQuery{
+		Queries: []query.SearchQuery{membershipsUserQuery},
+	}, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cascadingMemberships(memberships.Memberships), userGrantsToIDs(grants.UserGrants), nil
+}
+
+func listUsersToQuery(orgID string, params url.Values, maxResults uint64) (_ *query.UserSearchQueries, startIndex uint64, err error) {
+	startIndex, count, err := paginationParams(params, maxResults)
+	if err != nil {
+		return nil, 0, err
+	}
+	queries := make([]query.SearchQuery, 2)
+	if queries[0], err = query.NewUserResourceOwnerSearchQuery(orgID, query.TextEquals); err != nil {
+		return nil, 0, err
+	}
+	if queries[1], err = query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman)); err != nil {
+		return nil, 0, err
+	}
+	if expression := params.Get(paramFilter); expression != "" {
+		filters, err := parseFilter(expression)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, filter := range filters {
+			filterQuery, err := filter.toQuery()
+			if err != nil {
+				return nil, 0, err
+			}
+			queries = append(queries, filterQuery)
+		}
+	}
+	searchQuery := &query.UserSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: startIndex - 1,
+			Limit:  count,
+			Asc:    !strings.EqualFold(params.Get(paramSortOrder), "descending"),
+		},
+		Queries: queries,
+	}
+	switch strings.ToLower(params.Get(paramSortBy)) {
+	case "":
+	case "username":
+		searchQuery.SortingColumn = query.UserUsernameCol
+	default:
+		return nil, 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, "sorting by "+params.Get(paramSortBy)+" is not supported")
+	}
+	return searchQuery, startIndex, nil
+}
+
+// paginationParams returns the 1-based start index and the count of resources requested (RFC 7644 3.4.2.4)
+func paginationParams(params url.Values, maxResults uint64) (startIndex, count uint64, err error) {
+	startIndex, err = uintParam(params, paramStartIndex, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	count, err = uintParam(params, paramCount, maxResults)
+	if err != nil {
+		return 0, 0, err
+	}
+	if count > maxResults {
+		count = maxResults
+	}
+	if count == 0 {
+		count = 1
+	}
+	return startIndex, count, nil
+}
+
+func uintParam(params url.Values, key string, defaultValue uint64) (uint64, error) {
+	value := params.Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, newSCIMError(http.StatusBadRequest, scimTypeInvalidValue, key+" must be a positive integer")
+	}
+	return parsed, nil
+}
+
+func userToResource(user *query.User, externalID, location string) *User {
+	active := user.State != domain.UserStateInactive && user.State != domain.UserStateLocked
+	resource := &User{
+		Schemas:     []string{schemaUser},
+		ID:          user.ID,
+		ExternalID:  externalID,
+		UserName:    user.Username,
+		Active:      &active,
+		DisplayName: user.Human.DisplayName,
+		NickName:    user.Human.NickName,
+		Name: &Name{
+			GivenName:  user.Human.FirstName,
+			FamilyName: user.Human.LastName,
+		},
+		Emails: []*MultiValue{{Value: string(user.Human.Email), Primary: true}},
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      user.CreationDate,
+			LastModified: user.ChangeDate,
+			Location:     location,
+			Version:      `W/"` + strconv.FormatUint(user.Sequence, 10) + `"`,
+		},
+	}
+	if !user.Human.PreferredLanguage.IsRoot() {
+		resource.PreferredLanguage = user.Human.PreferredLanguage.String()
+	}
+	if user.Human.Phone != "" {
+		resource.PhoneNumbers = []*MultiValue{{Value: string(user.Human.Phone), Primary: true}}
+	}
+	return resource
+}
+
+// resourceToAddHuman maps the resource to a new human user,
+// the email and phone are managed by the SCIM client and therefore verified
+func resourceToAddHuman(resource *User) *command.AddHuman {
+	human := &command.AddHuman{
+		Username:          resource.UserName,
+		DisplayName:       resource.DisplayName,
+		NickName:          resource.NickName,
+		PreferredLanguage: language.Make(resource.PreferredLanguage),
+		Email: command.Email{
+			Address:  domain.EmailAddress(strings.TrimSpace(primaryValue(resource.Emails))),
+			Verified: true,
+		},
+		Password: resource.Password,
+	}
+	if resource.Name != nil {
+		human.FirstName = resource.Name.GivenName
+		human.LastName = resource.Name.FamilyName
+		if human.DisplayName == "" {
+			human.DisplayName = resource.Name.Formatted
+		}
+	}
+	if phone := primaryValue(resource.PhoneNumbers); phone != "" {
+		human.Phone = command.Phone{
+			Number:   domain.PhoneNumber(phone),
+			Verified: true,
+		}
+	}
+	return human
+}
+
+func resourceToProfile(resource *User, user *query.User, orgID string) *domain.Profile {
+	profile := &domain.Profile{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   user.ID,
+			ResourceOwner: orgID,
+		},
+		DisplayName:       resource.DisplayName,
+		NickName:          resource.NickName,
+		PreferredLanguage: language.Make(resource.PreferredLanguage),
+		Gender:            user.Human.Gender,
+	}
+	if resource.Name != nil {
+		profile.FirstName = resource.Name.GivenName
+		profile.LastName = resource.Name.FamilyName
+		if profile.DisplayName == "" {
+			profile.DisplayName = resource.Name.Formatted
+		}
+	}
+	if profile.DisplayName == "" {
+		profile.DisplayName = profile.FirstName + " " + profile.LastName
+	}
+	return profile
+}
+
+func profileChanged(profile *domain.Profile, human *query.Human) bool {
+	return profile.FirstName != human.FirstName ||
+		profile.LastName != human.LastName ||
+		profile.NickName != human.NickName ||
+		profile.DisplayName != human.DisplayName ||
+		profile.PreferredLanguage != human.PreferredLanguage
+}
+
+func cascadingMemberships(memberships []*query.Membership) []*command.CascadingMembership {
+	cascades := make([]*command.CascadingMembership, len(memberships))
+	for i, membership := range memberships {
+		cascades[i] = &command.CascadingMembership{
+			UserID:        membership.UserID,
+			ResourceOwner: membership.ResourceOwner,
+			IAM:           cascadingIAMMembership(membership.IAM),
+			Org:           cascadingOrgMembership(membership.Org),
+			Project:       cascadingProjectMembership(membership.Project),
+			ProjectGrant:  cascadingProjectGrantMembership(membership.ProjectGrant),
+		}
+	}
+	return cascades
+}
+
+func cascadingIAMMembership(membership *query.IAMMembership) *command.CascadingIAMMembership {
+	if membership == nil {
+		return nil
+	}
+	return &command.CascadingIAMMembership{IAMID: membership.IAMID}
+}
+
+func cascadingOrgMembership(membership *query.OrgMembership) *command.CascadingOrgMembership {
+	if membership == nil {
+		return nil
+	}
+	return &command.CascadingOrgMembership{OrgID: membership.OrgID}
+}
+
+func cascadingProjectMembership(membership *query.ProjectMembership) *command.CascadingProjectMembership {
+	if membership == nil {
+		return nil
+	}
+	return &command.CascadingProjectMembership{ProjectID: membership.ProjectID}
+}
+
+func cascadingProjectGrantMembership(membership *query.ProjectGrantMembership) *command.CascadingProjectGrantMembership {
+	if membership == nil {
+		return nil
+	}
+	return &command.CascadingProjectGrantMembership{ProjectID: membership.ProjectID, GrantID: membership.GrantID}
+}
+
+func userGrantsToIDs(userGrants []*query.UserGrant) []string {
+	converted := make([]string, len(userGrants))
+	for i, grant := range userGrants {
+		converted[i] = grant.ID
+	}
+	return converted
+}
@@ -0,0 +1,216 @@
+package scim
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_listUsersToQuery(t *testing.T) {
+	ownerQuery, _ := query.NewUserResourceOwnerSearchQuery("org-id", query.TextEquals)
+	typeQuery, _ := query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	userNameQuery, _ := query.NewUserUsernameSearchQuery("gigi", query.TextEqualsIgnoreCase)
+	type res struct {
+		query      *query.UserSearchQueries
+		startIndex uint64
+		err        bool
+	}
+	tests := []struct {
+		name   string
+		params url.Values
+		res    res
+	}{
+		{
+			name:   "defaults",
+			params: url.Values{},
+			res: res{
+				query: &query.UserSearchQueries{
+					SearchRequest: query.SearchRequest{Limit: 100, Asc: true},
+					Queries:       []query.SearchQuery{ownerQuery, typeQuery},
+				},
+				startIndex: 1,
+			},
+		},
+		{
+			name: "pagination, sorting and filter",
+			params: url.Values{
+				paramStartIndex: []string{"11"},
+				paramCount:      []string{"500"},
+				paramSortBy:     []string{"userName"},
+				paramSortOrder:  []string{"descending"},
+				paramFilter:     []string{`userName eq "gigi"`},
+			},
+			res: res{
+				query: &query.UserSearchQueries{
+					SearchRequest: query.SearchRequest{Offset: 10, Limit: 100, SortingColumn: query.UserUsernameCol},
+					Queries:       []query.SearchQuery{ownerQuery, typeQuery, userNameQuery},
+				},
+				startIndex: 11,
+			},
+		},
+		{
+			name:   "invalid count",
+			params: url.Values{paramCount: []string{"-1"}},
+			res:    res{err: true},
+		},
+		{
+			name:   "unsupported sorting",
+			params: url.Values{paramSortBy: []string{"title"}},
+			res:    res{err: true},
+		},
+		{
+			name:   "invalid filter",
+			params: url.Values{paramFilter: []string{`title eq "boss"`}},
+			res:    res{err: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, startIndex, err := listUsersToQuery("org-id", tt.params, 100)
+			if tt.res.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.res.query, got)
+			assert.Equal(t, tt.res.startIndex, startIndex)
+		})
+	}
+}
+
+func Test_userToResource(t *testing.T) {
+	active := true
+	date := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := userToResource(&query.User{
+		ID:           "user-id",
+		CreationDate: date,
+		ChangeDate:   date,
+		Sequence:     20,
+		State:        domain.UserStateActive,
+		Username:     "gigi",
+		Human: &query.Human{
+			FirstName:         "Gigi",
+			LastName:          "Giraffe",
+			DisplayName:       "Gigi Giraffe",
+			PreferredLanguage: language.German,
+			Email:             "gigi@zitadel.com",
+		},
+	}, "ext-id", "https://zitadel.cloud/scim/v2/Users/user-id")
+	assert.Equal(t, &User{
+		Schemas:           []string{schemaUser},
+		ID:                "user-id",
+		ExternalID:        "ext-id",
+		UserName:          "gigi",
+		Name:              &Name{GivenName: "Gigi", FamilyName: "Giraffe"},
+		DisplayName:       "Gigi Giraffe",
+		PreferredLanguage: "de",
+		Active:            &active,
+		Emails:            []*MultiValue{{Value: "gigi@zitadel.com", Primary: true}},
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      date,
+			LastModified: date,
+			Location:     "https://zitadel.cloud/scim/v2/Users/user-id",
+			Version:      `W/"20"`,
+		},
+	}, got)
+}
+
+func Test_resourceToAddHuman(t *testing.T) {
+	got := resourceToAddHuman(&User{
+		UserName: "gigi",
+		Name:     &Name{GivenName: "Gigi", FamilyName: "Giraffe", Formatted: "Ms. Gigi Giraffe"},
+		Emails: []*MultiValue{
+			{Value: "gigi@private.com"},
+			{Value: "gigi@zitadel.com", Primary: true},
+		},
+		PhoneNumbers: []*MultiValue{{Value: "+41791234567"}},
+		Password:     "Password1!",
+	})
+	assert.Equal(t, &command.AddHuman{
+		Username:          "gigi",
+		FirstName:         "Gigi",
+		LastName:          "Giraffe",
+		DisplayName:       "Ms. Gigi Giraffe",
+		PreferredLanguage: language.Und,
+		Email:             command.Email{Address: "gigi@zitadel.com", Verified: true},
+		Phone:             command.Phone{Number: "+41791234567", Verified: true},
+		Password:          "Password1!",
+	}, got)
+}
+
+func Test_bulkOperationResource(t *testing.T) {
+	bulkIDs := map[string]string{"qwerty": "user-id"}
+	tests := []struct {
+		name         string
+		path         string
+		wantEndpoint string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "users",
+			path:         "/Users",
+			wantEndpoint: "/Users",
+			want:         "",
+		},
+		{
+			name:         "user id",
+			path:         "/Users/123",
+			wantEndpoint: "/Users",
+			want:         "123",
+		},
+		{
+			name:         "bulk id",
+			path:         "/Users/bulkId:qwerty",
+			wantEndpoint: "/Users",
+			want:         "user-id",
+		},
+		{
+			name:    "unresolved bulk id",
+			path:    "/Users/bulkId:ytrewq",
+			wantErr: true,
+		},
+		{
+			name:         "groups",
+			path:         "/Groups",
+			wantEndpoint: "/Groups",
+			want:         "",
+		},
+		{
+			name:         "group id",
+			path:         "/Groups/456",
+			wantEndpoint: "/Groups",
+			want:         "456",
+		},
+		{
+			name:    "invalid path",
+			path:    "/UsersX",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported resource",
+			path:    "/Schemas",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, got, err := bulkOperationResource(tt.path, bulkIDs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEndpoint, endpoint)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
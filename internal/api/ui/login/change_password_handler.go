@@ -32,9 +32,31 @@ func (l *Login) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 		l.renderChangePassword(w, r, authReq, err)
 		return
 	}
+	if err = l.postPasswordChange(r, authReq, authReq.UserID, authReq.UserOrgID); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
 	l.renderChangePasswordDone(w, r, authReq)
 }
 
+// postPasswordChange runs the actions of the post password change flow,
+// the organisation of the user is queried if the password was set without auth request
+func (l *Login) postPasswordChange(r *http.Request, authReq *domain.AuthRequest, userID, userOrg string) error {
+	if userOrg == "" {
+		user, err := l.query.GetUserByID(r.Context(), false, userID, false)
+		if err != nil {
+			return err
+		}
+		userOrg = user.ResourceOwner
+	}
+	metadata, err := l.runPostPasswordChangeActions(userID, userOrg, authReq, r)
+	if err != nil || len(metadata) == 0 {
+		return err
+	}
+	_, err = l.command.BulkSetUserMetadata(setContext(r.Context(), userOrg), userID, userOrg, metadata...)
+	return err
+}
+
 func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
 	var errID, errMessage string
 	if err != nil {
@@ -44,6 +66,7 @@ func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, aut
 	data := passwordData{
 		baseData:    l.getBaseData(r, authReq, "PasswordChange.Title", "PasswordChange.Description", errID, errMessage),
 		profileData: l.getProfileData(authReq),
+		Expired:     passwordExpired(authReq),
 	}
 	policy := l.getPasswordComplexityPolicy(r, authReq.UserOrgID)
 	if policy != nil {
@@ -60,6 +83,7 @@ func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, aut
 		if policy.HasNumber {
 			data.HasNumber = NumberRegex
 		}
+		data.CheckBreached = policy.CheckBreached
 	}
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplChangePassword], data, nil)
 }
@@ -70,3 +94,13 @@ func (l *Login) renderChangePasswordDone(w http.ResponseWriter, r *http.Request,
 	data := l.getUserData(r, authReq, "PasswordChange.Title", "PasswordChange.Description", errType, errMessage)
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplChangePasswordDone], data, nil)
 }
+
+// passwordExpired returns true if the password change is required because the password has expired
+func passwordExpired(authReq *domain.AuthRequest) bool {
+	for _, step := range authReq.PossibleSteps {
+		if changePassword, ok := step.(*domain.ChangePasswordStep); ok {
+			return changePassword.Expired
+		}
+	}
+	return false
+}
@@ -121,6 +121,8 @@ type authMethod string
 const (
 	authMethodPassword     authMethod = "password"
 	authMethodOTP          authMethod = "OTP"
+	authMethodOTPSMS       authMethod = "OTP SMS"
+	authMethodOTPEmail     authMethod = "OTP Email"
 	authMethodU2F          authMethod = "U2F"
 	authMethodPasswordless authMethod = "passwordless"
 )
@@ -341,6 +343,119 @@ func (l *Login) runPostCreationActions(
 	return object.UserGrantsToDomain(userID, mutableUserGrants.UserGrants), err
 }
 
+func (l *Login) runPreRegistrationActions(
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+	form *object.RegistrationForm,
+	resourceOwner string,
+) error {
+	ctx := httpRequest.Context()
+
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypePreRegistration, domain.TriggerTypePreRegistrationValidation, resourceOwner, false)
+	if err != nil {
+		return err
+	}
+
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			object.RegistrationFormAPIFields(form),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFieldOptions := []interface{}{
+			actions.SetFields("form", object.RegistrationFormField(form)),
+			actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
+		}
+		if authRequest != nil {
+			ctxFieldOptions = append(ctxFieldOptions, actions.SetFields("authRequest", object.AuthRequestField(authRequest)))
+		}
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1", ctxFieldOptions...),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx))...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		// the following actions are not executed if the form is rejected
+		if err = form.Rejection(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Login) runPostPasswordChangeActions(
+	userID string,
+	resourceOwner string,
+	authRequest *domain.AuthRequest,
+	httpRequest *http.Request,
+) ([]*domain.Metadata, error) {
+	ctx := httpRequest.Context()
+
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypePostPasswordChange, domain.TriggerTypePostPasswordChange, resourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataList := object.MetadataListFromDomain(nil)
+	apiFields := actions.WithAPIFields(
+		actions.SetFields("v1",
+			actions.SetFields("user",
+				actions.SetFields("appendMetadata", metadataList.AppendMetadataFunc),
+			),
+		),
+	)
+
+	for _, a := range triggerActions {
+		actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+
+		ctxFieldOptions := []interface{}{
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(call goja.FunctionCall) goja.Value {
+					user, err := l.query.GetUserByID(actionCtx, true, userID, false)
+					if err != nil {
+						panic(err)
+					}
+					return object.UserFromQuery(c, user)
+				}
+			}),
+			actions.SetFields("httpRequest", object.HTTPRequestField(httpRequest)),
+		}
+		if authRequest != nil {
+			ctxFieldOptions = append(ctxFieldOptions, actions.SetFields("authRequest", object.AuthRequestField(authRequest)))
+		}
+		ctxFields := actions.SetContextFields(
+			actions.SetFields("v1", ctxFieldOptions...),
+		)
+
+		err = actions.Run(
+			actionCtx,
+			ctxFields,
+			apiFields,
+			a.Script,
+			a.Name,
+			append(actions.ActionToOptions(a), actions.WithHTTP(actionCtx))...,
+		)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return object.MetadataListToDomain(metadataList), nil
+}
+
 func tokenCtxFields(tokens *oidc.Tokens) []actions.FieldOption {
 	var accessToken, idToken string
 	getClaim := func(claim string) interface{} {
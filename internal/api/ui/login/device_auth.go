@@ -0,0 +1,203 @@
+package login
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplDeviceAuthUserCode = "device_usercode"
+	tmplDeviceAuthAction   = "device_action"
+	tmplDeviceAuthDone     = "device_done"
+
+	QueryUserCode = "user_code"
+
+	deviceAuthActionAllow = "allow"
+	deviceAuthActionDeny  = "deny"
+)
+
+type deviceAuthUserCodeFormData struct {
+	UserCode string `schema:"user_code"`
+}
+
+type deviceAuthUserCodeData struct {
+	baseData
+	UserCode string
+}
+
+type deviceAuthActionFormData struct {
+	Action string `schema:"action"`
+}
+
+type deviceAuthActionData struct {
+	userData
+	ClientName string
+}
+
+type deviceAuthDoneData struct {
+	baseData
+	Approved bool
+}
+
+// handleDeviceAuthUserCode renders the form for the user code shown on the device (verification_uri)
+// if the user code is provided (either by the form or as query param of the verification_uri_complete)
+// an auth request is created for the device authorization and the user is redirected to the login.
+// Unknown user codes count as failed attempts of the client ip.
+func (l *Login) handleDeviceAuthUserCode(w http.ResponseWriter, r *http.Request) {
+	data := new(deviceAuthUserCodeFormData)
+	if err := l.getParseData(r, data); err != nil {
+		l.renderDeviceAuthUserCode(w, r, "", err)
+		return
+	}
+	userCode := strings.ToUpper(strings.TrimSpace(data.UserCode))
+	if userCode == "" || r.Method == http.MethodGet {
+		l.renderDeviceAuthUserCode(w, r, userCode, nil)
+		return
+	}
+	// user codes are short, so the guessing of codes is throttled by the login rate limit of the client ip
+	limitKey := l.rateLimiter.ClientIP(r)
+	if err := l.rateLimiter.Check(r.Context(), limitKey); err != nil {
+		l.renderDeviceAuthUserCode(w, r, userCode, err)
+		return
+	}
+	deviceAuth, err := l.query.DeviceAuthByUserCode(r.Context(), true, userCode)
+	if errors.IsNotFound(err) {
+		l.rateLimiter.Failed(r.Context(), limitKey)
+	}
+	if err != nil {
+		l.renderDeviceAuthUserCode(w, r, userCode, err)
+		return
+	}
+	if deviceAuth.Expired() {
+		l.renderDeviceAuthUserCode(w, r, userCode, errors.ThrowPreconditionFailed(nil, "LOGIN-Wf3gr", "Errors.DeviceAuth.Expired"))
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	authReq, err := l.authRepo.CreateAuthRequest(r.Context(), &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		BrowserInfo:   domain.BrowserInfoFromRequest(r),
+		ApplicationID: deviceAuth.ClientID,
+		InstanceID:    authz.GetInstance(r.Context()).InstanceID(),
+		Request: &domain.AuthRequestDevice{
+			ID:       deviceAuth.ID,
+			UserCode: deviceAuth.UserCode,
+			Scopes:   deviceAuth.Scopes,
+		},
+	})
+	if err != nil {
+		l.renderDeviceAuthUserCode(w, r, userCode, err)
+		return
+	}
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointLogin+"?"+QueryAuthRequestID+"="+authReq.ID, http.StatusFound)
+}
+
+func (l *Login) renderDeviceAuthUserCode(w http.ResponseWriter, r *http.Request, userCode string, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := deviceAuthUserCodeData{
+		baseData: l.getBaseData(r, nil, "DeviceAuth.Title", "DeviceAuth.UserCode.Description", errID, errMessage),
+		UserCode: userCode,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), nil), l.renderer.Templates[tmplDeviceAuthUserCode], data, nil)
+}
+
+// redirectToDeviceAuthAction is called instead of the callback of the client once the user is authenticated
+func (l *Login) redirectToDeviceAuthAction(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointDeviceAuthAction+"?"+QueryAuthRequestID+"="+authReq.ID, http.StatusFound)
+}
+
+// handleDeviceAuthAction asks the authenticated user to allow or deny the access of the device
+func (l *Login) handleDeviceAuthAction(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getDeviceAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderDeviceAuthAction(w, r, authReq, nil)
+}
+
+func (l *Login) renderDeviceAuthAction(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := deviceAuthActionData{
+		userData:   l.getUserData(r, authReq, "DeviceAuth.Title", "DeviceAuth.Action.Description", errID, errMessage),
+		ClientName: authReq.ApplicationID,
+	}
+	if app, err := l.query.AppByOIDCClientID(r.Context(), authReq.ApplicationID, false); err == nil {
+		data.ClientName = app.Name
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplDeviceAuthAction], data, nil)
+}
+
+// handleDeviceAuthActionCheck approves or denies the device authorization,
+// so the device will either receive the tokens or an access_denied error on its next poll
+func (l *Login) handleDeviceAuthActionCheck(w http.ResponseWriter, r *http.Request) {
+	data := new(deviceAuthActionFormData)
+	if err := l.getParseData(r, data); err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	authReq, err := l.getDeviceAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	device := authReq.Request.(*domain.AuthRequestDevice)
+	switch data.Action {
+	case deviceAuthActionAllow:
+		_, err = l.command.ApproveDeviceAuth(r.Context(), device.ID, authReq.UserID, authReq.UserOrgID, authReq.AgentID, authReq.Audience, authReq.AuthMethodsReferences(), authReq.AuthTime)
+	case deviceAuthActionDeny:
+		_, err = l.command.DenyDeviceAuth(r.Context(), device.ID)
+	default:
+		err = errors.ThrowInvalidArgument(nil, "LOGIN-Ds3fq", "Errors.DeviceAuth.Invalid")
+	}
+	if err != nil {
+		l.renderDeviceAuthAction(w, r, authReq, err)
+		return
+	}
+	if err = l.authRepo.DeleteAuthRequest(r.Context(), authReq.ID); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderDeviceAuthDone(w, r, authReq, data.Action == deviceAuthActionAllow)
+}
+
+func (l *Login) renderDeviceAuthDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, approved bool) {
+	description := "DeviceAuth.Done.DeniedDescription"
+	if approved {
+		description = "DeviceAuth.Done.ApprovedDescription"
+	}
+	data := deviceAuthDoneData{
+		baseData: l.getBaseData(r, authReq, "DeviceAuth.Title", description, "", ""),
+		Approved: approved,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplDeviceAuthDone], data, nil)
+}
+
+// getDeviceAuthRequest returns the auth request of the device authorization, which must be completed by the user
+func (l *Login) getDeviceAuthRequest(r *http.Request) (*domain.AuthRequest, error) {
+	authRequestID := r.FormValue(QueryAuthRequestID)
+	if authRequestID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "LOGIN-Gs3ft", "Errors.AuthRequest.MissingParameters")
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	authReq, err := l.authRepo.AuthRequestByIDCheckLoggedIn(r.Context(), authRequestID, userAgentID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := authReq.Request.(*domain.AuthRequestDevice); !ok {
+		return nil, errors.ThrowInvalidArgument(nil, "LOGIN-Hkw3q", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+	return authReq, nil
+}
@@ -2,8 +2,11 @@ package login
 
 import (
 	"context"
+	"encoding/base64"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v2/pkg/client/rp"
@@ -13,11 +16,13 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
@@ -25,12 +30,25 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 )
 
 const (
 	queryIDPConfigID           = "idpConfigID"
 	tmplExternalNotFoundOption = "externalnotfoundoption"
+	tmplExternalSAMLPost       = "externalsamlpost"
+
+	samlRequestIDPrefix    = "id-"
+	samlRequestIDSeparator = ":"
+	samlAssertionKeyPrefix = "saml_assertion:"
+
+	// idpUserFormCookieName is the cookie the (encrypted) `user` form value of Apple is kept in
+	// between the cross-site form post and the callback
+	idpUserFormCookieName   = "zitadel.login.idp.user"
+	idpUserFormCookieMaxAge = 5 * 60
+	idpUserFormParam        = "user"
 )
 
 type externalIDPData struct {
@@ -42,6 +60,17 @@ type externalIDPCallbackData struct {
 	Code  string `schema:"code"`
 }
 
+type externalSAMLResponseData struct {
+	SAMLResponse string `schema:"SAMLResponse"`
+	RelayState   string `schema:"RelayState"`
+}
+
+type externalSAMLPostData struct {
+	baseData
+	URL    string
+	Fields url.Values
+}
+
 type externalNotFoundOptionFormData struct {
 	externalRegisterFormData
 	Link         bool `schema:"linkbutton"`
@@ -157,8 +186,13 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		provider, err = l.gitlabSelfHostedProvider(r.Context(), identityProvider)
 	case domain.IDPTypeGoogle:
 		provider, err = l.googleProvider(r.Context(), identityProvider)
-	case domain.IDPTypeLDAP,
-		domain.IDPTypeUnspecified:
+	case domain.IDPTypeSAML:
+		provider, err = l.samlProvider(r.Context(), identityProvider)
+	case domain.IDPTypeApple:
+		provider, err = l.appleProvider(r.Context(), identityProvider)
+	case domain.IDPTypeLDAP:
+		provider, err = l.ldapProvider(r.Context(), identityProvider)
+	case domain.IDPTypeUnspecified:
 		fallthrough
 	default:
 		l.renderLogin(w, r, authReq, errors.ThrowInvalidArgument(nil, "LOGIN-AShek", "Errors.ExternalIDP.IDPTypeNotImplemented"))
@@ -169,14 +203,36 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		return
 	}
 	params := l.sessionParamsFromAuthRequest(r.Context(), authReq, identityProvider.ID)
+	if identityProvider.Type == domain.IDPTypeSAML {
+		requestID, err := l.samlRequestID(authReq.ID, authReq.AgentID)
+		if err != nil {
+			l.renderLogin(w, r, authReq, err)
+			return
+		}
+		params = append(params, requestID)
+	}
 	session, err := provider.BeginAuth(r.Context(), authReq.ID, params...)
 	if err != nil {
 		l.renderLogin(w, r, authReq, err)
 		return
 	}
+	if samlSession, ok := session.(*saml.Session); ok && samlSession.RequestBinding == saml.BindingPost {
+		l.renderSAMLPost(w, r, authReq, samlSession)
+		return
+	}
 	http.Redirect(w, r, session.GetAuthURL(), http.StatusFound)
 }
 
+// renderSAMLPost renders a form, which is automatically submitted to the IDP (HTTP-POST binding)
+func (l *Login) renderSAMLPost(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, session *saml.Session) {
+	data := externalSAMLPostData{
+		baseData: l.getBaseData(r, authReq, "Login.Title", "", "", ""),
+		URL:      session.GetAuthURL(),
+		Fields:   session.RequestForm,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplExternalSAMLPost], data, nil)
+}
+
 // handleExternalLoginCallback handles the callback from a IDP
 // and tries to extract the user with the provided data
 func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Request) {
@@ -256,8 +312,19 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 			return
 		}
 		session = &openid.Session{Provider: provider.(*google.Provider).Provider, Code: data.Code}
+	case domain.IDPTypeApple:
+		provider, err = l.appleProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = &apple.Session{
+			Session:       &openid.Session{Provider: provider.(*apple.Provider).Provider, Code: data.Code},
+			UserFormValue: l.idpUserFormValue(w, r),
+		}
 	case domain.IDPTypeJWT,
 		domain.IDPTypeLDAP,
+		domain.IDPTypeSAML,
 		domain.IDPTypeUnspecified:
 		fallthrough
 	default:
@@ -273,6 +340,159 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 	l.handleExternalUserAuthenticated(w, r, authReq, identityProvider, session, user, l.renderNextStep)
 }
 
+// handleExternalLoginCallbackForm handles the callback of an IDP using the form_post response mode (e.g. Apple).
+// The form is posted cross-site, so the user agent cookie is not sent.
+// The data is therefore passed on to the "normal" callback endpoint by a (same-site) redirect.
+// The `user` form value (containing personal information) is not passed in the URL, but in an encrypted cookie.
+func (l *Login) handleExternalLoginCallbackForm(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		l.renderLogin(w, r, nil, err)
+		return
+	}
+	if user := r.Form.Get(idpUserFormParam); user != "" {
+		if err = l.setIDPUserFormCookie(w, user); err != nil {
+			l.renderLogin(w, r, nil, err)
+			return
+		}
+		r.Form.Del(idpUserFormParam)
+	}
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointExternalLoginCallback+"?"+r.Form.Encode(), http.StatusFound)
+}
+
+func (l *Login) setIDPUserFormCookie(w http.ResponseWriter, value string) error {
+	encrypted, err := l.idpConfigAlg.Encrypt([]byte(value))
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     idpUserFormCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(encrypted),
+		Path:     HandlerPrefix,
+		MaxAge:   idpUserFormCookieMaxAge,
+		Secure:   l.externalSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+// idpUserFormValue returns the decrypted `user` form value set by [handleExternalLoginCallbackForm] (if any)
+// and removes the cookie
+func (l *Login) idpUserFormValue(w http.ResponseWriter, r *http.Request) string {
+	cookie, err := r.Cookie(idpUserFormCookieName)
+	if err != nil {
+		return ""
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     idpUserFormCookieName,
+		Path:     HandlerPrefix,
+		MaxAge:   -1,
+		Secure:   l.externalSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	encrypted, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return ""
+	}
+	value, err := l.idpConfigAlg.DecryptString(encrypted, l.idpConfigAlg.EncryptionKeyID())
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// handleSAMLACS handles the SAML response posted by the IDP (assertion consumer service)
+// and tries to extract the user with the provided data
+func (l *Login) handleSAMLACS(w http.ResponseWriter, r *http.Request) {
+	data := new(externalSAMLResponseData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderLogin(w, r, nil, err)
+		return
+	}
+	// the response is posted cross-site, so the user agent cookie is not sent
+	// and the user agent id is recovered from the id of the request ZITADEL sent to the IDP
+	requestID, err := saml.ResponseRequestID(data.SAMLResponse)
+	if err != nil {
+		l.renderLogin(w, r, nil, err)
+		return
+	}
+	authReqID, userAgentID, err := l.samlRequestIDValues(requestID)
+	if err != nil {
+		l.renderLogin(w, r, nil, err)
+		return
+	}
+	// the request id is bound to the auth request, so the response cannot be replayed against another one
+	if authReqID != data.RelayState {
+		l.renderLogin(w, r, nil, errors.ThrowInvalidArgument(nil, "LOGIN-Gn3ao", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	authReq, err := l.authRepo.AuthRequestByID(r.Context(), authReqID, userAgentID)
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, nil, nil, err)
+		return
+	}
+	identityProvider, err := l.getIDPByID(r, authReq.SelectedIDPConfigID)
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, nil, nil, err)
+		return
+	}
+	if identityProvider.Type != domain.IDPTypeSAML {
+		l.externalAuthFailed(w, r, authReq, nil, nil, errors.ThrowInvalidArgument(nil, "LOGIN-3wtd4r9pq2", "Errors.ExternalIDP.IDPTypeNotImplemented"))
+		return
+	}
+	provider, err := l.samlProvider(r.Context(), identityProvider)
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, nil, nil, err)
+		return
+	}
+	session := &saml.Session{
+		Provider:       provider,
+		RequestID:      requestID,
+		Response:       data.SAMLResponse,
+		UsedAssertions: l.samlAssertions(r.Context(), identityProvider.ID),
+	}
+	user, err := session.FetchUser(r.Context())
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, tokens(session), user, err)
+		return
+	}
+	l.handleExternalUserAuthenticated(w, r, authReq, identityProvider, session, user, l.samlCallback)
+}
+
+// samlCallback redirects to the login (same-site), so the user agent cookie will be sent again
+func (l *Login) samlCallback(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointLogin+"?"+QueryAuthRequestID+"="+authReq.ID, http.StatusFound)
+}
+
+// handleSAMLMetadata returns the service provider metadata of ZITADEL for the requested IDP
+func (l *Login) handleSAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	identityProvider, err := l.getIDPByID(r, r.FormValue(queryIDPConfigID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if identityProvider.Type != domain.IDPTypeSAML {
+		http.Error(w, "idp is not of type saml", http.StatusNotFound)
+		return
+	}
+	provider, err := l.samlProvider(r.Context(), identityProvider)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	metadata, err := provider.Metadata()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, err = w.Write(metadata)
+	logging.OnError(err).Error("unable to write saml metadata")
+}
+
 // handleExternalUserAuthenticated maps the IDP user, checks for a corresponding externalID
 func (l *Login) handleExternalUserAuthenticated(
 	w http.ResponseWriter,
@@ -622,6 +842,21 @@ func (l *Login) googleProvider(ctx context.Context, identityProvider *query.IDPT
 	)
 }
 
+func (l *Login) appleProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*apple.Provider, error) {
+	privateKey, err := crypto.Decrypt(identityProvider.AppleIDPTemplate.PrivateKey, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return apple.New(
+		identityProvider.AppleIDPTemplate.ClientID,
+		identityProvider.AppleIDPTemplate.TeamID,
+		identityProvider.AppleIDPTemplate.KeyID,
+		l.baseURL(ctx)+EndpointExternalLoginCallbackFormPost,
+		privateKey,
+		identityProvider.AppleIDPTemplate.Scopes,
+	)
+}
+
 func (l *Login) oidcProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*openid.Provider, error) {
 	secret, err := crypto.DecryptString(identityProvider.OIDCIDPTemplate.ClientSecret, l.idpConfigAlg)
 	if err != nil {
@@ -759,6 +994,97 @@ func (l *Login) gitlabSelfHostedProvider(ctx context.Context, identityProvider *
 	)
 }
 
+func (l *Login) samlProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*saml.Provider, error) {
+	key, err := crypto.Decrypt(identityProvider.SAMLIDPTemplate.Key, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]saml.ProviderOpts, 0, 6)
+	if identityProvider.IsLinkingAllowed {
+		opts = append(opts, saml.WithLinkingAllowed())
+	}
+	if identityProvider.IsCreationAllowed {
+		opts = append(opts, saml.WithCreationAllowed())
+	}
+	if identityProvider.IsAutoCreation {
+		opts = append(opts, saml.WithAutoCreation())
+	}
+	if identityProvider.IsAutoUpdate {
+		opts = append(opts, saml.WithAutoUpdate())
+	}
+	if identityProvider.SAMLIDPTemplate.Binding != "" {
+		opts = append(opts, saml.WithBinding(identityProvider.SAMLIDPTemplate.Binding))
+	}
+	if identityProvider.SAMLIDPTemplate.WithSignedRequest {
+		opts = append(opts, saml.WithSignedRequest())
+	}
+	return saml.New(
+		identityProvider.Name,
+		l.baseURL(ctx)+EndpointSAMLMetadata+"?"+queryIDPConfigID+"="+url.QueryEscape(identityProvider.ID),
+		l.baseURL(ctx)+EndpointSAMLACS,
+		identityProvider.SAMLIDPTemplate.Metadata,
+		identityProvider.SAMLIDPTemplate.Certificate,
+		key,
+		opts...,
+	)
+}
+
+// samlRequestID encrypts the auth request id and the user agent id into the id of the SAML request,
+// which will be returned by the IDP as InResponseTo of the SAML response
+func (l *Login) samlRequestID(authReqID, userAgentID string) (saml.RequestID, error) {
+	encrypted, err := l.idpConfigAlg.Encrypt([]byte(authReqID + samlRequestIDSeparator + userAgentID))
+	if err != nil {
+		return "", err
+	}
+	return saml.RequestID(samlRequestIDPrefix + base64.RawURLEncoding.EncodeToString(encrypted)), nil
+}
+
+// samlAssertions returns the [saml.UsedAssertions] of the IDP of the current instance
+func (l *Login) samlAssertions(ctx context.Context, idpID string) *usedSAMLAssertions {
+	return &usedSAMLAssertions{
+		storage:    l.usedSAMLAssertions,
+		instanceID: authz.GetInstance(ctx).InstanceID(),
+		idpID:      idpID,
+	}
+}
+
+// usedSAMLAssertions counts the uses of the assertions of an IDP in the [ratelimit.Storage] until they expire,
+// so an assertion is only accepted on its first use
+type usedSAMLAssertions struct {
+	storage    ratelimit.Storage
+	instanceID string
+	idpID      string
+}
+
+func (u *usedSAMLAssertions) Use(ctx context.Context, id string, expiration time.Time) (bool, error) {
+	now := time.Now()
+	if !expiration.After(now) {
+		return false, nil
+	}
+	uses, err := u.storage.Increment(ctx, cache.InstanceKey(u.instanceID, samlAssertionKeyPrefix+u.idpID+":"+id), expiration.Sub(now), now)
+	if err != nil {
+		return false, err
+	}
+	return uses.Count == 1, nil
+}
+
+// samlRequestIDValues returns the auth request id and the user agent id encrypted into the id of the SAML request
+func (l *Login) samlRequestIDValues(requestID string) (authReqID, userAgentID string, err error) {
+	id, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(requestID, samlRequestIDPrefix))
+	if err != nil {
+		return "", "", errors.ThrowInvalidArgument(err, "LOGIN-Sh3b1", "Errors.AuthRequest.NotFound")
+	}
+	decrypted, err := l.idpConfigAlg.DecryptString(id, l.idpConfigAlg.EncryptionKeyID())
+	if err != nil {
+		return "", "", errors.ThrowInvalidArgument(err, "LOGIN-Sh3b2", "Errors.AuthRequest.NotFound")
+	}
+	authReqID, userAgentID, ok := strings.Cut(decrypted, samlRequestIDSeparator)
+	if !ok || authReqID == "" || userAgentID == "" {
+		return "", "", errors.ThrowInvalidArgument(nil, "LOGIN-Sh3b3", "Errors.AuthRequest.NotFound")
+	}
+	return authReqID, userAgentID, nil
+}
+
 func (l *Login) appendUserGrants(ctx context.Context, userGrants []*domain.UserGrant, resourceOwner string) error {
 	if len(userGrants) == 0 {
 		return nil
@@ -786,6 +1112,8 @@ func tokens(session idp.Session) *oidc.Tokens {
 		return s.Tokens
 	case *jwt.Session:
 		return s.Tokens
+	case *apple.Session:
+		return s.Tokens
 	}
 	return nil
 }
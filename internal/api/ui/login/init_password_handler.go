@@ -29,13 +29,14 @@ type initPasswordFormData struct {
 type initPasswordData struct {
 	baseData
 	profileData
-	Code         string
-	UserID       string
-	MinLength    uint64
-	HasUppercase string
-	HasLowercase string
-	HasNumber    string
-	HasSymbol    string
+	Code          string
+	UserID        string
+	MinLength     uint64
+	HasUppercase  string
+	HasLowercase  string
+	HasNumber     string
+	HasSymbol     string
+	CheckBreached bool
 }
 
 func InitPasswordLink(origin, userID, code, orgID string) string {
@@ -84,6 +85,10 @@ func (l *Login) checkPWCode(w http.ResponseWriter, r *http.Request, authReq *dom
 		l.renderInitPassword(w, r, authReq, data.UserID, "", err)
 		return
 	}
+	if err = l.postPasswordChange(r, authReq, data.UserID, userOrg); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
 	l.renderInitPasswordDone(w, r, authReq, userOrg)
 }
 
@@ -147,6 +152,7 @@ func (l *Login) renderInitPassword(w http.ResponseWriter, r *http.Request, authR
 		if policy.HasNumber {
 			data.HasNumber = NumberRegex
 		}
+		data.CheckBreached = policy.CheckBreached
 	}
 	if authReq == nil {
 		user, err := l.query.GetUserByID(r.Context(), false, userID, false)
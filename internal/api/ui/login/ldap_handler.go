@@ -0,0 +1,187 @@
+package login
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/ratelimit"
+)
+
+const (
+	tmplLDAPLogin = "ldaplogin"
+)
+
+type ldapData struct {
+	State string `schema:"state"`
+}
+
+type ldapFormData struct {
+	Username string `schema:"username"`
+	Password string `schema:"password"`
+}
+
+type ldapLoginData struct {
+	baseData
+	Username string
+}
+
+// handleLDAP renders the login form for the LDAP provider the user has selected
+// the auth request is passed as state by the provider (see [ldap.Provider.BeginAuth])
+func (l *Login) handleLDAP(w http.ResponseWriter, r *http.Request) {
+	data := new(ldapData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderLogin(w, r, nil, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	authReq, err := l.authRepo.AuthRequestByID(r.Context(), data.State, userAgentID)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	l.renderLDAPLogin(w, r, authReq, "", nil)
+}
+
+func (l *Login) renderLDAPLogin(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, username string, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := ldapLoginData{
+		baseData: l.getBaseData(r, authReq, "LDAP.Title", "LDAP.Description", errID, errMessage),
+		Username: username,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplLDAPLogin], data, nil)
+}
+
+// handleLDAPCallback verifies the entered credentials against the directory of the selected LDAP provider
+// and handles the returned user the same way as for any other external identity provider
+func (l *Login) handleLDAPCallback(w http.ResponseWriter, r *http.Request) {
+	data := new(ldapFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.renderLogin(w, r, nil, errors.ThrowInvalidArgument(nil, "LOGIN-Ks3ad", "Errors.AuthRequest.NotFound"))
+		return
+	}
+	identityProvider, err := l.getIDPByID(r, authReq.SelectedIDPConfigID)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	if identityProvider.Type != domain.IDPTypeLDAP {
+		l.renderLogin(w, r, authReq, errors.ThrowInvalidArgument(nil, "LOGIN-Bgs3q", "Errors.ExternalIDP.IDPTypeNotImplemented"))
+		return
+	}
+	limitKeys := []ratelimit.Key{l.rateLimiter.ClientIP(r), ratelimit.LoginName(data.Username)}
+	if err = l.rateLimiter.Check(r.Context(), limitKeys...); err != nil {
+		l.renderLDAPLogin(w, r, authReq, data.Username, err)
+		return
+	}
+	provider, err := l.ldapProvider(r.Context(), identityProvider)
+	if err != nil {
+		l.renderLDAPLogin(w, r, authReq, data.Username, err)
+		return
+	}
+	session := &ldap.Session{Provider: provider, User: data.Username, Password: data.Password}
+	user, err := session.FetchUser(r.Context())
+	if err != nil {
+		l.rateLimiter.Failed(r.Context(), limitKeys...)
+		if _, actionErr := l.runPostExternalAuthenticationActions(&domain.ExternalUser{}, nil, authReq, r, nil, err); actionErr != nil {
+			logging.WithError(err).Error("both ldap user authentication and action post authentication failed")
+		}
+		l.renderLDAPLogin(w, r, authReq, data.Username, errors.ThrowInvalidArgument(err, "LOGIN-nV4ws", "Errors.User.ExternalIDP.LoginFailed"))
+		return
+	}
+	l.rateLimiter.Succeeded(r.Context(), ratelimit.LoginName(data.Username))
+	l.handleExternalUserAuthenticated(w, r, authReq, identityProvider, session, user, l.renderNextStep)
+}
+
+func (l *Login) ldapProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*ldap.Provider, error) {
+	password, err := crypto.DecryptString(identityProvider.LDAPIDPTemplate.Password, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	var opts []ldap.ProviderOpts
+	if identityProvider.IsLinkingAllowed {
+		opts = append(opts, ldap.WithLinkingAllowed())
+	}
+	if identityProvider.IsCreationAllowed {
+		opts = append(opts, ldap.WithCreationAllowed())
+	}
+	if identityProvider.IsAutoCreation {
+		opts = append(opts, ldap.WithAutoCreation())
+	}
+	if identityProvider.IsAutoUpdate {
+		opts = append(opts, ldap.WithAutoUpdate())
+	}
+	if identityProvider.LDAPIDPTemplate.Port != "" {
+		opts = append(opts, ldap.WithCustomPort(identityProvider.LDAPIDPTemplate.Port))
+	}
+	if !identityProvider.LDAPIDPTemplate.TLS {
+		opts = append(opts, ldap.Insecure())
+	}
+	attributes := identityProvider.LDAPIDPTemplate.LDAPAttributes
+	if attributes.IDAttribute != "" {
+		opts = append(opts, ldap.WithCustomIDAttribute(attributes.IDAttribute))
+	}
+	if attributes.FirstNameAttribute != "" {
+		opts = append(opts, ldap.WithFirstNameAttribute(attributes.FirstNameAttribute))
+	}
+	if attributes.LastNameAttribute != "" {
+		opts = append(opts, ldap.WithLastNameAttribute(attributes.LastNameAttribute))
+	}
+	if attributes.DisplayNameAttribute != "" {
+		opts = append(opts, ldap.WithDisplayNameAttribute(attributes.DisplayNameAttribute))
+	}
+	if attributes.NickNameAttribute != "" {
+		opts = append(opts, ldap.WithNickNameAttribute(attributes.NickNameAttribute))
+	}
+	if attributes.PreferredUsernameAttribute != "" {
+		opts = append(opts, ldap.WithPreferredUsernameAttribute(attributes.PreferredUsernameAttribute))
+	}
+	if attributes.EmailAttribute != "" {
+		opts = append(opts, ldap.WithEmailAttribute(attributes.EmailAttribute))
+	}
+	if attributes.EmailVerifiedAttribute != "" {
+		opts = append(opts, ldap.WithEmailVerifiedAttribute(attributes.EmailVerifiedAttribute))
+	}
+	if attributes.PhoneAttribute != "" {
+		opts = append(opts, ldap.WithPhoneAttribute(attributes.PhoneAttribute))
+	}
+	if attributes.PhoneVerifiedAttribute != "" {
+		opts = append(opts, ldap.WithPhoneVerifiedAttribute(attributes.PhoneVerifiedAttribute))
+	}
+	if attributes.PreferredLanguageAttribute != "" {
+		opts = append(opts, ldap.WithPreferredLanguageAttribute(attributes.PreferredLanguageAttribute))
+	}
+	if attributes.AvatarURLAttribute != "" {
+		opts = append(opts, ldap.WithAvatarURLAttribute(attributes.AvatarURLAttribute))
+	}
+	if attributes.ProfileAttribute != "" {
+		opts = append(opts, ldap.WithProfileAttribute(attributes.ProfileAttribute))
+	}
+	return ldap.New(
+		identityProvider.Name,
+		identityProvider.LDAPIDPTemplate.Host,
+		identityProvider.LDAPIDPTemplate.BaseDN,
+		identityProvider.LDAPIDPTemplate.UserObjectClass,
+		identityProvider.LDAPIDPTemplate.UserUniqueAttribute,
+		identityProvider.LDAPIDPTemplate.Admin,
+		password,
+		l.baseURL(ctx)+EndpointLDAPLogin,
+		opts...,
+	), nil
+}
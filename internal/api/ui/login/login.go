@@ -22,6 +22,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/form"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 	"github.com/zitadel/zitadel/internal/static"
 )
 
@@ -40,6 +41,9 @@ type Login struct {
 	samlAuthCallbackURL func(context.Context, string) string
 	idpConfigAlg        crypto.EncryptionAlgorithm
 	userCodeAlg         crypto.EncryptionAlgorithm
+	rateLimiter         *ratelimit.Limiter
+	// usedSAMLAssertions stores the ids of the used SAML assertions until they expire
+	usedSAMLAssertions ratelimit.Storage
 }
 
 type Config struct {
@@ -73,6 +77,8 @@ func CreateLogin(config Config,
 	userCodeAlg crypto.EncryptionAlgorithm,
 	idpConfigAlg crypto.EncryptionAlgorithm,
 	csrfCookieKey []byte,
+	rateLimiter *ratelimit.Limiter,
+	usedSAMLAssertions ratelimit.Storage,
 ) (*Login, error) {
 	login := &Login{
 		oidcAuthCallbackURL: oidcAuthCallbackURL,
@@ -85,6 +91,8 @@ func CreateLogin(config Config,
 		authRepo:            authRepo,
 		idpConfigAlg:        idpConfigAlg,
 		userCodeAlg:         userCodeAlg,
+		rateLimiter:         rateLimiter,
+		usedSAMLAssertions:  usedSAMLAssertions,
 	}
 	statikFS, err := fs.NewWithNamespace("login")
 	if err != nil {
@@ -117,6 +125,10 @@ func createCSRFInterceptor(cookieName string, csrfCookieKey []byte, externalSecu
 				handler.ServeHTTP(w, r)
 				return
 			}
+			if strings.HasPrefix(r.URL.Path, EndpointSAMLACS) || strings.HasPrefix(r.URL.Path, EndpointExternalLoginCallbackFormPost) {
+				// the response is posted by the identity provider (cross-site), so there can't be a valid token
+				r = csrf.UnsafeSkipCheck(r)
+			}
 			csrf.Protect(csrfCookieKey,
 				csrf.Secure(externalSecure),
 				csrf.CookieName(http_utils.SetCookiePrefix(cookieName, "", path, externalSecure)),
@@ -174,3 +186,11 @@ func setContext(ctx context.Context, resourceOwner string) context.Context {
 func (l *Login) baseURL(ctx context.Context) string {
 	return http_utils.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), l.externalSecure) + HandlerPrefix
 }
+
+// rateLimitKeys returns the keys of the login rate limit for the client ip and the login name of the auth request
+func (l *Login) rateLimitKeys(r *http.Request, authReq *domain.AuthRequest) []ratelimit.Key {
+	return []ratelimit.Key{
+		l.rateLimiter.ClientIP(r),
+		ratelimit.LoginName(authReq.LoginName),
+	}
+}
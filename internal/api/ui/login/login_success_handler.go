@@ -32,6 +32,10 @@ func (l *Login) handleLoginSuccess(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if _, ok := authRequest.Request.(*domain.AuthRequestDevice); ok {
+		l.redirectToDeviceAuthAction(w, r, authRequest)
+		return
+	}
 	l.renderSuccessAndCallback(w, r, authRequest, nil)
 }
 
@@ -61,6 +65,9 @@ func (l *Login) redirectToCallback(w http.ResponseWriter, r *http.Request, authR
 		callback = l.oidcAuthCallbackURL(r.Context(), authReq.ID)
 	case *domain.AuthRequestSAML:
 		callback = l.samlAuthCallbackURL(r.Context(), authReq.ID)
+	case *domain.AuthRequestDevice:
+		l.redirectToDeviceAuthAction(w, r, authReq)
+		return
 	default:
 		l.renderInternalError(w, r, authReq, caos_errs.ThrowInternal(nil, "LOGIN-rhjQF", "Errors.AuthRequest.RequestTypeNotSupported"))
 		return
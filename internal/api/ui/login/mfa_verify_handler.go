@@ -5,10 +5,12 @@ import (
 
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 )
 
 const (
-	tmplMFAVerify = "mfaverify"
+	tmplMFAVerify        = "mfaverify"
+	tmplMFAVerifyOTPCode = "mfaverifyotpcode"
 )
 
 type mfaVerifyFormData struct {
@@ -33,21 +35,43 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 		l.renderMFAVerifySelected(w, r, authReq, step, data.SelectedProvider, nil)
 		return
 	}
-	if data.MFAType == domain.MFATypeOTP {
-		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	limitKeys := l.rateLimitKeys(r, authReq)
+	if err = l.rateLimiter.Check(r.Context(), limitKeys...); err != nil {
+		l.renderMFAVerifySelected(w, r, authReq, step, data.MFAType, err)
+		return
+	}
+	var method authMethod
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	switch data.MFAType {
+	case domain.MFATypeOTP:
+		method = authMethodOTP
 		err = l.authRepo.VerifyMFAOTP(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPSMS:
+		method = authMethodOTPSMS
+		err = l.authRepo.VerifyMFAOTPSMS(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPEmail:
+		method = authMethodOTPEmail
+		err = l.authRepo.VerifyMFAOTPEmail(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	default:
+		l.renderNextStep(w, r, authReq)
+		return
+	}
+	if err != nil {
+		l.rateLimiter.Failed(r.Context(), limitKeys...)
+	} else {
+		l.rateLimiter.Succeeded(r.Context(), ratelimit.LoginName(authReq.LoginName))
+	}
 
-		metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodOTP, err)
-		if err == nil && actionErr == nil && len(metadata) > 0 {
-			_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
-		} else if actionErr != nil && err == nil {
-			err = actionErr
-		}
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, method, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
 
-		if err != nil {
-			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeOTP, err)
-			return
-		}
+	if err != nil {
+		l.renderMFAVerifySelected(w, r, authReq, step, data.MFAType, err)
+		return
 	}
 	l.renderNextStep(w, r, authReq)
 }
@@ -84,6 +108,12 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 		data.SelectedMFAProvider = domain.MFATypeOTP
 		data.Title = translator.LocalizeWithoutArgs("VerifyMFAOTP.Title")
 		data.Description = translator.LocalizeWithoutArgs("VerifyMFAOTP.Description")
+	case domain.MFATypeOTPSMS:
+		l.renderOTPCodeVerification(w, r, authReq, verificationStep, data, domain.MFATypeOTPSMS, "VerifyMFAOTPSMS", err)
+		return
+	case domain.MFATypeOTPEmail:
+		l.renderOTPCodeVerification(w, r, authReq, verificationStep, data, domain.MFATypeOTPEmail, "VerifyMFAOTPEmail", err)
+		return
 	default:
 		l.renderError(w, r, authReq, err)
 		return
@@ -91,6 +121,30 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerify], data, nil)
 }
 
+// renderOTPCodeVerification sends a new code to the user (unless the page is rendered because of an error,
+// e.g. a wrong code) and renders the page to enter the code
+func (l *Login) renderOTPCodeVerification(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, verificationStep *domain.MFAVerificationStep, data userData, mfaType domain.MFAType, textKey string, err error) {
+	if err == nil {
+		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+		ctx := setContext(r.Context(), authReq.UserOrgID)
+		browserInfo := domain.BrowserInfoFromRequest(r)
+		if mfaType == domain.MFATypeOTPSMS {
+			err = l.authRepo.SendMFAOTPSMS(ctx, authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID, browserInfo)
+		} else {
+			err = l.authRepo.SendMFAOTPEmail(ctx, authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID, browserInfo)
+		}
+		if err != nil {
+			data.ErrID, data.ErrMessage = l.getErrorMessage(r, err)
+		}
+	}
+	translator := l.getTranslator(r.Context(), authReq)
+	data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, mfaType)
+	data.SelectedMFAProvider = mfaType
+	data.Title = translator.LocalizeWithoutArgs(textKey + ".Title")
+	data.Description = translator.LocalizeWithoutArgs(textKey + ".Description")
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFAVerifyOTPCode], data, nil)
+}
+
 func removeSelectedProviderFromList(providers []domain.MFAType, selected domain.MFAType) []domain.MFAType {
 	for i := len(providers) - 1; i >= 0; i-- {
 		if providers[i] == selected {
@@ -0,0 +1,56 @@
+package login
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	tmplPasswordExpiryWarning = "passwordexpirywarning"
+)
+
+type passwordExpiryWarningData struct {
+	userData
+	Days int
+}
+
+type passwordExpiryWarningFormData struct {
+	Skip bool `schema:"skip"`
+}
+
+func (l *Login) handlePasswordExpiryWarning(w http.ResponseWriter, r *http.Request) {
+	data := new(passwordExpiryWarningFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if !data.Skip {
+		l.renderChangePassword(w, r, authReq, nil)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err = l.authRepo.SkipPasswordExpiryWarning(r.Context(), authReq.ID, userAgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderPasswordExpiryWarning(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, step *domain.PasswordExpiryWarningStep, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := &passwordExpiryWarningData{
+		userData: l.getUserData(r, authReq, "PasswordExpiryWarning.Title", "PasswordExpiryWarning.Description", errID, errMessage),
+		Days:     int(math.Ceil(time.Until(step.Expiry).Hours() / 24)),
+	}
+	translator := l.getTranslator(r.Context(), authReq)
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplPasswordExpiryWarning], data, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/ratelimit"
 )
 
 const (
@@ -38,7 +39,17 @@ func (l *Login) handlePasswordCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	limitKeys := l.rateLimitKeys(r, authReq)
+	if err = l.rateLimiter.Check(r.Context(), limitKeys...); err != nil {
+		l.renderPassword(w, r, authReq, err)
+		return
+	}
 	err = l.authRepo.VerifyPassword(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Password, authReq.AgentID, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		l.rateLimiter.Failed(r.Context(), limitKeys...)
+	} else {
+		l.rateLimiter.Succeeded(r.Context(), ratelimit.LoginName(authReq.LoginName))
+	}
 
 	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodPassword, err)
 	if err == nil && actionErr == nil && len(metadata) > 0 {
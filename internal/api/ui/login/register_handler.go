@@ -5,6 +5,7 @@ import (
 
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/actions/object"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -34,6 +35,7 @@ type registerData struct {
 	HasLowercase       string
 	HasNumber          string
 	HasSymbol          string
+	CheckBreached      bool
 	ShowUsername       bool
 	ShowUsernameSuffix bool
 	OrgRegister        bool
@@ -67,6 +69,10 @@ func (l *Login) handleRegisterCheck(w http.ResponseWriter, r *http.Request) {
 	if authRequest != nil && authRequest.RequestedOrgID != "" && authRequest.RequestedOrgID != resourceOwner {
 		resourceOwner = authRequest.RequestedOrgID
 	}
+	if err = l.preRegistration(r, authRequest, data, resourceOwner); err != nil {
+		l.renderRegister(w, r, authRequest, data, err)
+		return
+	}
 	initCodeGenerator, err := l.query.InitEncryptionGenerator(r.Context(), domain.SecretGeneratorTypeInitCode, l.userCodeAlg)
 	if err != nil {
 		l.renderRegister(w, r, authRequest, data, err)
@@ -179,6 +185,7 @@ func (l *Login) renderRegister(w http.ResponseWriter, r *http.Request, authReque
 		if pwPolicy.HasNumber {
 			data.HasNumber = NumberRegex
 		}
+		data.CheckBreached = pwPolicy.CheckBreached
 	}
 
 	orgIAMPolicy, err := l.getOrgDomainPolicy(r, resourceOwner)
@@ -210,6 +217,25 @@ func (l *Login) renderRegister(w http.ResponseWriter, r *http.Request, authReque
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplRegister], data, funcs)
 }
 
+// preRegistration lets the actions of the pre registration flow validate and change the form,
+// the changed values are shown to the user if the form is rejected
+func (l *Login) preRegistration(r *http.Request, authRequest *domain.AuthRequest, data *registerFormData, resourceOwner string) error {
+	form := &object.RegistrationForm{
+		Email:             data.Email,
+		Username:          data.Username,
+		FirstName:         data.Firstname,
+		LastName:          data.Lastname,
+		PreferredLanguage: data.Language,
+	}
+	err := l.runPreRegistrationActions(authRequest, r, form, resourceOwner)
+	data.Email = form.Email
+	data.Username = form.Username
+	data.Firstname = form.FirstName
+	data.Lastname = form.LastName
+	data.Language = form.PreferredLanguage
+	return err
+}
+
 func (d registerFormData) toHumanDomain() *domain.Human {
 	return &domain.Human{
 		Username: d.Username,
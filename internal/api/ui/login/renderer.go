@@ -53,6 +53,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplPasswordlessRegistrationDone: "passwordless_registration_done.html",
 		tmplPasswordlessPrompt:           "passwordless_prompt.html",
 		tmplMFAVerify:                    "mfa_verify_otp.html",
+		tmplMFAVerifyOTPCode:             "mfa_verify_otp_code.html",
 		tmplMFAPrompt:                    "mfa_prompt.html",
 		tmplMFAInitVerify:                "mfa_init_otp.html",
 		tmplMFAU2FInit:                   "mfa_init_u2f.html",
@@ -67,6 +68,7 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplPasswordResetDone:            "password_reset_done.html",
 		tmplChangePassword:               "change_password.html",
 		tmplChangePasswordDone:           "change_password_done.html",
+		tmplPasswordExpiryWarning:        "password_expiry_warning.html",
 		tmplRegisterOption:               "register_option.html",
 		tmplRegister:                     "register.html",
 		tmplLogoutDone:                   "logout_done.html",
@@ -76,6 +78,11 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplLinkUsersDone:                "link_users_done.html",
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
 		tmplLoginSuccess:                 "login_success.html",
+		tmplExternalSAMLPost:             "external_saml_post.html",
+		tmplLDAPLogin:                    "ldap_login.html",
+		tmplDeviceAuthUserCode:           "device_usercode.html",
+		tmplDeviceAuthAction:             "device_action.html",
+		tmplDeviceAuthDone:               "device_done.html",
 	}
 	funcs := map[string]interface{}{
 		"resourceUrl": func(file string) string {
@@ -153,6 +160,15 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"passwordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPassword)
 		},
+		"ldapUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointLDAPCallback)
+		},
+		"deviceAuthUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointDeviceAuth)
+		},
+		"deviceAuthActionUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointDeviceAuthAction)
+		},
 		"mfaVerifyUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMFAVerify)
 		},
@@ -183,6 +199,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"changePasswordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointChangePassword)
 		},
+		"passwordExpiryWarningUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPasswordExpiryWarning)
+		},
 		"registerOptionUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointRegisterOption)
 		},
@@ -293,6 +312,8 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 		l.redirectToLoginSuccess(w, r, authReq.ID)
 	case *domain.ChangePasswordStep:
 		l.renderChangePassword(w, r, authReq, err)
+	case *domain.PasswordExpiryWarningStep:
+		l.renderPasswordExpiryWarning(w, r, authReq, step, err)
 	case *domain.VerifyEMailStep:
 		l.renderMailVerification(w, r, authReq, "", err)
 	case *domain.MFAPromptStep:
@@ -631,11 +652,13 @@ type profileData struct {
 type passwordData struct {
 	baseData
 	profileData
-	MinLength    uint64
-	HasUppercase string
-	HasLowercase string
-	HasNumber    string
-	HasSymbol    string
+	MinLength     uint64
+	HasUppercase  string
+	HasLowercase  string
+	HasNumber     string
+	HasSymbol     string
+	CheckBreached bool
+	Expired       bool
 }
 
 type userSelectionData struct {
@@ -7,40 +7,48 @@ import (
 )
 
 const (
-	EndpointRoot                     = "/"
-	EndpointHealthz                  = "/healthz"
-	EndpointReadiness                = "/ready"
-	EndpointLogin                    = "/login"
-	EndpointExternalLogin            = "/login/externalidp"
-	EndpointExternalLoginCallback    = "/login/externalidp/callback"
-	EndpointJWTAuthorize             = "/login/jwt/authorize"
-	EndpointJWTCallback              = "/login/jwt/callback"
-	EndpointPasswordlessLogin        = "/login/passwordless"
-	EndpointPasswordlessRegistration = "/login/passwordless/init"
-	EndpointPasswordlessPrompt       = "/login/passwordless/prompt"
-	EndpointLoginName                = "/loginname"
-	EndpointUserSelection            = "/userselection"
-	EndpointChangeUsername           = "/username/change"
-	EndpointPassword                 = "/password"
-	EndpointInitPassword             = "/password/init"
-	EndpointChangePassword           = "/password/change"
-	EndpointPasswordReset            = "/password/reset"
-	EndpointInitUser                 = "/user/init"
-	EndpointMFAVerify                = "/mfa/verify"
-	EndpointMFAPrompt                = "/mfa/prompt"
-	EndpointMFAInitVerify            = "/mfa/init/verify"
-	EndpointMFAInitU2FVerify         = "/mfa/init/u2f/verify"
-	EndpointU2FVerification          = "/mfa/u2f/verify"
-	EndpointMailVerification         = "/mail/verification"
-	EndpointMailVerified             = "/mail/verified"
-	EndpointRegisterOption           = "/register/option"
-	EndpointRegister                 = "/register"
-	EndpointExternalRegister         = "/register/externalidp"
-	EndpointExternalRegisterCallback = "/register/externalidp/callback"
-	EndpointRegisterOrg              = "/register/org"
-	EndpointLogoutDone               = "/logout/done"
-	EndpointLoginSuccess             = "/login/success"
-	EndpointExternalNotFoundOption   = "/externaluser/option"
+	EndpointRoot                          = "/"
+	EndpointHealthz                       = "/healthz"
+	EndpointReadiness                     = "/ready"
+	EndpointLogin                         = "/login"
+	EndpointExternalLogin                 = "/login/externalidp"
+	EndpointExternalLoginCallback         = "/login/externalidp/callback"
+	EndpointExternalLoginCallbackFormPost = "/login/externalidp/callback/form"
+	EndpointSAMLACS                       = "/login/externalidp/saml/acs"
+	EndpointSAMLMetadata                  = "/login/externalidp/saml/metadata"
+	EndpointLDAPLogin                     = "/login/ldap"
+	EndpointLDAPCallback                  = "/login/ldap/callback"
+	EndpointJWTAuthorize                  = "/login/jwt/authorize"
+	EndpointJWTCallback                   = "/login/jwt/callback"
+	EndpointPasswordlessLogin             = "/login/passwordless"
+	EndpointPasswordlessRegistration      = "/login/passwordless/init"
+	EndpointPasswordlessPrompt            = "/login/passwordless/prompt"
+	EndpointLoginName                     = "/loginname"
+	EndpointUserSelection                 = "/userselection"
+	EndpointChangeUsername                = "/username/change"
+	EndpointPassword                      = "/password"
+	EndpointInitPassword                  = "/password/init"
+	EndpointChangePassword                = "/password/change"
+	EndpointPasswordExpiryWarning         = "/password/expiry"
+	EndpointPasswordReset                 = "/password/reset"
+	EndpointInitUser                      = "/user/init"
+	EndpointMFAVerify                     = "/mfa/verify"
+	EndpointMFAPrompt                     = "/mfa/prompt"
+	EndpointMFAInitVerify                 = "/mfa/init/verify"
+	EndpointMFAInitU2FVerify              = "/mfa/init/u2f/verify"
+	EndpointU2FVerification               = "/mfa/u2f/verify"
+	EndpointMailVerification              = "/mail/verification"
+	EndpointMailVerified                  = "/mail/verified"
+	EndpointRegisterOption                = "/register/option"
+	EndpointRegister                      = "/register"
+	EndpointExternalRegister              = "/register/externalidp"
+	EndpointExternalRegisterCallback      = "/register/externalidp/callback"
+	EndpointRegisterOrg                   = "/register/org"
+	EndpointLogoutDone                    = "/logout/done"
+	EndpointLoginSuccess                  = "/login/success"
+	EndpointExternalNotFoundOption        = "/externaluser/option"
+	EndpointDeviceAuth                    = "/device"
+	EndpointDeviceAuthAction              = "/device/action"
 
 	EndpointResources        = "/resources"
 	EndpointDynamicResources = "/resources/dynamic"
@@ -64,6 +72,11 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointLogin, login.handleLogin).Methods(http.MethodGet, http.MethodPost)
 	router.HandleFunc(EndpointExternalLogin, login.handleExternalLogin).Methods(http.MethodGet)
 	router.HandleFunc(EndpointExternalLoginCallback, login.handleExternalLoginCallback).Methods(http.MethodGet)
+	router.HandleFunc(EndpointExternalLoginCallbackFormPost, login.handleExternalLoginCallbackForm).Methods(http.MethodPost)
+	router.HandleFunc(EndpointSAMLACS, login.handleSAMLACS).Methods(http.MethodPost)
+	router.HandleFunc(EndpointSAMLMetadata, login.handleSAMLMetadata).Methods(http.MethodGet)
+	router.HandleFunc(EndpointLDAPLogin, login.handleLDAP).Methods(http.MethodGet)
+	router.HandleFunc(EndpointLDAPCallback, login.handleLDAPCallback).Methods(http.MethodPost)
 	router.HandleFunc(EndpointJWTAuthorize, login.handleJWTRequest).Methods(http.MethodGet)
 	router.HandleFunc(EndpointJWTCallback, login.handleJWTCallback).Methods(http.MethodGet)
 	router.HandleFunc(EndpointPasswordlessLogin, login.handlePasswordlessVerification).Methods(http.MethodPost)
@@ -89,6 +102,7 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerification).Methods(http.MethodGet)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerificationCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointChangePassword, login.handleChangePassword).Methods(http.MethodPost)
+	router.HandleFunc(EndpointPasswordExpiryWarning, login.handlePasswordExpiryWarning).Methods(http.MethodPost)
 	router.HandleFunc(EndpointRegisterOption, login.handleRegisterOption).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegisterOption, login.handleRegisterOptionCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointExternalNotFoundOption, login.handleExternalNotFoundOptionCheck).Methods(http.MethodPost)
@@ -102,6 +116,9 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointRegisterOrg, login.handleRegisterOrg).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegisterOrg, login.handleRegisterOrgCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointLoginSuccess, login.handleLoginSuccess).Methods(http.MethodGet)
+	router.HandleFunc(EndpointDeviceAuth, login.handleDeviceAuthUserCode).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc(EndpointDeviceAuthAction, login.handleDeviceAuthAction).Methods(http.MethodGet)
+	router.HandleFunc(EndpointDeviceAuthAction, login.handleDeviceAuthActionCheck).Methods(http.MethodPost)
 	router.SkipClean(true).Handle("", http.RedirectHandler(HandlerPrefix+"/", http.StatusMovedPermanently))
 	return router
 }
@@ -0,0 +1,6 @@
+document.addEventListener('DOMContentLoaded', function () {
+    let form = document.getElementsByTagName('form')[0];
+    if (form) {
+        form.submit();
+    }
+});
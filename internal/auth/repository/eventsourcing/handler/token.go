@@ -93,6 +93,19 @@ func (t *Token) Reduce(event *es_models.Event) (err error) {
 			return err
 		}
 		return t.view.PutToken(token, event)
+	case user_repo.UserImpersonatedType:
+		id, err := tokenIDFromEvent(event)
+		if err != nil {
+			return err
+		}
+		token, err := t.view.TokenByIDs(id, event.AggregateID, event.InstanceID)
+		if err != nil {
+			return err
+		}
+		if err = token.AppendEvent(event); err != nil {
+			return err
+		}
+		return t.view.PutToken(token, event)
 	case user.UserV1ProfileChangedType,
 		user.HumanProfileChangedType:
 		user := new(view_model.UserView)
@@ -121,7 +134,7 @@ func (t *Token) Reduce(event *es_models.Event) (err error) {
 		return t.view.DeleteUserTokens(event.AggregateID, event.InstanceID, event)
 	case user_repo.UserTokenRemovedType,
 		user_repo.PersonalAccessTokenRemovedType:
-		id, err := tokenIDFromRemovedEvent(event)
+		id, err := tokenIDFromEvent(event)
 		if err != nil {
 			return err
 		}
@@ -185,7 +198,7 @@ func applicationFromSession(event *es_models.Event) (*project_es_model.Applicati
 	return application, nil
 }
 
-func tokenIDFromRemovedEvent(event *es_models.Event) (string, error) {
+func tokenIDFromEvent(event *es_models.Event) (string, error) {
 	removed := make(map[string]interface{})
 	if err := json.Unmarshal(event.Data, &removed); err != nil {
 		logging.WithError(err).Error("could not unmarshal event data")
@@ -138,6 +138,10 @@ func (u *User) ProcessUser(event *es_models.Event) (err error) {
 		user_repo.HumanMFAOTPAddedType,
 		user_repo.HumanMFAOTPVerifiedType,
 		user_repo.HumanMFAOTPRemovedType,
+		user_repo.HumanMFAOTPSMSAddedType,
+		user_repo.HumanMFAOTPSMSRemovedType,
+		user_repo.HumanMFAOTPEmailAddedType,
+		user_repo.HumanMFAOTPEmailRemovedType,
 		user_repo.HumanU2FTokenAddedType,
 		user_repo.HumanU2FTokenVerifiedType,
 		user_repo.HumanU2FTokenRemovedType,
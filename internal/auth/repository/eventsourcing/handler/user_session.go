@@ -94,6 +94,10 @@ func (u *UserSession) Reduce(event *models.Event) (err error) {
 		user.UserIDPLoginCheckSucceededType,
 		user.HumanMFAOTPCheckSucceededType,
 		user.HumanMFAOTPCheckFailedType,
+		user.HumanMFAOTPSMSCheckSucceededType,
+		user.HumanMFAOTPSMSCheckFailedType,
+		user.HumanMFAOTPEmailCheckSucceededType,
+		user.HumanMFAOTPEmailCheckFailedType,
 		user.HumanU2FTokenCheckSucceededType,
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanPasswordlessTokenCheckSucceededType,
@@ -125,6 +129,8 @@ func (u *UserSession) Reduce(event *models.Event) (err error) {
 		user.UserDeactivatedType,
 		user.HumanPasswordChangedType,
 		user.HumanMFAOTPRemovedType,
+		user.HumanMFAOTPSMSRemovedType,
+		user.HumanMFAOTPEmailRemovedType,
 		user.HumanProfileChangedType,
 		user.HumanAvatarAddedType,
 		user.HumanAvatarRemovedType,
@@ -83,6 +83,7 @@ func Start(ctx context.Context, conf Config, systemDefaults sd.SystemDefaults, c
 			IDPProviderViewProvider:   queries,
 			IDPUserLinksProvider:      queries,
 			LockoutPolicyViewProvider: queries,
+			PasswordAgePolicyProvider: queries,
 			LoginPolicyViewProvider:   queries,
 			UserGrantProvider:         queryView,
 			ProjectProvider:           queryView,
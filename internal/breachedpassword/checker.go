@@ -0,0 +1,48 @@
+package breachedpassword
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+)
+
+// prefixLength is the amount of hex characters of the SHA-1 hash sent to the source (k-anonymity)
+const prefixLength = 5
+
+// Source returns the breached passwords of a range of SHA-1 hashes
+type Source interface {
+	// Range returns the upper case hex encoded suffixes of the SHA-1 hashes of breached passwords
+	// starting with the upper case hex encoded prefix of five characters
+	Range(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Checker checks passwords against a corpus of breached passwords,
+// only the first five characters of the SHA-1 hash of the password are passed to the source
+type Checker struct {
+	source Source
+}
+
+func NewChecker(source Source) *Checker {
+	return &Checker{source: source}
+}
+
+// IsBreached returns true if the password is part of the breached passwords of the source.
+// A nil Checker never reports a password as breached.
+func (c *Checker) IsBreached(ctx context.Context, password string) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	hash := sha1.Sum([]byte(password))
+	encoded := strings.ToUpper(hex.EncodeToString(hash[:]))
+	suffixes, err := c.source.Range(ctx, encoded[:prefixLength])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if suffix == encoded[prefixLength:] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,116 @@
+package breachedpassword
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 of "password"
+const passwordHash = "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"
+
+func writeFile(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600))
+	return path
+}
+
+func TestChecker_IsBreached_File(t *testing.T) {
+	path := writeFile(t,
+		"000000005AD76BD555C1D6D771DE417A4B87E4B4:10",
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD800000:3",
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD7:1",
+		passwordHash+":9545824",
+		"5BAA6FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:2",
+		"7C4A8D09CA3762AF61E59520943DC26494F8941B:24230577",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:1",
+	)
+	source, err := NewFileSource(path)
+	require.NoError(t, err)
+	defer source.Close()
+
+	suffixes, err := source.Range(context.Background(), "5BAA6")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD7",
+		passwordHash[prefixLength:],
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+	}, suffixes)
+
+	checker := NewChecker(source)
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{password: "password", want: true},
+		{password: "123456", want: true},
+		{password: "Tr0ub4dor&3-correct-horse", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			got, err := checker.IsBreached(context.Background(), tt.password)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFileSource_Range_bounds(t *testing.T) {
+	path := writeFile(t,
+		"00000A5AD76BD555C1D6D771DE417A4B87E4B4A1:1",
+		"FFFFF95AD76BD555C1D6D771DE417A4B87E4B4A1",
+	)
+	source, err := NewFileSource(path)
+	require.NoError(t, err)
+	defer source.Close()
+
+	first, err := source.Range(context.Background(), "00000")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A5AD76BD555C1D6D771DE417A4B87E4B4A1"}, first)
+
+	last, err := source.Range(context.Background(), "FFFFF")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"95AD76BD555C1D6D771DE417A4B87E4B4A1"}, last)
+
+	none, err := source.Range(context.Background(), "12345")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestChecker_IsBreached_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/range/5BAA6" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n")
+		fmt.Fprint(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n")
+		fmt.Fprint(w, "FFFFFF0000000000000000000000000000A:0\r\n")
+	}))
+	defer server.Close()
+
+	checker := NewChecker(NewHTTPSource(server.Client(), server.URL+"/range/", 0))
+	breached, err := checker.IsBreached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.True(t, breached)
+
+	_, err = checker.IsBreached(context.Background(), "not-in-this-range")
+	assert.Error(t, err)
+}
+
+func TestChecker_IsBreached_nil(t *testing.T) {
+	var checker *Checker
+	breached, err := checker.IsBreached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
@@ -0,0 +1,60 @@
+package breachedpassword
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type SourceType string
+
+const (
+	SourceTypeHTTP SourceType = "http"
+	SourceTypeFile SourceType = "file"
+)
+
+type Config struct {
+	// Enabled allows password complexity policies to check passwords against the corpus of breached passwords
+	Enabled bool
+	// Type of the source: "http" (range API) or "file" (local file for air-gapped installations)
+	Type SourceType
+	HTTP HTTPConfig
+	File FileConfig
+}
+
+type HTTPConfig struct {
+	// Endpoint is the url of the range API, the first five characters of the SHA-1 hash are appended
+	Endpoint string
+	// Timeout of a single request
+	Timeout time.Duration
+}
+
+type FileConfig struct {
+	// Path of the file containing one upper case hex encoded SHA-1 hash per line, optionally followed by `:COUNT`.
+	// The lines must be sorted ascending.
+	Path string
+}
+
+// NewChecker returns a Checker of the configured source,
+// nil is returned if the check is disabled
+func (c *Config) NewChecker(client *http.Client) (*Checker, error) {
+	if c == nil || !c.Enabled {
+		return nil, nil
+	}
+	switch c.Type {
+	case SourceTypeHTTP:
+		if client == nil {
+			client = http.DefaultClient
+		}
+		return NewChecker(NewHTTPSource(client, c.HTTP.Endpoint, c.HTTP.Timeout)), nil
+	case SourceTypeFile:
+		source, err := NewFileSource(c.File.Path)
+		if err != nil {
+			return nil, err
+		}
+		return NewChecker(source), nil
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "BREAC-Sj3fa", "unknown breached password source type %q", c.Type)
+	}
+}
@@ -0,0 +1,109 @@
+package breachedpassword
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ Source = (*FileSource)(nil)
+
+// FileSource searches the breached passwords in a local file (e.g. the downloaded Have I Been Pwned corpus),
+// so that no request leaves air-gapped installations.
+// The file contains one upper case hex encoded SHA-1 hash per line, optionally followed by `:COUNT`,
+// and must be sorted ascending, which allows a binary search without loading it into memory.
+type FileSource struct {
+	file *os.File
+	size int64
+}
+
+func NewFileSource(path string) (*FileSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "BREAC-Fo2la", "unable to open breached passwords file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, errors.ThrowInternal(err, "BREAC-Fs8kd", "unable to read breached passwords file")
+	}
+	return &FileSource{
+		file: file,
+		size: info.Size(),
+	}, nil
+}
+
+func (s *FileSource) Close() error {
+	return s.file.Close()
+}
+
+func (s *FileSource) Range(ctx context.Context, prefix string) ([]string, error) {
+	// search the smallest offset whose next line is not lower than the prefix
+	low, high := int64(0), s.size
+	for low < high {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		mid := low + (high-low)/2
+		_, line, err := s.lineAt(mid)
+		if err != nil {
+			return nil, err
+		}
+		if line != "" && hashOfLine(line) < prefix {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	start, _, err := s.lineAt(low)
+	if err != nil {
+		return nil, err
+	}
+
+	suffixes := make([]string, 0, 1000)
+	scanner := bufio.NewScanner(io.NewSectionReader(s.file, start, s.size-start))
+	for scanner.Scan() {
+		hash := hashOfLine(scanner.Text())
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		suffixes = append(suffixes, hash[len(prefix):])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "BREAC-Rk4md", "unable to read breached passwords file")
+	}
+	return suffixes, nil
+}
+
+// lineAt returns the first complete line starting at or after the offset and its start offset,
+// the line is empty at the end of the file
+func (s *FileSource) lineAt(offset int64) (start int64, line string, err error) {
+	start = offset
+	reader := bufio.NewReader(io.NewSectionReader(s.file, offset, s.size-offset))
+	if offset > 0 {
+		// the offset might be in the middle of a line, skip to the beginning of the next one
+		reader = bufio.NewReader(io.NewSectionReader(s.file, offset-1, s.size-offset+1))
+		skipped, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return s.size, "", nil
+		}
+		if err != nil {
+			return 0, "", errors.ThrowInternal(err, "BREAC-Lk2sd", "unable to read breached passwords file")
+		}
+		start = offset - 1 + int64(len(skipped))
+	}
+	line, err = reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return 0, "", errors.ThrowInternal(err, "BREAC-Lp9wq", "unable to read breached passwords file")
+	}
+	return start, line, nil
+}
+
+func hashOfLine(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash)
+}
@@ -0,0 +1,69 @@
+package breachedpassword
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const DefaultEndpoint = "https://api.pwnedpasswords.com/range/"
+
+var _ Source = (*HTTPSource)(nil)
+
+// HTTPSource queries a range API (e.g. Have I Been Pwned),
+// which responds with one `SUFFIX:COUNT` line per breached password
+type HTTPSource struct {
+	client   *http.Client
+	endpoint string
+	timeout  time.Duration
+}
+
+func NewHTTPSource(client *http.Client, endpoint string, timeout time.Duration) *HTTPSource {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &HTTPSource{
+		client:   client,
+		endpoint: endpoint,
+		timeout:  timeout,
+	}
+}
+
+func (s *HTTPSource) Range(ctx context.Context, prefix string) ([]string, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+prefix, nil)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "BREAC-Hs8fq", "unable to create request")
+	}
+	// padded responses hide the amount of suffixes of the range, padding entries have a count of 0
+	req.Header.Set("Add-Padding", "true")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.ThrowUnavailable(err, "BREAC-Hn2ka", "breached password source unavailable")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.ThrowUnavailablef(nil, "BREAC-Kw9ds", "breached password source responded with status %d", resp.StatusCode)
+	}
+	suffixes := make([]string, 0, 1000)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		suffix, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if suffix == "" || count == "0" {
+			continue
+		}
+		suffixes = append(suffixes, strings.ToUpper(suffix))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.ThrowUnavailable(err, "BREAC-Pq3lf", "unable to read response of breached password source")
+	}
+	return suffixes, nil
+}
@@ -1,7 +1,25 @@
 package cache
 
+import "time"
+
 type Cache interface {
 	Set(key string, object interface{}) error
 	Get(key string, ptrToObject interface{}) error
 	Delete(key string) error
 }
+
+// InstanceKey namespaces the key by the instance,
+// caches shared by all instances (e.g. redis) must not return entries of other instances
+func InstanceKey(instanceID, key string) string {
+	return instanceID + ":" + key
+}
+
+// Counter is implemented by caches which increment counters atomically,
+// so the counters of a distributed cache are correct even if they are incremented by multiple processes simultaneously
+type Counter interface {
+	// Increment increments the counter of the key and returns its value and the duration until it expires,
+	// the expiration is set by the first increment
+	Increment(key string, ttl time.Duration) (count uint64, expiresIn time.Duration, err error)
+	// Count returns the value of the counter of the key and the duration until it expires, the count is 0 if there is no counter
+	Count(key string) (count uint64, expiresIn time.Duration, err error)
+}
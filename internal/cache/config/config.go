@@ -2,10 +2,14 @@ package config
 
 import (
 	"encoding/json"
+	"reflect"
+
+	"github.com/mitchellh/mapstructure"
 
 	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/cache/bigcache"
 	"github.com/zitadel/zitadel/internal/cache/fastcache"
+	"github.com/zitadel/zitadel/internal/cache/redis"
 	"github.com/zitadel/zitadel/internal/errors"
 )
 
@@ -17,6 +21,56 @@ type CacheConfig struct {
 var caches = map[string]func() cache.Config{
 	"bigcache":  func() cache.Config { return &bigcache.Config{} },
 	"fastcache": func() cache.Config { return &fastcache.Config{} },
+	"redis":     func() cache.Config { return &redis.Config{} },
+}
+
+func (c *CacheConfig) NewCache() (cache.Cache, error) {
+	if c == nil || c.Config == nil {
+		return nil, errors.ThrowInternal(nil, "CONFI-Nc8sw", "no cache configured")
+	}
+	return c.Config.NewCache()
+}
+
+// DecodeHook decodes the Config of a CacheConfig in the runtime configuration
+// into the config of the cache Type
+func DecodeHook(from, to reflect.Value) (interface{}, error) {
+	if to.Type() != reflect.TypeOf(CacheConfig{}) {
+		return from.Interface(), nil
+	}
+
+	var rc struct {
+		Type   string
+		Config map[string]interface{}
+	}
+	if err := decode(from.Interface(), &rc); err != nil {
+		return nil, errors.ThrowInternal(err, "CONFI-Dk3sf", "unable to decode config")
+	}
+
+	t, ok := caches[rc.Type]
+	if !ok {
+		return nil, errors.ThrowInternalf(nil, "CONFI-Tp2la", "unknown cache type %q", rc.Type)
+	}
+	cacheConfig := t()
+	if err := decode(rc.Config, cacheConfig); err != nil {
+		return nil, errors.ThrowInternal(err, "CONFI-Cq9fs", "unable to decode config of cache")
+	}
+
+	return CacheConfig{
+		Type:   rc.Type,
+		Config: cacheConfig,
+	}, nil
+}
+
+func decode(input, result interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           result,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
 }
 
 func (c *CacheConfig) UnmarshalJSON(data []byte) error {
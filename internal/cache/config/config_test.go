@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/cache/bigcache"
+	"github.com/zitadel/zitadel/internal/cache/redis"
+)
+
+func TestDecodeHook(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   map[string]interface{}
+		want    *CacheConfig
+		wantErr bool
+	}{
+		{
+			name: "bigcache",
+			input: map[string]interface{}{
+				"Type": "bigcache",
+				"Config": map[string]interface{}{
+					"MaxCacheSizeInMB": 32,
+					"CacheLifetime":    "1h",
+				},
+			},
+			want: &CacheConfig{
+				Type: "bigcache",
+				Config: &bigcache.Config{
+					MaxCacheSizeInMB: 32,
+					CacheLifetime:    time.Hour,
+				},
+			},
+		},
+		{
+			name: "redis",
+			input: map[string]interface{}{
+				"type": "redis",
+				"config": map[string]interface{}{
+					"addr":   "localhost:6379",
+					"db":     "1",
+					"prefix": "zitadel",
+					"ttl":    "15m",
+				},
+			},
+			want: &CacheConfig{
+				Type: "redis",
+				Config: &redis.Config{
+					Addr:   "localhost:6379",
+					DB:     1,
+					Prefix: "zitadel",
+					TTL:    15 * time.Minute,
+				},
+			},
+		},
+		{
+			name: "unknown type",
+			input: map[string]interface{}{
+				"Type": "memcached",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := new(CacheConfig)
+			decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+				DecodeHook: DecodeHook,
+				Result:     got,
+			})
+			require.NoError(t, err)
+			err = decoder.Decode(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
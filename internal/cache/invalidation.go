@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type invalidation struct {
+	cache  Cache
+	keys   func(eventstore.Event) []string
+	events chan eventstore.Event
+}
+
+// InvalidateOnEvents deletes the keys returned by keys from the cache,
+// whenever this process pushes one of the event types (all events of an aggregate type if no event types are provided).
+// Every event is pushed by exactly one process, therefore entries of distributed caches (e.g. redis) are invalidated for all processes,
+// whereas in-process caches (e.g. bigcache) of other processes keep their entries until they expire.
+// The invalidation lasts as long as the process.
+func InvalidateOnEvents(c Cache, keys func(eventstore.Event) []string, types map[eventstore.AggregateType][]eventstore.EventType) {
+	i := &invalidation{
+		cache:  c,
+		keys:   keys,
+		events: make(chan eventstore.Event, 100),
+	}
+	eventstore.SubscribeEventTypes(i.events, types)
+	go i.invalidate()
+}
+
+func (i *invalidation) invalidate() {
+	for event := range i.events {
+		for _, key := range i.keys(event) {
+			err := i.cache.Delete(key)
+			logging.WithFields("key", key, "type", event.Type()).OnError(err).Warn("unable to invalidate cache entry")
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+type deletedKeys struct {
+	Cache
+	keys chan string
+}
+
+func (c *deletedKeys) Delete(key string) error {
+	c.keys <- key
+	return nil
+}
+
+func testEvent(aggregateType eventstore.AggregateType, eventType eventstore.EventType) eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   "agg-id",
+		AggregateType: repository.AggregateType(aggregateType),
+		InstanceID:    "instance-id",
+		Type:          repository.EventType(eventType),
+	})
+}
+
+func Test_invalidation(t *testing.T) {
+	cache := &deletedKeys{keys: make(chan string, 10)}
+	i := &invalidation{
+		cache: cache,
+		keys: func(event eventstore.Event) []string {
+			if event.Type() == "user.unlocked" {
+				return nil
+			}
+			return []string{InstanceKey(event.Aggregate().InstanceID, event.Aggregate().ID)}
+		},
+		events: make(chan eventstore.Event, 10),
+	}
+	i.events <- testEvent("user", "user.locked")
+	i.events <- testEvent("user", "user.unlocked")
+	i.events <- testEvent("org", "org.added")
+	close(i.events)
+	i.invalidate()
+	close(cache.keys)
+
+	deleted := make([]string, 0, 2)
+	for key := range cache.keys {
+		deleted = append(deleted, key)
+	}
+	assert.Equal(t, []string{"instance-id:agg-id", "instance-id:agg-id"}, deleted)
+}
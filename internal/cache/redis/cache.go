@@ -0,0 +1,183 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/gob"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ cache.Counter = (*Redis)(nil)
+
+// incrementScript increments the counter and sets its expiration on the first increment,
+// it returns the value and the remaining time to live in milliseconds
+var incrementScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+// countScript returns the value of the counter and the remaining time to live in milliseconds
+var countScript = redis.NewScript(`
+local count = redis.call('GET', KEYS[1])
+if not count then
+	return {0, 0}
+end
+return {tonumber(count), redis.call('PTTL', KEYS[1])}
+`)
+
+// Redis is a cache shared by all ZITADEL processes connected to the same server (or any other server implementing the Redis protocol),
+// therefore keys must be unique over all instances (see cache.InstanceKey)
+type Redis struct {
+	client  *redis.Client
+	prefix  string
+	ttl     time.Duration
+	timeout time.Duration
+}
+
+func NewRedis(c *Config) (*Redis, error) {
+	options := &redis.Options{
+		Addr:         c.Addr,
+		Username:     c.Username,
+		Password:     c.Password,
+		DB:           c.DB,
+		DialTimeout:  c.Timeout,
+		ReadTimeout:  c.Timeout,
+		WriteTimeout: c.Timeout,
+	}
+	if c.TLS {
+		host, _, err := net.SplitHostPort(c.Addr)
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "REDIS-Ad3sf", "invalid address")
+		}
+		options.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: host,
+		}
+	}
+	cache := newRedis(redis.NewClient(options), c.Prefix, c.TTL, c.Timeout)
+	ctx, cancel := cache.context()
+	defer cancel()
+	if err := cache.client.Ping(ctx).Err(); err != nil {
+		return nil, errors.ThrowUnavailable(err, "REDIS-Kd9fw", "unable to connect to cache")
+	}
+	return cache, nil
+}
+
+func newRedis(client *redis.Client, prefix string, ttl, timeout time.Duration) *Redis {
+	return &Redis{
+		client:  client,
+		prefix:  prefix,
+		ttl:     ttl,
+		timeout: timeout,
+	}
+}
+
+func (c *Redis) Set(key string, object interface{}) error {
+	if key == "" || reflect.ValueOf(object).IsNil() {
+		return errors.ThrowInvalidArgument(nil, "REDIS-Sk2od", "key or value should not be empty")
+	}
+	var b bytes.Buffer
+	enc := gob.NewEncoder(&b)
+	if err := enc.Encode(object); err != nil {
+		return errors.ThrowInvalidArgument(err, "REDIS-Ty5gb", "unable to encode object")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	if err := c.client.Set(ctx, c.key(key), b.Bytes(), c.ttl).Err(); err != nil {
+		return errors.ThrowUnavailable(err, "REDIS-Hs7qe", "unable to write to cache")
+	}
+	return nil
+}
+
+func (c *Redis) Get(key string, ptrToObject interface{}) error {
+	if key == "" || reflect.ValueOf(ptrToObject).IsNil() {
+		return errors.ThrowInvalidArgument(nil, "REDIS-Gk4ad", "key or value should not be empty")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	value, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err == redis.Nil {
+		return errors.ThrowNotFound(err, "REDIS-Nf8sd", "not in cache")
+	}
+	if err != nil {
+		return errors.ThrowUnavailable(err, "REDIS-Lm3vx", "unable to read from cache")
+	}
+	dec := gob.NewDecoder(bytes.NewBuffer(value))
+	return dec.Decode(ptrToObject)
+}
+
+func (c *Redis) Delete(key string) error {
+	if key == "" {
+		return errors.ThrowInvalidArgument(nil, "REDIS-Dk2el", "key should not be empty")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	if err := c.client.Del(ctx, c.key(key)).Err(); err != nil {
+		return errors.ThrowUnavailable(err, "REDIS-Qz8rk", "unable to delete from cache")
+	}
+	return nil
+}
+
+func (c *Redis) Increment(key string, ttl time.Duration) (uint64, time.Duration, error) {
+	if key == "" || ttl <= 0 {
+		return 0, 0, errors.ThrowInvalidArgument(nil, "REDIS-Ic3vd", "key and ttl should not be empty")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	result, err := incrementScript.Run(ctx, c.client, []string{c.key(key)}, ttl.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, errors.ThrowUnavailable(err, "REDIS-Wq7xn", "unable to increment counter")
+	}
+	return counterResult(result)
+}
+
+func (c *Redis) Count(key string) (uint64, time.Duration, error) {
+	if key == "" {
+		return 0, 0, errors.ThrowInvalidArgument(nil, "REDIS-Rk4mb", "key should not be empty")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	result, err := countScript.Run(ctx, c.client, []string{c.key(key)}).Int64Slice()
+	if err != nil {
+		return 0, 0, errors.ThrowUnavailable(err, "REDIS-Ph6ts", "unable to read counter")
+	}
+	return counterResult(result)
+}
+
+// counterResult maps the result of the scripts,
+// a negative time to live (no expiration or missing key) is returned as 0
+func counterResult(result []int64) (uint64, time.Duration, error) {
+	if len(result) != 2 || result[0] < 0 {
+		return 0, 0, errors.ThrowInternal(nil, "REDIS-Mv2ol", "unexpected counter result")
+	}
+	var expiresIn time.Duration
+	if result[1] > 0 {
+		expiresIn = time.Duration(result[1]) * time.Millisecond
+	}
+	return uint64(result[0]), expiresIn, nil
+}
+
+func (c *Redis) key(key string) string {
+	if c.prefix == "" {
+		return key
+	}
+	return c.prefix + ":" + key
+}
+
+func (c *Redis) context() (context.Context, context.CancelFunc) {
+	if c.timeout > 0 {
+		return context.WithTimeout(context.Background(), c.timeout)
+	}
+	return context.WithCancel(context.Background())
+}
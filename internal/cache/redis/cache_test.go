@@ -0,0 +1,266 @@
+package redis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type TestStruct struct {
+	Test string
+}
+
+func getRedisMock(t *testing.T, prefix string, ttl time.Duration) (*Redis, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	return newRedis(redis.NewClient(&redis.Options{Addr: server.Addr()}), prefix, ttl, time.Second), server
+}
+
+func TestSet(t *testing.T) {
+	type args struct {
+		key   string
+		value *TestStruct
+	}
+	type res struct {
+		result  *TestStruct
+		errFunc func(err error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "set cache no err",
+			args: args{
+				key:   "KEY",
+				value: &TestStruct{Test: "Test"},
+			},
+			res: res{
+				result: &TestStruct{Test: "Test"},
+			},
+		},
+		{
+			name: "key empty",
+			args: args{
+				key:   "",
+				value: &TestStruct{Test: "Test"},
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "set cache nil value",
+			args: args{
+				key: "KEY",
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, _ := getRedisMock(t, "", 0)
+			err := cache.Set(tt.args.key, tt.args.value)
+			if tt.res.errFunc == nil && err != nil {
+				t.Errorf("got wrong result should not get err: %v ", err)
+			}
+			if tt.res.errFunc == nil {
+				result := new(TestStruct)
+				if err = cache.Get(tt.args.key, result); err != nil {
+					t.Errorf("got wrong result should not get err: %v ", err)
+				}
+				if !reflect.DeepEqual(result, tt.res.result) {
+					t.Errorf("got wrong result expected: %v actual: %v", tt.res.result, result)
+				}
+			}
+			if tt.res.errFunc != nil && !tt.res.errFunc(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	type args struct {
+		key      string
+		getValue *TestStruct
+	}
+	type res struct {
+		result  *TestStruct
+		errFunc func(err error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "get cache no err",
+			args: args{
+				key:      "KEY",
+				getValue: &TestStruct{},
+			},
+			res: res{
+				result: &TestStruct{Test: "Test"},
+			},
+		},
+		{
+			name: "get cache not found",
+			args: args{
+				key:      "OTHER",
+				getValue: &TestStruct{},
+			},
+			res: res{
+				errFunc: errors.IsNotFound,
+			},
+		},
+		{
+			name: "get cache no key",
+			args: args{
+				getValue: &TestStruct{},
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "get cache no value",
+			args: args{
+				key: "KEY",
+			},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, _ := getRedisMock(t, "", 0)
+			if err := cache.Set("KEY", &TestStruct{Test: "Test"}); err != nil {
+				t.Errorf("something went wrong")
+			}
+			err := cache.Get(tt.args.key, tt.args.getValue)
+			if tt.res.errFunc == nil && err != nil {
+				t.Errorf("got wrong result should not get err: %v ", err)
+			}
+			if tt.res.errFunc == nil && !reflect.DeepEqual(tt.args.getValue, tt.res.result) {
+				t.Errorf("got wrong result expected: %v actual: %v", tt.res.result, tt.args.getValue)
+			}
+			if tt.res.errFunc != nil && !tt.res.errFunc(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		key string
+	}
+	type res struct {
+		errFunc func(err error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "delete cache no err",
+			args: args{
+				key: "KEY",
+			},
+			res: res{},
+		},
+		{
+			name: "delete not existing no err",
+			args: args{
+				key: "OTHER",
+			},
+			res: res{},
+		},
+		{
+			name: "delete cache no key",
+			args: args{},
+			res: res{
+				errFunc: errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, _ := getRedisMock(t, "", 0)
+			if err := cache.Set("KEY", &TestStruct{Test: "Test"}); err != nil {
+				t.Errorf("something went wrong")
+			}
+			err := cache.Delete(tt.args.key)
+			if tt.res.errFunc == nil && err != nil {
+				t.Errorf("got wrong result should not get err: %v ", err)
+			}
+			if tt.res.errFunc != nil && !tt.res.errFunc(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.errFunc == nil {
+				if err = cache.Get(tt.args.key, new(TestStruct)); !errors.IsNotFound(err) {
+					t.Errorf("entry should be deleted: %v ", err)
+				}
+			}
+		})
+	}
+}
+
+func TestPrefixAndTTL(t *testing.T) {
+	cache, server := getRedisMock(t, "zitadel", time.Minute)
+	if err := cache.Set("instance:KEY", &TestStruct{Test: "Test"}); err != nil {
+		t.Fatalf("something went wrong: %v", err)
+	}
+	if !server.Exists("zitadel:instance:KEY") {
+		t.Errorf("key should be prefixed, keys: %v", server.Keys())
+	}
+	if ttl := server.TTL("zitadel:instance:KEY"); ttl != time.Minute {
+		t.Errorf("got wrong ttl expected: %v actual: %v", time.Minute, ttl)
+	}
+	server.FastForward(time.Minute)
+	if err := cache.Get("instance:KEY", new(TestStruct)); !errors.IsNotFound(err) {
+		t.Errorf("entry should be expired: %v", err)
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	cache, server := getRedisMock(t, "", 0)
+	server.Close()
+	if err := cache.Set("KEY", &TestStruct{Test: "Test"}); !errors.IsUnavailable(err) {
+		t.Errorf("got wrong err: %v", err)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	cache, server := getRedisMock(t, "zitadel", 0)
+	if count, expiresIn, err := cache.Count("instance:KEY"); err != nil || count != 0 || expiresIn != 0 {
+		t.Errorf("missing counter should be 0, count: %d, expires in: %v, err: %v", count, expiresIn, err)
+	}
+	for i := uint64(1); i <= 3; i++ {
+		count, expiresIn, err := cache.Increment("instance:KEY", time.Minute)
+		if wantExpiresIn := time.Minute - time.Duration(i-1)*time.Second; err != nil || count != i || expiresIn != wantExpiresIn {
+			t.Errorf("got wrong counter expected: %d, count: %d, expires in: %v, err: %v", i, count, expiresIn, err)
+		}
+		server.FastForward(time.Second)
+	}
+	if ttl := server.TTL("zitadel:instance:KEY"); ttl != time.Minute-3*time.Second {
+		t.Errorf("expiration should only be set by the first increment: %v", ttl)
+	}
+	if count, _, err := cache.Count("instance:KEY"); err != nil || count != 3 {
+		t.Errorf("got wrong count expected: 3, actual: %d, err: %v", count, err)
+	}
+	server.FastForward(time.Minute)
+	if count, _, err := cache.Count("instance:KEY"); err != nil || count != 0 {
+		t.Errorf("counter should be expired, count: %d, err: %v", count, err)
+	}
+}
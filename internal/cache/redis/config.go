@@ -0,0 +1,28 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+type Config struct {
+	// Addr is the address (host:port) of the server
+	Addr     string
+	Username string
+	Password string
+	// DB is the database selected after connecting
+	DB int
+	// TLS enables TLS for the connections to the server
+	TLS bool
+	// Prefix is prepended to all keys, so that multiple deployments can share a server
+	Prefix string
+	// TTL if set, entries older than the TTL are removed by the server
+	TTL time.Duration
+	// Timeout of connecting and of every command, if set
+	Timeout time.Duration
+}
+
+func (c *Config) NewCache() (cache.Cache, error) {
+	return NewRedis(c)
+}
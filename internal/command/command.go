@@ -7,6 +7,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	api_http "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/breachedpassword"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -15,6 +16,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
 	instance_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 	"github.com/zitadel/zitadel/internal/repository/org"
@@ -22,6 +24,7 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/quota"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	usr_grant_repo "github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
 	"github.com/zitadel/zitadel/internal/static"
 	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
 )
@@ -41,7 +44,11 @@ type Commands struct {
 	smtpEncryption              crypto.EncryptionAlgorithm
 	smsEncryption               crypto.EncryptionAlgorithm
 	userEncryption              crypto.EncryptionAlgorithm
+	webhookSigningKeyGenerator  crypto.Generator
+	actionSecretEncryption      crypto.EncryptionAlgorithm
+	actionStorageLimits         sd.ActionStorage
 	userPasswordAlg             crypto.HashAlgorithm
+	breachedPasswords           *breachedpassword.Checker
 	machineKeySize              int
 	applicationKeySize          int
 	domainVerificationAlg       crypto.EncryptionAlgorithm
@@ -57,6 +64,8 @@ type Commands struct {
 	privateKeyLifetime   time.Duration
 	publicKeyLifetime    time.Duration
 	certificateLifetime  time.Duration
+
+	samlCertificateAndKeyGenerator func(id string) ([]byte, []byte, error)
 }
 
 func StartCommands(es *eventstore.Eventstore,
@@ -74,34 +83,38 @@ func StartCommands(es *eventstore.Eventstore,
 	userEncryption,
 	domainVerificationEncryption,
 	oidcEncryption,
-	samlEncryption crypto.EncryptionAlgorithm,
+	samlEncryption,
+	webhookEncryption,
+	actionEncryption crypto.EncryptionAlgorithm,
 	httpClient *http.Client,
 ) (repo *Commands, err error) {
 	if externalDomain == "" {
 		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Df21s", "no external domain specified")
 	}
 	repo = &Commands{
-		eventstore:            es,
-		static:                staticStore,
-		idGenerator:           id.SonyFlakeGenerator(),
-		zitadelRoles:          zitadelRoles,
-		externalDomain:        externalDomain,
-		externalSecure:        externalSecure,
-		externalPort:          externalPort,
-		keySize:               defaults.KeyConfig.Size,
-		certKeySize:           defaults.KeyConfig.CertificateSize,
-		privateKeyLifetime:    defaults.KeyConfig.PrivateKeyLifetime,
-		publicKeyLifetime:     defaults.KeyConfig.PublicKeyLifetime,
-		certificateLifetime:   defaults.KeyConfig.CertificateLifetime,
-		idpConfigEncryption:   idpConfigEncryption,
-		smtpEncryption:        smtpEncryption,
-		smsEncryption:         smsEncryption,
-		userEncryption:        userEncryption,
-		domainVerificationAlg: domainVerificationEncryption,
-		keyAlgorithm:          oidcEncryption,
-		certificateAlgorithm:  samlEncryption,
-		webauthnConfig:        webAuthN,
-		httpClient:            httpClient,
+		eventstore:             es,
+		static:                 staticStore,
+		idGenerator:            id.SonyFlakeGenerator(),
+		zitadelRoles:           zitadelRoles,
+		externalDomain:         externalDomain,
+		externalSecure:         externalSecure,
+		externalPort:           externalPort,
+		keySize:                defaults.KeyConfig.Size,
+		certKeySize:            defaults.KeyConfig.CertificateSize,
+		privateKeyLifetime:     defaults.KeyConfig.PrivateKeyLifetime,
+		publicKeyLifetime:      defaults.KeyConfig.PublicKeyLifetime,
+		certificateLifetime:    defaults.KeyConfig.CertificateLifetime,
+		idpConfigEncryption:    idpConfigEncryption,
+		smtpEncryption:         smtpEncryption,
+		smsEncryption:          smsEncryption,
+		userEncryption:         userEncryption,
+		actionSecretEncryption: actionEncryption,
+		actionStorageLimits:    defaults.ActionStorage,
+		domainVerificationAlg:  domainVerificationEncryption,
+		keyAlgorithm:           oidcEncryption,
+		certificateAlgorithm:   samlEncryption,
+		webauthnConfig:         webAuthN,
+		httpClient:             httpClient,
 	}
 
 	instance_repo.RegisterEventMappers(repo.eventstore)
@@ -112,8 +125,17 @@ func StartCommands(es *eventstore.Eventstore,
 	keypair.RegisterEventMappers(repo.eventstore)
 	action.RegisterEventMappers(repo.eventstore)
 	quota.RegisterEventMappers(repo.eventstore)
+	webhook.RegisterEventMappers(repo.eventstore)
+	deviceauth.RegisterEventMappers(repo.eventstore)
 
-	repo.userPasswordAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
+	repo.userPasswordAlg, err = crypto.NewPasswordHasher(&defaults.PasswordHasher)
+	if err != nil {
+		return nil, err
+	}
+	repo.breachedPasswords, err = defaults.BreachedPasswords.NewChecker(httpClient)
+	if err != nil {
+		return nil, err
+	}
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
 	repo.applicationKeySize = int(defaults.SecretGenerators.ApplicationKeySize)
 
@@ -126,6 +148,8 @@ func StartCommands(es *eventstore.Eventstore,
 
 	repo.domainVerificationGenerator = crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, repo.domainVerificationAlg)
 	repo.domainVerificationValidator = api_http.ValidateDomain
+	repo.webhookSigningKeyGenerator = crypto.NewEncryptionGenerator(defaults.Webhooks.SigningKeyGenerator, webhookEncryption)
+	repo.samlCertificateAndKeyGenerator = samlCertificateAndKeyGenerator(defaults.KeyConfig.CertificateSize, defaults.KeyConfig.CertificateLifetime)
 	return repo, nil
 }
 
@@ -11,7 +11,13 @@ import (
 )
 
 func newCryptoCodeWithExpiry(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, alg crypto.Crypto) (value *crypto.CryptoValue, expiry time.Duration, err error) {
-	config, err := secretGeneratorConfig(ctx, filter, typ)
+	return newCryptoCodeWithExpiryAndDefault(ctx, filter, typ, alg, nil)
+}
+
+// newCryptoCodeWithExpiryAndDefault uses the defaultConfig (if not nil) when the secret generator of the given type
+// was never configured on the instance (e.g. for types added after the instance was set up)
+func newCryptoCodeWithExpiryAndDefault(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, alg crypto.Crypto, defaultConfig *crypto.GeneratorConfig) (value *crypto.CryptoValue, expiry time.Duration, err error) {
+	config, err := secretGeneratorConfigWithDefault(ctx, filter, typ, defaultConfig)
 	if err != nil {
 		return nil, -1, err
 	}
@@ -47,6 +53,10 @@ func newCryptoCodeWithPlain(ctx context.Context, filter preparation.FilterToQuer
 }
 
 func secretGeneratorConfig(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType) (*crypto.GeneratorConfig, error) {
+	return secretGeneratorConfigWithDefault(ctx, filter, typ, nil)
+}
+
+func secretGeneratorConfigWithDefault(ctx context.Context, filter preparation.FilterToQueryReducer, typ domain.SecretGeneratorType, defaultConfig *crypto.GeneratorConfig) (*crypto.GeneratorConfig, error) {
 	wm := NewInstanceSecretGeneratorConfigWriteModel(ctx, typ)
 	events, err := filter(ctx, wm.Query())
 	if err != nil {
@@ -56,6 +66,9 @@ func secretGeneratorConfig(ctx context.Context, filter preparation.FilterToQuery
 	if err := wm.Reduce(); err != nil {
 		return nil, err
 	}
+	if wm.State == domain.SecretGeneratorStateUnspecified && defaultConfig != nil {
+		return defaultConfig, nil
+	}
 	return &crypto.GeneratorConfig{
 		Length:              wm.Length,
 		Expiry:              wm.Expiry,
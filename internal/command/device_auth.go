@@ -0,0 +1,155 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+)
+
+// AddDeviceAuth starts a device authorization (RFC 8628) for the client,
+// which can be approved by a user entering the user code until it expires.
+// Only the hash of the device code is stored.
+func (c *Commands) AddDeviceAuth(ctx context.Context, clientID, deviceCode, userCode string, expires time.Time, scopes []string) (_ string, _ *domain.ObjectDetails, err error) {
+	if clientID == "" || deviceCode == "" || userCode == "" || expires.IsZero() {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ak2ld", "Errors.DeviceAuth.Invalid")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+
+	model := NewDeviceAuthWriteModel(id, authz.GetInstance(ctx).InstanceID())
+	agg := DeviceAuthAggregateFromWriteModel(&model.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, deviceauth.NewAddedEvent(
+		ctx,
+		agg,
+		clientID,
+		domain.DeviceCodeHash(deviceCode),
+		userCode,
+		expires,
+		scopes,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return model.AggregateID, writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// ApproveDeviceAuth approves the device authorization for the authenticated user,
+// so the device is able to retrieve its tokens
+func (c *Commands) ApproveDeviceAuth(ctx context.Context, id, userID, userOrgID, userAgentID string, audience, authMethodsReferences []string, authTime time.Time) (*domain.ObjectDetails, error) {
+	if id == "" || userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Bv2kf", "Errors.IDMissing")
+	}
+	model, err := c.initiatedDeviceAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	agg := DeviceAuthAggregateFromWriteModel(&model.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, deviceauth.NewApprovedEvent(
+		ctx,
+		agg,
+		model.UserCode,
+		userID,
+		userOrgID,
+		userAgentID,
+		audience,
+		authMethodsReferences,
+		authTime,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// DenyDeviceAuth denies the device authorization, the device will not receive any tokens
+func (c *Commands) DenyDeviceAuth(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Lp2dk", "Errors.IDMissing")
+	}
+	model, err := c.initiatedDeviceAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	agg := DeviceAuthAggregateFromWriteModel(&model.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, deviceauth.NewDeniedEvent(ctx, agg, model.UserCode))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// RemoveDeviceAuth removes the approved device authorization before the tokens are issued.
+// As the device code must only be used once, the removal fails
+// if the device authorization was already removed (e.g. by a concurrent request) after it was read.
+func (c *Commands) RemoveDeviceAuth(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ox8sl", "Errors.IDMissing")
+	}
+	model, err := c.getDeviceAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !model.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Qm3sf", "Errors.DeviceAuth.NotFound")
+	}
+	if model.State != domain.DeviceAuthStateApproved {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Wd0sl", "Errors.DeviceAuth.NotApproved")
+	}
+	if model.Expired() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hq7sd", "Errors.DeviceAuth.Expired")
+	}
+	agg := DeviceAuthAggregateFromWriteModel(&model.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, deviceauth.NewRemovedEvent(ctx, agg, model.userCodeInUse()))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+func (c *Commands) initiatedDeviceAuthWriteModelByID(ctx context.Context, id string) (*DeviceAuthWriteModel, error) {
+	model, err := c.getDeviceAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !model.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Jd82k", "Errors.DeviceAuth.NotFound")
+	}
+	if model.State != domain.DeviceAuthStateInitiated {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ue8sd", "Errors.DeviceAuth.AlreadyHandled")
+	}
+	if model.Expired() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Zs0vf", "Errors.DeviceAuth.Expired")
+	}
+	return model, nil
+}
+
+func (c *Commands) getDeviceAuthWriteModelByID(ctx context.Context, id string) (*DeviceAuthWriteModel, error) {
+	model := NewDeviceAuthWriteModel(id, authz.GetInstance(ctx).InstanceID())
+	err := c.eventstore.FilterToQueryReducer(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+)
+
+type DeviceAuthWriteModel struct {
+	eventstore.WriteModel
+
+	ClientID string
+	UserCode string
+	Expires  time.Time
+	Scopes   []string
+	UserID   string
+	State    domain.DeviceAuthState
+}
+
+func NewDeviceAuthWriteModel(id string, resourceOwner string) *DeviceAuthWriteModel {
+	return &DeviceAuthWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *DeviceAuthWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *deviceauth.AddedEvent:
+			wm.ClientID = e.ClientID
+			wm.UserCode = e.UserCode
+			wm.Expires = e.Expires
+			wm.Scopes = e.Scopes
+			wm.State = domain.DeviceAuthStateInitiated
+		case *deviceauth.ApprovedEvent:
+			wm.UserID = e.UserID
+			wm.State = domain.DeviceAuthStateApproved
+		case *deviceauth.DeniedEvent:
+			wm.State = domain.DeviceAuthStateDenied
+		case *deviceauth.RemovedEvent:
+			wm.State = domain.DeviceAuthStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *DeviceAuthWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(deviceauth.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(deviceauth.AddedEventType,
+			deviceauth.ApprovedEventType,
+			deviceauth.DeniedEventType,
+			deviceauth.RemovedEventType).
+		Builder()
+}
+
+// Expired returns if the user code and device code can no longer be used
+func (wm *DeviceAuthWriteModel) Expired() bool {
+	return wm.Expires.Before(time.Now())
+}
+
+// userCodeInUse returns the user code of the device authorization
+// as long as it is not released by the approval or denial
+func (wm *DeviceAuthWriteModel) userCodeInUse() string {
+	if wm.State != domain.DeviceAuthStateInitiated {
+		return ""
+	}
+	return wm.UserCode
+}
+
+func DeviceAuthAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, deviceauth.AggregateType, deviceauth.AggregateVersion)
+}
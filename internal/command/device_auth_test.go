@@ -0,0 +1,642 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+)
+
+func TestCommands_AddDeviceAuth(t *testing.T) {
+	expires := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx        context.Context
+		clientID   string
+		deviceCode string
+		userCode   string
+		expires    time.Time
+		scopes     []string
+	}
+	type res struct {
+		id      string
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"user code missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+				clientID:   "client1",
+				deviceCode: "device-code",
+				expires:    expires,
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"user code already used, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPushFailed(
+						errors.ThrowAlreadyExists(nil, "id", "user code already exists"),
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								deviceauth.NewAddedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"client1",
+									domain.DeviceCodeHash("device-code"),
+									"ABCD-EFGH",
+									expires,
+									[]string{"openid"},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddUserCodeUniqueConstraint("ABCD-EFGH")),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+				clientID:   "client1",
+				deviceCode: "device-code",
+				userCode:   "ABCD-EFGH",
+				expires:    expires,
+				scopes:     []string{"openid"},
+			},
+			res{
+				err: errors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								deviceauth.NewAddedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"client1",
+									domain.DeviceCodeHash("device-code"),
+									"ABCD-EFGH",
+									expires,
+									[]string{"openid"},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddUserCodeUniqueConstraint("ABCD-EFGH")),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx:        authz.WithInstanceID(context.Background(), "instance1"),
+				clientID:   "client1",
+				deviceCode: "device-code",
+				userCode:   "ABCD-EFGH",
+				expires:    expires,
+				scopes:     []string{"openid"},
+			},
+			res{
+				id: "id1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			id, details, err := c.AddDeviceAuth(tt.args.ctx, tt.args.clientID, tt.args.deviceCode, tt.args.userCode, tt.args.expires, tt.args.scopes)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ApproveDeviceAuth(t *testing.T) {
+	authTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		id     string
+		userID string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"user id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				userID: "user1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"expired, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device-code",
+								"ABCD-EFGH",
+								time.Now().Add(-time.Minute),
+								[]string{"openid"},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				userID: "user1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"already denied, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device-code",
+								"ABCD-EFGH",
+								time.Now().Add(time.Minute),
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewDeniedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"ABCD-EFGH",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				userID: "user1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device-code",
+								"ABCD-EFGH",
+								time.Now().Add(time.Minute),
+								[]string{"openid"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								deviceauth.NewApprovedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"ABCD-EFGH",
+									"user1",
+									"org1",
+									"agent1",
+									[]string{"client1", "project1"},
+									[]string{"pwd"},
+									authTime,
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewRemoveUserCodeUniqueConstraint("ABCD-EFGH")),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddDecisionUniqueConstraint("id1")),
+					),
+				),
+			},
+			args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				userID: "user1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ApproveDeviceAuth(tt.args.ctx, tt.args.id, tt.args.userID, "org1", "agent1", []string{"client1", "project1"}, []string{"pwd"}, authTime)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_DenyDeviceAuth(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device-code",
+								"ABCD-EFGH",
+								time.Now().Add(time.Minute),
+								[]string{"openid"},
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								deviceauth.NewDeniedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"ABCD-EFGH",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewRemoveUserCodeUniqueConstraint("ABCD-EFGH")),
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddDecisionUniqueConstraint("id1")),
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.DenyDeviceAuth(tt.args.ctx, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveDeviceAuth(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"initiated, precondition error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device-code",
+								"ABCD-EFGH",
+								time.Now().Add(time.Minute),
+								[]string{"openid"},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"approved but expired, precondition error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device-code",
+								"ABCD-EFGH",
+								time.Now().Add(-time.Minute),
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewApprovedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"ABCD-EFGH",
+								"user1",
+								"org1",
+								"agent1",
+								nil,
+								nil,
+								time.Now(),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"approved, concurrent removal, already exists error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device-code",
+								"ABCD-EFGH",
+								time.Now().Add(time.Minute),
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewApprovedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"ABCD-EFGH",
+								"user1",
+								"org1",
+								"agent1",
+								nil,
+								nil,
+								time.Now(),
+							),
+						),
+					),
+					expectPushFailed(
+						errors.ThrowAlreadyExists(nil, "id", "removal exists"),
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								deviceauth.NewRemovedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddRemovalUniqueConstraint("id1")),
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res{
+				err: errors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"approved, push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							deviceauth.NewAddedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"client1",
+								"device-code",
+								"ABCD-EFGH",
+								time.Now().Add(time.Minute),
+								[]string{"openid"},
+							),
+						),
+						eventFromEventPusher(
+							deviceauth.NewApprovedEvent(context.Background(),
+								&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+								"ABCD-EFGH",
+								"user1",
+								"org1",
+								"agent1",
+								nil,
+								nil,
+								time.Now(),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								deviceauth.NewRemovedEvent(context.Background(),
+									&deviceauth.NewAggregate("id1", "instance1").Aggregate,
+									"",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("instance1", deviceauth.NewAddRemovalUniqueConstraint("id1")),
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveDeviceAuth(tt.args.ctx, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
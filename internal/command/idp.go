@@ -2,9 +2,17 @@ package command
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"time"
+
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 )
 
@@ -107,6 +115,56 @@ type LDAPProvider struct {
 	IDPOptions          idp.Options
 }
 
+type SAMLProvider struct {
+	Name              string
+	Metadata          []byte
+	Binding           string
+	WithSignedRequest bool
+	IDPOptions        idp.Options
+}
+
+type AppleProvider struct {
+	Name       string
+	ClientID   string
+	TeamID     string
+	KeyID      string
+	PrivateKey []byte
+	Scopes     []string
+	IDPOptions idp.Options
+}
+
+// samlCertificateAndKeyGenerator returns a generator for the key and (self-signed) certificate
+// ZITADEL uses as service provider to sign the requests to the SAML identity provider
+func samlCertificateAndKeyGenerator(keySize int, lifetime time.Duration) func(id string) ([]byte, []byte, error) {
+	return func(id string) ([]byte, []byte, error) {
+		now := time.Now().UTC()
+		serial, err := rand.Int(rand.Reader, big.NewInt(1000))
+		if err != nil {
+			return nil, nil, err
+		}
+		privateKey, _, certificate, err := crypto.GenerateCACertificate(keySize, &crypto.CertificateInformations{
+			SerialNumber: serial,
+			Organisation: []string{"ZITADEL"},
+			CommonName:   id,
+			NotBefore:    now,
+			NotAfter:     now.Add(lifetime),
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return crypto.PrivateKeyToBytes(privateKey), certificate, nil
+	}
+}
+
+func validateSAMLMetadata(metadata []byte) error {
+	if len(metadata) == 0 {
+		return errors.New("metadata missing")
+	}
+	_, err := saml_xml.ParseMetadataXmlIntoStruct(metadata)
+	return err
+}
+
 func ExistsIDP(ctx context.Context, filter preparation.FilterToQueryReducer, id, orgID string) (exists bool, err error) {
 	writeModel := NewOrgIDPRemoveWriteModel(orgID, id)
 	events, err := filter(ctx, writeModel.Query())
@@ -48,17 +48,21 @@ type InstanceSetup struct {
 		PasswordVerificationCode *crypto.GeneratorConfig
 		PasswordlessInitCode     *crypto.GeneratorConfig
 		DomainVerification       *crypto.GeneratorConfig
+		OTPSMS                   *crypto.GeneratorConfig
+		OTPEmail                 *crypto.GeneratorConfig
 	}
 	PasswordComplexityPolicy struct {
-		MinLength    uint64
-		HasLowercase bool
-		HasUppercase bool
-		HasNumber    bool
-		HasSymbol    bool
+		MinLength     uint64
+		HasLowercase  bool
+		HasUppercase  bool
+		HasNumber     bool
+		HasSymbol     bool
+		CheckBreached bool
 	}
 	PasswordAgePolicy struct {
 		ExpireWarnDays uint64
 		MaxAgeDays     uint64
+		HistoryCount   uint64
 	}
 	DomainPolicy struct {
 		UserLoginMustBeDomain                  bool
@@ -106,7 +110,9 @@ type InstanceSetup struct {
 	}
 	LockoutPolicy struct {
 		MaxAttempts              uint64
+		MaxOTPAttempts           uint64
 		ShouldShowLockoutFailure bool
+		LockoutDuration          time.Duration
 	}
 	EmailTemplate     []byte
 	MessageTexts      []*domain.CustomMessageText
@@ -116,6 +122,9 @@ type InstanceSetup struct {
 		IdTokenLifetime            time.Duration
 		RefreshTokenIdleExpiration time.Duration
 		RefreshTokenExpiration     time.Duration
+		SigningKeyAlgorithm        string
+		SigningKeyLifetime         time.Duration
+		SigningKeyOverlap          time.Duration
 	}
 	Quotas *struct {
 		Items []*AddQuota
@@ -199,6 +208,8 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypePasswordResetCode, setup.SecretGenerators.PasswordVerificationCode),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypePasswordlessInitCode, setup.SecretGenerators.PasswordlessInitCode),
 		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeVerifyDomain, setup.SecretGenerators.DomainVerification),
+		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeOTPSMS, setup.SecretGenerators.OTPSMS),
+		prepareAddSecretGeneratorConfig(instanceAgg, domain.SecretGeneratorTypeOTPEmail, setup.SecretGenerators.OTPEmail),
 
 		prepareAddDefaultPasswordComplexityPolicy(
 			instanceAgg,
@@ -207,11 +218,13 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 			setup.PasswordComplexityPolicy.HasUppercase,
 			setup.PasswordComplexityPolicy.HasNumber,
 			setup.PasswordComplexityPolicy.HasSymbol,
+			setup.PasswordComplexityPolicy.CheckBreached,
 		),
 		prepareAddDefaultPasswordAgePolicy(
 			instanceAgg,
 			setup.PasswordAgePolicy.ExpireWarnDays,
 			setup.PasswordAgePolicy.MaxAgeDays,
+			setup.PasswordAgePolicy.HistoryCount,
 		),
 		prepareAddDefaultDomainPolicy(
 			instanceAgg,
@@ -244,7 +257,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink),
 		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
-		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
+		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.MaxOTPAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure, setup.LockoutPolicy.LockoutDuration),
 
 		prepareAddDefaultLabelPolicy(
 			instanceAgg,
@@ -333,7 +346,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 		}
 	} else if setup.Org.Human != nil {
 		validations = append(validations,
-			AddHumanCommand(userAgg, setup.Org.Human, c.userPasswordAlg, c.userEncryption),
+			AddHumanCommand(userAgg, setup.Org.Human, c.userPasswordAlg, c.userEncryption, c.breachedPasswords),
 		)
 	}
 
@@ -417,6 +430,9 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 				setup.OIDCSettings.IdTokenLifetime,
 				setup.OIDCSettings.RefreshTokenIdleExpiration,
 				setup.OIDCSettings.RefreshTokenExpiration,
+				setup.OIDCSettings.SigningKeyAlgorithm,
+				setup.OIDCSettings.SigningKeyLifetime,
+				setup.OIDCSettings.SigningKeyOverlap,
 			),
 		)
 	}
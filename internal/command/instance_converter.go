@@ -99,17 +99,19 @@ func writeModelToPasswordAgePolicy(wm *PasswordAgePolicyWriteModel) *domain.Pass
 		ObjectRoot:     writeModelToObjectRoot(wm.WriteModel),
 		MaxAgeDays:     wm.MaxAgeDays,
 		ExpireWarnDays: wm.ExpireWarnDays,
+		HistoryCount:   wm.HistoryCount,
 	}
 }
 
 func writeModelToPasswordComplexityPolicy(wm *PasswordComplexityPolicyWriteModel) *domain.PasswordComplexityPolicy {
 	return &domain.PasswordComplexityPolicy{
-		ObjectRoot:   writeModelToObjectRoot(wm.WriteModel),
-		MinLength:    wm.MinLength,
-		HasLowercase: wm.HasLowercase,
-		HasUppercase: wm.HasUppercase,
-		HasNumber:    wm.HasNumber,
-		HasSymbol:    wm.HasSymbol,
+		ObjectRoot:    writeModelToObjectRoot(wm.WriteModel),
+		MinLength:     wm.MinLength,
+		HasLowercase:  wm.HasLowercase,
+		HasUppercase:  wm.HasUppercase,
+		HasNumber:     wm.HasNumber,
+		HasSymbol:     wm.HasSymbol,
+		CheckBreached: wm.CheckBreached,
 	}
 }
 
@@ -117,7 +119,9 @@ func writeModelToLockoutPolicy(wm *LockoutPolicyWriteModel) *domain.LockoutPolic
 	return &domain.LockoutPolicy{
 		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
 		MaxPasswordAttempts: wm.MaxPasswordAttempts,
+		MaxOTPAttempts:      wm.MaxOTPAttempts,
 		ShowLockOutFailures: wm.ShowLockOutFailures,
+		LockoutDuration:     wm.LockoutDuration,
 	}
 }
 
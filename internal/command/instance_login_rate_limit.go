@@ -0,0 +1,22 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+// LoginRateLimitExceeded records that the key (client ip, login name or client id) reached
+// the maximum failed attempts of the login rate limit and is blocked until blockedUntil
+func (c *Commands) LoginRateLimitExceeded(ctx context.Context, keyType domain.RateLimitKeyType, key string, attempts uint64, blockedUntil time.Time) error {
+	if !keyType.Valid() || key == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Rl8fs", "Errors.Instance.LoginRateLimit.KeyInvalid")
+	}
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	_, err := c.eventstore.Push(ctx, instance.NewLoginRateLimitExceededEvent(ctx, &instanceAgg.Aggregate, keyType, key, attempts, blockedUntil))
+	return err
+}
@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestCommandSide_LoginRateLimitExceeded(t *testing.T) {
+	blockedUntil := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx          context.Context
+		keyType      domain.RateLimitKeyType
+		key          string
+		attempts     uint64
+		blockedUntil time.Time
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid key type, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				keyType: domain.RateLimitKeyTypeUnspecified,
+				key:     "127.0.0.1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "key missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				keyType: domain.RateLimitKeyTypeIP,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "exceeded, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"instance1",
+								instance.NewLoginRateLimitExceededEvent(context.Background(),
+									&instance.NewAggregate("instance1").Aggregate,
+									domain.RateLimitKeyTypeLoginName,
+									"username@org.zitadel.ch",
+									10,
+									blockedUntil,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:          authz.WithInstanceID(context.Background(), "instance1"),
+				keyType:      domain.RateLimitKeyTypeLoginName,
+				key:          "username@org.zitadel.ch",
+				attempts:     10,
+				blockedUntil: blockedUntil,
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.LoginRateLimitExceeded(tt.args.ctx, tt.args.keyType, tt.args.key, tt.args.attempts, tt.args.blockedUntil)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
-func (c *Commands) prepareAddOIDCSettings(a *instance.Aggregate, accessTokenLifetime, idTokenLifetime, refreshTokenIdleExpiration, refreshTokenExpiration time.Duration) preparation.Validation {
+func (c *Commands) prepareAddOIDCSettings(a *instance.Aggregate, accessTokenLifetime, idTokenLifetime, refreshTokenIdleExpiration, refreshTokenExpiration time.Duration, signingKeyAlgorithm string, signingKeyLifetime, signingKeyOverlap time.Duration) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if accessTokenLifetime == time.Duration(0) ||
 			idTokenLifetime == time.Duration(0) ||
@@ -20,6 +20,9 @@ func (c *Commands) prepareAddOIDCSettings(a *instance.Aggregate, accessTokenLife
 			refreshTokenExpiration == time.Duration(0) {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-10s82j", "Errors.Invalid.Argument")
 		}
+		if !signingKeySettingsValid(signingKeyAlgorithm, signingKeyLifetime, signingKeyOverlap) {
+			return nil, errors.ThrowInvalidArgument(nil, "INST-Ks8dq", "Errors.OIDCSettings.SigningKeyInvalid")
+		}
 
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			writeModel, err := c.getOIDCSettingsWriteModel(ctx, filter)
@@ -37,13 +40,16 @@ func (c *Commands) prepareAddOIDCSettings(a *instance.Aggregate, accessTokenLife
 					idTokenLifetime,
 					refreshTokenIdleExpiration,
 					refreshTokenExpiration,
+					signingKeyAlgorithm,
+					signingKeyLifetime,
+					signingKeyOverlap,
 				),
 			}, nil
 		}, nil
 	}
 }
 
-func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenLifetime, idTokenLifetime, refreshTokenIdleExpiration, refreshTokenExpiration time.Duration) preparation.Validation {
+func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenLifetime, idTokenLifetime, refreshTokenIdleExpiration, refreshTokenExpiration time.Duration, signingKeyAlgorithm string, signingKeyLifetime, signingKeyOverlap time.Duration) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if accessTokenLifetime == time.Duration(0) ||
 			idTokenLifetime == time.Duration(0) ||
@@ -51,6 +57,9 @@ func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenL
 			refreshTokenExpiration == time.Duration(0) {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-10sxks", "Errors.Invalid.Argument")
 		}
+		if !signingKeySettingsValid(signingKeyAlgorithm, signingKeyLifetime, signingKeyOverlap) {
+			return nil, errors.ThrowInvalidArgument(nil, "INST-Ue3sm", "Errors.OIDCSettings.SigningKeyInvalid")
+		}
 
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			writeModel, err := c.getOIDCSettingsWriteModel(ctx, filter)
@@ -67,6 +76,9 @@ func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenL
 				idTokenLifetime,
 				refreshTokenIdleExpiration,
 				refreshTokenExpiration,
+				signingKeyAlgorithm,
+				signingKeyLifetime,
+				signingKeyOverlap,
 			)
 			if err != nil {
 				return nil, err
@@ -83,7 +95,7 @@ func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenL
 
 func (c *Commands) AddOIDCSettings(ctx context.Context, settings *domain.OIDCSettings) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareAddOIDCSettings(instanceAgg, settings.AccessTokenLifetime, settings.IdTokenLifetime, settings.RefreshTokenIdleExpiration, settings.RefreshTokenExpiration)
+	validation := c.prepareAddOIDCSettings(instanceAgg, settings.AccessTokenLifetime, settings.IdTokenLifetime, settings.RefreshTokenIdleExpiration, settings.RefreshTokenExpiration, settings.SigningKeyAlgorithm, settings.SigningKeyLifetime, settings.SigningKeyOverlap)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -101,7 +113,7 @@ func (c *Commands) AddOIDCSettings(ctx context.Context, settings *domain.OIDCSet
 
 func (c *Commands) ChangeOIDCSettings(ctx context.Context, settings *domain.OIDCSettings) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareUpdateOIDCSettings(instanceAgg, settings.AccessTokenLifetime, settings.IdTokenLifetime, settings.RefreshTokenIdleExpiration, settings.RefreshTokenExpiration)
+	validation := c.prepareUpdateOIDCSettings(instanceAgg, settings.AccessTokenLifetime, settings.IdTokenLifetime, settings.RefreshTokenIdleExpiration, settings.RefreshTokenExpiration, settings.SigningKeyAlgorithm, settings.SigningKeyLifetime, settings.SigningKeyOverlap)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -130,3 +142,10 @@ func (c *Commands) getOIDCSettingsWriteModel(ctx context.Context, filter prepara
 	err = writeModel.Reduce()
 	return writeModel, err
 }
+
+// signingKeySettingsValid checks the optional signing key settings, empty values use the system defaults
+func signingKeySettingsValid(algorithm string, lifetime, overlap time.Duration) bool {
+	return (algorithm == "" || domain.SigningKeyAlgorithmValid(algorithm)) &&
+		(lifetime == 0 || lifetime >= domain.SigningKeyMinLifetime) &&
+		overlap >= 0
+}
@@ -17,6 +17,9 @@ type InstanceOIDCSettingsWriteModel struct {
 	IdTokenLifetime            time.Duration
 	RefreshTokenIdleExpiration time.Duration
 	RefreshTokenExpiration     time.Duration
+	SigningKeyAlgorithm        string
+	SigningKeyLifetime         time.Duration
+	SigningKeyOverlap          time.Duration
 	State                      domain.OIDCSettingsState
 }
 
@@ -37,6 +40,9 @@ func (wm *InstanceOIDCSettingsWriteModel) Reduce() error {
 			wm.IdTokenLifetime = e.IdTokenLifetime
 			wm.RefreshTokenIdleExpiration = e.RefreshTokenIdleExpiration
 			wm.RefreshTokenExpiration = e.RefreshTokenExpiration
+			wm.SigningKeyAlgorithm = e.SigningKeyAlgorithm
+			wm.SigningKeyLifetime = e.SigningKeyLifetime
+			wm.SigningKeyOverlap = e.SigningKeyOverlap
 			wm.State = domain.OIDCSettingsStateActive
 		case *instance.OIDCSettingsChangedEvent:
 			if e.AccessTokenLifetime != nil {
@@ -51,6 +57,15 @@ func (wm *InstanceOIDCSettingsWriteModel) Reduce() error {
 			if e.RefreshTokenExpiration != nil {
 				wm.RefreshTokenExpiration = *e.RefreshTokenExpiration
 			}
+			if e.SigningKeyAlgorithm != nil {
+				wm.SigningKeyAlgorithm = *e.SigningKeyAlgorithm
+			}
+			if e.SigningKeyLifetime != nil {
+				wm.SigningKeyLifetime = *e.SigningKeyLifetime
+			}
+			if e.SigningKeyOverlap != nil {
+				wm.SigningKeyOverlap = *e.SigningKeyOverlap
+			}
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -75,8 +90,11 @@ func (wm *InstanceOIDCSettingsWriteModel) NewChangedEvent(
 	idTokenLifetime,
 	refreshTokenIdleExpiration,
 	refreshTokenExpiration time.Duration,
+	signingKeyAlgorithm string,
+	signingKeyLifetime,
+	signingKeyOverlap time.Duration,
 ) (*instance.OIDCSettingsChangedEvent, bool, error) {
-	changes := make([]instance.OIDCSettingsChanges, 0, 4)
+	changes := make([]instance.OIDCSettingsChanges, 0, 7)
 	var err error
 
 	if wm.AccessTokenLifetime != accessTokenLifetime {
@@ -91,6 +109,15 @@ func (wm *InstanceOIDCSettingsWriteModel) NewChangedEvent(
 	if wm.RefreshTokenExpiration != refreshTokenExpiration {
 		changes = append(changes, instance.ChangeOIDCSettingsRefreshTokenExpiration(refreshTokenExpiration))
 	}
+	if wm.SigningKeyAlgorithm != signingKeyAlgorithm {
+		changes = append(changes, instance.ChangeOIDCSettingsSigningKeyAlgorithm(signingKeyAlgorithm))
+	}
+	if wm.SigningKeyLifetime != signingKeyLifetime {
+		changes = append(changes, instance.ChangeOIDCSettingsSigningKeyLifetime(signingKeyLifetime))
+	}
+	if wm.SigningKeyOverlap != signingKeyOverlap {
+		changes = append(changes, instance.ChangeOIDCSettingsSigningKeyOverlap(signingKeyOverlap))
+	}
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
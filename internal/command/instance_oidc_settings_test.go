@@ -47,6 +47,9 @@ func TestCommandSide_AddOIDCConfig(t *testing.T) {
 								time.Hour*1,
 								time.Hour*1,
 								time.Hour*1,
+								"",
+								0,
+								0,
 							),
 						),
 					),
@@ -82,6 +85,9 @@ func TestCommandSide_AddOIDCConfig(t *testing.T) {
 									time.Hour*1,
 									time.Hour*1,
 									time.Hour*1,
+									"",
+									0,
+									0,
 								),
 							),
 						},
@@ -103,6 +109,48 @@ func TestCommandSide_AddOIDCConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add oidc settings, invalid signing key algorithm",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				oidcConfig: &domain.OIDCSettings{
+					AccessTokenLifetime:        1 * time.Hour,
+					IdTokenLifetime:            1 * time.Hour,
+					RefreshTokenIdleExpiration: 1 * time.Hour,
+					RefreshTokenExpiration:     1 * time.Hour,
+					SigningKeyAlgorithm:        "HS256",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add oidc settings, signing key lifetime too short",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				oidcConfig: &domain.OIDCSettings{
+					AccessTokenLifetime:        1 * time.Hour,
+					IdTokenLifetime:            1 * time.Hour,
+					RefreshTokenIdleExpiration: 1 * time.Hour,
+					RefreshTokenExpiration:     1 * time.Hour,
+					SigningKeyLifetime:         time.Minute,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "add oidc settings, invalid argument 1",
 			fields: fields{
@@ -336,6 +384,9 @@ func TestCommandSide_ChangeOIDCConfig(t *testing.T) {
 								time.Hour*1,
 								time.Hour*1,
 								time.Hour*1,
+								"",
+								0,
+								0,
 							),
 						),
 					),
@@ -368,6 +419,9 @@ func TestCommandSide_ChangeOIDCConfig(t *testing.T) {
 								time.Hour*1,
 								time.Hour*1,
 								time.Hour*1,
+								"",
+								0,
+								0,
 							),
 						),
 					),
@@ -400,6 +454,63 @@ func TestCommandSide_ChangeOIDCConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "oidc settings change signing key, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewOIDCSettingsAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								time.Hour*1,
+								time.Hour*1,
+								time.Hour*1,
+								time.Hour*1,
+								"",
+								0,
+								0,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID("INSTANCE",
+								func() *instance.OIDCSettingsChangedEvent {
+									event, _ := instance.NewOIDCSettingsChangeEvent(context.Background(),
+										&instance.NewAggregate("INSTANCE").Aggregate,
+										[]instance.OIDCSettingsChanges{
+											instance.ChangeOIDCSettingsSigningKeyAlgorithm("ES256"),
+											instance.ChangeOIDCSettingsSigningKeyLifetime(time.Hour * 24),
+											instance.ChangeOIDCSettingsSigningKeyOverlap(time.Hour * 12),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				oidcConfig: &domain.OIDCSettings{
+					AccessTokenLifetime:        1 * time.Hour,
+					IdTokenLifetime:            1 * time.Hour,
+					RefreshTokenIdleExpiration: 1 * time.Hour,
+					RefreshTokenExpiration:     1 * time.Hour,
+					SigningKeyAlgorithm:        "ES256",
+					SigningKeyLifetime:         24 * time.Hour,
+					SigningKeyOverlap:          12 * time.Hour,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
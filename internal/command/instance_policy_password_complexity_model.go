@@ -59,7 +59,8 @@ func (wm *InstancePasswordComplexityPolicyWriteModel) NewChangedEvent(
 	hasLowercase,
 	hasUppercase,
 	hasNumber,
-	hasSymbol bool,
+	hasSymbol,
+	checkBreached bool,
 ) (*instance.PasswordComplexityPolicyChangedEvent, bool) {
 
 	changes := make([]policy.PasswordComplexityPolicyChanges, 0)
@@ -78,6 +79,9 @@ func (wm *InstancePasswordComplexityPolicyWriteModel) NewChangedEvent(
 	if wm.HasSymbol != hasSymbol {
 		changes = append(changes, policy.ChangeHasSymbol(hasSymbol))
 	}
+	if wm.CheckBreached != checkBreached {
+		changes = append(changes, policy.ChangeCheckBreached(checkBreached))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
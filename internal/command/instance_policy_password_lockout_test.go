@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -23,7 +24,9 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 	type args struct {
 		ctx                 context.Context
 		maxPasswordAttempts uint64
+		maxOTPAttempts      uint64
 		showLockOutFailures bool
+		lockoutDuration     time.Duration
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -45,7 +48,9 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -73,7 +78,9 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 								instance.NewLockoutPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									10,
+									5,
 									true,
+									time.Hour,
 								),
 							),
 						},
@@ -83,7 +90,9 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 			args: args{
 				ctx:                 authz.WithInstanceID(context.Background(), "INSTANCE"),
 				maxPasswordAttempts: 10,
+				maxOTPAttempts:      5,
 				showLockOutFailures: true,
+				lockoutDuration:     time.Hour,
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -97,7 +106,7 @@ func TestCommandSide_AddDefaultLockoutPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultLockoutPolicy(tt.args.ctx, tt.args.maxPasswordAttempts, tt.args.showLockOutFailures)
+			got, err := r.AddDefaultLockoutPolicy(tt.args.ctx, tt.args.maxPasswordAttempts, tt.args.maxOTPAttempts, tt.args.showLockOutFailures, tt.args.lockoutDuration)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -158,7 +167,9 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -185,14 +196,16 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 							instance.NewLockoutPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								10,
+								0,
 								true,
+								0,
 							),
 						),
 					),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								newDefaultLockoutPolicyChangedEvent(context.Background(), 20, false),
+								newDefaultLockoutPolicyChangedEvent(context.Background(), 20, 5, false, time.Hour),
 							),
 						},
 					),
@@ -202,7 +215,9 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 				ctx: context.Background(),
 				policy: &domain.LockoutPolicy{
 					MaxPasswordAttempts: 20,
+					MaxOTPAttempts:      5,
 					ShowLockOutFailures: false,
+					LockoutDuration:     time.Hour,
 				},
 			},
 			res: res{
@@ -212,7 +227,9 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 						ResourceOwner: "INSTANCE",
 					},
 					MaxPasswordAttempts: 20,
+					MaxOTPAttempts:      5,
 					ShowLockOutFailures: false,
+					LockoutDuration:     time.Hour,
 				},
 			},
 		},
@@ -236,12 +253,14 @@ func TestCommandSide_ChangeDefaultLockoutPolicy(t *testing.T) {
 	}
 }
 
-func newDefaultLockoutPolicyChangedEvent(ctx context.Context, maxAttempts uint64, showLockoutFailure bool) *instance.LockoutPolicyChangedEvent {
+func newDefaultLockoutPolicyChangedEvent(ctx context.Context, maxAttempts, maxOTPAttempts uint64, showLockoutFailure bool, lockoutDuration time.Duration) *instance.LockoutPolicyChangedEvent {
 	event, _ := instance.NewLockoutPolicyChangedEvent(ctx,
 		&instance.NewAggregate("INSTANCE").Aggregate,
 		[]policy.LockoutPolicyChanges{
 			policy.ChangeMaxAttempts(maxAttempts),
+			policy.ChangeMaxOTPAttempts(maxOTPAttempts),
 			policy.ChangeShowLockOutFailures(showLockoutFailure),
+			policy.ChangeLockoutDuration(lockoutDuration),
 		},
 	)
 	return event
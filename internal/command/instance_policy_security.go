@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
@@ -10,9 +11,26 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
-func (c *Commands) SetSecurityPolicy(ctx context.Context, enabled bool, allowedOrigins []string) (*domain.ObjectDetails, error) {
+type SecurityPolicy struct {
+	// Enabled states if iframe embedding is enabled
+	Enabled bool
+	// AllowedOrigins are allowed to load ZITADEL in an iframe if Enabled is true
+	AllowedOrigins []string
+	// TokenExchangeClientIDs are the clients allowed to use the token exchange grant
+	TokenExchangeClientIDs []string
+	// ImpersonationClientIDs are the clients allowed to impersonate users by token exchange
+	ImpersonationClientIDs []string
+	// LoginRateLimitMaxAttemptsPerIP are the failed login attempts of a client ip until it's blocked (0 uses the system default)
+	LoginRateLimitMaxAttemptsPerIP uint64
+	// LoginRateLimitMaxAttemptsPerLoginName are the failed login attempts on a login name until it's blocked (0 uses the system default)
+	LoginRateLimitMaxAttemptsPerLoginName uint64
+	// LoginRateLimitWindow is the duration the failed attempts are counted and a key is blocked (0 uses the system default)
+	LoginRateLimitWindow time.Duration
+}
+
+func (c *Commands) SetSecurityPolicy(ctx context.Context, policy *SecurityPolicy) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareSetSecurityPolicy(instanceAgg, enabled, allowedOrigins)
+	validation := c.prepareSetSecurityPolicy(instanceAgg, policy)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -28,14 +46,14 @@ func (c *Commands) SetSecurityPolicy(ctx context.Context, enabled bool, allowedO
 	}, nil
 }
 
-func (c *Commands) prepareSetSecurityPolicy(a *instance.Aggregate, enabled bool, allowedOrigins []string) preparation.Validation {
+func (c *Commands) prepareSetSecurityPolicy(a *instance.Aggregate, policy *SecurityPolicy) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			writeModel, err := c.getSecurityPolicyWriteModel(ctx, filter)
 			if err != nil {
 				return nil, err
 			}
-			cmd, err := writeModel.NewSetEvent(ctx, &a.Aggregate, enabled, allowedOrigins)
+			cmd, err := writeModel.NewSetEvent(ctx, &a.Aggregate, policy)
 			if err != nil {
 				return nil, err
 			}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -12,8 +13,14 @@ import (
 type InstanceSecurityPolicyWriteModel struct {
 	eventstore.WriteModel
 
-	Enabled        bool
-	AllowedOrigins []string
+	Enabled                bool
+	AllowedOrigins         []string
+	TokenExchangeClientIDs []string
+	ImpersonationClientIDs []string
+
+	LoginRateLimitMaxAttemptsPerIP        uint64
+	LoginRateLimitMaxAttemptsPerLoginName uint64
+	LoginRateLimitWindow                  time.Duration
 }
 
 func NewInstanceSecurityPolicyWriteModel(ctx context.Context) *InstanceSecurityPolicyWriteModel {
@@ -34,6 +41,21 @@ func (wm *InstanceSecurityPolicyWriteModel) Reduce() error {
 			if e.AllowedOrigins != nil {
 				wm.AllowedOrigins = *e.AllowedOrigins
 			}
+			if e.TokenExchangeClientIDs != nil {
+				wm.TokenExchangeClientIDs = *e.TokenExchangeClientIDs
+			}
+			if e.ImpersonationClientIDs != nil {
+				wm.ImpersonationClientIDs = *e.ImpersonationClientIDs
+			}
+			if e.LoginRateLimitMaxAttemptsPerIP != nil {
+				wm.LoginRateLimitMaxAttemptsPerIP = *e.LoginRateLimitMaxAttemptsPerIP
+			}
+			if e.LoginRateLimitMaxAttemptsPerLoginName != nil {
+				wm.LoginRateLimitMaxAttemptsPerLoginName = *e.LoginRateLimitMaxAttemptsPerLoginName
+			}
+			if e.LoginRateLimitWindow != nil {
+				wm.LoginRateLimitWindow = *e.LoginRateLimitWindow
+			}
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -53,17 +75,31 @@ func (wm *InstanceSecurityPolicyWriteModel) Query() *eventstore.SearchQueryBuild
 func (wm *InstanceSecurityPolicyWriteModel) NewSetEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	enabled bool,
-	allowedOrigins []string,
+	policy *SecurityPolicy,
 ) (*instance.SecurityPolicySetEvent, error) {
-	changes := make([]instance.SecurityPolicyChanges, 0, 2)
+	changes := make([]instance.SecurityPolicyChanges, 0, 7)
 	var err error
 
-	if wm.Enabled != enabled {
-		changes = append(changes, instance.ChangeSecurityPolicyEnabled(enabled))
+	if wm.Enabled != policy.Enabled {
+		changes = append(changes, instance.ChangeSecurityPolicyEnabled(policy.Enabled))
+	}
+	if policy.Enabled && !reflect.DeepEqual(wm.AllowedOrigins, policy.AllowedOrigins) {
+		changes = append(changes, instance.ChangeSecurityPolicyAllowedOrigins(policy.AllowedOrigins))
 	}
-	if enabled && !reflect.DeepEqual(wm.AllowedOrigins, allowedOrigins) {
-		changes = append(changes, instance.ChangeSecurityPolicyAllowedOrigins(allowedOrigins))
+	if clientIDsChanged(wm.TokenExchangeClientIDs, policy.TokenExchangeClientIDs) {
+		changes = append(changes, instance.ChangeSecurityPolicyTokenExchangeClientIDs(policy.TokenExchangeClientIDs))
+	}
+	if clientIDsChanged(wm.ImpersonationClientIDs, policy.ImpersonationClientIDs) {
+		changes = append(changes, instance.ChangeSecurityPolicyImpersonationClientIDs(policy.ImpersonationClientIDs))
+	}
+	if wm.LoginRateLimitMaxAttemptsPerIP != policy.LoginRateLimitMaxAttemptsPerIP {
+		changes = append(changes, instance.ChangeSecurityPolicyLoginRateLimitMaxAttemptsPerIP(policy.LoginRateLimitMaxAttemptsPerIP))
+	}
+	if wm.LoginRateLimitMaxAttemptsPerLoginName != policy.LoginRateLimitMaxAttemptsPerLoginName {
+		changes = append(changes, instance.ChangeSecurityPolicyLoginRateLimitMaxAttemptsPerLoginName(policy.LoginRateLimitMaxAttemptsPerLoginName))
+	}
+	if wm.LoginRateLimitWindow != policy.LoginRateLimitWindow {
+		changes = append(changes, instance.ChangeSecurityPolicyLoginRateLimitWindow(policy.LoginRateLimitWindow))
 	}
 	changeEvent, err := instance.NewSecurityPolicySetEvent(ctx, aggregate, changes)
 	if err != nil {
@@ -71,3 +107,10 @@ func (wm *InstanceSecurityPolicyWriteModel) NewSetEvent(
 	}
 	return changeEvent, nil
 }
+
+func clientIDsChanged(existing, clientIDs []string) bool {
+	if len(existing) == 0 && len(clientIDs) == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(existing, clientIDs)
+}
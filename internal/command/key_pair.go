@@ -11,22 +11,36 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 )
 
-func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string) error {
-	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedKeyPair(c.keySize, c.keyAlgorithm)
+// SigningKeyRotation returns the rotation of the signing keys,
+// lifetime and overlap use the system defaults if empty
+func (c *Commands) SigningKeyRotation(algorithm string, lifetime, overlap time.Duration) *domain.SigningKeyRotation {
+	if lifetime == 0 {
+		lifetime = c.privateKeyLifetime
+	}
+	if overlap == 0 {
+		overlap = c.publicKeyLifetime - c.privateKeyLifetime
+	}
+	return &domain.SigningKeyRotation{
+		Algorithm: algorithm,
+		Lifetime:  lifetime,
+		Overlap:   overlap,
+	}
+}
+
+func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string, privateKeyExp, publicKeyExp time.Time) error {
+	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedSigningKeyPair(algorithm, c.keySize, c.keyAlgorithm)
 	if err != nil {
-		return err
+		return errors.ThrowInvalidArgument(err, "COMMAND-Rk3vd", "Errors.OIDCSettings.SigningKeyInvalid")
 	}
 	keyID, err := c.idGenerator.Next()
 	if err != nil {
 		return err
 	}
 
-	privateKeyExp := time.Now().UTC().Add(c.privateKeyLifetime)
-	publicKeyExp := time.Now().UTC().Add(c.publicKeyLifetime)
-
 	keyPairWriteModel := NewKeyPairWriteModel(keyID, authz.GetInstance(ctx).InstanceID())
 	keyAgg := KeyPairAggregateFromWriteModel(&keyPairWriteModel.WriteModel)
 	_, err = c.eventstore.Push(ctx, keypair.NewAddedEvent(
@@ -35,7 +49,7 @@ func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string)
 		domain.KeyUsageSigning,
 		algorithm,
 		privateCrypto, publicCrypto,
-		privateKeyExp, publicKeyExp))
+		privateKeyExp.UTC(), publicKeyExp.UTC()))
 	return err
 }
 
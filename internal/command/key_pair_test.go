@@ -0,0 +1,58 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func TestCommands_SigningKeyRotation(t *testing.T) {
+	type args struct {
+		algorithm string
+		lifetime  time.Duration
+		overlap   time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want *domain.SigningKeyRotation
+	}{
+		{
+			name: "system defaults",
+			args: args{
+				algorithm: "RS256",
+			},
+			want: &domain.SigningKeyRotation{
+				Algorithm: "RS256",
+				Lifetime:  6 * time.Hour,
+				Overlap:   24 * time.Hour,
+			},
+		},
+		{
+			name: "instance settings",
+			args: args{
+				algorithm: "EdDSA",
+				lifetime:  48 * time.Hour,
+				overlap:   12 * time.Hour,
+			},
+			want: &domain.SigningKeyRotation{
+				Algorithm: "EdDSA",
+				Lifetime:  48 * time.Hour,
+				Overlap:   12 * time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				privateKeyLifetime: 6 * time.Hour,
+				publicKeyLifetime:  30 * time.Hour,
+			}
+			got := c.SigningKeyRotation(tt.args.algorithm, tt.args.lifetime, tt.args.overlap)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -14,12 +14,14 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/repository"
 	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
 	action_repo "github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	key_repo "github.com/zitadel/zitadel/internal/repository/keypair"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
 )
 
 type expect func(mockRepository *mock.MockRepository)
@@ -37,6 +39,8 @@ func eventstoreExpect(t *testing.T, expects ...expect) *eventstore.Eventstore {
 	usergrant.RegisterEventMappers(es)
 	key_repo.RegisterEventMappers(es)
 	action_repo.RegisterEventMappers(es)
+	webhook.RegisterEventMappers(es)
+	deviceauth.RegisterEventMappers(es)
 	return es
 }
 
@@ -182,6 +186,25 @@ func eventFromEventPusherWithCreationDateNow(event eventstore.Command) *reposito
 	return e
 }
 
+func eventFromEventPusherWithSequence(event eventstore.Command, sequence uint64) *repository.Event {
+	e := eventFromEventPusher(event)
+	e.Sequence = sequence
+	return e
+}
+
+// eventWithSequenceCheck returns the event as it is pushed with [eventstore.CheckSequence]
+func eventWithSequenceCheck(event *repository.Event, sequence uint64, eventTypes ...eventstore.EventType) *repository.Event {
+	types := make([]repository.EventType, len(eventTypes))
+	for i, eventType := range eventTypes {
+		types[i] = repository.EventType(eventType)
+	}
+	event.SequenceCheck = &repository.SequenceCheck{
+		Sequence:   sequence,
+		EventTypes: types,
+	}
+	return event
+}
+
 func uniqueConstraintsFromEventConstraint(constraint *eventstore.EventUniqueConstraint) *repository.UniqueConstraint {
 	return &repository.UniqueConstraint{
 		UniqueType:   constraint.UniqueType,
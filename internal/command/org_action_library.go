@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// SetActionLibrary adds a new version of the library if the script changed,
+// the libraries required by the script must exist and must not require the library themselves
+func (c *Commands) SetActionLibrary(ctx context.Context, name, script, resourceOwner string) (uint64, *domain.ObjectDetails, error) {
+	if !domain.ActionLibraryNameValid(name) || script == "" || resourceOwner == "" {
+		return 0, nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Qe4hn", "Errors.Action.Library.Invalid")
+	}
+	if err := c.checkOrgExists(ctx, resourceOwner); err != nil {
+		return 0, nil, err
+	}
+	existing := NewOrgActionLibrariesWriteModel(resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return 0, nil, err
+	}
+	library, ok := existing.Libraries[name]
+	if ok && library.Latest > 0 && library.Script == script {
+		return library.Latest, writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	if !ok {
+		library = &ActionLibraryWriteModel{Dependencies: make(map[uint64][]string)}
+		existing.Libraries[name] = library
+	}
+
+	dependencies := domain.ActionLibraryDependencies(script)
+	version := library.LastVersion + 1
+	// the new version is reduced up front to resolve the dependencies on the library itself
+	library.Latest = version
+	library.Dependencies[version] = dependencies
+	for _, dependency := range dependencies {
+		if _, ok := existing.resolve(dependency); !ok {
+			return 0, nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ov9sd", "Errors.Action.Library.DependencyNotFound")
+		}
+	}
+	if existing.hasCycle(actionLibraryVersion{name: name, version: version}) {
+		return 0, nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Bh3ty", "Errors.Action.Library.DependencyCycle")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionLibrarySetEvent(ctx, orgAgg, name, version, script, dependencies))
+	if err != nil {
+		return 0, nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return 0, nil, err
+	}
+	return version, writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+// RemoveActionLibrary removes all versions of the library,
+// it's not possible as long as the latest version of another library requires it
+func (c *Commands) RemoveActionLibrary(ctx context.Context, name, resourceOwner string) (*domain.ObjectDetails, error) {
+	if name == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ic8wu", "Errors.Action.Library.Invalid")
+	}
+	existing := NewOrgActionLibrariesWriteModel(resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return nil, err
+	}
+	if !existing.exists(name) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ta6ml", "Errors.Action.Library.NotFound")
+	}
+	if existing.isRequired(name) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ep2gz", "Errors.Action.Library.InUse")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionLibraryRemovedEvent(ctx, orgAgg, name))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
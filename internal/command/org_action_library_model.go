@@ -0,0 +1,147 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// OrgActionLibrariesWriteModel contains all libraries of the organisation
+// to resolve the dependencies between them
+type OrgActionLibrariesWriteModel struct {
+	eventstore.WriteModel
+
+	Libraries map[string]*ActionLibraryWriteModel
+}
+
+type ActionLibraryWriteModel struct {
+	// Latest is the current version, 0 if the library is removed
+	Latest uint64
+	// LastVersion is kept after the library is removed so versions are never reused
+	LastVersion  uint64
+	Script       string
+	Dependencies map[uint64][]string
+}
+
+func NewOrgActionLibrariesWriteModel(resourceOwner string) *OrgActionLibrariesWriteModel {
+	return &OrgActionLibrariesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   resourceOwner,
+			ResourceOwner: resourceOwner,
+		},
+		Libraries: make(map[string]*ActionLibraryWriteModel),
+	}
+}
+
+func (wm *OrgActionLibrariesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.ActionLibrarySetEvent:
+			library, ok := wm.Libraries[e.Name]
+			if !ok {
+				library = &ActionLibraryWriteModel{Dependencies: make(map[uint64][]string)}
+				wm.Libraries[e.Name] = library
+			}
+			library.Latest = e.Version
+			library.LastVersion = e.Version
+			library.Script = e.Script
+			library.Dependencies[e.Version] = e.Dependencies
+		case *org.ActionLibraryRemovedEvent:
+			library, ok := wm.Libraries[e.Name]
+			if !ok {
+				continue
+			}
+			library.Latest = 0
+			library.Script = ""
+			library.Dependencies = make(map[uint64][]string)
+		case *org.OrgRemovedEvent:
+			wm.Libraries = make(map[string]*ActionLibraryWriteModel)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgActionLibrariesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.AggregateID).
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.ActionLibrarySetEventType,
+			org.ActionLibraryRemovedEventType,
+			org.OrgRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgActionLibrariesWriteModel) exists(name string) bool {
+	library, ok := wm.Libraries[name]
+	return ok && library.Latest > 0
+}
+
+type actionLibraryVersion struct {
+	name    string
+	version uint64
+}
+
+// resolve returns the library version of the dependency,
+// a dependency without version resolves to the latest version
+func (wm *OrgActionLibrariesWriteModel) resolve(dependency string) (actionLibraryVersion, bool) {
+	name, version, ok := domain.ParseActionLibraryDependency(dependency)
+	if !ok {
+		return actionLibraryVersion{}, false
+	}
+	library, ok := wm.Libraries[name]
+	if !ok || library.Latest == 0 {
+		return actionLibraryVersion{}, false
+	}
+	if version == 0 {
+		version = library.Latest
+	}
+	if _, ok = library.Dependencies[version]; !ok {
+		return actionLibraryVersion{}, false
+	}
+	return actionLibraryVersion{name: name, version: version}, true
+}
+
+// hasCycle checks if the dependencies of the library version lead back to it,
+// the library version must already be reduced
+func (wm *OrgActionLibrariesWriteModel) hasCycle(start actionLibraryVersion) bool {
+	visited := make(map[actionLibraryVersion]bool)
+	var visit func(current actionLibraryVersion) bool
+	visit = func(current actionLibraryVersion) bool {
+		for _, dependency := range wm.Libraries[current.name].Dependencies[current.version] {
+			next, ok := wm.resolve(dependency)
+			if !ok {
+				continue
+			}
+			if next == start {
+				return true
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if visit(next) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(start)
+}
+
+// isRequired checks if the latest version of another library requires the library
+func (wm *OrgActionLibrariesWriteModel) isRequired(name string) bool {
+	for dependentName, library := range wm.Libraries {
+		if dependentName == name || library.Latest == 0 {
+			continue
+		}
+		for _, dependency := range library.Dependencies[library.Latest] {
+			if dependencyName, _, _ := domain.ParseActionLibraryDependency(dependency); dependencyName == name {
+				return true
+			}
+		}
+	}
+	return false
+}
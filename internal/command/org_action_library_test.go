@@ -0,0 +1,441 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestCommands_SetActionLibrary(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		name          string
+		script        string
+		resourceOwner string
+	}
+	type res struct {
+		version uint64
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid name, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "lib/helpers",
+				script:        "module.exports = {}",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"dependency not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org",
+							),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "crm",
+				script:        `let helpers = require("lib/helpers")`,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"self reference, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org",
+							),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "helpers",
+				script:        `let helpers = require('lib/helpers')`,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"dependency cycle, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+								1,
+								"module.exports = {}",
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"crm",
+								1,
+								`let helpers = require("lib/helpers")`,
+								[]string{"helpers"},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "helpers",
+				script:        `let crm = require("lib/crm@1")`,
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"unchanged, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+								1,
+								"module.exports = {}",
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "helpers",
+				script:        "module.exports = {}",
+				resourceOwner: "org1",
+			},
+			res{
+				version: 1,
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"pinned previous version, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+								1,
+								"module.exports = {}",
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+								2,
+								"module.exports = {v2: true}",
+								nil,
+							),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"crm",
+								1,
+								`let helpers = require("lib/helpers@1")`,
+								[]string{"helpers@1"},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "crm",
+				script:        `let helpers = require("lib/helpers@1")`,
+				resourceOwner: "org1",
+			},
+			res{
+				version: 1,
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"new version after removal, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"org",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+								1,
+								"module.exports = {}",
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							org.NewActionLibraryRemovedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+							),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+								2,
+								"module.exports = {}",
+								[]string{},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "helpers",
+				script:        "module.exports = {}",
+				resourceOwner: "org1",
+			},
+			res{
+				version: 2,
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			version, details, err := c.SetActionLibrary(tt.args.ctx, tt.args.name, tt.args.script, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.version, version)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveActionLibrary(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		name          string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "helpers",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"required by other library, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+								1,
+								"module.exports = {}",
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"crm",
+								1,
+								`let helpers = require("lib/helpers@1")`,
+								[]string{"helpers@1"},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "helpers",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionLibrarySetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+								1,
+								"module.exports = {}",
+								nil,
+							),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							org.NewActionLibraryRemovedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"helpers",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				name:          "helpers",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveActionLibrary(tt.args.ctx, tt.args.name, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
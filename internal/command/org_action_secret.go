@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// SetActionSecret stores the value encrypted, it's only readable by the actions of the organisation
+func (c *Commands) SetActionSecret(ctx context.Context, name, value, resourceOwner string) (*domain.ObjectDetails, error) {
+	if name == "" || value == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ug4ke", "Errors.Action.Secret.Invalid")
+	}
+	if err := c.checkOrgExists(ctx, resourceOwner); err != nil {
+		return nil, err
+	}
+	encrypted, err := crypto.Encrypt([]byte(value), c.actionSecretEncryption)
+	if err != nil {
+		return nil, err
+	}
+	existing := NewOrgActionSecretWriteModel(name, resourceOwner)
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionSecretSetEvent(ctx, orgAgg, name, encrypted))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) RemoveActionSecret(ctx context.Context, name, resourceOwner string) (*domain.ObjectDetails, error) {
+	if name == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ro9vb", "Errors.Action.Secret.Invalid")
+	}
+	existing := NewOrgActionSecretWriteModel(name, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return nil, err
+	}
+	if !existing.Exists {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Dm2xq", "Errors.Action.Secret.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionSecretRemovedEvent(ctx, orgAgg, name))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
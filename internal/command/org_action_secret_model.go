@@ -0,0 +1,64 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgActionSecretWriteModel struct {
+	eventstore.WriteModel
+
+	Name   string
+	Exists bool
+}
+
+func NewOrgActionSecretWriteModel(name, resourceOwner string) *OrgActionSecretWriteModel {
+	return &OrgActionSecretWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   resourceOwner,
+			ResourceOwner: resourceOwner,
+		},
+		Name: name,
+	}
+}
+
+func (wm *OrgActionSecretWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.ActionSecretSetEvent:
+			if e.Name != wm.Name {
+				continue
+			}
+		case *org.ActionSecretRemovedEvent:
+			if e.Name != wm.Name {
+				continue
+			}
+		}
+		wm.WriteModel.AppendEvents(event)
+	}
+}
+
+func (wm *OrgActionSecretWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch event.(type) {
+		case *org.ActionSecretSetEvent:
+			wm.Exists = true
+		case *org.ActionSecretRemovedEvent, *org.OrgRemovedEvent:
+			wm.Exists = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgActionSecretWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.AggregateID).
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.ActionSecretSetEventType,
+			org.ActionSecretRemovedEventType,
+			org.OrgRemovedEventType).
+		Builder()
+}
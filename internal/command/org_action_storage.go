@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const maxActionStorageKeyLength = 200
+
+// SetActionStorageValue stores the value for the actions of the organisation,
+// the length of the value and the size of all values of the organisation are limited.
+// The value is only stored if no other value was changed in the meantime, so concurrent changes can't exceed the limit
+func (c *Commands) SetActionStorageValue(ctx context.Context, key, value, resourceOwner string) (*domain.ObjectDetails, error) {
+	if key == "" || len(key) > maxActionStorageKeyLength || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wn5rf", "Errors.Action.Storage.Invalid")
+	}
+	if len(value) > c.actionStorageLimits.MaxValueLength {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fz8ou", "Errors.Action.Storage.ValueTooLong")
+	}
+	existing := NewOrgActionStorageWriteModel(resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return nil, err
+	}
+	if current, ok := existing.Values[key]; ok && current == value {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	if existing.sizeWith(key, value) > c.actionStorageLimits.MaxSizePerOrg {
+		return nil, caos_errs.ThrowResourceExhausted(nil, "COMMAND-Ka3yd", "Errors.Action.Storage.LimitExceeded")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, eventstore.CheckSequence(
+		org.NewActionStorageSetEvent(ctx, orgAgg, key, value),
+		existing.ProcessedSequence,
+		existing.eventTypes()...,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
+func (c *Commands) RemoveActionStorageValue(ctx context.Context, key, resourceOwner string) (*domain.ObjectDetails, error) {
+	if key == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pb6sc", "Errors.Action.Storage.Invalid")
+	}
+	existing := NewOrgActionStorageWriteModel(resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return nil, err
+	}
+	if _, ok := existing.Values[key]; !ok {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ye1gt", "Errors.Action.Storage.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewActionStorageRemovedEvent(ctx, orgAgg, key))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
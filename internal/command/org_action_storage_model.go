@@ -0,0 +1,68 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// OrgActionStorageWriteModel contains all values of the organisation
+// to check the size limit of the storage
+type OrgActionStorageWriteModel struct {
+	eventstore.WriteModel
+
+	Values map[string]string
+}
+
+func NewOrgActionStorageWriteModel(resourceOwner string) *OrgActionStorageWriteModel {
+	return &OrgActionStorageWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   resourceOwner,
+			ResourceOwner: resourceOwner,
+		},
+		Values: make(map[string]string),
+	}
+}
+
+func (wm *OrgActionStorageWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.ActionStorageSetEvent:
+			wm.Values[e.Key] = e.Value
+		case *org.ActionStorageRemovedEvent:
+			delete(wm.Values, e.Key)
+		case *org.OrgRemovedEvent:
+			wm.Values = make(map[string]string)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgActionStorageWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.AggregateID).
+		AggregateTypes(org.AggregateType).
+		EventTypes(wm.eventTypes()...).
+		Builder()
+}
+
+func (wm *OrgActionStorageWriteModel) eventTypes() []eventstore.EventType {
+	return []eventstore.EventType{
+		org.ActionStorageSetEventType,
+		org.ActionStorageRemovedEventType,
+		org.OrgRemovedEventType,
+	}
+}
+
+// sizeWith returns the size of all keys and values if the value of the key is replaced
+func (wm *OrgActionStorageWriteModel) sizeWith(key, value string) int {
+	size := len(key) + len(value)
+	for k, v := range wm.Values {
+		if k == key {
+			continue
+		}
+		size += len(k) + len(v)
+	}
+	return size
+}
@@ -0,0 +1,347 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sd "github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestCommands_SetActionStorageValue(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		key           string
+		value         string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"missing key, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				value:         "value",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"value too long, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key",
+				value:         "value longer than the limit",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"org limit exceeded, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionStorageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"counter",
+								"1234567890",
+							),
+						),
+						eventFromEventPusher(
+							org.NewActionStorageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"other",
+								"1234567890",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key",
+				value:         "value",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsResourceExhausted,
+			},
+		},
+		{
+			"unchanged, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionStorageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"key",
+								"value",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key",
+				value:         "value",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"changed concurrently, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithSequence(
+							org.NewActionStorageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"counter",
+								"1234567890",
+							),
+							4,
+						),
+					),
+					expectPushFailed(
+						errors.ThrowPreconditionFailed(nil, "ERROR", "Errors.ConcurrentChange"),
+						[]*repository.Event{
+							eventWithSequenceCheck(
+								eventFromEventPusher(
+									org.NewActionStorageSetEvent(context.Background(),
+										&org.NewAggregate("org1").Aggregate,
+										"counter",
+										"1",
+									),
+								),
+								4,
+								org.ActionStorageSetEventType,
+								org.ActionStorageRemovedEventType,
+								org.OrgRemovedEventType,
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "counter",
+				value:         "1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"replace within limit, ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithSequence(
+							org.NewActionStorageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"counter",
+								"1234567890",
+							),
+							4,
+						),
+						eventFromEventPusherWithSequence(
+							org.NewActionStorageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"other",
+								"1234567890",
+							),
+							5,
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventWithSequenceCheck(
+								eventFromEventPusher(
+									org.NewActionStorageSetEvent(context.Background(),
+										&org.NewAggregate("org1").Aggregate,
+										"counter",
+										"1",
+									),
+								),
+								5,
+								org.ActionStorageSetEventType,
+								org.ActionStorageRemovedEventType,
+								org.OrgRemovedEventType,
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "counter",
+				value:         "1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+				actionStorageLimits: sd.ActionStorage{
+					MaxValueLength: 10,
+					MaxSizePerOrg:  30,
+				},
+			}
+			details, err := c.SetActionStorageValue(tt.args.ctx, tt.args.key, tt.args.value, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveActionStorageValue(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		key           string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionStorageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"key",
+								"value",
+							),
+						),
+						eventFromEventPusher(
+							org.NewActionStorageRemovedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"key",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewActionStorageSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"key",
+								"value",
+							),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							org.NewActionStorageRemovedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"key",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				key:           "key",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveActionStorageValue(tt.args.ctx, tt.args.key, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/eventpayload"
 	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
@@ -67,6 +69,44 @@ func (c *Commands) SetTriggerActions(ctx context.Context, flowType domain.FlowTy
 	return writeModelToObjectDetails(&existingFlow.WriteModel), nil
 }
 
+// SetEventTriggerActions binds the actions to the event type,
+// they are executed asynchronously for each event of the type created on a resource of the organisation
+func (c *Commands) SetEventTriggerActions(ctx context.Context, eventType string, actionIDs []string, resourceOwner string) (*domain.ObjectDetails, error) {
+	if eventType == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Jf9wq", "Errors.Flow.EventTypeMissing")
+	}
+	// only events without secrets can be bound, the binding of other event types can only be removed
+	if len(actionIDs) > 0 && !eventpayload.IsPublic(eventstore.EventType(eventType)) {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vb3fq", "Errors.Flow.EventTypeNotAllowed")
+	}
+	existing := NewOrgEventTriggerActionsWriteModel(eventstore.EventType(eventType), resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return nil, err
+	}
+	if len(existing.ActionIDs) == 0 && len(actionIDs) == 0 || reflect.DeepEqual(existing.ActionIDs, actionIDs) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Tb2ko", "Errors.Flow.NoChanges")
+	}
+	if len(actionIDs) > 0 {
+		exists, err := c.actionsIDsExist(ctx, actionIDs, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Xe4rn", "Errors.Flow.ActionIDsNotExist")
+		}
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewEventTriggerActionsSetEvent(ctx, orgAgg, existing.EventType, actionIDs))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existing, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existing.WriteModel), nil
+}
+
 func (c *Commands) getOrgFlowWriteModelByType(ctx context.Context, flowType domain.FlowType, resourceOwner string) (*OrgFlowWriteModel, error) {
 	flowWriteModel := NewOrgFlowWriteModel(flowType, resourceOwner)
 	err := c.eventstore.FilterToQueryReducer(ctx, flowWriteModel)
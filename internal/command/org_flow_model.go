@@ -52,3 +52,47 @@ func (wm *OrgFlowWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.FlowClearedEventType).
 		Builder()
 }
+
+type OrgEventTriggerActionsWriteModel struct {
+	eventstore.WriteModel
+
+	EventType eventstore.EventType
+	ActionIDs []string
+}
+
+func NewOrgEventTriggerActionsWriteModel(eventType eventstore.EventType, resourceOwner string) *OrgEventTriggerActionsWriteModel {
+	return &OrgEventTriggerActionsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   resourceOwner,
+			ResourceOwner: resourceOwner,
+		},
+		EventType: eventType,
+	}
+}
+
+func (wm *OrgEventTriggerActionsWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if e, ok := event.(*org.EventTriggerActionsSetEvent); ok && e.EventType != wm.EventType {
+			continue
+		}
+		wm.WriteModel.AppendEvents(event)
+	}
+}
+
+func (wm *OrgEventTriggerActionsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*org.EventTriggerActionsSetEvent); ok {
+			wm.ActionIDs = e.ActionIDs
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgEventTriggerActionsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		EventTypes(org.EventTriggerActionsSetEventType).
+		Builder()
+}
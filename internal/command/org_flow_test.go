@@ -285,3 +285,207 @@ func TestCommands_SetTriggerActions(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_SetEventTriggerActions(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		eventType     string
+		actionIDs     []string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"missing event type, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				actionIDs:     []string{"actionID1"},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"event type with secrets, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				eventType:     "user.human.password.code.added",
+				actionIDs:     []string{"actionID1"},
+				resourceOwner: "org1",
+			},
+			res{
+				err: func(err error) bool {
+					return errors.IsErrorInvalidArgument(err) && errors.Contains(err, "Errors.Flow.EventTypeNotAllowed")
+				},
+			},
+		},
+		{
+			"no changes, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewEventTriggerActionsSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"user.removed",
+								[]string{"actionID1"},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				eventType:     "user.removed",
+				actionIDs:     []string{"actionID1"},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"remove unbound, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				eventType:     "user.removed",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"actionID not exists, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				eventType:     "user.removed",
+				actionIDs:     []string{"actionID1"},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"set ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("action1", "org1").Aggregate,
+								"actionID1",
+								"function(ctx, api) action {};",
+								0,
+								false,
+							),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							org.NewEventTriggerActionsSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"user.removed",
+								[]string{"actionID1"},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				eventType:     "user.removed",
+				actionIDs:     []string{"actionID1"},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewEventTriggerActionsSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"user.removed",
+								[]string{"actionID1"},
+							),
+						),
+					),
+					expectPush(
+						eventPusherToEvents(
+							org.NewEventTriggerActionsSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"user.removed",
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				eventType:     "user.removed",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.SetEventTriggerActions(tt.args.ctx, tt.args.eventType, tt.args.actionIDs, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
@@ -63,6 +63,7 @@ func TestCommandSide_AddPasswordAgePolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								365,
 								10,
+								0,
 							),
 						),
 					),
@@ -93,6 +94,7 @@ func TestCommandSide_AddPasswordAgePolicy(t *testing.T) {
 									&org.NewAggregate("org1").Aggregate,
 									10,
 									365,
+									0,
 								),
 							),
 						},
@@ -206,6 +208,7 @@ func TestCommandSide_ChangePasswordAgePolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								365,
+								0,
 							),
 						),
 					),
@@ -234,6 +237,7 @@ func TestCommandSide_ChangePasswordAgePolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								365,
+								0,
 							),
 						),
 					),
@@ -344,6 +348,7 @@ func TestCommandSide_RemovePasswordAgePolicy(t *testing.T) {
 								&org.NewAggregate("org1").Aggregate,
 								10,
 								365,
+								0,
 							),
 						),
 					),
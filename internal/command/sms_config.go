@@ -2,11 +2,15 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/vonage"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
@@ -110,6 +114,225 @@ func (c *Commands) ChangeSMSConfigTwilioToken(ctx context.Context, instanceID, i
 	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
 }
 
+func (c *Commands) AddSMSConfigHTTP(ctx context.Context, instanceID string, config *webhook.Config) (string, *domain.ObjectDetails, error) {
+	if !config.IsValid() {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hs3gw", "Errors.SMSConfig.Invalid")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return "", nil, err
+	}
+	headers, err := c.encryptSMSHeaders(config.Headers)
+	if err != nil {
+		return "", nil, err
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigHTTPAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Endpoint,
+		config.BodyTemplate,
+		config.SenderNumber,
+		headers))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// ChangeSMSConfigHTTP changes the endpoint, body template and sender number of the http provider,
+// the headers are changed separately by ChangeSMSConfigHTTPHeaders as they might contain secrets
+func (c *Commands) ChangeSMSConfigHTTP(ctx context.Context, instanceID, id string, config *webhook.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SMS-Lw2fs", "Errors.IDMissing")
+	}
+	if !config.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Rw3fa", "Errors.SMSConfig.Invalid")
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ms2fq", "Errors.SMSConfig.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewHTTPChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.Endpoint,
+		config.BodyTemplate,
+		config.SenderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Tq3fs", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeSMSConfigHTTPHeaders(ctx context.Context, instanceID, id string, headers map[string]string) (*domain.ObjectDetails, error) {
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Vd3sf", "Errors.SMSConfig.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	encryptedHeaders, err := c.encryptSMSHeaders(headers)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigHTTPHeadersChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		encryptedHeaders))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) encryptSMSHeaders(headers map[string]string) (*crypto.CryptoValue, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	marshalled, err := json.Marshal(headers)
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "COMMAND-Gq2fe", "Errors.Internal")
+	}
+	return crypto.Encrypt(marshalled, c.smsEncryption)
+}
+
+func (c *Commands) AddSMSConfigVonage(ctx context.Context, instanceID string, config *vonage.Config) (string, *domain.ObjectDetails, error) {
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var apiSecret *crypto.CryptoValue
+	if config.APISecret != "" {
+		apiSecret, err = crypto.Encrypt([]byte(config.APISecret), c.smsEncryption)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigVonageAddedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.APIKey,
+		config.SenderNumber,
+		apiSecret))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeSMSConfigVonage(ctx context.Context, instanceID, id string, config *vonage.Config) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "SMS-Fw3sq", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.Vonage == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ud2fw", "Errors.SMSConfig.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewVonageChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		config.APIKey,
+		config.SenderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Xs3gq", "Errors.NoChangesFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeSMSConfigVonageAPISecret(ctx context.Context, instanceID, id, apiSecret string) (*domain.ObjectDetails, error) {
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, instanceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.Vonage == nil {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Bq2sd", "Errors.SMSConfig.NotFound")
+	}
+	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
+	newSecret, err := crypto.Encrypt([]byte(apiSecret), c.smsEncryption)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigVonageAPISecretChangedEvent(
+		ctx,
+		iamAgg,
+		id,
+		newSecret))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(smsConfigWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+// ActivateSMSConfig activates the config of any provider and deactivates the currently active config,
+// so at most one config is active
 func (c *Commands) ActivateSMSConfig(ctx context.Context, instanceID, id string) (*domain.ObjectDetails, error) {
 	if id == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "SMS-dn93n", "Errors.IDMissing")
@@ -125,11 +348,17 @@ func (c *Commands) ActivateSMSConfig(ctx context.Context, instanceID, id string)
 	if smsConfigWriteModel.State == domain.SMSConfigStateActive {
 		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-sn9we", "Errors.SMSConfig.AlreadyActive")
 	}
+	activeWriteModel, err := c.getActiveSMSConfigs(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
 	iamAgg := InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel)
-	pushedEvents, err := c.eventstore.Push(ctx, instance.NewSMSConfigTwilioActivatedEvent(
-		ctx,
-		iamAgg,
-		id))
+	events := make([]eventstore.Command, 0, len(activeWriteModel.ActiveIDs)+1)
+	for _, activeID := range activeWriteModel.ActiveIDs {
+		events = append(events, instance.NewSMSConfigDeactivatedEvent(ctx, iamAgg, activeID))
+	}
+	events = append(events, instance.NewSMSConfigActivatedEvent(ctx, iamAgg, id))
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
 		return nil, err
 	}
@@ -196,6 +425,16 @@ func (c *Commands) RemoveSMSConfig(ctx context.Context, instanceID, id string) (
 	}
 	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
 }
+
+func (c *Commands) getActiveSMSConfigs(ctx context.Context, instanceID string) (_ *IAMSMSConfigActiveWriteModel, err error) {
+	writeModel := NewIAMSMSConfigActiveWriteModel(instanceID)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
 func (c *Commands) getSMSConfig(ctx context.Context, instanceID, id string) (_ *IAMSMSConfigWriteModel, err error) {
 	writeModel := NewIAMSMSConfigWriteModel(instanceID, id)
 	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
@@ -14,6 +14,8 @@ type IAMSMSConfigWriteModel struct {
 
 	ID     string
 	Twilio *TwilioConfig
+	HTTP   *HTTPConfig
+	Vonage *VonageConfig
 	State  domain.SMSConfigState
 }
 
@@ -23,6 +25,19 @@ type TwilioConfig struct {
 	SenderNumber string
 }
 
+type HTTPConfig struct {
+	Endpoint     string
+	Headers      *crypto.CryptoValue
+	BodyTemplate string
+	SenderNumber string
+}
+
+type VonageConfig struct {
+	APIKey       string
+	APISecret    *crypto.CryptoValue
+	SenderNumber string
+}
+
 func NewIAMSMSConfigWriteModel(instanceID, id string) *IAMSMSConfigWriteModel {
 	return &IAMSMSConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
@@ -61,6 +76,60 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio.Token = e.Token
+		case *instance.SMSConfigHTTPAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP = &HTTPConfig{
+				Endpoint:     e.Endpoint,
+				Headers:      e.Headers,
+				BodyTemplate: e.BodyTemplate,
+				SenderNumber: e.SenderNumber,
+			}
+			wm.State = domain.SMSConfigStateInactive
+		case *instance.SMSConfigHTTPChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			if e.Endpoint != nil {
+				wm.HTTP.Endpoint = *e.Endpoint
+			}
+			if e.BodyTemplate != nil {
+				wm.HTTP.BodyTemplate = *e.BodyTemplate
+			}
+			if e.SenderNumber != nil {
+				wm.HTTP.SenderNumber = *e.SenderNumber
+			}
+		case *instance.SMSConfigHTTPHeadersChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.HTTP.Headers = e.Headers
+		case *instance.SMSConfigVonageAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.Vonage = &VonageConfig{
+				APIKey:       e.APIKey,
+				APISecret:    e.APISecret,
+				SenderNumber: e.SenderNumber,
+			}
+			wm.State = domain.SMSConfigStateInactive
+		case *instance.SMSConfigVonageChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			if e.APIKey != nil {
+				wm.Vonage.APIKey = *e.APIKey
+			}
+			if e.SenderNumber != nil {
+				wm.Vonage.SenderNumber = *e.SenderNumber
+			}
+		case *instance.SMSConfigVonageAPISecretChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.Vonage.APISecret = e.APISecret
 		case *instance.SMSConfigActivatedEvent:
 			if wm.ID != e.ID {
 				continue
@@ -76,6 +145,8 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio = nil
+			wm.HTTP = nil
+			wm.Vonage = nil
 			wm.State = domain.SMSConfigStateRemoved
 		}
 	}
@@ -91,6 +162,12 @@ func (wm *IAMSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMSConfigTwilioAddedEventType,
 			instance.SMSConfigTwilioChangedEventType,
 			instance.SMSConfigTwilioTokenChangedEventType,
+			instance.SMSConfigHTTPAddedEventType,
+			instance.SMSConfigHTTPChangedEventType,
+			instance.SMSConfigHTTPHeadersChangedEventType,
+			instance.SMSConfigVonageAddedEventType,
+			instance.SMSConfigVonageChangedEventType,
+			instance.SMSConfigVonageAPISecretChangedEventType,
 			instance.SMSConfigActivatedEventType,
 			instance.SMSConfigDeactivatedEventType,
 			instance.SMSConfigRemovedEventType).
@@ -117,3 +194,99 @@ func (wm *IAMSMSConfigWriteModel) NewChangedEvent(ctx context.Context, aggregate
 	}
 	return changeEvent, true, nil
 }
+
+func (wm *IAMSMSConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id, endpoint, bodyTemplate, senderNumber string) (*instance.SMSConfigHTTPChangedEvent, bool, error) {
+	changes := make([]instance.SMSConfigHTTPChanges, 0)
+
+	if wm.HTTP.Endpoint != endpoint {
+		changes = append(changes, instance.ChangeSMSConfigHTTPEndpoint(endpoint))
+	}
+	if wm.HTTP.BodyTemplate != bodyTemplate {
+		changes = append(changes, instance.ChangeSMSConfigHTTPBodyTemplate(bodyTemplate))
+	}
+	if wm.HTTP.SenderNumber != senderNumber {
+		changes = append(changes, instance.ChangeSMSConfigHTTPSenderNumber(senderNumber))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMSConfigHTTPChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+func (wm *IAMSMSConfigWriteModel) NewVonageChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id, apiKey, senderNumber string) (*instance.SMSConfigVonageChangedEvent, bool, error) {
+	changes := make([]instance.SMSConfigVonageChanges, 0)
+
+	if wm.Vonage.APIKey != apiKey {
+		changes = append(changes, instance.ChangeSMSConfigVonageAPIKey(apiKey))
+	}
+	if wm.Vonage.SenderNumber != senderNumber {
+		changes = append(changes, instance.ChangeSMSConfigVonageSenderNumber(senderNumber))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMSConfigVonageChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+// IAMSMSConfigActiveWriteModel contains the ids of the active sms configs of the instance
+type IAMSMSConfigActiveWriteModel struct {
+	eventstore.WriteModel
+
+	ActiveIDs []string
+}
+
+func NewIAMSMSConfigActiveWriteModel(instanceID string) *IAMSMSConfigActiveWriteModel {
+	return &IAMSMSConfigActiveWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   instanceID,
+			ResourceOwner: instanceID,
+		},
+	}
+}
+
+func (wm *IAMSMSConfigActiveWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.SMSConfigActivatedEvent:
+			wm.removeActiveID(e.ID)
+			wm.ActiveIDs = append(wm.ActiveIDs, e.ID)
+		case *instance.SMSConfigDeactivatedEvent:
+			wm.removeActiveID(e.ID)
+		case *instance.SMSConfigRemovedEvent:
+			wm.removeActiveID(e.ID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IAMSMSConfigActiveWriteModel) removeActiveID(id string) {
+	for i, activeID := range wm.ActiveIDs {
+		if activeID == id {
+			wm.ActiveIDs = append(wm.ActiveIDs[:i], wm.ActiveIDs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (wm *IAMSMSConfigActiveWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.SMSConfigActivatedEventType,
+			instance.SMSConfigDeactivatedEventType,
+			instance.SMSConfigRemovedEventType).
+		Builder()
+}
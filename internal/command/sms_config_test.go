@@ -15,6 +15,8 @@ import (
 	"github.com/zitadel/zitadel/internal/id"
 	id_mock "github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/vonage"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
@@ -267,6 +269,332 @@ func TestCommandSide_ChangeSMSConfigTwilio(t *testing.T) {
 	}
 }
 
+func TestCommandSide_AddSMSConfigHTTP(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx        context.Context
+		instanceID string
+		sms        *webhook.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid endpoint, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &webhook.Config{
+					Endpoint: "no url",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid body template, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &webhook.Config{
+					Endpoint:     "https://sms.example.com",
+					BodyTemplate: "{{.Content",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add sms config http, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(instance.NewSMSConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"https://sms.example.com",
+								`{"text":{{json .Content}}}`,
+								"senderName",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte(`{"Authorization":"Bearer token"}`),
+								},
+							),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &webhook.Config{
+					Endpoint:     "https://sms.example.com",
+					Headers:      map[string]string{"Authorization": "Bearer token"},
+					BodyTemplate: `{"text":{{json .Content}}}`,
+					SenderNumber: "senderName",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				idGenerator:   tt.fields.idGenerator,
+				smsEncryption: tt.fields.alg,
+			}
+			_, got, err := r.AddSMSConfigHTTP(tt.args.ctx, tt.args.instanceID, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AddSMSConfigVonage(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx        context.Context
+		instanceID string
+		sms        *vonage.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "add sms config vonage, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(instance.NewSMSConfigVonageAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"apiKey",
+								"senderName",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("apiSecret"),
+								},
+							),
+							),
+						},
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				sms: &vonage.Config{
+					APIKey:       "apiKey",
+					APISecret:    "apiSecret",
+					SenderNumber: "senderName",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore,
+				idGenerator:   tt.fields.idGenerator,
+				smsEncryption: tt.fields.alg,
+			}
+			_, got, err := r.AddSMSConfigVonage(tt.args.ctx, tt.args.instanceID, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMSConfigVonage(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx        context.Context
+		instanceID string
+		id         string
+		sms        *vonage.Config
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "twilio config, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigTwilioAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"sid",
+								"senderName",
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				sms:        &vonage.Config{},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "sms config vonage change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigVonageAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"apiKey",
+								"senderName",
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newSMSConfigVonageChangedEvent(
+									context.Background(),
+									"providerid",
+									"apiKey2",
+									"senderName2",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &vonage.Config{
+					APIKey:       "apiKey2",
+					SenderNumber: "senderName2",
+				},
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ChangeSMSConfigVonage(tt.args.ctx, tt.args.instanceID, tt.args.id, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
 func TestCommandSide_ActivateSMSConfigTwilio(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -334,10 +662,11 @@ func TestCommandSide_ActivateSMSConfigTwilio(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectPush(
 						[]*repository.Event{
 							eventFromEventPusher(
-								instance.NewSMSConfigTwilioActivatedEvent(
+								instance.NewSMSConfigActivatedEvent(
 									context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									"providerid",
@@ -358,6 +687,170 @@ func TestCommandSide_ActivateSMSConfigTwilio(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "sms config already active, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigTwilioAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"sid",
+								"sender-name",
+								&crypto.CryptoValue{},
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMSConfigActivatedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				id:         "providerid",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "switch from twilio to http, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"httpid",
+								"https://sms.example.com",
+								"",
+								"sender-name",
+								nil,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigActivatedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"twilioid",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								instance.NewSMSConfigDeactivatedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"twilioid",
+								),
+							),
+							eventFromEventPusher(
+								instance.NewSMSConfigActivatedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"httpid",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				id:         "httpid",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "switch from http to vonage after twilio was deactivated, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigVonageAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"vonageid",
+								"apikey",
+								"sender-name",
+								&crypto.CryptoValue{},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigActivatedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"twilioid",
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMSConfigDeactivatedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"twilioid",
+							),
+						),
+						eventFromEventPusher(
+							instance.NewSMSConfigActivatedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"httpid",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								instance.NewSMSConfigDeactivatedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"httpid",
+								),
+							),
+							eventFromEventPusher(
+								instance.NewSMSConfigActivatedEvent(
+									context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"vonageid",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:        context.Background(),
+				instanceID: "INSTANCE",
+				id:         "vonageid",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -445,7 +938,7 @@ func TestCommandSide_DeactivateSMSConfigTwilio(t *testing.T) {
 							),
 						),
 						eventFromEventPusher(
-							instance.NewSMSConfigTwilioActivatedEvent(
+							instance.NewSMSConfigActivatedEvent(
 								context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								"providerid",
@@ -619,3 +1112,16 @@ func newSMSConfigTwilioChangedEvent(ctx context.Context, id, sid, senderName str
 	)
 	return event
 }
+
+func newSMSConfigVonageChangedEvent(ctx context.Context, id, apiKey, senderName string) *instance.SMSConfigVonageChangedEvent {
+	changes := []instance.SMSConfigVonageChanges{
+		instance.ChangeSMSConfigVonageAPIKey(apiKey),
+		instance.ChangeSMSConfigVonageSenderNumber(senderName),
+	}
+	event, _ := instance.NewSMSConfigVonageChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		id,
+		changes,
+	)
+	return event
+}
@@ -6,6 +6,7 @@ import (
 
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/breachedpassword"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -70,7 +71,7 @@ func (c *Commands) AddHumanWithID(ctx context.Context, resourceOwner string, use
 
 func (c *Commands) addHumanWithID(ctx context.Context, resourceOwner string, userID string, human *AddHuman) (*domain.HumanDetails, error) {
 	agg := user.NewAggregate(userID, resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, AddHumanCommand(agg, human, c.userPasswordAlg, c.userEncryption))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, AddHumanCommand(agg, human, c.userPasswordAlg, c.userEncryption, c.breachedPasswords))
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +109,7 @@ type humanCreationCommand interface {
 	AddPasswordData(secret *crypto.CryptoValue, changeRequired bool)
 }
 
-func AddHumanCommand(a *user.Aggregate, human *AddHuman, passwordAlg crypto.HashAlgorithm, codeAlg crypto.EncryptionAlgorithm) preparation.Validation {
+func AddHumanCommand(a *user.Aggregate, human *AddHuman, passwordAlg crypto.HashAlgorithm, codeAlg crypto.EncryptionAlgorithm, breachedPasswords *breachedpassword.Checker) preparation.Validation {
 	return func() (_ preparation.CreateCommands, err error) {
 		if err := human.Email.Validate(); err != nil {
 			return nil, err
@@ -177,7 +178,7 @@ func AddHumanCommand(a *user.Aggregate, human *AddHuman, passwordAlg crypto.Hash
 			}
 
 			if human.Password != "" {
-				if err = humanValidatePassword(ctx, filter, human.Password); err != nil {
+				if err = humanValidatePassword(ctx, filter, breachedPasswords, human.Password); err != nil {
 					return nil, err
 				}
 
@@ -259,13 +260,16 @@ func userValidateDomain(ctx context.Context, a *user.Aggregate, username string,
 	return nil
 }
 
-func humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string) error {
+func humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, breachedPasswords *breachedpassword.Checker, password string) error {
 	passwordComplexity, err := passwordComplexityPolicyWriteModel(ctx, filter)
 	if err != nil {
 		return err
 	}
 
-	return passwordComplexity.Validate(password)
+	if err = passwordComplexity.Validate(password); err != nil {
+		return err
+	}
+	return checkPasswordBreached(ctx, breachedPasswords, passwordComplexity.CheckBreached, password)
 }
 
 func (h *AddHuman) ensureDisplayName() {
@@ -441,6 +445,12 @@ func (c *Commands) createHuman(ctx context.Context, orgID string, human *domain.
 	if err := human.CheckDomainPolicy(domainPolicy); err != nil {
 		return nil, nil, err
 	}
+	// hashed passwords of other systems are stored as is, but must be parsable and verifiable on login
+	if human.HashedPassword != nil {
+		if err := crypto.CheckHash(human.HashedPassword.SecretCrypto, c.userPasswordAlg); err != nil {
+			return nil, nil, errors.ThrowInvalidArgument(err, "COMMAND-Gs3fw", "Errors.User.Password.HashAlgorithmNotSupported")
+		}
+	}
 	human.Username = strings.TrimSpace(human.Username)
 	human.EmailAddress = human.EmailAddress.Normalize()
 	if !domainPolicy.UserLoginMustBeDomain {
@@ -466,7 +476,14 @@ func (c *Commands) createHuman(ctx context.Context, orgID string, human *domain.
 
 	human.SetNamesAsDisplayname()
 	if human.Password != nil {
-		if err := human.HashPasswordIfExisting(pwPolicy, c.userPasswordAlg, human.Password.ChangeRequired); err != nil {
+		if err := human.Password.CheckPolicy(pwPolicy); err != nil {
+			return nil, nil, err
+		}
+		if err := checkPasswordBreached(ctx, c.breachedPasswords, pwPolicy.CheckBreached, human.Password.SecretString); err != nil {
+			return nil, nil, err
+		}
+		// the password is only hashed after all checks passed
+		if err := human.HashPasswordIfExisting(c.userPasswordAlg, human.Password.ChangeRequired); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// unlockedHumanLockoutWriteModel returns the lockout state of the user
+// or an error if the user is locked and therefore not allowed to check any (further) factors.
+// A user locked temporarily by the lockout policy is unlocked, as soon as the lockout duration has passed.
+func (c *Commands) unlockedHumanLockoutWriteModel(ctx context.Context, userID, resourceOwner string) (*HumanLockoutWriteModel, error) {
+	lockout, err := c.humanLockoutWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if lockout.UserState != domain.UserStateLocked {
+		return lockout, nil
+	}
+	if lockout.LockoutDuration == 0 {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gk3ds", "Errors.User.Locked")
+	}
+	if !lockout.lockoutExpired() {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Zu3wf", "Errors.User.LockedTemporarily")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewUserUnlockedEvent(ctx, UserAggregateFromWriteModel(&lockout.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(lockout, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return lockout, nil
+}
+
+// pushHumanMFACheckFailed pushes the failed second factor check
+// and locks the user if the failed checks reached the max otp attempts of the lockout policy.
+// It returns the error to be returned to the user.
+func (c *Commands) pushHumanMFACheckFailed(ctx context.Context, lockout *HumanLockoutWriteModel, lockoutPolicy *domain.LockoutPolicy, checkFailed eventstore.Command, checkErr error) error {
+	events := []eventstore.Command{checkFailed}
+	locked := lockoutPolicy != nil && lockoutPolicy.MaxOTPAttempts > 0 && lockout.OTPCheckFailedCount+1 >= lockoutPolicy.MaxOTPAttempts
+	if locked {
+		events = append(events, user.NewUserLockedEvent(ctx, UserAggregateFromWriteModel(&lockout.WriteModel), lockoutPolicy.LockoutDuration))
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
+	logging.WithFields("traceID", tracing.TraceIDFromCtx(ctx)).OnError(pushErr).Error("error create mfa check failed event")
+	if locked && lockoutPolicy.ShowLockOutFailures {
+		if lockoutPolicy.IsTemporary() {
+			return caos_errs.ThrowInvalidArgument(checkErr, "COMMAND-Rf3hs", "Errors.User.MFA.InvalidAndLockedTemporarily")
+		}
+		return caos_errs.ThrowInvalidArgument(checkErr, "COMMAND-Kd8wq", "Errors.User.MFA.InvalidAndLocked")
+	}
+	return checkErr
+}
+
+func (c *Commands) humanLockoutWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanLockoutWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanLockoutWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanLockoutWriteModel tracks the lock of a human user
+// and the failed second factor checks since the last successful one
+type HumanLockoutWriteModel struct {
+	eventstore.WriteModel
+
+	UserState           domain.UserState
+	LockedDate          time.Time
+	LockoutDuration     time.Duration
+	OTPCheckFailedCount uint64
+}
+
+func NewHumanLockoutWriteModel(userID, resourceOwner string) *HumanLockoutWriteModel {
+	return &HumanLockoutWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanLockoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.UserLockedEvent:
+			wm.UserState = domain.UserStateLocked
+			wm.LockedDate = e.CreationDate()
+			wm.LockoutDuration = e.LockoutDuration
+		case *user.UserUnlockedEvent:
+			wm.UserState = domain.UserStateActive
+			wm.LockedDate = time.Time{}
+			wm.LockoutDuration = 0
+			wm.OTPCheckFailedCount = 0
+		case *user.HumanOTPCheckFailedEvent,
+			*user.HumanOTPSMSCheckFailedEvent,
+			*user.HumanOTPEmailCheckFailedEvent,
+			*user.HumanU2FCheckFailedEvent:
+			wm.OTPCheckFailedCount += 1
+		case *user.HumanOTPCheckSucceededEvent,
+			*user.HumanOTPSMSCheckSucceededEvent,
+			*user.HumanOTPEmailCheckSucceededEvent,
+			*user.HumanU2FCheckSucceededEvent:
+			wm.OTPCheckFailedCount = 0
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanLockoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanMFAOTPSMSCheckFailedType,
+			user.HumanMFAOTPSMSCheckSucceededType,
+			user.HumanMFAOTPEmailCheckFailedType,
+			user.HumanMFAOTPEmailCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.UserRemovedType,
+			user.UserV1AddedType,
+			user.UserV1RegisteredType,
+			user.UserV1MFAOTPCheckFailedType,
+			user.UserV1MFAOTPCheckSucceededType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// lockoutExpired returns true if the user was locked temporarily and the lockout duration has passed
+func (wm *HumanLockoutWriteModel) lockoutExpired() bool {
+	return wm.UserState == domain.UserStateLocked &&
+		wm.LockoutDuration > 0 &&
+		!wm.LockedDate.Add(wm.LockoutDuration).After(time.Now())
+}
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_unlockedHumanLockoutWriteModel(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	lockedEvent := func(lockoutDuration time.Duration, lockedAt time.Time) *repository.Event {
+		event := eventFromEventPusher(
+			user.NewUserLockedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				lockoutDuration,
+			),
+		)
+		event.CreationDate = lockedAt
+		return event
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "user not locked, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{},
+		},
+		{
+			name: "user locked permanently, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						lockedEvent(0, time.Now().Add(-24*time.Hour)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return caos_errs.IsPreconditionFailed(err) && caos_errs.Contains(err, "Errors.User.Locked")
+				},
+			},
+		},
+		{
+			name: "lockout duration not passed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						lockedEvent(time.Hour, time.Now().Add(-time.Minute)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: func(err error) bool {
+					return caos_errs.IsPreconditionFailed(err) && caos_errs.Contains(err, "Errors.User.LockedTemporarily")
+				},
+			},
+		},
+		{
+			name: "lockout duration passed, unlocked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						lockedEvent(time.Hour, time.Now().Add(-2*time.Hour)),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewUserUnlockedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			_, err := r.unlockedHumanLockoutWriteModel(tt.args.ctx, tt.args.userID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
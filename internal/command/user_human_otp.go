@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 
@@ -13,6 +14,17 @@ import (
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
+// defaultOTPCodeConfig is used to generate the otp sms and email codes on instances,
+// which were set up before the corresponding secret generators existed
+var defaultOTPCodeConfig = &crypto.GeneratorConfig{
+	Length:        8,
+	Expiry:        5 * time.Minute,
+	IncludeDigits: true,
+}
+
+// otpCodeResendInterval is the minimum time between two otp sms or email codes of a user
+const otpCodeResendInterval = 30 * time.Second
+
 func (c *Commands) ImportHumanOTP(ctx context.Context, userID, userAgentID, resourceowner string, key string) error {
 	encryptedSecret, err := crypto.Encrypt([]byte(key), c.multifactors.OTP.CryptoMFA)
 	if err != nil {
@@ -120,7 +132,7 @@ func (c *Commands) HumanCheckMFAOTPSetup(ctx context.Context, userID, code, user
 	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
 }
 
-func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceowner string, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceowner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	if userID == "" {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-8N9ds", "Errors.User.UserIDMissing")
 	}
@@ -131,15 +143,17 @@ func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceo
 	if existingOTP.State != domain.MFAStateReady {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-3Mif9s", "Errors.User.MFA.OTP.NotReady")
 	}
+	lockout, err := c.unlockedHumanLockoutWriteModel(ctx, userID, resourceowner)
+	if err != nil {
+		return err
+	}
 	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
 	err = domain.VerifyMFAOTP(code, existingOTP.Secret, c.multifactors.OTP.CryptoMFA)
 	if err == nil {
 		_, err = c.eventstore.Push(ctx, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
 		return err
 	}
-	_, pushErr := c.eventstore.Push(ctx, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
-	logging.Log("COMMAND-9fj7s").OnError(pushErr).Error("error create password check failed event")
-	return err
+	return c.pushHumanMFACheckFailed(ctx, lockout, lockoutPolicy, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), err)
 }
 
 func (c *Commands) HumanRemoveOTP(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
@@ -166,6 +180,254 @@ func (c *Commands) HumanRemoveOTP(ctx context.Context, userID, resourceOwner str
 	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
 }
 
+func (c *Commands) AddHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sf3gq", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingOTP.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Qk2fw", "Errors.User.MFA.OTP.AlreadyReady")
+	}
+	if !existingOTP.IsPhoneVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ws3fg", "Errors.User.Phone.NotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingOTP, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gd2fs", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Bt3gd", "Errors.User.MFA.OTP.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingOTP, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
+}
+
+// HumanSendOTPSMS creates a new code, which will be sent to the verified phone of the user by the notification handler
+func (c *Commands) HumanSendOTPSMS(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Mw2dg", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady || !existingOTP.IsPhoneVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Pj3fw", "Errors.User.MFA.OTP.NotReady")
+	}
+	if existingOTP.Code != nil && time.Since(existingOTP.CodeCreationDate) < otpCodeResendInterval {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Sq2fw", "Errors.User.Code.ResendTooEarly")
+	}
+	code, expiry, err := newCryptoCodeWithExpiryAndDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPSMS, c.userEncryption, defaultOTPCodeConfig)
+	if err != nil {
+		return err
+	}
+	var info *user.AuthRequestInfo
+	if authRequest != nil {
+		info = authRequestDomainToAuthRequestInfo(authRequest)
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeAddedEvent(ctx, userAgg, code, expiry, info))
+	return err
+}
+
+func (c *Commands) HumanOTPSMSCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hs2dq", "Errors.User.MFA.OTP.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) HumanCheckOTPSMS(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if userID == "" {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Dc3fe", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Zr2gs", "Errors.User.Code.Empty")
+	}
+	existingOTP, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ud3gw", "Errors.User.MFA.OTP.NotReady")
+	}
+	if existingOTP.Code == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ko2vs", "Errors.User.Code.NotFound")
+	}
+	// the code can only be used in the auth request it was sent for
+	if existingOTP.CodeAuthRequestID != "" && (authRequest == nil || authRequest.ID != existingOTP.CodeAuthRequestID) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Hw3gs", "Errors.User.Code.NotFound")
+	}
+	lockout, err := c.unlockedHumanLockoutWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	err = crypto.VerifyCode(existingOTP.CodeCreationDate, existingOTP.CodeExpiry, existingOTP.Code, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	return c.pushHumanMFACheckFailed(ctx, lockout, lockoutPolicy, user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), err)
+}
+
+func (c *Commands) AddHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ab3ws", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingOTP.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Yw2ds", "Errors.User.MFA.OTP.AlreadyReady")
+	}
+	if !existingOTP.IsEmailVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nd3fs", "Errors.User.Email.NotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingOTP, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Re2gq", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Cx3fd", "Errors.User.MFA.OTP.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingOTP, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
+}
+
+// HumanSendOTPEmail creates a new code, which will be sent to the verified email of the user by the notification handler
+func (c *Commands) HumanSendOTPEmail(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vm2sf", "Errors.User.UserIDMissing")
+	}
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady || !existingOTP.IsEmailVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ek3gw", "Errors.User.MFA.OTP.NotReady")
+	}
+	if existingOTP.Code != nil && time.Since(existingOTP.CodeCreationDate) < otpCodeResendInterval {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Wz3fd", "Errors.User.Code.ResendTooEarly")
+	}
+	code, expiry, err := newCryptoCodeWithExpiryAndDefault(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPEmail, c.userEncryption, defaultOTPCodeConfig)
+	if err != nil {
+		return err
+	}
+	var info *user.AuthRequestInfo
+	if authRequest != nil {
+		info = authRequestDomainToAuthRequestInfo(authRequest)
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeAddedEvent(ctx, userAgg, code, expiry, info))
+	return err
+}
+
+func (c *Commands) HumanOTPEmailCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lq2fc", "Errors.User.MFA.OTP.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) HumanCheckOTPEmail(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if userID == "" {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ft3rw", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Jh2qg", "Errors.User.Code.Empty")
+	}
+	existingOTP, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if existingOTP.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Io3fd", "Errors.User.MFA.OTP.NotReady")
+	}
+	if existingOTP.Code == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Tn2gf", "Errors.User.Code.NotFound")
+	}
+	// the code can only be used in the auth request it was sent for
+	if existingOTP.CodeAuthRequestID != "" && (authRequest == nil || authRequest.ID != existingOTP.CodeAuthRequestID) {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Mq2sd", "Errors.User.Code.NotFound")
+	}
+	lockout, err := c.unlockedHumanLockoutWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&existingOTP.WriteModel)
+	err = crypto.VerifyCode(existingOTP.CodeCreationDate, existingOTP.CodeExpiry, existingOTP.Code, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	return c.pushHumanMFACheckFailed(ctx, lockout, lockoutPolicy, user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)), err)
+}
+
 func (c *Commands) otpWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPWriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -177,3 +439,27 @@ func (c *Commands) otpWriteModelByID(ctx context.Context, userID, resourceOwner
 	}
 	return writeModel, nil
 }
+
+func (c *Commands) otpSMSWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPSMSWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPSMSWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) otpEmailWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPEmailWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPEmailWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -59,3 +61,150 @@ func (wm *HumanOTPWriteModel) Query() *eventstore.SearchQueryBuilder {
 	}
 	return query
 }
+
+type HumanOTPSMSWriteModel struct {
+	eventstore.WriteModel
+
+	State           domain.MFAState
+	IsPhoneVerified bool
+
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+	// CodeAuthRequestID is the auth request the code was sent for
+	CodeAuthRequestID string
+}
+
+func NewHumanOTPSMSWriteModel(userID, resourceOwner string) *HumanOTPSMSWriteModel {
+	return &HumanOTPSMSWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanOTPSMSWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanPhoneVerifiedEvent:
+			wm.IsPhoneVerified = true
+		case *user.HumanPhoneChangedEvent,
+			*user.HumanPhoneRemovedEvent:
+			wm.IsPhoneVerified = false
+		case *user.HumanOTPSMSAddedEvent:
+			wm.State = domain.MFAStateReady
+		case *user.HumanOTPSMSRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		case *user.HumanOTPSMSCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+			wm.CodeAuthRequestID = ""
+			if e.AuthRequestInfo != nil {
+				wm.CodeAuthRequestID = e.AuthRequestInfo.ID
+			}
+		case *user.HumanOTPSMSCheckSucceededEvent:
+			wm.Code = nil
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanOTPSMSWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.UserV1PhoneChangedType,
+			user.HumanPhoneChangedType,
+			user.UserV1PhoneVerifiedType,
+			user.HumanPhoneVerifiedType,
+			user.UserV1PhoneRemovedType,
+			user.HumanPhoneRemovedType,
+			user.HumanMFAOTPSMSAddedType,
+			user.HumanMFAOTPSMSRemovedType,
+			user.HumanMFAOTPSMSCodeAddedType,
+			user.HumanMFAOTPSMSCheckSucceededType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+type HumanOTPEmailWriteModel struct {
+	eventstore.WriteModel
+
+	State           domain.MFAState
+	IsEmailVerified bool
+
+	Code             *crypto.CryptoValue
+	CodeCreationDate time.Time
+	CodeExpiry       time.Duration
+	// CodeAuthRequestID is the auth request the code was sent for
+	CodeAuthRequestID string
+}
+
+func NewHumanOTPEmailWriteModel(userID, resourceOwner string) *HumanOTPEmailWriteModel {
+	return &HumanOTPEmailWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanOTPEmailWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanEmailVerifiedEvent:
+			wm.IsEmailVerified = true
+		case *user.HumanEmailChangedEvent:
+			wm.IsEmailVerified = false
+		case *user.HumanOTPEmailAddedEvent:
+			wm.State = domain.MFAStateReady
+		case *user.HumanOTPEmailRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		case *user.HumanOTPEmailCodeAddedEvent:
+			wm.Code = e.Code
+			wm.CodeCreationDate = e.CreationDate()
+			wm.CodeExpiry = e.Expiry
+			wm.CodeAuthRequestID = ""
+			if e.AuthRequestInfo != nil {
+				wm.CodeAuthRequestID = e.AuthRequestInfo.ID
+			}
+		case *user.HumanOTPEmailCheckSucceededEvent:
+			wm.Code = nil
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanOTPEmailWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.UserV1EmailChangedType,
+			user.HumanEmailChangedType,
+			user.UserV1EmailVerifiedType,
+			user.HumanEmailVerifiedType,
+			user.HumanMFAOTPEmailAddedType,
+			user.HumanMFAOTPEmailRemovedType,
+			user.HumanMFAOTPEmailCodeAddedType,
+			user.HumanMFAOTPEmailCheckSucceededType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
@@ -142,12 +142,43 @@ func (c *Commands) changePassword(ctx context.Context, userAgentID string, passw
 	if err != nil {
 		return nil, err
 	}
-	if err := password.HashPasswordIfExisting(pwPolicy, c.userPasswordAlg); err != nil {
+	if err := password.CheckPolicy(pwPolicy); err != nil {
+		return nil, err
+	}
+	if err := checkPasswordBreached(ctx, c.breachedPasswords, pwPolicy.CheckBreached, password.SecretString); err != nil {
+		return nil, err
+	}
+	if err := c.checkPasswordHistory(ctx, userAgg.ResourceOwner, existingPassword, password.SecretString); err != nil {
+		return nil, err
+	}
+	// the password is only hashed after all checks passed
+	if err := password.HashPasswordIfExisting(c.userPasswordAlg); err != nil {
 		return nil, err
 	}
 	return user.NewHumanPasswordChangedEvent(ctx, userAgg, password.SecretCrypto, password.ChangeRequired, userAgentID), nil
 }
 
+// checkPasswordHistory returns an error if the password matches one of the recent passwords,
+// which must not be reused according to the password age policy
+func (c *Commands) checkPasswordHistory(ctx context.Context, orgID string, existingPassword *HumanPasswordWriteModel, password string) (err error) {
+	if password == "" || len(existingPassword.PasswordHistory) == 0 {
+		return nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	agePolicy, err := c.getOrgPasswordAgePolicy(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, secret := range existingPassword.RecentPasswords(agePolicy.HistoryCount) {
+		if crypto.CompareHash(secret, []byte(password), c.userPasswordAlg) == nil {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pa8hq", "Errors.User.Password.RecentlyUsed")
+		}
+	}
+	return nil
+}
+
 func (c *Commands) RequestSetPassword(ctx context.Context, userID, resourceOwner string, notifyType domain.NotificationType, passwordVerificationCode crypto.Generator) (objectDetails *domain.ObjectDetails, err error) {
 	if userID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-M00oL", "Errors.User.UserIDMissing")
@@ -232,6 +263,10 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 		return caos_errs.ThrowPreconditionFailed(err, "COMMAND-Dft32", "Errors.Org.LoginPolicy.UsernamePasswordNotAllowed")
 	}
 
+	// an expired lockout is lifted before the password is loaded, as the unlock resets the failed checks
+	if _, err = c.unlockedHumanLockoutWriteModel(ctx, userID, orgID); err != nil {
+		return err
+	}
 	existingPassword, err := c.passwordWriteModel(ctx, userID, orgID)
 	if err != nil {
 		return err
@@ -249,22 +284,44 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	err = crypto.CompareHash(existingPassword.Secret, []byte(password), c.userPasswordAlg)
 	spanPasswordComparison.EndWithError(err)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		events := []eventstore.Command{user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest))}
+		if crypto.NeedsRehash(existingPassword.Secret, c.userPasswordAlg) {
+			events = append(events, c.rehashPassword(ctx, userAgg, password)...)
+		}
+		_, err = c.eventstore.Push(ctx, events...)
 		return err
 	}
 	events := make([]eventstore.Command, 0)
 	events = append(events, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
-	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 {
-		if existingPassword.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
-			events = append(events, user.NewUserLockedEvent(ctx, userAgg))
-		}
-
+	locked := lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 && existingPassword.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts
+	if locked {
+		events = append(events, user.NewUserLockedEvent(ctx, userAgg, lockoutPolicy.LockoutDuration))
 	}
 	_, err = c.eventstore.Push(ctx, events...)
 	logging.Log("COMMAND-9fj7s").OnError(err).Error("error create password check failed event")
+	if locked && lockoutPolicy.ShowLockOutFailures {
+		if lockoutPolicy.IsTemporary() {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hs3gq", "Errors.User.Password.InvalidAndLockedTemporarily")
+		}
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sw3gq", "Errors.User.Password.InvalidAndLocked")
+	}
 	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-452ad", "Errors.User.Password.Invalid")
 }
 
+// rehashPassword hashes the (verified) password with the current password hasher,
+// so hashes of outdated algorithms or costs are upgraded on login.
+// A failed rehash must not prevent the login and is therefore only logged.
+func (c *Commands) rehashPassword(ctx context.Context, userAgg *eventstore.Aggregate, password string) []eventstore.Command {
+	ctx, span := tracing.NewNamedSpan(ctx, "crypto.Hash")
+	secret, err := crypto.Hash([]byte(password), c.userPasswordAlg)
+	span.EndWithError(err)
+	if err != nil {
+		logging.WithFields("userid", userAgg.ID).WithError(err).Warn("unable to rehash password")
+		return nil
+	}
+	return []eventstore.Command{user.NewHumanPasswordHashUpdatedEvent(ctx, userAgg, secret)}
+}
+
 func (c *Commands) passwordWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPasswordWriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -15,6 +15,9 @@ type HumanPasswordWriteModel struct {
 
 	Secret               *crypto.CryptoValue
 	SecretChangeRequired bool
+	// PasswordHistory contains the hashes of the previous passwords including the current one (oldest first),
+	// limited to domain.PasswordHistoryMaxCount
+	PasswordHistory []*crypto.CryptoValue
 
 	Code                     *crypto.CryptoValue
 	CodeCreationDate         time.Time
@@ -40,10 +43,12 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			wm.Secret = e.Secret
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.UserState = domain.UserStateActive
+			wm.appendPasswordHistory(e.Secret)
 		case *user.HumanRegisteredEvent:
 			wm.Secret = e.Secret
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.UserState = domain.UserStateActive
+			wm.appendPasswordHistory(e.Secret)
 		case *user.HumanInitialCodeAddedEvent:
 			wm.UserState = domain.UserStateInitial
 		case *user.HumanInitializedCheckSucceededEvent:
@@ -53,6 +58,13 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.Code = nil
 			wm.PasswordCheckFailedCount = 0
+			wm.appendPasswordHistory(e.Secret)
+		case *user.HumanPasswordHashUpdatedEvent:
+			wm.Secret = e.Secret
+			// the current password was rehashed
+			if len(wm.PasswordHistory) > 0 {
+				wm.PasswordHistory[len(wm.PasswordHistory)-1] = e.Secret
+			}
 		case *user.HumanPasswordCodeAddedEvent:
 			wm.Code = e.Code
 			wm.CodeCreationDate = e.CreationDate()
@@ -74,6 +86,24 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+func (wm *HumanPasswordWriteModel) appendPasswordHistory(secret *crypto.CryptoValue) {
+	if secret == nil {
+		return
+	}
+	wm.PasswordHistory = append(wm.PasswordHistory, secret)
+	if len(wm.PasswordHistory) > domain.PasswordHistoryMaxCount {
+		wm.PasswordHistory = wm.PasswordHistory[len(wm.PasswordHistory)-domain.PasswordHistoryMaxCount:]
+	}
+}
+
+// RecentPasswords returns the hashes of the last count passwords including the current one
+func (wm *HumanPasswordWriteModel) RecentPasswords(count uint64) []*crypto.CryptoValue {
+	if uint64(len(wm.PasswordHistory)) <= count {
+		return wm.PasswordHistory
+	}
+	return wm.PasswordHistory[uint64(len(wm.PasswordHistory))-count:]
+}
+
 func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
@@ -84,6 +114,7 @@ func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanInitialCodeAddedType,
 			user.HumanInitializedCheckSucceededType,
 			user.HumanPasswordChangedType,
+			user.HumanPasswordHashUpdatedType,
 			user.HumanPasswordCodeAddedType,
 			user.HumanEmailVerifiedType,
 			user.HumanPasswordCheckFailedType,
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/breachedpassword"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
@@ -18,10 +19,24 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/user"
 )
 
+// breachedPasswordsSource returns the SHA-1 suffixes of "password" and "123456"
+type breachedPasswordsSource struct{}
+
+func (breachedPasswordsSource) Range(_ context.Context, prefix string) ([]string, error) {
+	switch prefix {
+	case "5BAA6":
+		return []string{"1E4C9B93F3F0682250B6CF8331B7EE68FD8"}, nil
+	case "7C4A8":
+		return []string{"D09CA3762AF61E59520943DC26494F8941B"}, nil
+	}
+	return nil, nil
+}
+
 func TestCommandSide_SetOneTimePassword(t *testing.T) {
 	type fields struct {
-		eventstore      *eventstore.Eventstore
-		userPasswordAlg crypto.HashAlgorithm
+		eventstore        *eventstore.Eventstore
+		userPasswordAlg   crypto.HashAlgorithm
+		breachedPasswords *breachedpassword.Checker
 	}
 	type args struct {
 		ctx           context.Context
@@ -72,6 +87,60 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 				err: caos_errs.IsPreconditionFailed,
 			},
 		},
+		{
+			name: "breached password, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+								true,
+							),
+						),
+					),
+				),
+				userPasswordAlg:   crypto.CreateMockHashAlg(gomock.NewController(t)),
+				breachedPasswords: breachedpassword.NewChecker(breachedPasswordsSource{}),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				password:      "password",
+				oneTime:       true,
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "change password onetime, ok",
 			fields: fields{
@@ -107,6 +176,7 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -178,6 +248,7 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -218,8 +289,9 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore:      tt.fields.eventstore,
-				userPasswordAlg: tt.fields.userPasswordAlg,
+				eventstore:        tt.fields.eventstore,
+				userPasswordAlg:   tt.fields.userPasswordAlg,
+				breachedPasswords: tt.fields.breachedPasswords,
 			}
 			got, err := r.SetPassword(tt.args.ctx, tt.args.resourceOwner, tt.args.userID, tt.args.password, tt.args.oneTime)
 			if tt.res.err == nil {
@@ -438,6 +510,7 @@ func TestCommandSide_SetPassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -672,6 +745,91 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "password recently used, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPasswordChangedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "hash",
+									KeyID:      "",
+									Crypted:    []byte("password"),
+								},
+								false,
+								"")),
+						eventFromEventPusher(
+							user.NewHumanPasswordChangedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "hash",
+									KeyID:      "",
+									Crypted:    []byte("password1"),
+								},
+								false,
+								"")),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordAgePolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								0,
+								0,
+								2,
+							),
+						),
+					),
+				),
+				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				oldPassword:   "password1",
+				newPassword:   "password",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "change password, ok",
 			fields: fields{
@@ -718,6 +876,17 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordAgePolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								0,
+								0,
+								1,
 							),
 						),
 					),
@@ -1182,6 +1351,56 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 				err: caos_errs.IsPreconditionFailed,
 			},
 		},
+		{
+			name: "user locked, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								domain.PasswordlessTypeNotAllowed,
+								"",
+								time.Hour*1,
+								time.Hour*2,
+								time.Hour*3,
+								time.Hour*4,
+								time.Hour*5,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserLockedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								0,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				password:      "password",
+			},
+			res: res{
+				err: func(err error) bool {
+					return caos_errs.IsPreconditionFailed(err) && caos_errs.Contains(err, "Errors.User.Locked")
+				},
+			},
+		},
 		{
 			name: "user not existing, precondition error",
 			fields: fields{
@@ -1211,6 +1430,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 						),
 					),
 					expectFilter(),
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -1251,6 +1471,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							user.NewHumanAddedEvent(context.Background(),
@@ -1308,6 +1529,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							user.NewHumanAddedEvent(context.Background(),
@@ -1399,6 +1621,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							user.NewHumanAddedEvent(context.Background(),
@@ -1445,6 +1668,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 							eventFromEventPusher(
 								user.NewUserLockedEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
+									0,
 								),
 							),
 						},
@@ -1497,6 +1721,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectFilter(
 						eventFromEventPusher(
 							user.NewHumanAddedEvent(context.Background(),
@@ -1557,6 +1782,105 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 			},
 			res: res{},
 		},
+		{
+			name: "check password, outdated hash, rehashed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLoginPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
+								domain.PasswordlessTypeNotAllowed,
+								"",
+								time.Hour*1,
+								time.Hour*2,
+								time.Hour*3,
+								time.Hour*4,
+								time.Hour*5,
+							),
+						),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanEmailVerifiedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanPasswordChangedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeHash,
+									Algorithm:  "hash",
+									KeyID:      "",
+									Crypted:    []byte("password"),
+								},
+								false,
+								"")),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanPasswordCheckSucceededEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&user.AuthRequestInfo{
+										ID:          "request1",
+										UserAgentID: "agent1",
+									},
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanPasswordHashUpdatedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeHash,
+										Algorithm:  "hash",
+										Crypted:    []byte("password"),
+									},
+								),
+							),
+						},
+					),
+				),
+				userPasswordAlg: &rehashingHashAlg{crypto.CreateMockHashAlg(gomock.NewController(t))},
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				password:      "password",
+				authReq: &domain.AuthRequest{
+					ID:      "request1",
+					AgentID: "agent1",
+				},
+			},
+			res: res{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1574,3 +1898,12 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 		})
 	}
 }
+
+// rehashingHashAlg is a mock hash algorithm, which requires every hash to be rehashed
+type rehashingHashAlg struct {
+	crypto.HashAlgorithm
+}
+
+func (*rehashingHashAlg) NeedsRehash([]byte) bool {
+	return true
+}
@@ -273,6 +273,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -364,6 +365,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -434,6 +436,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -504,6 +507,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -631,6 +635,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -722,6 +727,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1049,6 +1055,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1068,6 +1075,57 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				err: errors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "hashed password algorithm not supported, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								true,
+								true,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+								false,
+							),
+						),
+					),
+				),
+				userPasswordAlg: crypto.NewBCrypt(4),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				human: &domain.Human{
+					Username: "username",
+					Profile: &domain.Profile{
+						FirstName: "firstname",
+						LastName:  "lastname",
+					},
+					Email: &domain.Email{
+						EmailAddress:    "email@test.ch",
+						IsEmailVerified: true,
+					},
+					HashedPassword: domain.NewHashedPassword("5f4dcc3b5aa765d61d8327deb882cf99", "md5"),
+				},
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "add human (with password and initial code), ok",
 			fields: fields{
@@ -1092,6 +1150,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1183,6 +1242,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1268,6 +1328,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1375,6 +1436,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1486,6 +1548,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1593,6 +1656,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1695,6 +1759,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1966,6 +2031,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2014,6 +2080,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2083,6 +2150,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2152,6 +2220,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2238,6 +2307,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2382,6 +2452,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2494,6 +2565,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2606,6 +2678,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2712,6 +2785,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2840,6 +2914,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -2963,6 +3038,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -3572,6 +3648,7 @@ func TestAddHumanCommand(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
 								),
 							}, nil
 						}).
@@ -3618,6 +3695,7 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									false,
 								),
 							}, nil
 						}).
@@ -3654,7 +3732,7 @@ func TestAddHumanCommand(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			AssertValidation(t, context.Background(), AddHumanCommand(tt.args.a, tt.args.human, tt.args.passwordAlg, tt.args.codeAlg), tt.args.filter, tt.want)
+			AssertValidation(t, context.Background(), AddHumanCommand(tt.args.a, tt.args.human, tt.args.passwordAlg, tt.args.codeAlg, nil), tt.args.filter, tt.want)
 		})
 	}
 }
@@ -357,7 +357,7 @@ func (c *Commands) beginWebAuthNLogin(ctx context.Context, userID, resourceOwner
 	return userAgg, webAuthNLogin, nil
 }
 
-func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwner string, credentialData []byte, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	webAuthNLogin, err := c.getHumanU2FLogin(ctx, userID, authRequest.ID, resourceOwner)
 	if err != nil {
 		return err
@@ -366,6 +366,10 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 	if err != nil {
 		return err
 	}
+	lockout, err := c.unlockedHumanLockoutWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
 
 	userAgg, token, signCount, err := c.finishWebAuthNLogin(ctx, userID, resourceOwner, credentialData, webAuthNLogin, u2fTokens)
 	if err != nil {
@@ -373,15 +377,14 @@ func (c *Commands) HumanFinishU2FLogin(ctx context.Context, userID, resourceOwne
 			logging.WithFields("userID", userID, "resourceOwner", resourceOwner).WithError(err).Warn("missing userAggregate for pushing failed u2f check event")
 			return err
 		}
-		_, pushErr := c.eventstore.Push(ctx,
+		return c.pushHumanMFACheckFailed(ctx, lockout, lockoutPolicy,
 			usr_repo.NewHumanU2FCheckFailedEvent(
 				ctx,
 				userAgg,
 				authRequestDomainToAuthRequestInfo(authRequest),
 			),
+			err,
 		)
-		logging.WithFields("userID", userID, "resourceOwner", resourceOwner).OnError(pushErr).Warn("could not push failed u2f check event")
-		return err
 	}
 
 	_, err = c.eventstore.Push(ctx,
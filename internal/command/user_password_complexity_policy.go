@@ -3,7 +3,10 @@ package command
 import (
 	"context"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/breachedpassword"
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/errors"
 )
@@ -47,3 +50,20 @@ func defaultPasswordComplexityPolicy(ctx context.Context, filter preparation.Fil
 	err = policy.Reduce()
 	return &policy.PasswordComplexityPolicyWriteModel, err
 }
+
+// checkPasswordBreached returns an error if the policy requires the check and the password is part of the breached passwords.
+// Passwords are accepted if the source of the breached passwords is unavailable, so that users are not locked out by its outage.
+func checkPasswordBreached(ctx context.Context, checker *breachedpassword.Checker, checkBreached bool, password string) error {
+	if !checkBreached || password == "" {
+		return nil
+	}
+	breached, err := checker.IsBreached(ctx, password)
+	if err != nil {
+		logging.WithError(err).Warn("unable to check password against breached passwords")
+		return nil
+	}
+	if breached {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Bp4sw", "Errors.User.PasswordComplexityPolicy.Breached")
+	}
+	return nil
+}
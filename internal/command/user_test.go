@@ -796,6 +796,7 @@ func TestCommandSide_LockUser(t *testing.T) {
 						eventFromEventPusher(
 							user.NewUserLockedEvent(context.Background(),
 								&user.NewAggregate("user1", "org1").Aggregate,
+								0,
 							),
 						),
 					),
@@ -836,6 +837,7 @@ func TestCommandSide_LockUser(t *testing.T) {
 							eventFromEventPusher(
 								user.NewUserLockedEvent(context.Background(),
 									&user.NewAggregate("user1", "org1").Aggregate,
+									0,
 								),
 							),
 						},
@@ -981,7 +983,7 @@ func TestCommandSide_UnlockUser(t *testing.T) {
 						),
 						eventFromEventPusher(
 							user.NewUserLockedEvent(context.Background(),
-								&user.NewAggregate("user1", "org1").Aggregate),
+								&user.NewAggregate("user1", "org1").Aggregate, 0),
 						),
 					),
 					expectPush(
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// ExchangeUserToken creates a new access token for the user by token exchange (RFC 8693).
+// If an actor is provided, the token is issued to the actor in the name of the user,
+// which will be recorded on the user as impersonation.
+func (c *Commands) ExchangeUserToken(ctx context.Context, orgID, clientID, userID, actorUserID, actorOrgID string, audience, scopes []string, lifetime time.Duration) (*domain.Token, error) {
+	if userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Hg3sq", "Errors.IDMissing")
+	}
+	if actorUserID == userID {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Jg3fw", "Errors.User.Impersonation.SameUser")
+	}
+	userWriteModel := NewUserWriteModel(userID, orgID)
+	event, accessToken, err := c.addUserToken(ctx, userWriteModel, "", clientID, "", audience, scopes, lifetime)
+	if err != nil {
+		return nil, err
+	}
+	cmds := []eventstore.Command{event}
+	if actorUserID != "" {
+		userAgg := UserAggregateFromWriteModel(&userWriteModel.WriteModel)
+		cmds = append(cmds, user.NewUserImpersonatedEvent(ctx, userAgg, accessToken.TokenID, clientID, actorUserID, actorOrgID))
+	}
+	_, err = c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return accessToken, nil
+}
@@ -0,0 +1,236 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+// AddWebhook adds a webhook for the resource owner (organisation or instance)
+// and returns the generated signing key, which will only be returned once
+func (c *Commands) AddWebhook(ctx context.Context, addWebhook *domain.Webhook, resourceOwner string) (_ string, _ string, _ *domain.ObjectDetails, err error) {
+	if !addWebhook.IsValid() {
+		return "", "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Nf3g1", "Errors.Webhook.Invalid")
+	}
+	if err = c.checkWebhookFilter(addWebhook.AggregateTypes, addWebhook.EventTypes); err != nil {
+		return "", "", nil, err
+	}
+	webhookID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", "", nil, err
+	}
+	signingKey, plainSigningKey, err := crypto.NewCode(c.webhookSigningKeyGenerator)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	webhookModel := NewWebhookWriteModel(webhookID, resourceOwner)
+	webhookAgg := WebhookAggregateFromWriteModel(&webhookModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewAddedEvent(
+		ctx,
+		webhookAgg,
+		addWebhook.Name,
+		addWebhook.URL,
+		addWebhook.AggregateTypes,
+		addWebhook.EventTypes,
+		signingKey,
+	))
+	if err != nil {
+		return "", "", nil, err
+	}
+	err = AppendAndReduce(webhookModel, pushedEvents...)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return webhookModel.AggregateID, plainSigningKey, writeModelToObjectDetails(&webhookModel.WriteModel), nil
+}
+
+func (c *Commands) ChangeWebhook(ctx context.Context, webhookChange *domain.Webhook, resourceOwner string) (*domain.ObjectDetails, error) {
+	if !webhookChange.IsValid() || webhookChange.AggregateID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ml2ks", "Errors.Webhook.Invalid")
+	}
+	if err := c.checkWebhookFilter(webhookChange.AggregateTypes, webhookChange.EventTypes); err != nil {
+		return nil, err
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookChange.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sd3fq", "Errors.Webhook.NotFound")
+	}
+
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	changedEvent, err := existingWebhook.NewChangedEvent(
+		ctx,
+		webhookAgg,
+		webhookChange.Name,
+		webhookChange.URL,
+		webhookChange.AggregateTypes,
+		webhookChange.EventTypes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+// RegenerateWebhookSigningKey replaces the signing key of the webhook
+// and returns the new key, which will only be returned once
+func (c *Commands) RegenerateWebhookSigningKey(ctx context.Context, webhookID, resourceOwner string) (_ string, _ *domain.ObjectDetails, err error) {
+	if webhookID == "" || resourceOwner == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hs2k1", "Errors.IDMissing")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return "", nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return "", nil, caos_errs.ThrowNotFound(nil, "COMMAND-Vb3ne", "Errors.Webhook.NotFound")
+	}
+	signingKey, plainSigningKey, err := crypto.NewCode(c.webhookSigningKeyGenerator)
+	if err != nil {
+		return "", nil, err
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewSigningKeyChangedEvent(ctx, webhookAgg, signingKey))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return plainSigningKey, writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+func (c *Commands) DeactivateWebhook(ctx context.Context, webhookID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Kq2n0", "Errors.IDMissing")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Tm3la", "Errors.Webhook.NotFound")
+	}
+	if existingWebhook.State != domain.WebhookStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ow9d2", "Errors.Webhook.NotActive")
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewDeactivatedEvent(ctx, webhookAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+func (c *Commands) ReactivateWebhook(ctx context.Context, webhookID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xe8sj", "Errors.IDMissing")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Rj2ma", "Errors.Webhook.NotFound")
+	}
+	if existingWebhook.State != domain.WebhookStateInactive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Lq0wd", "Errors.Webhook.NotInactive")
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewReactivatedEvent(ctx, webhookAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+func (c *Commands) RemoveWebhook(ctx context.Context, webhookID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if webhookID == "" || resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Pz7sb", "Errors.IDMissing")
+	}
+
+	existingWebhook, err := c.getWebhookWriteModelByID(ctx, webhookID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingWebhook.State.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ug1qs", "Errors.Webhook.NotFound")
+	}
+	webhookAgg := WebhookAggregateFromWriteModel(&existingWebhook.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, webhook.NewRemovedEvent(ctx, webhookAgg, existingWebhook.Name))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWebhook, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingWebhook.WriteModel), nil
+}
+
+// checkWebhookFilter ensures the aggregate and event types can be delivered:
+// all aggregate types must be subscribable and the event types must be events of the subscribable aggregates,
+// if aggregate types are set, the event types must belong to them, because both filters have to match
+func (c *Commands) checkWebhookFilter(aggregateTypes, eventTypes []string) error {
+	for _, aggregateType := range aggregateTypes {
+		if !webhook.IsSubscribable(eventstore.AggregateType(aggregateType)) {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wq3sa", "Errors.Webhook.InvalidFilter")
+		}
+	}
+	for _, eventType := range eventTypes {
+		aggregateType, ok := c.eventstore.EventAggregateType(eventstore.EventType(eventType))
+		if !ok || !webhook.IsSubscribable(aggregateType) {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Hd5ks", "Errors.Webhook.InvalidFilter")
+		}
+		if len(aggregateTypes) > 0 && !containsAggregateType(aggregateTypes, aggregateType) {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xn2ro", "Errors.Webhook.InvalidFilter")
+		}
+	}
+	return nil
+}
+
+func (c *Commands) getWebhookWriteModelByID(ctx context.Context, webhookID string, resourceOwner string) (*WebhookWriteModel, error) {
+	webhookWriteModel := NewWebhookWriteModel(webhookID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, webhookWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return webhookWriteModel, nil
+}
+
+func containsAggregateType(aggregateTypes []string, aggregateType eventstore.AggregateType) bool {
+	for _, t := range aggregateTypes {
+		if t == string(aggregateType) {
+			return true
+		}
+	}
+	return false
+}
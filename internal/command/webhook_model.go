@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+type WebhookWriteModel struct {
+	eventstore.WriteModel
+
+	Name           string
+	URL            string
+	AggregateTypes []string
+	EventTypes     []string
+	SigningKey     *crypto.CryptoValue
+	State          domain.WebhookState
+}
+
+func NewWebhookWriteModel(webhookID string, resourceOwner string) *WebhookWriteModel {
+	return &WebhookWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   webhookID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *WebhookWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *webhook.AddedEvent:
+			wm.Name = e.Name
+			wm.URL = e.URL
+			wm.AggregateTypes = e.AggregateTypes
+			wm.EventTypes = e.EventTypes
+			wm.SigningKey = e.SigningKey
+			wm.State = domain.WebhookStateActive
+		case *webhook.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.URL != nil {
+				wm.URL = *e.URL
+			}
+			if e.AggregateTypes != nil {
+				wm.AggregateTypes = *e.AggregateTypes
+			}
+			if e.EventTypes != nil {
+				wm.EventTypes = *e.EventTypes
+			}
+		case *webhook.SigningKeyChangedEvent:
+			wm.SigningKey = e.SigningKey
+		case *webhook.DeactivatedEvent:
+			wm.State = domain.WebhookStateInactive
+		case *webhook.ReactivatedEvent:
+			wm.State = domain.WebhookStateActive
+		case *webhook.RemovedEvent:
+			wm.State = domain.WebhookStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *WebhookWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(webhook.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(webhook.AddedEventType,
+			webhook.ChangedEventType,
+			webhook.SigningKeyChangedEventType,
+			webhook.DeactivatedEventType,
+			webhook.ReactivatedEventType,
+			webhook.RemovedEventType).
+		Builder()
+}
+
+func (wm *WebhookWriteModel) NewChangedEvent(
+	ctx context.Context,
+	agg *eventstore.Aggregate,
+	name,
+	url string,
+	aggregateTypes,
+	eventTypes []string,
+) (*webhook.ChangedEvent, error) {
+	changes := make([]webhook.WebhookChanges, 0)
+	if wm.Name != name {
+		changes = append(changes, webhook.ChangeName(name, wm.Name))
+	}
+	if wm.URL != url {
+		changes = append(changes, webhook.ChangeURL(url))
+	}
+	if !reflect.DeepEqual(wm.AggregateTypes, aggregateTypes) {
+		changes = append(changes, webhook.ChangeAggregateTypes(aggregateTypes))
+	}
+	if !reflect.DeepEqual(wm.EventTypes, eventTypes) {
+		changes = append(changes, webhook.ChangeEventTypes(eventTypes))
+	}
+	return webhook.NewChangedEvent(ctx, agg, changes)
+}
+
+func WebhookAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return eventstore.AggregateFromWriteModel(wm, webhook.AggregateType, webhook.AggregateVersion)
+}
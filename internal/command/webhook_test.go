@@ -0,0 +1,918 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+func TestCommands_AddWebhook(t *testing.T) {
+	type fields struct {
+		eventstore                 *eventstore.Eventstore
+		idGenerator                id.Generator
+		webhookSigningKeyGenerator crypto.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		addWebhook    *domain.Webhook
+		resourceOwner string
+	}
+	type res struct {
+		id         string
+		signingKey string
+		details    *domain.ObjectDetails
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no name, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					URL: "https://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"invalid url, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name: "name",
+					URL:  "ftp://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"aggregate type not subscribable, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:           "name",
+					URL:            "https://example.com/hook",
+					AggregateTypes: []string{"webhook"},
+					EventTypes:     nil,
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"unknown event type, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:           "name",
+					URL:            "https://example.com/hook",
+					AggregateTypes: nil,
+					EventTypes:     []string{"user.unknown"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"event type of other aggregate, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:           "name",
+					URL:            "https://example.com/hook",
+					AggregateTypes: []string{"user"},
+					EventTypes:     []string{"org.added"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"unique constraint failed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPushFailed(
+						errors.ThrowPreconditionFailed(nil, "id", "name already exists"),
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewAddedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									"name",
+									"https://example.com/hook",
+									nil,
+									nil,
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(webhook.NewAddWebhookNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator:                mock.ExpectID(t, "id1"),
+				webhookSigningKeyGenerator: GetMockSecretGenerator(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name: "name",
+					URL:  "https://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewAddedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									"name",
+									"https://example.com/hook",
+									[]string{"user"},
+									[]string{"user.human.added"},
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(webhook.NewAddWebhookNameUniqueConstraint("name", "org1")),
+					),
+				),
+				idGenerator:                mock.ExpectID(t, "id1"),
+				webhookSigningKeyGenerator: GetMockSecretGenerator(t),
+			},
+			args{
+				ctx: context.Background(),
+				addWebhook: &domain.Webhook{
+					Name:           "name",
+					URL:            "https://example.com/hook",
+					AggregateTypes: []string{"user"},
+					EventTypes:     []string{"user.human.added"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				id:         "id1",
+				signingKey: "a",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                 tt.fields.eventstore,
+				idGenerator:                tt.fields.idGenerator,
+				webhookSigningKeyGenerator: tt.fields.webhookSigningKeyGenerator,
+			}
+			id, signingKey, details, err := c.AddWebhook(tt.args.ctx, tt.args.addWebhook, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assert.Equal(t, tt.res.signingKey, signingKey)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		changeWebhook *domain.Webhook
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					Name: "name",
+					URL:  "https://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"event type not subscribable, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:       "name",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{"webhook.added"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name: "name",
+					URL:  "https://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"no changes, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								[]string{"user"},
+								nil,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:           "name",
+					URL:            "https://example.com/hook",
+					AggregateTypes: []string{"user"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								[]string{"user"},
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *webhook.ChangedEvent {
+									event, _ := webhook.NewChangedEvent(context.Background(),
+										&webhook.NewAggregate("id1", "org1").Aggregate,
+										[]webhook.WebhookChanges{
+											webhook.ChangeName("name2", "name"),
+											webhook.ChangeURL("https://example.com/hook2"),
+											webhook.ChangeAggregateTypes([]string{"user", "org"}),
+											webhook.ChangeEventTypes([]string{"org.added"}),
+										},
+									)
+									return event
+								}(),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(webhook.NewRemoveWebhookNameUniqueConstraint("name", "org1")),
+						uniqueConstraintsFromEventConstraint(webhook.NewAddWebhookNameUniqueConstraint("name2", "org1")),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeWebhook: &domain.Webhook{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:           "name2",
+					URL:            "https://example.com/hook2",
+					AggregateTypes: []string{"user", "org"},
+					EventTypes:     []string{"org.added"},
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ChangeWebhook(tt.args.ctx, tt.args.changeWebhook, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RegenerateWebhookSigningKey(t *testing.T) {
+	type fields struct {
+		eventstore                 *eventstore.Eventstore
+		webhookSigningKeyGenerator crypto.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		webhookID     string
+		resourceOwner string
+	}
+	type res struct {
+		signingKey string
+		details    *domain.ObjectDetails
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								nil,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewSigningKeyChangedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("a"),
+									},
+								),
+							),
+						},
+					),
+				),
+				webhookSigningKeyGenerator: GetMockSecretGenerator(t),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				signingKey: "a",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:                 tt.fields.eventstore,
+				webhookSigningKeyGenerator: tt.fields.webhookSigningKeyGenerator,
+			}
+			signingKey, details, err := c.RegenerateWebhookSigningKey(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.signingKey, signingKey)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_DeactivateWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		webhookID     string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"not active, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								nil,
+								nil,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							webhook.NewDeactivatedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"deactivate ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								nil,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewDeactivatedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.DeactivateWebhook(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ReactivateWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		webhookID     string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not inactive, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								nil,
+								nil,
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"reactivate ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								nil,
+								nil,
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							webhook.NewDeactivatedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewReactivatedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.ReactivateWebhook(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveWebhook(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		webhookID     string
+		resourceOwner string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							webhook.NewAddedEvent(context.Background(),
+								&webhook.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								nil,
+								nil,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								webhook.NewRemovedEvent(context.Background(),
+									&webhook.NewAggregate("id1", "org1").Aggregate,
+									"name",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(webhook.NewRemoveWebhookNameUniqueConstraint("name", "org1")),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				webhookID:     "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			details, err := c.RemoveWebhook(tt.args.ctx, tt.args.webhookID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
@@ -1,25 +1,55 @@
 package systemdefaults
 
 import (
+	"strings"
 	"time"
 
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/breachedpassword"
 	"github.com/zitadel/zitadel/internal/crypto"
 )
 
 type SystemDefaults struct {
 	SecretGenerators   SecretGenerators
+	PasswordHasher     crypto.PasswordHashConfig
+	BreachedPasswords  *breachedpassword.Config
 	Multifactors       MultifactorConfig
 	DomainVerification DomainVerification
 	Notifications      Notifications
 	KeyConfig          KeyConfig
+	Webhooks           Webhooks
+	ActionStorage      ActionStorage
 }
 
 type SecretGenerators struct {
+	// Deprecated: use the Cost param of the bcrypt hasher of the PasswordHasher
 	PasswordSaltCost   int
 	MachineKeySize     uint32
 	ApplicationKeySize uint32
 }
 
+// MapDeprecated maps the deprecated settings onto the ones replacing them
+func (d *SystemDefaults) MapDeprecated() {
+	if d.SecretGenerators.PasswordSaltCost == 0 {
+		return
+	}
+	algorithm := d.PasswordHasher.Hasher.Algorithm
+	if algorithm != "" && algorithm != crypto.HashAlgorithmBCrypt {
+		logging.Warn("SystemDefaults.SecretGenerators.PasswordSaltCost is deprecated and ignored as the PasswordHasher does not use bcrypt")
+		return
+	}
+	logging.Warn("SystemDefaults.SecretGenerators.PasswordSaltCost is deprecated, use SystemDefaults.PasswordHasher.Hasher.Params.Cost instead")
+	params := make(map[string]interface{}, len(d.PasswordHasher.Hasher.Params)+1)
+	for key, value := range d.PasswordHasher.Hasher.Params {
+		if !strings.EqualFold(key, "cost") {
+			params[key] = value
+		}
+	}
+	params["cost"] = d.SecretGenerators.PasswordSaltCost
+	d.PasswordHasher.Hasher.Params = params
+}
+
 type MultifactorConfig struct {
 	OTP OTPConfig
 }
@@ -43,3 +73,15 @@ type KeyConfig struct {
 	CertificateSize     int
 	CertificateLifetime time.Duration
 }
+
+type Webhooks struct {
+	SigningKeyGenerator crypto.GeneratorConfig
+}
+
+// ActionStorage limits the values actions of an organisation store with the zitadel/storage module
+type ActionStorage struct {
+	// MaxValueLength is the maximum length of a single value
+	MaxValueLength int
+	// MaxSizePerOrg is the maximum length of all keys and values of an organisation
+	MaxSizePerOrg int
+}
@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	argon2idIdentifier = "argon2id"
+	argon2iIdentifier  = "argon2i"
+
+	argon2DefaultTime    = 3
+	argon2DefaultMemory  = 64 * 1024
+	argon2DefaultThreads = 4
+	argon2SaltLength     = 16
+	argon2KeyLength      = 32
+
+	// maximum params of hashes, which are verified
+	argon2MaxTime    = 64
+	argon2MaxMemory  = 1024 * 1024
+	argon2MaxThreads = 64
+)
+
+var _ PasswordHashAlgorithm = (*Argon2)(nil)
+
+type Argon2Config struct {
+	// Time is the number of iterations
+	Time uint32
+	// Memory is the used memory in KiB
+	Memory  uint32
+	Threads uint8
+}
+
+// Argon2 hashes with argon2id and verifies argon2id and argon2i hashes
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>
+type Argon2 struct {
+	config Argon2Config
+}
+
+func NewArgon2id(config Argon2Config) *Argon2 {
+	return &Argon2{config: config}
+}
+
+func (a *Argon2) Algorithm() string {
+	return HashAlgorithmArgon2id
+}
+
+func (a *Argon2) Identifiers() []string {
+	return []string{argon2idIdentifier, argon2iIdentifier}
+}
+
+func (a *Argon2) Hash(value []byte) ([]byte, error) {
+	salt, err := randomSalt(argon2SaltLength)
+	if err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(value, salt, a.config.Time, a.config.Memory, a.config.Threads, argon2KeyLength)
+	return []byte(fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idIdentifier,
+		argon2.Version,
+		a.config.Memory,
+		a.config.Time,
+		a.config.Threads,
+		encodeHashBase64(salt),
+		encodeHashBase64(key),
+	)), nil
+}
+
+func (a *Argon2) CompareHash(hashed, value []byte) error {
+	h, config, err := parseArgon2(hashed)
+	if err != nil {
+		return err
+	}
+	keyLength := uint32(len(h.hash))
+	var key []byte
+	switch h.id {
+	case argon2idIdentifier:
+		key = argon2.IDKey(value, h.salt, config.Time, config.Memory, config.Threads, keyLength)
+	case argon2iIdentifier:
+		key = argon2.Key(value, h.salt, config.Time, config.Memory, config.Threads, keyLength)
+	}
+	return compareDerivedKeys(h.hash, key)
+}
+
+func (a *Argon2) ValidateHash(hashed []byte) error {
+	_, _, err := parseArgon2(hashed)
+	return err
+}
+
+func (a *Argon2) NeedsRehash(hashed []byte) bool {
+	h, config, err := parseArgon2(hashed)
+	return err != nil || h.id != argon2idIdentifier || config != a.config
+}
+
+func parseArgon2(hashed []byte) (*phcHash, Argon2Config, error) {
+	h, err := parsePHC(hashed)
+	if err != nil {
+		return nil, Argon2Config{}, err
+	}
+	if h.id != argon2idIdentifier && h.id != argon2iIdentifier {
+		return nil, Argon2Config{}, errors.ThrowInvalidArgument(nil, "CRYPT-Kd3fs", "not an argon2 hash")
+	}
+	if version, ok := h.params["v"]; ok && version != fmt.Sprint(argon2.Version) {
+		return nil, Argon2Config{}, errors.ThrowInvalidArgument(nil, "CRYPT-Fw2sx", "argon2 version not supported")
+	}
+	memory, err := h.uintParam("m", 32)
+	if err != nil {
+		return nil, Argon2Config{}, err
+	}
+	time, err := h.uintParam("t", 32)
+	if err != nil {
+		return nil, Argon2Config{}, err
+	}
+	threads, err := h.uintParam("p", 8)
+	if err != nil {
+		return nil, Argon2Config{}, err
+	}
+	config := Argon2Config{Time: uint32(time), Memory: uint32(memory), Threads: uint8(threads)}
+	if err = config.check(); err != nil {
+		return nil, Argon2Config{}, err
+	}
+	return h, config, nil
+}
+
+// check ensures the params do not exhaust the resources on verification
+func (c Argon2Config) check() error {
+	if c.Time == 0 || c.Threads == 0 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ns3fq", "argon2 time and threads must be positive")
+	}
+	if err := checkHashParam(uint64(c.Time), argon2MaxTime); err != nil {
+		return err
+	}
+	if err := checkHashParam(uint64(c.Memory), argon2MaxMemory); err != nil {
+		return err
+	}
+	return checkHashParam(uint64(c.Threads), argon2MaxThreads)
+}
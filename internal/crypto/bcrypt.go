@@ -2,9 +2,19 @@ package crypto
 
 import (
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/zitadel/zitadel/internal/errors"
 )
 
-var _ HashAlgorithm = (*BCrypt)(nil)
+const (
+	bcryptDefaultCost = 14
+
+	// maximum cost of hashes, which are verified,
+	// every additional cost doubles the time of a verification
+	bcryptMaxCost = 16
+)
+
+var _ PasswordHashAlgorithm = (*BCrypt)(nil)
 
 type BCrypt struct {
 	cost int
@@ -15,7 +25,11 @@ func NewBCrypt(cost int) *BCrypt {
 }
 
 func (b *BCrypt) Algorithm() string {
-	return "bcrypt"
+	return HashAlgorithmBCrypt
+}
+
+func (b *BCrypt) Identifiers() []string {
+	return []string{"2", "2a", "2b", "2x", "2y"}
 }
 
 func (b *BCrypt) Hash(value []byte) ([]byte, error) {
@@ -23,5 +37,21 @@ func (b *BCrypt) Hash(value []byte) ([]byte, error) {
 }
 
 func (b *BCrypt) CompareHash(hashed, value []byte) error {
+	if err := b.ValidateHash(hashed); err != nil {
+		return err
+	}
 	return bcrypt.CompareHashAndPassword(hashed, value)
 }
+
+func (b *BCrypt) NeedsRehash(hashed []byte) bool {
+	cost, err := bcrypt.Cost(hashed)
+	return err != nil || cost != b.cost
+}
+
+func (b *BCrypt) ValidateHash(hashed []byte) error {
+	cost, err := bcrypt.Cost(hashed)
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "CRYPT-Bq2sd", "invalid bcrypt hash")
+	}
+	return checkHashParam(uint64(cost), bcryptMaxCost)
+}
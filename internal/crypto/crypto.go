@@ -119,12 +119,35 @@ func Hash(value []byte, alg HashAlgorithm) (*CryptoValue, error) {
 }
 
 func CompareHash(value *CryptoValue, comparer []byte, alg HashAlgorithm) error {
-	if value.Algorithm != alg.Algorithm() {
+	if !verifiesAlgorithm(alg, value.Algorithm) {
 		return errors.ThrowInvalidArgument(nil, "CRYPT-HF32f", "value was hashed with a different algorithm")
 	}
 	return alg.CompareHash(value.Crypted, comparer)
 }
 
+// CheckHash checks if the (imported) value is a hash, which can be verified by the alg
+func CheckHash(value *CryptoValue, alg HashAlgorithm) error {
+	if value == nil || value.CryptoType != TypeHash || !verifiesAlgorithm(alg, value.Algorithm) {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ks2fw", "hash algorithm not supported")
+	}
+	if checker, ok := alg.(interface{ CheckHash(string, []byte) error }); ok {
+		return checker.CheckHash(value.Algorithm, value.Crypted)
+	}
+	if verifier, ok := alg.(PasswordVerifier); ok {
+		return verifier.ValidateHash(value.Crypted)
+	}
+	return nil
+}
+
+// verifiesAlgorithm checks if the alg is able to verify values hashed by the algorithm,
+// which might be other algorithms in case of a [PasswordHasher]
+func verifiesAlgorithm(alg HashAlgorithm, algorithm string) bool {
+	if verifier, ok := alg.(interface{ Verifies(string) bool }); ok {
+		return verifier.Verifies(algorithm)
+	}
+	return alg.Algorithm() == algorithm
+}
+
 func FillHash(value []byte, alg HashAlgorithm) *CryptoValue {
 	return &CryptoValue{
 		CryptoType: TypeHash,
@@ -6,6 +6,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/crypto/kms"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 )
 
@@ -34,6 +35,19 @@ func NewKeyStorage(client *sql.DB, masterKey string) (*database, error) {
 	}, nil
 }
 
+// NewKMSKeyStorage stores the keys wrapped by the kms, so that no masterkey is needed
+func NewKMSKeyStorage(client *sql.DB, kms kms.KMS) *database {
+	return &database{
+		client: client,
+		encrypt: func(key, _ string) (string, error) {
+			return kms.Encrypt(key)
+		},
+		decrypt: func(encryptedKey, _ string) (string, error) {
+			return kms.Decrypt(encryptedKey)
+		},
+	}
+}
+
 func (d *database) ReadKeys() (crypto.Keys, error) {
 	keys := make(map[string]string)
 	stmt, args, err := sq.Select(encryptionKeysIDCol, encryptionKeysKeyCol).
@@ -125,6 +139,45 @@ func (d *database) CreateKeys(keys ...*crypto.Key) error {
 	return nil
 }
 
+// UpdateKeys encrypts the existing keys again (e.g. after a rotation of the key encryption key)
+func (d *database) UpdateKeys(keys ...*crypto.Key) error {
+	stmts := make([]string, len(keys))
+	args := make([][]interface{}, len(keys))
+	for i, key := range keys {
+		encryptionKey, err := d.encrypt(key.Value, d.masterKey)
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "", "unable to encrypt key")
+		}
+		stmts[i], args[i], err = sq.Update(EncryptionKeysTable).
+			Set(encryptionKeysKeyCol, encryptionKey).
+			Where(sq.Eq{encryptionKeysIDCol: key.ID}).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "", "unable to update keys")
+		}
+	}
+	tx, err := d.client.Begin()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to update keys")
+	}
+	for i, stmt := range stmts {
+		res, err := tx.Exec(stmt, args[i]...)
+		if err != nil {
+			tx.Rollback()
+			return caos_errs.ThrowInternal(err, "", "unable to update keys")
+		}
+		if rows, err := res.RowsAffected(); err != nil || rows != 1 {
+			tx.Rollback()
+			return caos_errs.ThrowNotFoundf(err, "", "key %s not found", keys[i].ID)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return caos_errs.ThrowInternal(err, "", "unable to update keys")
+	}
+	return nil
+}
+
 func checkMasterKeyLength(masterKey string) error {
 	if length := len([]byte(masterKey)); length != 32 {
 		return caos_errs.ThrowInternalf(nil, "", "masterkey must be 32 bytes, but is %d", length)
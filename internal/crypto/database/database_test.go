@@ -407,6 +407,152 @@ func Test_database_CreateKeys(t *testing.T) {
 	}
 }
 
+func Test_database_UpdateKeys(t *testing.T) {
+	type fields struct {
+		client    db
+		masterKey string
+		encrypt   func(key, masterKey string) (encryptedKey string, err error)
+	}
+	type args struct {
+		keys []*crypto.Key
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"encryption fails, error",
+			fields{
+				client:    dbMock(t),
+				masterKey: "",
+				encrypt: func(key, masterKey string) (encryptedKey string, err error) {
+					return "", fmt.Errorf("encryption failed")
+				},
+			},
+			args{
+				keys: []*crypto.Key{
+					{
+						"id1",
+						"key1",
+					},
+				},
+			},
+			res{
+				err: caos_errs.IsInternal,
+			},
+		},
+		{
+			"update fails, error",
+			fields{
+				client: dbMock(t,
+					expectBegin(nil),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", sql.ErrTxDone),
+					expectRollback(nil),
+				),
+				masterKey: "masterkey",
+				encrypt: func(key, masterKey string) (encryptedKey string, err error) {
+					return key, nil
+				},
+			},
+			args{
+				keys: []*crypto.Key{
+					{
+						"id1",
+						"key1",
+					},
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, sql.ErrTxDone)
+				},
+			},
+		},
+		{
+			"key not found, error",
+			fields{
+				client: dbMock(t,
+					expectBegin(nil),
+					func(m sqlmock.Sqlmock) {
+						m.ExpectExec(regexp.QuoteMeta("UPDATE system.encryption_keys SET key = $1 WHERE id = $2")).
+							WithArgs("key1", "id1").
+							WillReturnResult(sqlmock.NewResult(0, 0))
+					},
+					expectRollback(nil),
+				),
+				masterKey: "masterkey",
+				encrypt: func(key, masterKey string) (encryptedKey string, err error) {
+					return key, nil
+				},
+			},
+			args{
+				keys: []*crypto.Key{
+					{
+						"id1",
+						"key1",
+					},
+				},
+			},
+			res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			"multiple update ok",
+			fields{
+				client: dbMock(t,
+					expectBegin(nil),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", nil, "key1", "id1"),
+					expectExec("UPDATE system.encryption_keys SET key = $1 WHERE id = $2", nil, "key2", "id2"),
+					expectCommit(nil),
+				),
+				masterKey: "masterkey",
+				encrypt: func(key, masterKey string) (encryptedKey string, err error) {
+					return key, nil
+				},
+			},
+			args{
+				keys: []*crypto.Key{
+					{
+						"id1",
+						"key1",
+					},
+					{
+						"id2",
+						"key2",
+					},
+				},
+			},
+			res{
+				err: nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &database{
+				client:    tt.fields.client.db,
+				masterKey: tt.fields.masterKey,
+				encrypt:   tt.fields.encrypt,
+			}
+			err := d.UpdateKeys(tt.args.keys...)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			} else if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v", err)
+			}
+			if err := tt.fields.client.mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func Test_checkMasterKeyLength(t *testing.T) {
 	type args struct {
 		masterKey string
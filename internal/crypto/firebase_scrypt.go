@@ -0,0 +1,104 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	firebaseScryptIdentifier = "firebase-scrypt"
+	firebaseScryptKeyLength  = 32
+)
+
+var _ PasswordVerifier = (*FirebaseScrypt)(nil)
+
+// FirebaseScrypt verifies password hashes exported from Firebase Authentication,
+// which are created by a modified scrypt using the hash parameters of the Firebase project.
+// The hashes must be provided in the following format,
+// where the signer key and salt separator are the (standard base64 encoded) values of the project:
+// $firebase-scrypt$ln=<mem_cost>,r=<rounds>,k=<signer key>,s=<salt separator>$<salt>$<hash>
+type FirebaseScrypt struct{}
+
+func (f *FirebaseScrypt) Algorithm() string {
+	return HashAlgorithmFirebaseScrypt
+}
+
+func (f *FirebaseScrypt) Identifiers() []string {
+	return []string{firebaseScryptIdentifier}
+}
+
+func (f *FirebaseScrypt) CompareHash(hashed, value []byte) error {
+	h, err := parseFirebaseScrypt(hashed)
+	if err != nil {
+		return err
+	}
+	salt := append(append(make([]byte, 0, len(h.salt)+len(h.saltSeparator)), h.salt...), h.saltSeparator...)
+	derivedKey, err := scrypt.Key(value, salt, 1<<h.memCost, h.rounds, 1, firebaseScryptKeyLength)
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "CRYPT-Wf3sd", "invalid scrypt parameters")
+	}
+	// the signer key is encrypted with the derived key (AES-256-CTR with a zero IV)
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return errors.ThrowInternal(err, "CRYPT-Ms2fq", "unable to create cipher")
+	}
+	key := make([]byte, len(h.signerKey))
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(key, h.signerKey)
+	return compareDerivedKeys(h.hash, key)
+}
+
+func (f *FirebaseScrypt) ValidateHash(hashed []byte) error {
+	_, err := parseFirebaseScrypt(hashed)
+	return err
+}
+
+type firebaseScryptHash struct {
+	*phcHash
+	memCost       uint64
+	rounds        int
+	signerKey     []byte
+	saltSeparator []byte
+}
+
+func parseFirebaseScrypt(hashed []byte) (*firebaseScryptHash, error) {
+	h, err := parsePHC(hashed)
+	if err != nil {
+		return nil, err
+	}
+	if h.id != firebaseScryptIdentifier {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Dk3sw", "not a firebase scrypt hash")
+	}
+	memCost, err := h.uintParam("ln", 6)
+	if err != nil {
+		return nil, err
+	}
+	if memCost > scryptMaxLogCost {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Gd2qs", "hash parameter exceeds the supported maximum")
+	}
+	rounds, err := h.uintParam("r", 32)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkScryptParams(1<<memCost, int(rounds), 1); err != nil {
+		return nil, err
+	}
+	signerKey, err := decodeHashBase64(h.params["k"])
+	if err != nil || len(signerKey) == 0 {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-Pq2fs", "invalid hash parameter")
+	}
+	saltSeparator, err := decodeHashBase64(h.params["s"])
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-Hs3fe", "invalid hash parameter")
+	}
+	return &firebaseScryptHash{
+		phcHash:       h,
+		memCost:       memCost,
+		rounds:        int(rounds),
+		signerKey:     signerKey,
+		saltSeparator: saltSeparator,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package kms
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto/kms/transit"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	TypeTransit = "transit"
+)
+
+// KMS wraps and unwraps the encryption keys of ZITADEL with a key encryption key,
+// which is managed by an external key management service and never leaves it
+type KMS interface {
+	// Encrypt wraps the key with the latest version of the key encryption key
+	Encrypt(key string) (encryptedKey string, err error)
+	// Decrypt unwraps the key with the version of the key encryption key it was wrapped with
+	Decrypt(encryptedKey string) (key string, err error)
+	// Rotate creates a new version of the key encryption key, which is used by all following calls of Encrypt
+	Rotate() error
+}
+
+// Config of the KMS, if no Type is set the keys are encrypted by the masterkey
+type Config struct {
+	Type    string
+	Transit *transit.Config
+}
+
+func (c *Config) Enabled() bool {
+	return c != nil && c.Type != ""
+}
+
+func (c *Config) NewKMS() (KMS, error) {
+	if !c.Enabled() {
+		return nil, errors.ThrowInvalidArgument(nil, "KMS-Hq3xd", "no kms configured")
+	}
+	switch c.Type {
+	case TypeTransit:
+		if c.Transit == nil {
+			return nil, errors.ThrowInvalidArgument(nil, "KMS-Vb6ws", "transit config missing")
+		}
+		return transit.New(c.Transit)
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "KMS-Pc2ow", "kms type %s not supported", c.Type)
+	}
+}
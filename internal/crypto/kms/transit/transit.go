@@ -0,0 +1,158 @@
+package transit
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	defaultMount = "transit"
+
+	headerToken     = "X-Vault-Token"
+	headerNamespace = "X-Vault-Namespace"
+)
+
+// Config of a server implementing the HTTP API of the HashiCorp Vault transit secrets engine (e.g. Vault or OpenBao)
+type Config struct {
+	// Address is the base URL of the server (e.g. https://vault.example.com:8200)
+	Address string
+	// Mount is the path the transit secrets engine is mounted at, defaults to transit
+	Mount string
+	// Key is the name of the key encryption key
+	Key       string
+	Token     string
+	Namespace string
+	Timeout   time.Duration
+}
+
+// Transit wraps the keys with a named key of the transit secrets engine,
+// the ciphertexts contain the version of the key they were wrapped with
+type Transit struct {
+	client    *http.Client
+	endpoint  string
+	key       string
+	token     string
+	namespace string
+	timeout   time.Duration
+}
+
+func New(c *Config) (*Transit, error) {
+	if c.Address == "" || c.Key == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "TRANS-Fj3ad", "address and key must be set")
+	}
+	if _, err := url.Parse(c.Address); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "TRANS-Ow8ke", "invalid address")
+	}
+	mount := strings.Trim(c.Mount, "/")
+	if mount == "" {
+		mount = defaultMount
+	}
+	return &Transit{
+		client:    http.DefaultClient,
+		endpoint:  strings.TrimSuffix(c.Address, "/") + "/v1/" + mount,
+		key:       url.PathEscape(c.Key),
+		token:     c.Token,
+		namespace: c.Namespace,
+		timeout:   c.Timeout,
+	}, nil
+}
+
+type encryptRequest struct {
+	Plaintext string `json:"plaintext"`
+}
+
+type decryptRequest struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+type response struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+		Plaintext  string `json:"plaintext"`
+	} `json:"data"`
+}
+
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+func (t *Transit) Encrypt(key string) (string, error) {
+	resp := new(response)
+	err := t.call("/encrypt/"+t.key, &encryptRequest{Plaintext: base64.StdEncoding.EncodeToString([]byte(key))}, resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.Data.Ciphertext == "" {
+		return "", errors.ThrowInternal(nil, "TRANS-Mv5gs", "no ciphertext returned")
+	}
+	return resp.Data.Ciphertext, nil
+}
+
+func (t *Transit) Decrypt(encryptedKey string) (string, error) {
+	resp := new(response)
+	err := t.call("/decrypt/"+t.key, &decryptRequest{Ciphertext: encryptedKey}, resp)
+	if err != nil {
+		return "", err
+	}
+	key, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return "", errors.ThrowInternal(err, "TRANS-Zu7nb", "unable to decode plaintext")
+	}
+	return string(key), nil
+}
+
+func (t *Transit) Rotate() error {
+	return t.call("/keys/"+t.key+"/rotate", nil, nil)
+}
+
+func (t *Transit) call(path string, body, result interface{}) error {
+	ctx := context.Background()
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return errors.ThrowInternal(err, "TRANS-Rt4cz", "unable to marshal request")
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint+path, &payload)
+	if err != nil {
+		return errors.ThrowInternal(err, "TRANS-Lx9ep", "unable to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if t.token != "" {
+		req.Header.Set(headerToken, t.token)
+	}
+	if t.namespace != "" {
+		req.Header.Set(headerNamespace, t.namespace)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return errors.ThrowUnavailable(err, "TRANS-Wd2mq", "kms unavailable")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errResp := new(errorResponse)
+		// the errors are only added to the message, the status is sufficient if the body is not parsable
+		_ = json.NewDecoder(resp.Body).Decode(errResp)
+		return errors.ThrowUnavailablef(nil, "TRANS-Jy3bo", "kms responded with status %d: %s", resp.StatusCode, strings.Join(errResp.Errors, ", "))
+	}
+	if result == nil {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.ThrowInternal(err, "TRANS-Ep6vk", "unable to parse response")
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package transit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// fakeTransit wraps the plaintext with the current version of the key, without actually encrypting it
+type fakeTransit struct {
+	version int
+}
+
+func (f *fakeTransit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(headerToken) != "token" {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"errors":["permission denied"]}`)
+		return
+	}
+	body := make(map[string]string)
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	switch r.URL.Path {
+	case "/v1/transit/encrypt/zitadel":
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"ciphertext": fmt.Sprintf("vault:v%d:%s", f.version, body["plaintext"])}})
+	case "/v1/transit/decrypt/zitadel":
+		parts := strings.SplitN(body["ciphertext"], ":", 3)
+		if len(parts) != 3 || parts[1] > fmt.Sprintf("v%d", f.version) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errors":["invalid ciphertext"]}`)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"plaintext": parts[2]}})
+	case "/v1/transit/keys/zitadel/rotate":
+		f.version++
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestTransit(t *testing.T) {
+	server := httptest.NewServer(&fakeTransit{version: 1})
+	defer server.Close()
+	kms, err := New(&Config{Address: server.URL + "/", Key: "zitadel", Token: "token"})
+	require.NoError(t, err)
+
+	encrypted, err := kms.Encrypt("key")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "vault:v1:"), encrypted)
+
+	require.NoError(t, kms.Rotate())
+	rotated, err := kms.Encrypt("key")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rotated, "vault:v2:"), rotated)
+
+	for _, ciphertext := range []string{encrypted, rotated} {
+		key, err := kms.Decrypt(ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "key", key)
+	}
+
+	_, err = kms.Decrypt("invalid")
+	assert.True(t, errors.IsUnavailable(err), err)
+}
+
+func TestTransit_permissionDenied(t *testing.T) {
+	server := httptest.NewServer(&fakeTransit{version: 1})
+	defer server.Close()
+	kms, err := New(&Config{Address: server.URL, Key: "zitadel", Token: "wrong"})
+	require.NoError(t, err)
+
+	_, err = kms.Encrypt("key")
+	require.True(t, errors.IsUnavailable(err), err)
+	assert.Contains(t, err.Error(), "permission denied")
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&Config{Address: "http://localhost:8200"})
+	assert.True(t, errors.IsErrorInvalidArgument(err), err)
+
+	kms, err := New(&Config{Address: "http://localhost:8200/", Mount: "/keys/", Key: "zitadel"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8200/v1/keys", kms.endpoint)
+}
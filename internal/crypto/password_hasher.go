@@ -0,0 +1,340 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	HashAlgorithmBCrypt   = "bcrypt"
+	HashAlgorithmArgon2id = "argon2id"
+	HashAlgorithmScrypt   = "scrypt"
+	HashAlgorithmPBKDF2   = "pbkdf2"
+	// HashAlgorithmFirebaseScrypt and HashAlgorithmSSHA can only be used as verifiers for imported passwords
+	HashAlgorithmFirebaseScrypt = "firebase-scrypt"
+	HashAlgorithmSSHA           = "ssha"
+)
+
+// PasswordHashConfig defines the algorithm used for hashing new passwords
+// and the algorithms existing password hashes can still be verified with
+type PasswordHashConfig struct {
+	Hasher    HasherConfig
+	Verifiers []string
+}
+
+type HasherConfig struct {
+	// Algorithm is one of bcrypt, argon2id, scrypt or pbkdf2,
+	// bcrypt is used if none is set
+	Algorithm string
+	// Params are the algorithm specific parameters, e.g. Cost for bcrypt
+	Params map[string]interface{}
+}
+
+// PasswordVerifier verifies self describing hashes
+// in the PHC string format (or modular crypt format for bcrypt),
+// so hashes of multiple algorithms and parameters can coexist
+type PasswordVerifier interface {
+	Algorithm() string
+	// Identifiers returns the ids of the hashes (`$<id>$...`) the algorithm is able to verify
+	Identifiers() []string
+	CompareHash(hashed, value []byte) error
+	// ValidateHash parses the hash and checks its parameters,
+	// so (imported) hashes, which cannot be verified or would exhaust the resources on verification, are rejected
+	ValidateHash(hashed []byte) error
+}
+
+// PasswordHashAlgorithm is a PasswordVerifier, which is also able to create the hashes
+type PasswordHashAlgorithm interface {
+	HashAlgorithm
+	PasswordVerifier
+	// NeedsRehash reports if the hash was created with other parameters than the algorithm is configured with
+	NeedsRehash(hashed []byte) bool
+}
+
+var _ HashAlgorithm = (*PasswordHasher)(nil)
+
+// PasswordHasher hashes passwords with the configured hasher
+// and verifies the hashes of the hasher and all configured verifiers
+type PasswordHasher struct {
+	hasher    PasswordHashAlgorithm
+	verifiers map[string]PasswordVerifier
+}
+
+func NewPasswordHasher(config *PasswordHashConfig) (*PasswordHasher, error) {
+	hasher, err := newPasswordHashAlgorithm(config.Hasher.Algorithm, config.Hasher.Params)
+	if err != nil {
+		return nil, err
+	}
+	h := &PasswordHasher{
+		hasher:    hasher,
+		verifiers: make(map[string]PasswordVerifier),
+	}
+	// bcrypt hashes can always be verified as all existing passwords and secrets were hashed with it
+	h.addVerifier(NewBCrypt(bcryptDefaultCost))
+	for _, algorithm := range config.Verifiers {
+		verifier, err := newPasswordVerifier(algorithm)
+		if err != nil {
+			return nil, err
+		}
+		h.addVerifier(verifier)
+	}
+	h.addVerifier(hasher)
+	return h, nil
+}
+
+func (h *PasswordHasher) addVerifier(verifier PasswordVerifier) {
+	for _, id := range verifier.Identifiers() {
+		h.verifiers[id] = verifier
+	}
+}
+
+func (h *PasswordHasher) Algorithm() string {
+	return h.hasher.Algorithm()
+}
+
+func (h *PasswordHasher) Hash(value []byte) ([]byte, error) {
+	return h.hasher.Hash(value)
+}
+
+// CompareHash verifies the value with the algorithm the hash was created with
+func (h *PasswordHasher) CompareHash(hashed, value []byte) error {
+	verifier, ok := h.verifiers[hashIdentifier(hashed)]
+	if !ok {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Gs3fq", "hash algorithm not supported")
+	}
+	return verifier.CompareHash(hashed, value)
+}
+
+// Verifies reports if hashes of the algorithm can be verified
+func (h *PasswordHasher) Verifies(algorithm string) bool {
+	for _, verifier := range h.verifiers {
+		if verifier.Algorithm() == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckHash checks if the (imported) hash can be verified and was created by the algorithm
+func (h *PasswordHasher) CheckHash(algorithm string, hashed []byte) error {
+	verifier, ok := h.verifiers[hashIdentifier(hashed)]
+	if !ok || verifier.Algorithm() != algorithm {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Lw3sf", "hash algorithm not supported")
+	}
+	return verifier.ValidateHash(hashed)
+}
+
+// NeedsRehash reports if the hash was created by another algorithm than the hasher
+// or with other parameters than the hasher is configured with
+func (h *PasswordHasher) NeedsRehash(hashed []byte) bool {
+	id := hashIdentifier(hashed)
+	for _, hasherID := range h.hasher.Identifiers() {
+		if id == hasherID {
+			return h.hasher.NeedsRehash(hashed)
+		}
+	}
+	return true
+}
+
+// NeedsRehash reports if the value has to be hashed again,
+// because the algorithm is configured differently than at the time of the hashing
+func NeedsRehash(value *CryptoValue, alg HashAlgorithm) bool {
+	rehasher, ok := alg.(interface{ NeedsRehash([]byte) bool })
+	if !ok || value == nil {
+		return false
+	}
+	return value.Algorithm != alg.Algorithm() || rehasher.NeedsRehash(value.Crypted)
+}
+
+func newPasswordVerifier(algorithm string) (PasswordVerifier, error) {
+	switch algorithm {
+	case HashAlgorithmFirebaseScrypt:
+		return new(FirebaseScrypt), nil
+	case HashAlgorithmSSHA:
+		return new(SSHA), nil
+	default:
+		return newPasswordHashAlgorithm(algorithm, nil)
+	}
+}
+
+func newPasswordHashAlgorithm(algorithm string, params map[string]interface{}) (PasswordHashAlgorithm, error) {
+	switch algorithm {
+	case HashAlgorithmBCrypt, "":
+		config := struct{ Cost int }{Cost: bcryptDefaultCost}
+		if err := decodeHasherParams(params, &config); err != nil {
+			return nil, err
+		}
+		if err := checkHashParam(uint64(config.Cost), bcryptMaxCost); err != nil {
+			return nil, err
+		}
+		return NewBCrypt(config.Cost), nil
+	case HashAlgorithmArgon2id:
+		config := Argon2Config{
+			Time:    argon2DefaultTime,
+			Memory:  argon2DefaultMemory,
+			Threads: argon2DefaultThreads,
+		}
+		if err := decodeHasherParams(params, &config); err != nil {
+			return nil, err
+		}
+		if err := config.check(); err != nil {
+			return nil, err
+		}
+		return NewArgon2id(config), nil
+	case HashAlgorithmScrypt:
+		config := ScryptConfig{
+			Cost: scryptDefaultCost,
+			R:    scryptDefaultR,
+			P:    scryptDefaultP,
+		}
+		if err := decodeHasherParams(params, &config); err != nil {
+			return nil, err
+		}
+		return NewScrypt(config)
+	case HashAlgorithmPBKDF2:
+		config := PBKDF2Config{
+			Rounds: pbkdf2DefaultRounds,
+			Hash:   pbkdf2DefaultHash,
+		}
+		if err := decodeHasherParams(params, &config); err != nil {
+			return nil, err
+		}
+		return NewPBKDF2(config)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Sk2ft", "password hash algorithm not supported")
+	}
+}
+
+func decodeHasherParams(params map[string]interface{}, config interface{}) error {
+	if len(params) == 0 {
+		return nil
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           config,
+	})
+	if err != nil {
+		return errors.ThrowInternal(err, "CRYPT-Hs3fg", "unable to create params decoder")
+	}
+	if err = decoder.Decode(params); err != nil {
+		return errors.ThrowInvalidArgument(err, "CRYPT-Ko3sf", "invalid password hasher params")
+	}
+	return nil
+}
+
+// hashIdentifier returns the id of the hash in modular crypt / PHC string format (`$<id>$...`)
+// or the scheme of an LDAP password hash (`{<scheme>}...`)
+func hashIdentifier(hashed []byte) string {
+	if len(hashed) > 0 && hashed[0] == '{' {
+		if i := strings.IndexByte(string(hashed), '}'); i > 0 {
+			return string(hashed[:i+1])
+		}
+		return ""
+	}
+	if len(hashed) == 0 || hashed[0] != '$' {
+		return ""
+	}
+	id := hashed[1:]
+	if i := strings.IndexByte(string(id), '$'); i >= 0 {
+		id = id[:i]
+	}
+	return string(id)
+}
+
+const (
+	phcMinSaltLength = 8
+	phcMinHashLength = 16
+)
+
+// phcHash is a parsed hash in the PHC string format:
+// $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*]$<salt>$<hash>
+type phcHash struct {
+	id     string
+	params map[string]string
+	salt   []byte
+	hash   []byte
+}
+
+func parsePHC(hashed []byte) (*phcHash, error) {
+	parts := strings.Split(string(hashed), "$")
+	if len(parts) < 4 || parts[0] != "" {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Jf3sw", "invalid hash format")
+	}
+	h := &phcHash{
+		id:     parts[1],
+		params: make(map[string]string),
+	}
+	for _, part := range parts[2 : len(parts)-2] {
+		for _, param := range strings.Split(part, ",") {
+			key, value, ok := strings.Cut(param, "=")
+			if !ok {
+				return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ld3fs", "invalid hash format")
+			}
+			h.params[key] = value
+		}
+	}
+	var err error
+	if h.salt, err = decodeHashBase64(parts[len(parts)-2]); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-Pw3fg", "invalid hash format")
+	}
+	if h.hash, err = decodeHashBase64(parts[len(parts)-1]); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-Mf2sq", "invalid hash format")
+	}
+	// an empty digest would match any password
+	if len(h.salt) < phcMinSaltLength || len(h.hash) < phcMinHashLength {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Tq3sf", "salt or hash too short")
+	}
+	return h, nil
+}
+
+// uintParam returns the (non zero) value of the param
+func (h *phcHash) uintParam(name string, bitSize int) (uint64, error) {
+	value, err := strconv.ParseUint(h.params[name], 10, bitSize)
+	if err != nil {
+		return 0, errors.ThrowInvalidArgument(err, "CRYPT-Zs3fv", "invalid hash parameter")
+	}
+	if value == 0 {
+		return 0, errors.ThrowInvalidArgument(nil, "CRYPT-Xw2sd", "invalid hash parameter")
+	}
+	return value, nil
+}
+
+// checkHashParam checks if the value of a hash param is within the supported range
+func checkHashParam(value, max uint64) error {
+	if value > max {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Fs3gq", "hash parameter exceeds the supported maximum")
+	}
+	return nil
+}
+
+// encodeHashBase64 encodes salts and hashes as the PHC string format defines it (standard base64 without padding)
+func encodeHashBase64(value []byte) string {
+	return base64.RawStdEncoding.EncodeToString(value)
+}
+
+// decodeHashBase64 decodes salts and hashes with or without padding
+func decodeHashBase64(value string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+func randomSalt(length int) ([]byte, error) {
+	salt := make([]byte, length)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Ns2fg", "unable to generate salt")
+	}
+	return salt, nil
+}
+
+func compareDerivedKeys(expected, derived []byte) error {
+	if subtle.ConstantTimeCompare(expected, derived) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Bs3fw", "hash does not match")
+	}
+	return nil
+}
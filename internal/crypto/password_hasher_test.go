@@ -0,0 +1,498 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testBCryptConfig   = HasherConfig{Algorithm: HashAlgorithmBCrypt, Params: map[string]interface{}{"Cost": 4}}
+	testArgon2idConfig = HasherConfig{Algorithm: HashAlgorithmArgon2id, Params: map[string]interface{}{"Time": 1, "Memory": 64, "Threads": 1}}
+	testScryptConfig   = HasherConfig{Algorithm: HashAlgorithmScrypt, Params: map[string]interface{}{"Cost": 16, "R": 8, "P": 1}}
+	testPBKDF2Config   = HasherConfig{Algorithm: HashAlgorithmPBKDF2, Params: map[string]interface{}{"Rounds": 1000, "Hash": "sha512"}}
+)
+
+func TestPasswordHasher_HashAndCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		hasher   HasherConfig
+		wantID   string
+		wantAlgo string
+	}{
+		{
+			name:     "bcrypt",
+			hasher:   testBCryptConfig,
+			wantID:   "2a",
+			wantAlgo: HashAlgorithmBCrypt,
+		},
+		{
+			name:     "argon2id",
+			hasher:   testArgon2idConfig,
+			wantID:   argon2idIdentifier,
+			wantAlgo: HashAlgorithmArgon2id,
+		},
+		{
+			name:     "scrypt",
+			hasher:   testScryptConfig,
+			wantID:   scryptIdentifier,
+			wantAlgo: HashAlgorithmScrypt,
+		},
+		{
+			name:     "pbkdf2",
+			hasher:   testPBKDF2Config,
+			wantID:   pbkdf2SHA512Identifier,
+			wantAlgo: HashAlgorithmPBKDF2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := NewPasswordHasher(&PasswordHashConfig{Hasher: tt.hasher})
+			require.NoError(t, err)
+
+			value, err := Hash([]byte("password"), hasher)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlgo, value.Algorithm)
+			assert.Equal(t, tt.wantID, hashIdentifier(value.Crypted))
+
+			assert.NoError(t, CompareHash(value, []byte("password"), hasher))
+			assert.Error(t, CompareHash(value, []byte("wrong"), hasher))
+			assert.False(t, NeedsRehash(value, hasher))
+		})
+	}
+}
+
+func TestPasswordHasher_CompareHash(t *testing.T) {
+	type args struct {
+		verifiers []string
+		value     *CryptoValue
+		password  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "pbkdf2-sha256, ok",
+			args: args{
+				verifiers: []string{HashAlgorithmPBKDF2},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmPBKDF2,
+					Crypted:    []byte("$pbkdf2-sha256$i=1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA"),
+				},
+				password: "password",
+			},
+		},
+		{
+			name: "scrypt, ok",
+			args: args{
+				verifiers: []string{HashAlgorithmScrypt},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmScrypt,
+					Crypted:    []byte("$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA"),
+				},
+				password: "password",
+			},
+		},
+		{
+			name: "scrypt, wrong password",
+			args: args{
+				verifiers: []string{HashAlgorithmScrypt},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmScrypt,
+					Crypted:    []byte("$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA"),
+				},
+				password: "wrong",
+			},
+			wantErr: true,
+		},
+		{
+			name: "firebase scrypt, ok",
+			args: args{
+				verifiers: []string{HashAlgorithmFirebaseScrypt},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmFirebaseScrypt,
+					Crypted:    []byte("$firebase-scrypt$ln=14,r=8,k=jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==,s=Bw==$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ=="),
+				},
+				password: "user1password",
+			},
+		},
+		{
+			name: "firebase scrypt, wrong password",
+			args: args{
+				verifiers: []string{HashAlgorithmFirebaseScrypt},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmFirebaseScrypt,
+					Crypted:    []byte("$firebase-scrypt$ln=14,r=8,k=jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==,s=Bw==$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ=="),
+				},
+				password: "password",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ssha, ok",
+			args: args{
+				verifiers: []string{HashAlgorithmSSHA},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmSSHA,
+					Crypted:    []byte("{SSHA}rXVtWiPAY6/w8MuTLKIjpBjj2mtzYWx0MTIzNA=="),
+				},
+				password: "password",
+			},
+		},
+		{
+			name: "ssha512, ok",
+			args: args{
+				verifiers: []string{HashAlgorithmSSHA},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmSSHA,
+					Crypted:    []byte("{SSHA512}nOkBUt6l7zlKAfjtk1EfB0TmckXfDiA4FPLcpywOLORZ1PWQK4+PZVEiT4+9rFjqR3xnaruZBiRjDGcDpxxTinNhbHQxMjM0"),
+				},
+				password: "password",
+			},
+		},
+		{
+			name: "ssha, wrong password",
+			args: args{
+				verifiers: []string{HashAlgorithmSSHA},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmSSHA,
+					Crypted:    []byte("{SSHA}rXVtWiPAY6/w8MuTLKIjpBjj2mtzYWx0MTIzNA=="),
+				},
+				password: "wrong",
+			},
+			wantErr: true,
+		},
+		{
+			name: "algorithm not verified, error",
+			args: args{
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmScrypt,
+					Crypted:    []byte("$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA"),
+				},
+				password: "password",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid hash, error",
+			args: args{
+				verifiers: []string{HashAlgorithmPBKDF2},
+				value: &CryptoValue{
+					CryptoType: TypeHash,
+					Algorithm:  HashAlgorithmPBKDF2,
+					Crypted:    []byte("$pbkdf2-sha256$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA"),
+				},
+				password: "password",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := NewPasswordHasher(&PasswordHashConfig{Hasher: testBCryptConfig, Verifiers: tt.args.verifiers})
+			require.NoError(t, err)
+			err = CompareHash(tt.args.value, []byte(tt.args.password), hasher)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	oldHasher, err := NewPasswordHasher(&PasswordHashConfig{Hasher: testBCryptConfig})
+	require.NoError(t, err)
+	bcryptValue, err := Hash([]byte("password"), oldHasher)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		config PasswordHashConfig
+		value  *CryptoValue
+		want   bool
+	}{
+		{
+			name:   "same algorithm and cost",
+			config: PasswordHashConfig{Hasher: testBCryptConfig},
+			value:  bcryptValue,
+			want:   false,
+		},
+		{
+			name:   "other cost",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: HashAlgorithmBCrypt, Params: map[string]interface{}{"Cost": 5}}},
+			value:  bcryptValue,
+			want:   true,
+		},
+		{
+			name:   "other algorithm",
+			config: PasswordHashConfig{Hasher: testArgon2idConfig},
+			value:  bcryptValue,
+			want:   true,
+		},
+		{
+			name:   "other argon2 params",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: HashAlgorithmArgon2id, Params: map[string]interface{}{"Time": 2, "Memory": 64, "Threads": 1}}},
+			value: &CryptoValue{
+				CryptoType: TypeHash,
+				Algorithm:  HashAlgorithmArgon2id,
+				Crypted:    []byte("$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$c2FsdHNhbHRzYWx0c2FsdA"),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := NewPasswordHasher(&tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, NeedsRehash(tt.value, hasher))
+		})
+	}
+}
+
+func TestNewPasswordHasher_invalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config PasswordHashConfig
+	}{
+		{
+			name:   "unknown algorithm",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: "md5"}},
+		},
+		{
+			name:   "unknown verifier",
+			config: PasswordHashConfig{Verifiers: []string{"md5"}},
+		},
+		{
+			name:   "scrypt cost not power of two",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: HashAlgorithmScrypt, Params: map[string]interface{}{"Cost": 10}}},
+		},
+		{
+			name:   "pbkdf2 unknown hash",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: HashAlgorithmPBKDF2, Params: map[string]interface{}{"Hash": "md5"}}},
+		},
+		{
+			name:   "argon2id memory exceeded",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: HashAlgorithmArgon2id, Params: map[string]interface{}{"Memory": 4 * 1024 * 1024}}},
+		},
+		{
+			name:   "argon2id no threads",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: HashAlgorithmArgon2id, Params: map[string]interface{}{"Threads": 0}}},
+		},
+		{
+			name:   "bcrypt cost exceeded",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: HashAlgorithmBCrypt, Params: map[string]interface{}{"Cost": 17}}},
+		},
+		{
+			name:   "pbkdf2 rounds exceeded",
+			config: PasswordHashConfig{Hasher: HasherConfig{Algorithm: HashAlgorithmPBKDF2, Params: map[string]interface{}{"Rounds": 100000000}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPasswordHasher(&tt.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCheckHash(t *testing.T) {
+	hasher, err := NewPasswordHasher(&PasswordHashConfig{Hasher: testBCryptConfig, Verifiers: []string{HashAlgorithmPBKDF2, HashAlgorithmSSHA}})
+	require.NoError(t, err)
+	tests := []struct {
+		name    string
+		value   *CryptoValue
+		wantErr bool
+	}{
+		{
+			name:  "bcrypt",
+			value: &CryptoValue{CryptoType: TypeHash, Algorithm: HashAlgorithmBCrypt, Crypted: []byte("$2a$04$7lK9lZgT1CwvD1CF2gG6qeE5oBbXStBRuWAuJ6CjHt5jLmN9iy5Ni")},
+		},
+		{
+			name:  "ssha",
+			value: &CryptoValue{CryptoType: TypeHash, Algorithm: HashAlgorithmSSHA, Crypted: []byte("{SSHA}rXVtWiPAY6/w8MuTLKIjpBjj2mtzYWx0MTIzNA==")},
+		},
+		{
+			name:    "algorithm not verified",
+			value:   &CryptoValue{CryptoType: TypeHash, Algorithm: HashAlgorithmScrypt, Crypted: []byte("$scrypt$ln=4,r=8,p=1$c2FsdA$c2FsdA")},
+			wantErr: true,
+		},
+		{
+			name:    "algorithm does not match hash",
+			value:   &CryptoValue{CryptoType: TypeHash, Algorithm: HashAlgorithmPBKDF2, Crypted: []byte("{SSHA}rXVtWiPAY6/w8MuTLKIjpBjj2mtzYWx0MTIzNA==")},
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			value:   &CryptoValue{CryptoType: TypeHash, Algorithm: HashAlgorithmBCrypt, Crypted: []byte("5f4dcc3b5aa765d61d8327deb882cf99")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckHash(tt.value, hasher)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckHash_invalidHashes(t *testing.T) {
+	hasher, err := NewPasswordHasher(&PasswordHashConfig{
+		Hasher:    testBCryptConfig,
+		Verifiers: []string{HashAlgorithmArgon2id, HashAlgorithmScrypt, HashAlgorithmPBKDF2, HashAlgorithmFirebaseScrypt, HashAlgorithmSSHA},
+	})
+	require.NoError(t, err)
+	tests := []struct {
+		name      string
+		algorithm string
+		hash      string
+		wantErr   bool
+	}{
+		{
+			name:      "argon2id, ok",
+			algorithm: HashAlgorithmArgon2id,
+			hash:      "$argon2id$v=19$m=65536,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+		},
+		{
+			name:      "argon2id, empty hash",
+			algorithm: HashAlgorithmArgon2id,
+			hash:      "$argon2id$v=19$m=65536,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$",
+			wantErr:   true,
+		},
+		{
+			name:      "argon2id, short salt",
+			algorithm: HashAlgorithmArgon2id,
+			hash:      "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr:   true,
+		},
+		{
+			name:      "argon2id, no iterations",
+			algorithm: HashAlgorithmArgon2id,
+			hash:      "$argon2id$v=19$m=65536,t=0,p=4$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr:   true,
+		},
+		{
+			name:      "argon2id, no parallelism",
+			algorithm: HashAlgorithmArgon2id,
+			hash:      "$argon2id$v=19$m=65536,t=3,p=0$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr:   true,
+		},
+		{
+			name:      "argon2id, memory exceeded",
+			algorithm: HashAlgorithmArgon2id,
+			hash:      "$argon2id$v=19$m=4194304,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr:   true,
+		},
+		{
+			name:      "scrypt, ok",
+			algorithm: HashAlgorithmScrypt,
+			hash:      "$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA",
+		},
+		{
+			name:      "scrypt, short hash",
+			algorithm: HashAlgorithmScrypt,
+			hash:      "$scrypt$ln=4,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XR",
+			wantErr:   true,
+		},
+		{
+			name:      "scrypt, cost exceeded",
+			algorithm: HashAlgorithmScrypt,
+			hash:      "$scrypt$ln=30,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA",
+			wantErr:   true,
+		},
+		{
+			name:      "scrypt, parallelism exceeded",
+			algorithm: HashAlgorithmScrypt,
+			hash:      "$scrypt$ln=4,r=8,p=1000$c2FsdHNhbHRzYWx0c2FsdA$5f/Vi+XRWGUNGScbsma6KJ4zLFIke/NJsrvr7lQLAyA",
+			wantErr:   true,
+		},
+		{
+			name:      "pbkdf2, ok",
+			algorithm: HashAlgorithmPBKDF2,
+			hash:      "$pbkdf2-sha256$i=1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+		},
+		{
+			name:      "pbkdf2, no rounds",
+			algorithm: HashAlgorithmPBKDF2,
+			hash:      "$pbkdf2-sha256$i=0$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr:   true,
+		},
+		{
+			name:      "pbkdf2, rounds exceeded",
+			algorithm: HashAlgorithmPBKDF2,
+			hash:      "$pbkdf2-sha256$i=100000000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr:   true,
+		},
+		{
+			name:      "pbkdf2, empty salt",
+			algorithm: HashAlgorithmPBKDF2,
+			hash:      "$pbkdf2-sha256$i=1000$$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA",
+			wantErr:   true,
+		},
+		{
+			name:      "firebase scrypt, ok",
+			algorithm: HashAlgorithmFirebaseScrypt,
+			hash:      "$firebase-scrypt$ln=14,r=8,k=jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==,s=Bw==$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==",
+		},
+		{
+			name:      "firebase scrypt, signer key missing",
+			algorithm: HashAlgorithmFirebaseScrypt,
+			hash:      "$firebase-scrypt$ln=14,r=8,s=Bw==$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==",
+			wantErr:   true,
+		},
+		{
+			name:      "firebase scrypt, memory cost exceeded",
+			algorithm: HashAlgorithmFirebaseScrypt,
+			hash:      "$firebase-scrypt$ln=25,r=8,k=jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==,s=Bw==$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==",
+			wantErr:   true,
+		},
+		{
+			name:      "ssha, short salt",
+			algorithm: HashAlgorithmSSHA,
+			hash:      "{SSHA}rXVtWiPAY6/w8MuTLKIjpBjj2mtzYQ==",
+			wantErr:   true,
+		},
+		{
+			name:      "bcrypt, invalid cost",
+			algorithm: HashAlgorithmBCrypt,
+			hash:      "$2a$xx$7lK9lZgT1CwvD1CF2gG6qeE5oBbXStBRuWAuJ6CjHt5jLmN9iy5Ni",
+			wantErr:   true,
+		},
+		{
+			name:      "bcrypt, cost exceeded",
+			algorithm: HashAlgorithmBCrypt,
+			hash:      "$2a$31$7lK9lZgT1CwvD1CF2gG6qeE5oBbXStBRuWAuJ6CjHt5jLmN9iy5Ni",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := &CryptoValue{CryptoType: TypeHash, Algorithm: tt.algorithm, Crypted: []byte(tt.hash)}
+			err := CheckHash(value, hasher)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			// invalid hashes must not match any password on login either
+			assert.Error(t, CompareHash(value, []byte("password"), hasher))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	pbkdf2SHA1Identifier   = "pbkdf2"
+	pbkdf2SHA256Identifier = "pbkdf2-sha256"
+	pbkdf2SHA512Identifier = "pbkdf2-sha512"
+
+	pbkdf2DefaultRounds = 600000
+	pbkdf2DefaultHash   = "sha256"
+	pbkdf2SaltLength    = 16
+
+	// maximum rounds of hashes, which are verified
+	pbkdf2MaxRounds = 10000000
+)
+
+var _ PasswordHashAlgorithm = (*PBKDF2)(nil)
+
+type PBKDF2Config struct {
+	Rounds int
+	// Hash is one of sha1, sha256 or sha512
+	Hash string
+}
+
+// PBKDF2 hashes and verifies PBKDF2 hashes,
+// which are mostly used by the password hashes of other identity systems
+// $pbkdf2-<hash>$i=<rounds>$<salt>$<hash>
+type PBKDF2 struct {
+	config PBKDF2Config
+	id     string
+}
+
+func NewPBKDF2(config PBKDF2Config) (*PBKDF2, error) {
+	id, err := pbkdf2Identifier(config.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if config.Rounds <= 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Jw3sd", "pbkdf2 rounds must be positive")
+	}
+	if err = checkHashParam(uint64(config.Rounds), pbkdf2MaxRounds); err != nil {
+		return nil, err
+	}
+	return &PBKDF2{config: config, id: id}, nil
+}
+
+func (p *PBKDF2) Algorithm() string {
+	return HashAlgorithmPBKDF2
+}
+
+func (p *PBKDF2) Identifiers() []string {
+	return []string{pbkdf2SHA1Identifier, pbkdf2SHA256Identifier, pbkdf2SHA512Identifier}
+}
+
+func (p *PBKDF2) Hash(value []byte) ([]byte, error) {
+	salt, err := randomSalt(pbkdf2SaltLength)
+	if err != nil {
+		return nil, err
+	}
+	hashFunc := pbkdf2HashFunc(p.id)
+	key := pbkdf2.Key(value, salt, p.config.Rounds, hashFunc().Size(), hashFunc)
+	return []byte(fmt.Sprintf("$%s$i=%d$%s$%s",
+		p.id,
+		p.config.Rounds,
+		encodeHashBase64(salt),
+		encodeHashBase64(key),
+	)), nil
+}
+
+func (p *PBKDF2) CompareHash(hashed, value []byte) error {
+	h, rounds, err := parsePBKDF2(hashed)
+	if err != nil {
+		return err
+	}
+	key := pbkdf2.Key(value, h.salt, rounds, len(h.hash), pbkdf2HashFunc(h.id))
+	return compareDerivedKeys(h.hash, key)
+}
+
+func (p *PBKDF2) ValidateHash(hashed []byte) error {
+	_, _, err := parsePBKDF2(hashed)
+	return err
+}
+
+func (p *PBKDF2) NeedsRehash(hashed []byte) bool {
+	h, rounds, err := parsePBKDF2(hashed)
+	return err != nil || h.id != p.id || rounds != p.config.Rounds
+}
+
+func parsePBKDF2(hashed []byte) (*phcHash, int, error) {
+	h, err := parsePHC(hashed)
+	if err != nil {
+		return nil, 0, err
+	}
+	if pbkdf2HashFunc(h.id) == nil {
+		return nil, 0, errors.ThrowInvalidArgument(nil, "CRYPT-Rs3fw", "not a pbkdf2 hash")
+	}
+	rounds, err := h.uintParam("i", 31)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = checkHashParam(rounds, pbkdf2MaxRounds); err != nil {
+		return nil, 0, err
+	}
+	return h, int(rounds), nil
+}
+
+func pbkdf2Identifier(hash string) (string, error) {
+	switch hash {
+	case "sha1":
+		return pbkdf2SHA1Identifier, nil
+	case "sha256":
+		return pbkdf2SHA256Identifier, nil
+	case "sha512":
+		return pbkdf2SHA512Identifier, nil
+	default:
+		return "", errors.ThrowInvalidArgument(nil, "CRYPT-Dw2fs", "pbkdf2 hash not supported")
+	}
+}
+
+func pbkdf2HashFunc(id string) func() hash.Hash {
+	switch id {
+	case pbkdf2SHA1Identifier:
+		return sha1.New
+	case pbkdf2SHA256Identifier:
+		return sha256.New
+	case pbkdf2SHA512Identifier:
+		return sha512.New
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+package crypto
+
+import (
+	"fmt"
+	"math/bits"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	scryptIdentifier = "scrypt"
+
+	scryptDefaultCost = 1 << 15
+	scryptDefaultR    = 8
+	scryptDefaultP    = 1
+	scryptSaltLength  = 16
+	scryptKeyLength   = 32
+
+	// maximum params of hashes, which are verified,
+	// the memory used by scrypt (128 * cost * r) must not exceed 1 GiB
+	scryptMaxLogCost = 20
+	scryptMaxR       = 32
+	scryptMaxP       = 16
+	scryptMaxMemory  = 1 << 30
+)
+
+var _ PasswordHashAlgorithm = (*Scrypt)(nil)
+
+type ScryptConfig struct {
+	// Cost is the CPU/memory cost parameter N, which must be a power of two
+	Cost int
+	R    int
+	P    int
+}
+
+// Scrypt hashes and verifies scrypt hashes
+// $scrypt$ln=<log2(cost)>,r=<r>,p=<p>$<salt>$<hash>
+type Scrypt struct {
+	config ScryptConfig
+}
+
+func NewScrypt(config ScryptConfig) (*Scrypt, error) {
+	if config.Cost <= 1 || config.Cost&(config.Cost-1) != 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Vf3sq", "scrypt cost must be a power of two")
+	}
+	if err := checkScryptParams(config.Cost, config.R, config.P); err != nil {
+		return nil, err
+	}
+	return &Scrypt{config: config}, nil
+}
+
+func (s *Scrypt) Algorithm() string {
+	return HashAlgorithmScrypt
+}
+
+func (s *Scrypt) Identifiers() []string {
+	return []string{scryptIdentifier}
+}
+
+func (s *Scrypt) Hash(value []byte) ([]byte, error) {
+	salt, err := randomSalt(scryptSaltLength)
+	if err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(value, salt, s.config.Cost, s.config.R, s.config.P, scryptKeyLength)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Hw3fs", "unable to hash with scrypt")
+	}
+	return []byte(fmt.Sprintf("$%s$ln=%d,r=%d,p=%d$%s$%s",
+		scryptIdentifier,
+		bits.TrailingZeros(uint(s.config.Cost)),
+		s.config.R,
+		s.config.P,
+		encodeHashBase64(salt),
+		encodeHashBase64(key),
+	)), nil
+}
+
+func (s *Scrypt) CompareHash(hashed, value []byte) error {
+	h, config, err := parseScrypt(hashed)
+	if err != nil {
+		return err
+	}
+	key, err := scrypt.Key(value, h.salt, config.Cost, config.R, config.P, len(h.hash))
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "CRYPT-Ql2fs", "invalid scrypt parameters")
+	}
+	return compareDerivedKeys(h.hash, key)
+}
+
+func (s *Scrypt) ValidateHash(hashed []byte) error {
+	_, _, err := parseScrypt(hashed)
+	return err
+}
+
+func (s *Scrypt) NeedsRehash(hashed []byte) bool {
+	_, config, err := parseScrypt(hashed)
+	return err != nil || config != s.config
+}
+
+func parseScrypt(hashed []byte) (*phcHash, ScryptConfig, error) {
+	h, err := parsePHC(hashed)
+	if err != nil {
+		return nil, ScryptConfig{}, err
+	}
+	if h.id != scryptIdentifier {
+		return nil, ScryptConfig{}, errors.ThrowInvalidArgument(nil, "CRYPT-Ws2fq", "not a scrypt hash")
+	}
+	logCost, err := h.uintParam("ln", 6)
+	if err != nil {
+		return nil, ScryptConfig{}, err
+	}
+	if logCost > scryptMaxLogCost {
+		return nil, ScryptConfig{}, errors.ThrowInvalidArgument(nil, "CRYPT-Rw3qs", "hash parameter exceeds the supported maximum")
+	}
+	r, err := h.uintParam("r", 32)
+	if err != nil {
+		return nil, ScryptConfig{}, err
+	}
+	p, err := h.uintParam("p", 32)
+	if err != nil {
+		return nil, ScryptConfig{}, err
+	}
+	config := ScryptConfig{Cost: 1 << logCost, R: int(r), P: int(p)}
+	if err = checkScryptParams(config.Cost, config.R, config.P); err != nil {
+		return nil, ScryptConfig{}, err
+	}
+	return h, config, nil
+}
+
+// checkScryptParams ensures the params of a (firebase) scrypt hash do not exhaust the resources on verification
+func checkScryptParams(cost, r, p int) error {
+	if cost > 1<<scryptMaxLogCost {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Kw2sf", "hash parameter exceeds the supported maximum")
+	}
+	if err := checkHashParam(uint64(r), scryptMaxR); err != nil {
+		return err
+	}
+	if err := checkHashParam(uint64(p), scryptMaxP); err != nil {
+		return err
+	}
+	return checkHashParam(uint64(128*cost*r), scryptMaxMemory)
+}
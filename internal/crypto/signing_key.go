@@ -0,0 +1,83 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmES256 = "ES256"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+// GenerateEncryptedSigningKeyPair generates a key pair for the signature algorithm (RS256, ES256 or EdDSA),
+// bits are only used for RSA keys
+func GenerateEncryptedSigningKeyPair(algorithm string, bits int, alg EncryptionAlgorithm) (*CryptoValue, *CryptoValue, error) {
+	switch algorithm {
+	case "", SigningAlgorithmRS256:
+		return GenerateEncryptedKeyPair(bits, alg)
+	case SigningAlgorithmES256:
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return encryptPKCS8KeyPair(privateKey, &privateKey.PublicKey, alg)
+	case SigningAlgorithmEdDSA:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return encryptPKCS8KeyPair(privateKey, publicKey, alg)
+	default:
+		return nil, nil, fmt.Errorf("signing algorithm %s not supported", algorithm)
+	}
+}
+
+func encryptPKCS8KeyPair(privateKey, publicKey interface{}, alg EncryptionAlgorithm) (*CryptoValue, *CryptoValue, error) {
+	privateASN1, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicASN1, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedPrivateKey, err := Encrypt(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateASN1}), alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedPublicKey, err := Encrypt(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicASN1}), alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encryptedPrivateKey, encryptedPublicKey, nil
+}
+
+// BytesToSigningKey parses a private key of any signature algorithm
+// (*rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey)
+func BytesToSigningKey(priv []byte) (interface{}, error) {
+	block, _ := pem.Decode(priv)
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	if block.Type != "PRIVATE KEY" {
+		return BytesToPrivateKey(priv)
+	}
+	return x509.ParsePKCS8PrivateKey(block.Bytes)
+}
+
+// BytesToSigningPublicKey parses a public key of any signature algorithm
+// (*rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey)
+func BytesToSigningPublicKey(pub []byte) (interface{}, error) {
+	block, _ := pem.Decode(pub)
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
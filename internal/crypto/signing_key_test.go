@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateEncryptedSigningKeyPair(t *testing.T) {
+	tests := []struct {
+		algorithm      string
+		wantPrivateKey interface{}
+		wantPublicKey  interface{}
+		wantErr        bool
+	}{
+		{
+			algorithm:      SigningAlgorithmRS256,
+			wantPrivateKey: &rsa.PrivateKey{},
+			wantPublicKey:  &rsa.PublicKey{},
+		},
+		{
+			algorithm:      SigningAlgorithmES256,
+			wantPrivateKey: &ecdsa.PrivateKey{},
+			wantPublicKey:  &ecdsa.PublicKey{},
+		},
+		{
+			algorithm:      SigningAlgorithmEdDSA,
+			wantPrivateKey: ed25519.PrivateKey{},
+			wantPublicKey:  ed25519.PublicKey{},
+		},
+		{
+			algorithm: "HS256",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			alg := CreateMockEncryptionAlg(gomock.NewController(t))
+			privateCrypto, publicCrypto, err := GenerateEncryptedSigningKeyPair(tt.algorithm, 1024, alg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			privateBytes, err := Decrypt(privateCrypto, alg)
+			require.NoError(t, err)
+			privateKey, err := BytesToSigningKey(privateBytes)
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantPrivateKey, privateKey)
+
+			publicBytes, err := Decrypt(publicCrypto, alg)
+			require.NoError(t, err)
+			publicKey, err := BytesToSigningPublicKey(publicBytes)
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantPublicKey, publicKey)
+			assert.True(t, privateKey.(crypto.Signer).Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(publicKey))
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"hash"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	sshaIdentifier    = "{SSHA}"
+	ssha256Identifier = "{SSHA256}"
+	ssha512Identifier = "{SSHA512}"
+
+	// sshaMinSaltLength is the salt length of OpenLDAP
+	sshaMinSaltLength = 4
+)
+
+var _ PasswordVerifier = (*SSHA)(nil)
+
+// SSHA verifies salted SHA password hashes as used by LDAP directories:
+// {SSHA}<base64(sha1(password + salt) + salt)> (or {SSHA256} and {SSHA512} respectively)
+type SSHA struct{}
+
+func (s *SSHA) Algorithm() string {
+	return HashAlgorithmSSHA
+}
+
+func (s *SSHA) Identifiers() []string {
+	return []string{sshaIdentifier, ssha256Identifier, ssha512Identifier}
+}
+
+func (s *SSHA) CompareHash(hashed, value []byte) error {
+	hashFunc, digest, salt, err := parseSSHA(hashed)
+	if err != nil {
+		return err
+	}
+	h := hashFunc()
+	h.Write(value)
+	h.Write(salt)
+	return compareDerivedKeys(digest, h.Sum(nil))
+}
+
+func (s *SSHA) ValidateHash(hashed []byte) error {
+	_, _, _, err := parseSSHA(hashed)
+	return err
+}
+
+func parseSSHA(hashed []byte) (hashFunc func() hash.Hash, digest, salt []byte, err error) {
+	id := hashIdentifier(hashed)
+	switch id {
+	case sshaIdentifier:
+		hashFunc = sha1.New
+	case ssha256Identifier:
+		hashFunc = sha256.New
+	case ssha512Identifier:
+		hashFunc = sha512.New
+	default:
+		return nil, nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Vs3fw", "not a salted sha hash")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(string(hashed[len(id):]))
+	if err != nil {
+		return nil, nil, nil, errors.ThrowInvalidArgument(err, "CRYPT-Nw2fs", "invalid hash format")
+	}
+	size := hashFunc().Size()
+	if len(decoded) < size+sshaMinSaltLength {
+		return nil, nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Xs3qf", "invalid hash format")
+	}
+	return hashFunc, decoded[:size], decoded[size:], nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ActionLibraryModulePrefix is the prefix of the module name to require a library in an action,
+// e.g. `require("lib/crm")` for the latest or `require("lib/crm@2")` for a specific version
+const ActionLibraryModulePrefix = "lib/"
+
+var (
+	actionLibraryNameRegex    = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,200}$`)
+	actionLibraryRequireRegex = regexp.MustCompile(`require\(\s*["']` + ActionLibraryModulePrefix + `([^"'\s]+)["']\s*\)`)
+)
+
+func ActionLibraryNameValid(name string) bool {
+	return actionLibraryNameRegex.MatchString(name)
+}
+
+// ActionLibraryDependencies returns the distinct libraries required by the script
+// as `name` or `name@version`
+func ActionLibraryDependencies(script string) []string {
+	matches := actionLibraryRequireRegex.FindAllStringSubmatch(script, -1)
+	dependencies := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if !containsString(dependencies, match[1]) {
+			dependencies = append(dependencies, match[1])
+		}
+	}
+	return dependencies
+}
+
+// ParseActionLibraryDependency splits the dependency into name and version,
+// the version is 0 if the latest version is required
+func ParseActionLibraryDependency(dependency string) (name string, version uint64, ok bool) {
+	name, rawVersion, pinned := strings.Cut(dependency, "@")
+	if !ActionLibraryNameValid(name) {
+		return "", 0, false
+	}
+	if !pinned {
+		return name, 0, true
+	}
+	version, err := strconv.ParseUint(rawVersion, 10, 64)
+	if err != nil || version == 0 {
+		return "", 0, false
+	}
+	return name, version, true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestActionLibraryDependencies(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "no dependencies",
+			script: `let http = require("zitadel/http")`,
+			want:   []string{},
+		},
+		{
+			name: "distinct dependencies",
+			script: `
+let helpers = require("lib/helpers");
+let crm = require( 'lib/crm@2' );
+let again = require("lib/helpers");`,
+			want: []string{"helpers", "crm@2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ActionLibraryDependencies(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActionLibraryDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseActionLibraryDependency(t *testing.T) {
+	tests := []struct {
+		name        string
+		dependency  string
+		wantName    string
+		wantVersion uint64
+		wantOK      bool
+	}{
+		{
+			name:       "latest",
+			dependency: "helpers",
+			wantName:   "helpers",
+			wantOK:     true,
+		},
+		{
+			name:        "pinned",
+			dependency:  "helpers@3",
+			wantName:    "helpers",
+			wantVersion: 3,
+			wantOK:      true,
+		},
+		{
+			name:       "invalid version",
+			dependency: "helpers@0",
+		},
+		{
+			name:       "invalid name",
+			dependency: "../helpers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, version, ok := ParseActionLibraryDependency(tt.dependency)
+			if name != tt.wantName || version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("ParseActionLibraryDependency() = %q, %d, %v, want %q, %d, %v", name, version, ok, tt.wantName, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
@@ -44,6 +44,7 @@ type AuthRequest struct {
 	LinkingUsers             []*ExternalUser
 	PossibleSteps            []NextStep
 	PasswordVerified         bool
+	PasswordWarningSkipped   bool
 	MFAsVerified             []MFAType
 	Audience                 []string
 	AuthTime                 time.Time
@@ -105,8 +106,36 @@ const (
 	MFATypeOTP MFAType = iota
 	MFATypeU2F
 	MFATypeU2FUserVerification
+	MFATypeOTPSMS
+	MFATypeOTPEmail
 )
 
+const (
+	// DEPRECATED: use `AMRPWD` instead
+	AMRPassword     = "password"
+	AMRPWD          = "pwd"
+	AMRMFA          = "mfa"
+	AMROTP          = "otp"
+	AMRSMS          = "sms"
+	AMRUserPresence = "user"
+)
+
+// AMRFromMFAType returns the authentication method reference (amr) of the mfa type
+func AMRFromMFAType(mfaType MFAType) string {
+	switch mfaType {
+	case MFATypeOTP,
+		MFATypeOTPEmail:
+		return AMROTP
+	case MFATypeOTPSMS:
+		return AMRSMS
+	case MFATypeU2F,
+		MFATypeU2FUserVerification:
+		return AMRUserPresence
+	default:
+		return ""
+	}
+}
+
 type MFALevel int
 
 const (
@@ -122,6 +151,8 @@ func NewAuthRequestFromType(requestType AuthRequestType) (*AuthRequest, error) {
 		return &AuthRequest{Request: &AuthRequestOIDC{}}, nil
 	case AuthRequestTypeSAML:
 		return &AuthRequest{Request: &AuthRequestSAML{}}, nil
+	case AuthRequestTypeDevice:
+		return &AuthRequest{Request: &AuthRequestDevice{}}, nil
 	}
 	return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-ds2kl", "invalid request type")
 }
@@ -152,6 +183,23 @@ func (a *AuthRequest) MFALevel() MFALevel {
 	//PLANNED: check a.PossibleLOAs (and Prompt Login?)
 }
 
+// AuthMethodsReferences returns the authentication methods (amr) the user verified during the auth request
+func (a *AuthRequest) AuthMethodsReferences() []string {
+	amr := make([]string, 0)
+	if a.PasswordVerified {
+		amr = append(amr, AMRPassword, AMRPWD)
+	}
+	if len(a.MFAsVerified) > 0 {
+		amr = append(amr, AMRMFA)
+		for _, mfa := range a.MFAsVerified {
+			if amrMFA := AMRFromMFAType(mfa); amrMFA != "" {
+				amr = append(amr, amrMFA)
+			}
+		}
+	}
+	return amr
+}
+
 func (a *AuthRequest) AppendAudIfNotExisting(aud string) {
 	for _, a := range a.Audience {
 		if a == aud {
@@ -162,25 +210,29 @@ func (a *AuthRequest) AppendAudIfNotExisting(aud string) {
 }
 
 func (a *AuthRequest) GetScopeOrgPrimaryDomain() string {
-	switch request := a.Request.(type) {
-	case *AuthRequestOIDC:
-		for _, scope := range request.Scopes {
-			if strings.HasPrefix(scope, OrgDomainPrimaryScope) {
-				return strings.TrimPrefix(scope, OrgDomainPrimaryScope)
-			}
+	for _, scope := range a.requestScopes() {
+		if strings.HasPrefix(scope, OrgDomainPrimaryScope) {
+			return strings.TrimPrefix(scope, OrgDomainPrimaryScope)
 		}
 	}
 	return ""
 }
 
 func (a *AuthRequest) GetScopeOrgID() string {
-	switch request := a.Request.(type) {
-	case *AuthRequestOIDC:
-		for _, scope := range request.Scopes {
-			if strings.HasPrefix(scope, OrgIDScope) {
-				return strings.TrimPrefix(scope, OrgIDScope)
-			}
+	for _, scope := range a.requestScopes() {
+		if strings.HasPrefix(scope, OrgIDScope) {
+			return strings.TrimPrefix(scope, OrgIDScope)
 		}
 	}
 	return ""
 }
+
+func (a *AuthRequest) requestScopes() []string {
+	switch request := a.Request.(type) {
+	case *AuthRequestOIDC:
+		return request.Scopes
+	case *AuthRequestDevice:
+		return request.Scopes
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ const (
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
+	VerifySMSOTPMessageType             = "VerifySMSOTP"
+	VerifyEmailOTPMessageType           = "VerifyEmailOTP"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -31,6 +33,8 @@ type MessageTexts struct {
 	DomainClaimed            CustomMessageText
 	PasswordlessRegistration CustomMessageText
 	PasswordChange           CustomMessageText
+	VerifySMSOTP             CustomMessageText
+	VerifyEmailOTP           CustomMessageText
 }
 
 type CustomMessageText struct {
@@ -69,6 +73,10 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *CustomMessageText {
 		return &m.PasswordlessRegistration
 	case PasswordChangeMessageType:
 		return &m.PasswordChange
+	case VerifySMSOTPMessageType:
+		return &m.VerifySMSOTP
+	case VerifyEmailOTPMessageType:
+		return &m.VerifyEmailOTP
 	}
 	return nil
 }
@@ -80,5 +88,7 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyPhoneMessageType ||
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
-		textType == PasswordChangeMessageType
+		textType == PasswordChangeMessageType ||
+		textType == VerifySMSOTPMessageType ||
+		textType == VerifyEmailOTPMessageType
 }
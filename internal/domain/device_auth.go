@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+type DeviceAuthState int32
+
+const (
+	DeviceAuthStateUnspecified DeviceAuthState = iota
+	DeviceAuthStateInitiated
+	DeviceAuthStateApproved
+	DeviceAuthStateDenied
+	DeviceAuthStateRemoved
+	deviceAuthStateCount
+)
+
+func (s DeviceAuthState) Valid() bool {
+	return s >= 0 && s < deviceAuthStateCount
+}
+
+func (s DeviceAuthState) Exists() bool {
+	return s != DeviceAuthStateUnspecified && s != DeviceAuthStateRemoved
+}
+
+// DeviceCodeHash returns the hash of the device code,
+// only the hash is stored as the device code is the secret used by the device to retrieve the tokens
+func DeviceCodeHash(deviceCode string) string {
+	hash := sha256.Sum256([]byte(deviceCode))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -21,6 +21,9 @@ const (
 	FlowTypeExternalAuthentication
 	FlowTypeCustomiseToken
 	FlowTypeInternalAuthentication
+	FlowTypeCustomiseSAMLResponse
+	FlowTypePreRegistration
+	FlowTypePostPasswordChange
 	flowTypeCount
 )
 
@@ -56,6 +59,18 @@ func (s FlowType) TriggerTypes() []TriggerType {
 			TriggerTypePreCreation,
 			TriggerTypePostCreation,
 		}
+	case FlowTypeCustomiseSAMLResponse:
+		return []TriggerType{
+			TriggerTypePreSAMLResponseCreation,
+		}
+	case FlowTypePreRegistration:
+		return []TriggerType{
+			TriggerTypePreRegistrationValidation,
+		}
+	case FlowTypePostPasswordChange:
+		return []TriggerType{
+			TriggerTypePostPasswordChange,
+		}
 	default:
 		return nil
 	}
@@ -80,6 +95,12 @@ func (s FlowType) LocalizationKey() string {
 		return "Action.Flow.Type.CustomiseToken"
 	case FlowTypeInternalAuthentication:
 		return "Action.Flow.Type.InternalAuthentication"
+	case FlowTypeCustomiseSAMLResponse:
+		return "Action.Flow.Type.CustomiseSAMLResponse"
+	case FlowTypePreRegistration:
+		return "Action.Flow.Type.PreRegistration"
+	case FlowTypePostPasswordChange:
+		return "Action.Flow.Type.PostPasswordChange"
 	default:
 		return "Action.Flow.Type.Unspecified"
 	}
@@ -94,6 +115,9 @@ const (
 	TriggerTypePostCreation
 	TriggerTypePreUserinfoCreation
 	TriggerTypePreAccessTokenCreation
+	TriggerTypePreSAMLResponseCreation
+	TriggerTypePreRegistrationValidation
+	TriggerTypePostPasswordChange
 	triggerTypeCount
 )
 
@@ -124,6 +148,12 @@ func (s TriggerType) LocalizationKey() string {
 		return "Action.TriggerType.PreUserinfoCreation"
 	case TriggerTypePreAccessTokenCreation:
 		return "Action.TriggerType.PreAccessTokenCreation"
+	case TriggerTypePreSAMLResponseCreation:
+		return "Action.TriggerType.PreSAMLResponseCreation"
+	case TriggerTypePreRegistrationValidation:
+		return "Action.TriggerType.PreRegistrationValidation"
+	case TriggerTypePostPasswordChange:
+		return "Action.TriggerType.PostPasswordChange"
 	default:
 		return "Action.TriggerType.Unspecified"
 	}
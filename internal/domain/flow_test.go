@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestFlowType_HasTrigger(t *testing.T) {
+	tests := []struct {
+		name        string
+		flowType    FlowType
+		triggerType TriggerType
+		want        bool
+	}{
+		{
+			name:        "customise saml response, pre saml response creation",
+			flowType:    FlowTypeCustomiseSAMLResponse,
+			triggerType: TriggerTypePreSAMLResponseCreation,
+			want:        true,
+		},
+		{
+			name:        "customise saml response, pre userinfo creation",
+			flowType:    FlowTypeCustomiseSAMLResponse,
+			triggerType: TriggerTypePreUserinfoCreation,
+			want:        false,
+		},
+		{
+			name:        "pre registration, pre registration validation",
+			flowType:    FlowTypePreRegistration,
+			triggerType: TriggerTypePreRegistrationValidation,
+			want:        true,
+		},
+		{
+			name:        "internal authentication, pre registration validation",
+			flowType:    FlowTypeInternalAuthentication,
+			triggerType: TriggerTypePreRegistrationValidation,
+			want:        false,
+		},
+		{
+			name:        "post password change, post password change",
+			flowType:    FlowTypePostPasswordChange,
+			triggerType: TriggerTypePostPasswordChange,
+			want:        true,
+		},
+		{
+			name:        "unspecified",
+			flowType:    FlowTypeUnspecified,
+			triggerType: TriggerTypePostPasswordChange,
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flowType.HasTrigger(tt.triggerType); got != tt.want {
+				t.Errorf("HasTrigger() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlowType_Valid(t *testing.T) {
+	for _, flowType := range []FlowType{FlowTypeCustomiseSAMLResponse, FlowTypePreRegistration, FlowTypePostPasswordChange} {
+		if !flowType.Valid() {
+			t.Errorf("flow type %d must be valid", flowType)
+		}
+	}
+	if flowTypeCount.Valid() {
+		t.Error("flow type count must not be valid")
+	}
+}
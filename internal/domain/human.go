@@ -95,10 +95,10 @@ func (u *Human) SetNamesAsDisplayname() {
 	}
 }
 
-func (u *Human) HashPasswordIfExisting(policy *PasswordComplexityPolicy, passwordAlg crypto.HashAlgorithm, onetime bool) error {
+func (u *Human) HashPasswordIfExisting(passwordAlg crypto.HashAlgorithm, onetime bool) error {
 	if u.Password != nil {
 		u.Password.ChangeRequired = onetime
-		return u.Password.HashPasswordIfExisting(policy, passwordAlg)
+		return u.Password.HashPasswordIfExisting(passwordAlg)
 	}
 	return nil
 }
@@ -30,15 +30,22 @@ type PasswordCode struct {
 	NotificationType NotificationType
 }
 
-func (p *Password) HashPasswordIfExisting(policy *PasswordComplexityPolicy, passwordAlg crypto.HashAlgorithm) error {
+// CheckPolicy returns an error if the (plain) password does not match the complexity policy
+func (p *Password) CheckPolicy(policy *PasswordComplexityPolicy) error {
 	if p.SecretString == "" {
 		return nil
 	}
 	if policy == nil {
 		return caos_errs.ThrowPreconditionFailed(nil, "DOMAIN-s8ifS", "Errors.User.PasswordComplexityPolicy.NotFound")
 	}
-	if err := policy.Check(p.SecretString); err != nil {
-		return err
+	return policy.Check(p.SecretString)
+}
+
+// HashPasswordIfExisting hashes the (plain) password,
+// which must be checked against the policies (see [Password.CheckPolicy]) beforehand
+func (p *Password) HashPasswordIfExisting(passwordAlg crypto.HashAlgorithm) error {
+	if p.SecretString == "" {
+		return nil
 	}
 	secret, err := crypto.Hash([]byte(p.SecretString), passwordAlg)
 	if err != nil {
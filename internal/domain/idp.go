@@ -33,6 +33,8 @@ const (
 	IDPTypeGitLab
 	IDPTypeGitLabSelfHosted
 	IDPTypeGoogle
+	IDPTypeSAML
+	IDPTypeApple
 )
 
 func (t IDPType) GetCSSClass() string {
@@ -50,7 +52,9 @@ func (t IDPType) GetCSSClass() string {
 		IDPTypeJWT,
 		IDPTypeOAuth,
 		IDPTypeLDAP,
-		IDPTypeAzureAD:
+		IDPTypeAzureAD,
+		IDPTypeSAML,
+		IDPTypeApple:
 		fallthrough
 	default:
 		return ""
@@ -16,6 +16,13 @@ type OIDCSettings struct {
 	IdTokenLifetime            time.Duration
 	RefreshTokenIdleExpiration time.Duration
 	RefreshTokenExpiration     time.Duration
+
+	// SigningKeyAlgorithm of the next generated signing keys (empty uses the system default)
+	SigningKeyAlgorithm string
+	// SigningKeyLifetime is the duration a signing key is used for signing (0 uses the system default)
+	SigningKeyLifetime time.Duration
+	// SigningKeyOverlap is the duration a signing key is published before it's used and after it's retired (0 uses the system default)
+	SigningKeyOverlap time.Duration
 }
 
 type OIDCSettingsState int32
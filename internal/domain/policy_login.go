@@ -80,6 +80,8 @@ func (p IDPProvider) DisplayName() string {
 		return "GitLab"
 	case IDPTypeGoogle:
 		return "Google"
+	case IDPTypeApple:
+		return "Apple"
 	case IDPTypeUnspecified,
 		IDPTypeOIDC,
 		IDPTypeJWT,
@@ -87,7 +89,8 @@ func (p IDPProvider) DisplayName() string {
 		IDPTypeLDAP,
 		IDPTypeAzureAD,
 		IDPTypeGitHubEnterprise,
-		IDPTypeGitLabSelfHosted:
+		IDPTypeGitLabSelfHosted,
+		IDPTypeSAML:
 		fallthrough
 	default:
 		// we should never get here, so log it
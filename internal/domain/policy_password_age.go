@@ -1,12 +1,51 @@
 package domain
 
 import (
+	"time"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
+// PasswordHistoryMaxCount is the maximum of previous passwords a password age policy can prevent from being reused
+const PasswordHistoryMaxCount = 24
+
 type PasswordAgePolicy struct {
 	models.ObjectRoot
 
 	MaxAgeDays     uint64
 	ExpireWarnDays uint64
+	// HistoryCount is the amount of previous passwords, which must not be reused (0 disables the check)
+	HistoryCount uint64
+}
+
+func (p *PasswordAgePolicy) IsValid() error {
+	if p.HistoryCount > PasswordHistoryMaxCount {
+		return caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Hc9sw", "Errors.User.PasswordAgePolicy.HistoryCountNotAllowed")
+	}
+	return nil
+}
+
+// PasswordExpiry returns the time a password changed at the given time expires,
+// the zero time is returned if passwords never expire
+func (p *PasswordAgePolicy) PasswordExpiry(changed time.Time) time.Time {
+	if p.MaxAgeDays == 0 || changed.IsZero() {
+		return time.Time{}
+	}
+	return changed.AddDate(0, 0, int(p.MaxAgeDays))
+}
+
+// PasswordExpired returns true if a password changed at the given time is expired at now
+func (p *PasswordAgePolicy) PasswordExpired(changed, now time.Time) bool {
+	expiry := p.PasswordExpiry(changed)
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+// PasswordExpiryWarning returns true if a password changed at the given time expires within the warn days
+func (p *PasswordAgePolicy) PasswordExpiryWarning(changed, now time.Time) bool {
+	expiry := p.PasswordExpiry(changed)
+	if expiry.IsZero() || p.ExpireWarnDays == 0 {
+		return false
+	}
+	return !now.Before(expiry.AddDate(0, 0, -int(p.ExpireWarnDays)))
 }
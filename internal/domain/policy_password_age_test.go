@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordAgePolicy_PasswordExpiry(t *testing.T) {
+	changed := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		policy *PasswordAgePolicy
+		now    time.Time
+	}
+	type want struct {
+		expired bool
+		warning bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"no max age, not expired",
+			args{
+				policy: &PasswordAgePolicy{ExpireWarnDays: 5},
+				now:    changed.AddDate(1, 0, 0),
+			},
+			want{},
+		},
+		{
+			"before warn days, no warning",
+			args{
+				policy: &PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 5},
+				now:    changed.AddDate(0, 0, 24),
+			},
+			want{},
+		},
+		{
+			"within warn days, warning",
+			args{
+				policy: &PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 5},
+				now:    changed.AddDate(0, 0, 25),
+			},
+			want{warning: true},
+		},
+		{
+			"within warn days and no warn days, no warning",
+			args{
+				policy: &PasswordAgePolicy{MaxAgeDays: 30},
+				now:    changed.AddDate(0, 0, 29),
+			},
+			want{},
+		},
+		{
+			"max age reached, expired",
+			args{
+				policy: &PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 5},
+				now:    changed.AddDate(0, 0, 30),
+			},
+			want{expired: true, warning: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want.expired, tt.args.policy.PasswordExpired(changed, tt.args.now))
+			assert.Equal(t, tt.want.warning, tt.args.policy.PasswordExpiryWarning(changed, tt.args.now))
+		})
+	}
+}
@@ -22,6 +22,8 @@ type PasswordComplexityPolicy struct {
 	HasUppercase bool
 	HasNumber    bool
 	HasSymbol    bool
+	// CheckBreached checks passwords against the corpus of breached passwords
+	CheckBreached bool
 
 	Default bool
 }
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -9,5 +11,14 @@ type LockoutPolicy struct {
 
 	Default             bool
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
 	ShowLockOutFailures bool
+	// LockoutDuration defines after how long a locked user is unlocked automatically.
+	// If zero, the user stays locked until unlocked by an administrator.
+	LockoutDuration time.Duration
+}
+
+// IsTemporary returns true if users locked by the policy are unlocked automatically
+func (p *LockoutPolicy) IsTemporary() bool {
+	return p != nil && p.LockoutDuration > 0
 }
@@ -0,0 +1,31 @@
+package domain
+
+type RateLimitKeyType int32
+
+const (
+	RateLimitKeyTypeUnspecified RateLimitKeyType = iota
+	// RateLimitKeyTypeIP counts the failed attempts of a client ip
+	RateLimitKeyTypeIP
+	// RateLimitKeyTypeLoginName counts the failed attempts on a login name
+	RateLimitKeyTypeLoginName
+	// RateLimitKeyTypeClientID counts the failed client authentications of an application
+	RateLimitKeyTypeClientID
+	rateLimitKeyTypeCount
+)
+
+func (t RateLimitKeyType) Valid() bool {
+	return t > RateLimitKeyTypeUnspecified && t < rateLimitKeyTypeCount
+}
+
+func (t RateLimitKeyType) String() string {
+	switch t {
+	case RateLimitKeyTypeIP:
+		return "ip"
+	case RateLimitKeyTypeLoginName:
+		return "login_name"
+	case RateLimitKeyTypeClientID:
+		return "client_id"
+	default:
+		return "unspecified"
+	}
+}
@@ -22,6 +22,7 @@ type AuthRequestType int32
 const (
 	AuthRequestTypeOIDC AuthRequestType = iota
 	AuthRequestTypeSAML
+	AuthRequestTypeDevice
 )
 
 type AuthRequestOIDC struct {
@@ -56,3 +57,17 @@ func (a *AuthRequestSAML) Type() AuthRequestType {
 func (a *AuthRequestSAML) IsValid() bool {
 	return true
 }
+
+type AuthRequestDevice struct {
+	ID       string
+	UserCode string
+	Scopes   []string
+}
+
+func (a *AuthRequestDevice) Type() AuthRequestType {
+	return AuthRequestTypeDevice
+}
+
+func (a *AuthRequestDevice) IsValid() bool {
+	return a.ID != "" && a.UserCode != ""
+}
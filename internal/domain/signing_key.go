@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+const (
+	// SigningKeyGracefulPeriod before the expiry of its private key, a signing key is no longer used for signing
+	SigningKeyGracefulPeriod = 10 * time.Minute
+	// SigningKeyMinLifetime is the minimal duration a signing key must be used for signing
+	SigningKeyMinLifetime = time.Hour
+)
+
+type KeyState int32
+
+const (
+	KeyStateUnspecified KeyState = iota
+	// KeyStateNext keys are published, but not yet used for signing
+	KeyStateNext
+	// KeyStateActive keys are used for signing
+	KeyStateActive
+	// KeyStateRetired keys are no longer used for signing, but still published
+	KeyStateRetired
+)
+
+func SigningKeyAlgorithmValid(algorithm string) bool {
+	switch algorithm {
+	case crypto.SigningAlgorithmRS256,
+		crypto.SigningAlgorithmES256,
+		crypto.SigningAlgorithmEdDSA:
+		return true
+	}
+	return false
+}
+
+// SigningKeyRotation defines the lifecycle of the signing keys of an instance
+type SigningKeyRotation struct {
+	Algorithm string
+	// Lifetime is the duration a key is used for signing
+	Lifetime time.Duration
+	// Overlap is the duration a key is published before it's used for signing and after it's retired
+	Overlap time.Duration
+}
+
+// NextSigningKey returns if a new signing key must be generated and the expiries of its private and public key.
+// privateKeyExpiries are the expiries of the keys not yet retired ordered ascending,
+// the first one is the active key.
+// At most one next key is generated, as soon as the active key is retired within the overlap.
+func (r *SigningKeyRotation) NextSigningKey(privateKeyExpiries []time.Time, now time.Time) (due bool, privateKeyExpiry, publicKeyExpiry time.Time) {
+	switch len(privateKeyExpiries) {
+	case 0:
+		privateKeyExpiry = now.Add(r.Lifetime)
+	case 1:
+		active := privateKeyExpiries[0]
+		if active.Add(-SigningKeyGracefulPeriod).Sub(now) > r.Overlap {
+			return false, time.Time{}, time.Time{}
+		}
+		privateKeyExpiry = active.Add(r.Lifetime)
+	default:
+		return false, time.Time{}, time.Time{}
+	}
+	return true, privateKeyExpiry, privateKeyExpiry.Add(r.Overlap)
+}
+
+// SigningKeyState returns the state of a signing key,
+// activeExpiry is the expiry of the private key of the active signing key
+func SigningKeyState(privateKeyExpiry, activeExpiry, now time.Time) KeyState {
+	if !privateKeyExpiry.After(now.Add(SigningKeyGracefulPeriod)) {
+		return KeyStateRetired
+	}
+	if privateKeyExpiry.After(activeExpiry) {
+		return KeyStateNext
+	}
+	return KeyStateActive
+}
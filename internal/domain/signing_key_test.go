@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigningKeyRotation_NextSigningKey(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	rotation := &SigningKeyRotation{
+		Lifetime: 6 * time.Hour,
+		Overlap:  2 * time.Hour,
+	}
+	type want struct {
+		due              bool
+		privateKeyExpiry time.Time
+		publicKeyExpiry  time.Time
+	}
+	tests := []struct {
+		name     string
+		expiries []time.Time
+		want     want
+	}{
+		{
+			"no key, due",
+			nil,
+			want{
+				due:              true,
+				privateKeyExpiry: now.Add(6 * time.Hour),
+				publicKeyExpiry:  now.Add(8 * time.Hour),
+			},
+		},
+		{
+			"active key not retired within overlap, not due",
+			[]time.Time{now.Add(3 * time.Hour)},
+			want{},
+		},
+		{
+			"active key retired within overlap, due",
+			[]time.Time{now.Add(2 * time.Hour)},
+			want{
+				due:              true,
+				privateKeyExpiry: now.Add(8 * time.Hour),
+				publicKeyExpiry:  now.Add(10 * time.Hour),
+			},
+		},
+		{
+			"next key exists, not due",
+			[]time.Time{now.Add(time.Hour), now.Add(7 * time.Hour)},
+			want{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, privateKeyExpiry, publicKeyExpiry := rotation.NextSigningKey(tt.expiries, now)
+			assert.Equal(t, tt.want, want{due, privateKeyExpiry, publicKeyExpiry})
+		})
+	}
+}
+
+func TestSigningKeyState(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	active := now.Add(time.Hour)
+	assert.Equal(t, KeyStateRetired, SigningKeyState(now.Add(-time.Hour), active, now))
+	assert.Equal(t, KeyStateRetired, SigningKeyState(now.Add(SigningKeyGracefulPeriod), active, now))
+	assert.Equal(t, KeyStateActive, SigningKeyState(active, active, now))
+	assert.Equal(t, KeyStateNext, SigningKeyState(now.Add(7*time.Hour), active, now))
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type Webhook struct {
+	models.ObjectRoot
+
+	Name           string
+	URL            string
+	AggregateTypes []string
+	EventTypes     []string
+	State          WebhookState
+}
+
+func (w *Webhook) IsValid() bool {
+	if w.Name == "" {
+		return false
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return false
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return false
+	}
+	// hosts resolving to internal addresses are refused when the events are delivered
+	if strings.EqualFold(u.Hostname(), "localhost") {
+		return false
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !IsPublicIP(ip) {
+		return false
+	}
+	return true
+}
+
+var (
+	// sharedAddressSpace is used for carrier-grade NAT (RFC 6598)
+	sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+	// thisNetwork addresses the current network (RFC 1122)
+	thisNetwork = &net.IPNet{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)}
+)
+
+// IsPublicIP returns false for loopback, private, link-local (e.g. cloud metadata services),
+// shared, multicast and unspecified addresses, webhooks must not be delivered to them
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip) &&
+		!thisNetwork.Contains(ip)
+}
+
+type WebhookState int32
+
+const (
+	WebhookStateUnspecified WebhookState = iota
+	WebhookStateActive
+	WebhookStateInactive
+	WebhookStateRemoved
+	webhookStateCount
+)
+
+func (s WebhookState) Valid() bool {
+	return s >= 0 && s < webhookStateCount
+}
+
+func (s WebhookState) Exists() bool {
+	return s != WebhookStateUnspecified && s != WebhookStateRemoved
+}
+
+type WebhookDeliveryState int32
+
+const (
+	WebhookDeliveryStateUnspecified WebhookDeliveryState = iota
+	WebhookDeliveryStateSucceeded
+	WebhookDeliveryStateFailed
+	WebhookDeliveryStatePending
+)
@@ -25,6 +25,24 @@ type Command interface {
 	UniqueConstraints() []*EventUniqueConstraint
 }
 
+// sequenceCheckedCommand is only pushed if the aggregate wasn't changed since the sequence
+type sequenceCheckedCommand struct {
+	Command
+	sequence   uint64
+	eventTypes []EventType
+}
+
+// CheckSequence returns the command, which is only pushed if the aggregate contains no events of the event types
+// with a higher sequence than the sequence (e.g. the processed sequence of a write model),
+// otherwise the push fails with a precondition failed error
+func CheckSequence(cmd Command, sequence uint64, eventTypes ...EventType) Command {
+	return &sequenceCheckedCommand{
+		Command:    cmd,
+		sequence:   sequence,
+		eventTypes: eventTypes,
+	}
+}
+
 // Event is a stored activity
 type Event interface {
 	// EditorService is the service who pushed the event
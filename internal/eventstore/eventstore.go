@@ -26,7 +26,8 @@ type Eventstore struct {
 }
 
 type eventTypeInterceptors struct {
-	eventMapper func(*repository.Event) (Event, error)
+	aggregateType AggregateType
+	eventMapper   func(*repository.Event) (Event, error)
 }
 
 func NewEventstore(config *Config) *Eventstore {
@@ -84,6 +85,14 @@ func (es *Eventstore) AggregateTypes() []string {
 	return es.aggregateTypes
 }
 
+// EventAggregateType returns the aggregate type the event type is registered for
+func (es *Eventstore) EventAggregateType(eventType EventType) (AggregateType, bool) {
+	es.interceptorMutex.Lock()
+	defer es.interceptorMutex.Unlock()
+	interceptor, ok := es.eventInterceptors[eventType]
+	return interceptor.aggregateType, ok
+}
+
 func commandsToRepository(instanceID string, cmds []Command) (events []*repository.Event, constraints []*repository.UniqueConstraint, err error) {
 	events = make([]*repository.Event, len(cmds))
 	for i, cmd := range cmds {
@@ -114,6 +123,15 @@ func commandsToRepository(instanceID string, cmds []Command) (events []*reposito
 			Version:       repository.Version(cmd.Aggregate().Version),
 			Data:          data,
 		}
+		if checked, ok := cmd.(*sequenceCheckedCommand); ok {
+			events[i].SequenceCheck = &repository.SequenceCheck{
+				Sequence:   checked.sequence,
+				EventTypes: make([]repository.EventType, len(checked.eventTypes)),
+			}
+			for j, eventType := range checked.eventTypes {
+				events[i].SequenceCheck.EventTypes[j] = repository.EventType(eventType)
+			}
+		}
 		if len(cmd.UniqueConstraints()) > 0 {
 			constraints = append(constraints, uniqueConstraintsToRepository(instanceID, cmd.UniqueConstraints())...)
 		}
@@ -246,6 +264,7 @@ func (es *Eventstore) RegisterFilterEventMapper(aggregateType AggregateType, eve
 	es.appendAggregateType(aggregateType)
 
 	interceptor := es.eventInterceptors[eventType]
+	interceptor.aggregateType = aggregateType
 	interceptor.eventMapper = mapper
 	es.eventInterceptors[eventType] = interceptor
 
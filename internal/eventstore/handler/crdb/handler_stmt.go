@@ -45,6 +45,7 @@ type StatementHandler struct {
 
 	aggregates  []eventstore.AggregateType
 	reduces     map[eventstore.EventType]handler.Reduce
+	aggReduces  map[eventstore.AggregateType]handler.Reduce
 	initCheck   *handler.Check
 	initialized chan bool
 
@@ -57,8 +58,12 @@ func NewStatementHandler(
 ) StatementHandler {
 	aggregateTypes := make([]eventstore.AggregateType, 0, len(config.Reducers))
 	reduces := make(map[eventstore.EventType]handler.Reduce, len(config.Reducers))
+	aggReduces := make(map[eventstore.AggregateType]handler.Reduce)
 	for _, aggReducer := range config.Reducers {
 		aggregateTypes = append(aggregateTypes, aggReducer.Aggregate)
+		if aggReducer.Reduce != nil {
+			aggReduces[aggReducer.Aggregate] = aggReducer.Reduce
+		}
 		for _, eventReducer := range aggReducer.EventRedusers {
 			reduces[eventReducer.Event] = eventReducer.Reduce
 		}
@@ -74,6 +79,7 @@ func NewStatementHandler(
 		setFailureCountStmt:     fmt.Sprintf(setFailureCountStmtFormat, config.FailedEventsTable),
 		aggregates:              aggregateTypes,
 		reduces:                 reduces,
+		aggReduces:              aggReduces,
 		bulkLimit:               config.BulkLimit,
 		Locker:                  NewLocker(config.Client.DB, config.LockTable, config.ProjectionName),
 		initCheck:               config.InitCheck,
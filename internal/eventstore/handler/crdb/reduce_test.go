@@ -0,0 +1,66 @@
+package crdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+func TestStatementHandler_reduce(t *testing.T) {
+	eventReduce := func(event eventstore.Event) (*handler.Statement, error) {
+		return &handler.Statement{AggregateType: event.Aggregate().Type, Sequence: 1}, nil
+	}
+	aggregateReduce := func(event eventstore.Event) (*handler.Statement, error) {
+		return &handler.Statement{AggregateType: event.Aggregate().Type, Sequence: 2}, nil
+	}
+	tests := []struct {
+		name  string
+		event *testEvent
+		want  *handler.Statement
+	}{
+		{
+			name: "event reducer",
+			event: &testEvent{
+				BaseEvent:     eventstore.BaseEvent{EventType: "agg.event"},
+				aggregateType: "agg",
+			},
+			want: &handler.Statement{AggregateType: "agg", Sequence: 1},
+		},
+		{
+			name: "aggregate reducer",
+			event: &testEvent{
+				BaseEvent:     eventstore.BaseEvent{EventType: "agg.unknown"},
+				aggregateType: "agg",
+			},
+			want: &handler.Statement{AggregateType: "agg", Sequence: 2},
+		},
+		{
+			name: "no reducer",
+			event: &testEvent{
+				BaseEvent:     eventstore.BaseEvent{EventType: "other.unknown"},
+				aggregateType: "other",
+				sequence:      3,
+			},
+			want: &handler.Statement{AggregateType: "other", Sequence: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &StatementHandler{
+				reduces: map[eventstore.EventType]handler.Reduce{
+					"agg.event":   eventReduce,
+					"other.event": eventReduce,
+				},
+				aggReduces: map[eventstore.AggregateType]handler.Reduce{
+					"agg": aggregateReduce,
+				},
+			}
+			got, err := h.reduce(tt.event)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package eventaction
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/eventpayload"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// ProjectionName identifies the checkpoints of the handler in the current sequences
+// and its customization in the projections config,
+// it's also the name of the table containing the pending runs
+const ProjectionName = "projections.event_actions"
+
+const (
+	RunInstanceIDCol        = "instance_id"
+	RunActionIDCol          = "action_id"
+	RunEventSequenceCol     = "event_sequence"
+	RunCreationDateCol      = "creation_date"
+	RunResourceOwnerCol     = "resource_owner"
+	RunAggregateTypeCol     = "aggregate_type"
+	RunAggregateIDCol       = "aggregate_id"
+	RunEventTypeCol         = "event_type"
+	RunEventCreationDateCol = "event_creation_date"
+	RunEditorUserCol        = "editor_user"
+	RunVersionCol           = "version"
+	RunPayloadCol           = "payload"
+	RunAttemptsCol          = "attempts"
+	RunNextAttemptCol       = "next_attempt"
+)
+
+type Config struct {
+	// MaxAttempts is the amount of attempts to run an action for an event,
+	// actions which are allowed to fail are run once
+	MaxAttempts uint64
+	// InitialBackoff is the time waited after the first failed attempt, it is doubled after every further attempt
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// MaxConcurrentRunsPerInstance limits the actions of an instance running at the same time in a process
+	MaxConcurrentRunsPerInstance uint
+	// WorkerInterval is the interval in which the pending runs are executed
+	WorkerInterval time.Duration
+	// BulkLimit is the maximum amount of runs executed per interval
+	BulkLimit uint64
+}
+
+type eventActionQueries interface {
+	GetActiveEventTriggerActions(ctx context.Context, eventType, orgID string, withOwnerRemoved bool) (*query.EventTriggerActions, error)
+	GetActionByID(ctx context.Context, id string, orgID string, withOwnerRemoved bool) (*query.Action, error)
+}
+
+// Start creates the handler queuing the runs of the actions bound to the event types and starts it
+// together with the worker executing the queued runs.
+// The actions are run after the events are created, outside of the request which created them
+func Start(ctx context.Context, customConfig projection.CustomConfig, config *Config, queries *query.Queries) {
+	h := newEventActionHandler(ctx, projection.ApplyCustomConfig(customConfig), config, queries)
+	h.Start()
+	go h.work(ctx)
+}
+
+type eventActionHandler struct {
+	crdb.StatementHandler
+	config  *Config
+	client  *database.DB
+	queries eventActionQueries
+
+	mu       sync.Mutex
+	limiters map[string]chan struct{}
+}
+
+func newEventActionHandler(
+	ctx context.Context,
+	handlerConfig crdb.StatementHandlerConfig,
+	config *Config,
+	queries eventActionQueries,
+) *eventActionHandler {
+	h := new(eventActionHandler)
+	handlerConfig.ProjectionName = ProjectionName
+	handlerConfig.Reducers = h.reducers()
+	handlerConfig.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(RunInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RunActionIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RunEventSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(RunCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RunResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RunAggregateTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RunAggregateIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RunEventTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RunEventCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(RunEditorUserCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RunVersionCol, crdb.ColumnTypeText),
+			crdb.NewColumn(RunPayloadCol, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(RunAttemptsCol, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(RunNextAttemptCol, crdb.ColumnTypeTimestamp),
+		},
+			crdb.NewPrimaryKey(RunInstanceIDCol, RunActionIDCol, RunEventSequenceCol),
+			crdb.WithIndex(crdb.NewIndex("next_attempt", []string{RunNextAttemptCol})),
+		),
+	)
+	h.StatementHandler = crdb.NewStatementHandler(ctx, handlerConfig)
+	h.config = config
+	h.client = handlerConfig.Client
+	h.queries = queries
+	h.limiters = make(map[string]chan struct{})
+	return h
+}
+
+func (h *eventActionHandler) reducers() []handler.AggregateReducer {
+	aggregateTypes := []eventstore.AggregateType{
+		instance.AggregateType,
+		org.AggregateType,
+		project.AggregateType,
+		user.AggregateType,
+		usergrant.AggregateType,
+		action.AggregateType,
+	}
+	reducers := make([]handler.AggregateReducer, len(aggregateTypes))
+	for i, aggregateType := range aggregateTypes {
+		reducers[i] = handler.AggregateReducer{
+			Aggregate: aggregateType,
+			Reduce:    h.reduceEvent,
+		}
+	}
+	return reducers
+}
+
+// queueRunStmt creates a pending run of the action for the event,
+// the event is stored with its public fields only, so the run doesn't depend on the events of the eventstore
+const queueRunStmt = "INSERT INTO " + ProjectionName +
+	" (" + RunInstanceIDCol +
+	", " + RunActionIDCol +
+	", " + RunEventSequenceCol +
+	", " + RunCreationDateCol +
+	", " + RunResourceOwnerCol +
+	", " + RunAggregateTypeCol +
+	", " + RunAggregateIDCol +
+	", " + RunEventTypeCol +
+	", " + RunEventCreationDateCol +
+	", " + RunEditorUserCol +
+	", " + RunVersionCol +
+	", " + RunPayloadCol +
+	", " + RunNextAttemptCol +
+	") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $4)" +
+	" ON CONFLICT (" + RunInstanceIDCol + ", " + RunActionIDCol + ", " + RunEventSequenceCol + ") DO NOTHING"
+
+// reduceEvent only queues the runs of the actions bound to the type of the event by the organisation owning the event,
+// they are executed by the worker, so the projection is never blocked by slow or failing actions
+// and the actions are run once per event, even if the event is reduced multiple times.
+// Actions bound after the event was created are not run for it,
+// so binding an action doesn't run it for the past events
+func (h *eventActionHandler) reduceEvent(event eventstore.Event) (*handler.Statement, error) {
+	ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+	triggers, err := h.queries.GetActiveEventTriggerActions(ctx, string(event.Type()), event.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+	if triggers == nil || triggers.Sequence >= event.Sequence() || len(triggers.Actions) == 0 {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	var payload []byte
+	if fields := eventpayload.Fields(event); fields != nil {
+		if payload, err = json.Marshal(fields); err != nil {
+			return nil, errors.ThrowInternal(err, "EVACT-Kd8wq", "unable to marshal payload")
+		}
+	}
+	now := time.Now()
+	args := make([][]interface{}, len(triggers.Actions))
+	for i, a := range triggers.Actions {
+		args[i] = []interface{}{
+			event.Aggregate().InstanceID,
+			a.ID,
+			event.Sequence(),
+			now,
+			event.Aggregate().ResourceOwner,
+			event.Aggregate().Type,
+			event.Aggregate().ID,
+			event.Type(),
+			event.CreationDate(),
+			event.EditorUser(),
+			event.Aggregate().Version,
+			payload,
+		}
+	}
+	return &handler.Statement{
+		AggregateType:    event.Aggregate().Type,
+		Sequence:         event.Sequence(),
+		PreviousSequence: event.PreviousAggregateTypeSequence(),
+		InstanceID:       event.Aggregate().InstanceID,
+		Execute: func(ex handler.Executer, _ string) error {
+			for _, runArgs := range args {
+				if _, err := ex.Exec(queueRunStmt, runArgs...); err != nil {
+					return errors.ThrowInternal(err, "EVACT-Pq3vn", "unable to queue action run")
+				}
+			}
+			return nil
+		},
+	}, nil
+}
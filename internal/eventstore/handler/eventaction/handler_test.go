@@ -0,0 +1,332 @@
+package eventaction
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockQueries struct {
+	triggers *query.EventTriggerActions
+	action   *query.Action
+	err      error
+}
+
+func (m *mockQueries) GetActiveEventTriggerActions(context.Context, string, string, bool) (*query.EventTriggerActions, error) {
+	return m.triggers, m.err
+}
+
+func (m *mockQueries) GetActionByID(context.Context, string, string, bool) (*query.Action, error) {
+	if m.action == nil {
+		return nil, errors.ThrowNotFound(nil, "id", "not found")
+	}
+	return m.action, m.err
+}
+
+func testEvent(sequence uint64) eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   "user-id",
+		AggregateType: "user",
+		Type:          "user.human.added",
+		Sequence:      sequence,
+		InstanceID:    "instance-id",
+		ResourceOwner: sql.NullString{String: "org-id", Valid: true},
+		EditorUser:    "editor-user",
+		Version:       "v1",
+		Data:          []byte(`{"userName":"username","secret":{"cryptoType":1,"algorithm":"bcrypt","crypted":"JDJhJDEw"}}`),
+	})
+}
+
+const script = `function notify(ctx, api) {
+	let http = require('zitadel/http')
+	let res = http.fetch(URL, {
+		method: 'POST',
+		body: {
+			type: ctx.v1.event.type,
+			userName: ctx.v1.event.payload.userName,
+			attempt: ctx.v1.attempt,
+		},
+	})
+	if (res.status != 200) {
+		throw 'unexpected status'
+	}
+}`
+
+type testExecuter struct {
+	stmts []string
+	args  [][]interface{}
+}
+
+func (e *testExecuter) Exec(stmt string, args ...interface{}) (sql.Result, error) {
+	e.stmts = append(e.stmts, stmt)
+	e.args = append(e.args, args)
+	return nil, nil
+}
+
+type request struct {
+	Type     string
+	UserName string
+	Attempt  uint64
+}
+
+type recorder struct {
+	mu       sync.Mutex
+	requests []request
+	failures int
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var body request
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.requests = append(r.requests, body)
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func Test_eventActionHandler_reduceEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		triggers   *query.EventTriggerActions
+		wantQueued []string
+	}{
+		{
+			name: "no actions bound",
+		},
+		{
+			name: "bound after event",
+			triggers: &query.EventTriggerActions{
+				Sequence: 15,
+				Actions:  []*query.Action{{ID: "action1"}},
+			},
+		},
+		{
+			name: "runs queued",
+			triggers: &query.EventTriggerActions{
+				Sequence: 10,
+				Actions:  []*query.Action{{ID: "action1"}, {ID: "action2"}},
+			},
+			wantQueued: []string{"action1", "action2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &eventActionHandler{
+				queries: &mockQueries{triggers: tt.triggers},
+			}
+			event := testEvent(15)
+			stmt, err := h.reduceEvent(event)
+			require.NoError(t, err)
+			assert.Equal(t, event.Sequence(), stmt.Sequence)
+			assert.Equal(t, event.Aggregate().InstanceID, stmt.InstanceID)
+			if len(tt.wantQueued) == 0 {
+				assert.Nil(t, stmt.Execute)
+				return
+			}
+
+			executer := new(testExecuter)
+			require.NoError(t, stmt.Execute(executer, ProjectionName))
+			require.Len(t, executer.args, len(tt.wantQueued))
+			for i, actionID := range tt.wantQueued {
+				assert.Equal(t, queueRunStmt, executer.stmts[i])
+				args := executer.args[i]
+				if assert.Len(t, args, 12) {
+					assert.Equal(t, "instance-id", args[0])
+					assert.Equal(t, actionID, args[1])
+					assert.Equal(t, uint64(15), args[2])
+					assert.Equal(t, "org-id", args[4])
+					assert.Equal(t, eventstore.EventType("user.human.added"), args[7])
+					assert.JSONEq(t, `{"userName":"username"}`, string(args[11].([]byte)))
+				}
+			}
+		})
+	}
+}
+
+func Test_eventActionHandler_attempt(t *testing.T) {
+	actions.SetLogstoreService(logstore.New(nil, nil, nil))
+	tests := []struct {
+		name         string
+		action       func(url string) *query.Action
+		failures     int
+		attempts     uint64
+		wantErr      bool
+		wantRetry    bool
+		wantRequests []request
+	}{
+		{
+			name: "action removed",
+			action: func(string) *query.Action {
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "action inactive",
+			action: func(url string) *query.Action {
+				a := testAction(url, false)
+				a.State = domain.ActionStateInactive
+				return a
+			},
+			wantErr: true,
+		},
+		{
+			name: "action run",
+			action: func(url string) *query.Action {
+				return testAction(url, false)
+			},
+			wantRequests: []request{
+				{Type: "user.human.added", UserName: "username", Attempt: 1},
+			},
+		},
+		{
+			name: "failed action retried",
+			action: func(url string) *query.Action {
+				return testAction(url, false)
+			},
+			failures:  1,
+			attempts:  1,
+			wantErr:   true,
+			wantRetry: true,
+			wantRequests: []request{
+				{Type: "user.human.added", UserName: "username", Attempt: 2},
+			},
+		},
+		{
+			name: "action allowed to fail not retried",
+			action: func(url string) *query.Action {
+				return testAction(url, true)
+			},
+			failures: 1,
+			wantRequests: []request{
+				{Type: "user.human.added", UserName: "username", Attempt: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{failures: tt.failures}
+			server := httptest.NewServer(rec)
+			defer server.Close()
+
+			h := &eventActionHandler{
+				queries: &mockQueries{action: tt.action(server.URL)},
+			}
+			event := testEvent(15)
+			run := &pendingRun{
+				instanceID:    "instance-id",
+				actionID:      "action-id",
+				sequence:      event.Sequence(),
+				resourceOwner: "org-id",
+				aggregateType: "user",
+				aggregateID:   "user-id",
+				eventType:     "user.human.added",
+				payload:       []byte(`{"userName":"username"}`),
+				attempts:      tt.attempts,
+			}
+			result := h.attempt(context.Background(), run)
+			assert.Equal(t, tt.wantErr, result.err != nil)
+			assert.Equal(t, tt.wantRetry, result.retry)
+			assert.Equal(t, tt.wantRequests, rec.requests)
+		})
+	}
+}
+
+func Test_eventActionHandler_nextAttempt(t *testing.T) {
+	now := time.Now()
+	h := &eventActionHandler{
+		config: &Config{
+			MaxAttempts:    4,
+			InitialBackoff: time.Second,
+			MaxBackoff:     3 * time.Second,
+		},
+	}
+	tests := []struct {
+		name        string
+		attempts    uint64
+		result      runResult
+		nextAttempt time.Time
+		done        bool
+	}{
+		{
+			name:        "succeeded",
+			attempts:    1,
+			nextAttempt: now,
+			done:        true,
+		},
+		{
+			name:        "not retryable",
+			attempts:    1,
+			result:      runResult{err: io.EOF},
+			nextAttempt: now,
+			done:        true,
+		},
+		{
+			name:        "first retry, initial backoff",
+			attempts:    1,
+			result:      runResult{retry: true, err: io.EOF},
+			nextAttempt: now.Add(time.Second),
+		},
+		{
+			name:        "second retry, doubled backoff",
+			attempts:    2,
+			result:      runResult{retry: true, err: io.EOF},
+			nextAttempt: now.Add(2 * time.Second),
+		},
+		{
+			name:        "third retry, max backoff",
+			attempts:    3,
+			result:      runResult{retry: true, err: io.EOF},
+			nextAttempt: now.Add(3 * time.Second),
+		},
+		{
+			name:        "max attempts reached",
+			attempts:    4,
+			result:      runResult{retry: true, err: io.EOF},
+			nextAttempt: now,
+			done:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextAttempt, done := h.nextAttempt(tt.attempts, tt.result, now)
+			assert.Equal(t, tt.nextAttempt, nextAttempt)
+			assert.Equal(t, tt.done, done)
+		})
+	}
+}
+
+func testAction(url string, allowedToFail bool) *query.Action {
+	return &query.Action{
+		ID:            "action-id",
+		Name:          "notify",
+		Script:        "const URL = '" + url + "'\n" + script,
+		State:         domain.ActionStateActive,
+		AllowedToFail: allowedToFail,
+	}
+}
@@ -0,0 +1,268 @@
+package eventaction
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// claimTimeout reserves the claimed runs for the worker,
+// it's longer than the maximum timeout of an action
+const claimTimeout = time.Minute
+
+// claimRunsStmt reserves the pending runs due for the next attempt,
+// by moving the next attempt after the claim timeout, so they are not executed by other workers simultaneously
+const claimRunsStmt = "UPDATE " + ProjectionName + " SET " + RunNextAttemptCol + " = $1" +
+	" WHERE (" + RunInstanceIDCol + ", " + RunActionIDCol + ", " + RunEventSequenceCol + ") IN (" +
+	"SELECT " + RunInstanceIDCol + ", " + RunActionIDCol + ", " + RunEventSequenceCol +
+	" FROM " + ProjectionName +
+	" WHERE " + RunNextAttemptCol + " <= $2" +
+	" ORDER BY " + RunNextAttemptCol + " LIMIT $3)" +
+	" RETURNING " + RunInstanceIDCol +
+	", " + RunActionIDCol +
+	", " + RunEventSequenceCol +
+	", " + RunResourceOwnerCol +
+	", " + RunAggregateTypeCol +
+	", " + RunAggregateIDCol +
+	", " + RunEventTypeCol +
+	", " + RunEventCreationDateCol +
+	", " + RunEditorUserCol +
+	", " + RunVersionCol +
+	", " + RunPayloadCol +
+	", " + RunAttemptsCol
+
+const retryRunStmt = "UPDATE " + ProjectionName + " SET " +
+	RunAttemptsCol + " = $1, " +
+	RunNextAttemptCol + " = $2" +
+	" WHERE " + RunInstanceIDCol + " = $3" +
+	" AND " + RunActionIDCol + " = $4" +
+	" AND " + RunEventSequenceCol + " = $5"
+
+const removeRunStmt = "DELETE FROM " + ProjectionName +
+	" WHERE " + RunInstanceIDCol + " = $1" +
+	" AND " + RunActionIDCol + " = $2" +
+	" AND " + RunEventSequenceCol + " = $3"
+
+type pendingRun struct {
+	instanceID    string
+	actionID      string
+	sequence      uint64
+	resourceOwner string
+	aggregateType string
+	aggregateID   string
+	eventType     string
+	creationDate  time.Time
+	editorUser    string
+	version       string
+	payload       []byte
+	attempts      uint64
+}
+
+// event returns the event of the run, its payload only contains the public fields of the event
+func (r *pendingRun) event() eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		InstanceID:    r.instanceID,
+		AggregateType: repository.AggregateType(r.aggregateType),
+		AggregateID:   r.aggregateID,
+		ResourceOwner: sql.NullString{String: r.resourceOwner, Valid: true},
+		Type:          repository.EventType(r.eventType),
+		Sequence:      r.sequence,
+		CreationDate:  r.creationDate,
+		EditorUser:    r.editorUser,
+		Version:       repository.Version(r.version),
+		Data:          r.payload,
+	})
+}
+
+type runResult struct {
+	retry bool
+	err   error
+}
+
+// work executes the pending runs in the configured interval until the context is done
+func (h *eventActionHandler) work(ctx context.Context) {
+	ticker := time.NewTicker(h.config.WorkerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.runPending(ctx)
+		}
+	}
+}
+
+// runPending executes the claimed runs concurrently, limited by the runs of the instance
+// and waits until all of them are finished
+func (h *eventActionHandler) runPending(ctx context.Context) {
+	runs, err := h.claimRuns(ctx, time.Now())
+	if err != nil {
+		logging.WithError(err).Warn("unable to claim event action runs")
+		return
+	}
+	var wg sync.WaitGroup
+	for _, run := range runs {
+		wg.Add(1)
+		go func(run *pendingRun) {
+			defer wg.Done()
+			limiter := h.limiter(run.instanceID)
+			limiter <- struct{}{}
+			result := h.attempt(ctx, run)
+			<-limiter
+			err := h.finishAttempt(ctx, run, result, time.Now())
+			logging.WithFields("instanceID", run.instanceID, "actionID", run.actionID, "sequence", run.sequence).
+				OnError(err).
+				Warn("unable to update event action run")
+		}(run)
+	}
+	wg.Wait()
+}
+
+func (h *eventActionHandler) limiter(instanceID string) chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	limiter, ok := h.limiters[instanceID]
+	if !ok {
+		size := h.config.MaxConcurrentRunsPerInstance
+		if size == 0 {
+			size = 1
+		}
+		limiter = make(chan struct{}, size)
+		h.limiters[instanceID] = limiter
+	}
+	return limiter
+}
+
+func (h *eventActionHandler) claimRuns(ctx context.Context, now time.Time) (_ []*pendingRun, err error) {
+	rows, err := h.client.QueryContext(ctx, claimRunsStmt,
+		now.Add(claimTimeout),
+		now,
+		h.config.BulkLimit,
+	)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "EVACT-Wn4xo", "unable to claim action runs")
+	}
+	defer rows.Close()
+	runs := make([]*pendingRun, 0)
+	for rows.Next() {
+		run := new(pendingRun)
+		if err = rows.Scan(
+			&run.instanceID,
+			&run.actionID,
+			&run.sequence,
+			&run.resourceOwner,
+			&run.aggregateType,
+			&run.aggregateID,
+			&run.eventType,
+			&run.creationDate,
+			&run.editorUser,
+			&run.version,
+			&run.payload,
+			&run.attempts,
+		); err != nil {
+			return nil, errors.ThrowInternal(err, "EVACT-Jt6sa", "unable to scan action run")
+		}
+		runs = append(runs, run)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "EVACT-Rz2ec", "unable to claim action runs")
+	}
+	return runs, nil
+}
+
+// attempt runs the current version of the action once,
+// the result defines if the run can be retried: actions which are allowed to fail are never retried,
+// neither are actions which are removed or inactive or if the execution quota of the instance is exhausted
+func (h *eventActionHandler) attempt(ctx context.Context, run *pendingRun) (result runResult) {
+	ctx = authz.WithInstanceID(ctx, run.instanceID)
+	a, err := h.queries.GetActionByID(ctx, run.actionID, run.resourceOwner, false)
+	if err != nil {
+		result.retry = !errors.IsNotFound(err)
+		result.err = err
+		return result
+	}
+	if a.State != domain.ActionStateActive {
+		result.err = errors.ThrowPreconditionFailed(nil, "EVACT-Hs7qm", "action is not active")
+		return result
+	}
+	result.err = h.execute(ctx, a, run.event(), run.attempts+1)
+	result.retry = result.err != nil && !a.AllowedToFail && !errors.IsResourceExhausted(result.err)
+	return result
+}
+
+// finishAttempt removes the run if it's done, otherwise the next attempt is scheduled
+func (h *eventActionHandler) finishAttempt(ctx context.Context, run *pendingRun, result runResult, now time.Time) error {
+	attempts := run.attempts + 1
+	if result.err != nil {
+		logging.WithFields("instanceID", run.instanceID, "actionID", run.actionID, "sequence", run.sequence, "attempts", attempts).
+			WithError(result.err).
+			Info("event action failed")
+	}
+	nextAttempt, done := h.nextAttempt(attempts, result, now)
+	if done {
+		if _, err := h.client.ExecContext(ctx, removeRunStmt, run.instanceID, run.actionID, run.sequence); err != nil {
+			return errors.ThrowInternal(err, "EVACT-Mf4wd", "unable to remove action run")
+		}
+		return nil
+	}
+	if _, err := h.client.ExecContext(ctx, retryRunStmt, attempts, nextAttempt, run.instanceID, run.actionID, run.sequence); err != nil {
+		return errors.ThrowInternal(err, "EVACT-Xb8ul", "unable to update action run")
+	}
+	return nil
+}
+
+// nextAttempt returns if the run is done, because it succeeded or can't be retried anymore,
+// otherwise the time of the next attempt with an exponential backoff
+func (h *eventActionHandler) nextAttempt(attempts uint64, result runResult, now time.Time) (_ time.Time, done bool) {
+	if result.err == nil || !result.retry || attempts >= h.config.MaxAttempts {
+		return now, true
+	}
+	backoff := h.config.InitialBackoff
+	for i := uint64(1); i < attempts && backoff < h.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > h.config.MaxBackoff {
+		backoff = h.config.MaxBackoff
+	}
+	return now.Add(backoff), false
+}
+
+func (h *eventActionHandler) execute(ctx context.Context, a *query.Action, event eventstore.Event, attempt uint64) error {
+	actionCtx, cancel := context.WithTimeout(ctx, a.Timeout())
+	defer cancel()
+
+	ctxFields := actions.SetContextFields(
+		actions.SetFields("v1",
+			actions.SetFields("event", object.EventField(event)),
+			actions.SetFields("attempt", attempt),
+		),
+	)
+	return actions.Run(
+		actionCtx,
+		ctxFields,
+		actions.WithAPIFields(),
+		a.Script,
+		a.Name,
+		append(actions.ActionToOptions(a),
+			actions.WithHTTP(actionCtx),
+			actions.WithLogMetadata(map[string]interface{}{
+				"eventType": event.Type(),
+				"sequence":  event.Sequence(),
+				"attempt":   attempt,
+			}),
+		)...,
+	)
+}
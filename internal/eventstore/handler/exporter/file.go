@@ -0,0 +1,41 @@
+package exporter
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type FileConfig struct {
+	// Path of the file the events are appended to, one event per line
+	Path string
+}
+
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(config *FileConfig) (Sink, error) {
+	if config.Path == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "EXPOR-Rf2ud", "file path missing")
+	}
+	file, err := os.OpenFile(config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "EXPOR-Gx7pe", "unable to open file")
+	}
+	return &fileSink{file: file}, nil
+}
+
+// Write appends the event as a line and syncs the file
+func (s *fileSink) Write(_ context.Context, _ eventstore.Event, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
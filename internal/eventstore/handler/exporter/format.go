@@ -0,0 +1,86 @@
+package exporter
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/eventpayload"
+)
+
+type Format string
+
+const (
+	// FormatJSON exports an [Event] per message
+	FormatJSON Format = "json"
+	// FormatCloudEvents exports a [CloudEvent] in the structured content mode per message
+	FormatCloudEvents Format = "cloudevents"
+
+	cloudEventsSpecVersion = "1.0"
+)
+
+func (f Format) Valid() bool {
+	return f == FormatJSON || f == FormatCloudEvents
+}
+
+// Event is the exported representation of an event,
+// Sequence is increasing for the events of an instance and identifies the event.
+// The payload only contains the public fields of the event (see [eventpayload.Fields]),
+// so secrets, codes, hashes and encrypted values are never exported
+type Event struct {
+	InstanceID    string                 `json:"instanceID"`
+	AggregateType string                 `json:"aggregateType"`
+	AggregateID   string                 `json:"aggregateID"`
+	ResourceOwner string                 `json:"resourceOwner"`
+	EventType     string                 `json:"eventType"`
+	Sequence      uint64                 `json:"sequence"`
+	CreationDate  time.Time              `json:"creationDate"`
+	EditorUser    string                 `json:"editorUser"`
+	Version       string                 `json:"version"`
+	Payload       map[string]interface{} `json:"payload,omitempty"`
+}
+
+// CloudEvent wraps the [Event] as data of a CloudEvent (https://cloudevents.io) in version 1.0
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            *Event    `json:"data"`
+}
+
+func eventFromEventstore(event eventstore.Event) *Event {
+	return &Event{
+		InstanceID:    event.Aggregate().InstanceID,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		EventType:     string(event.Type()),
+		Sequence:      event.Sequence(),
+		CreationDate:  event.CreationDate(),
+		EditorUser:    event.EditorUser(),
+		Version:       string(event.Aggregate().Version),
+		Payload:       eventpayload.Fields(event),
+	}
+}
+
+func (f Format) Marshal(event eventstore.Event) ([]byte, error) {
+	exported := eventFromEventstore(event)
+	if f != FormatCloudEvents {
+		return json.Marshal(exported)
+	}
+	return json.Marshal(&CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              strconv.FormatUint(exported.Sequence, 10),
+		Source:          "zitadel/instances/" + exported.InstanceID,
+		Type:            exported.EventType,
+		Subject:         exported.AggregateType + "/" + exported.AggregateID,
+		Time:            exported.CreationDate,
+		DataContentType: "application/json",
+		Data:            exported,
+	})
+}
@@ -0,0 +1,120 @@
+package exporter
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func TestFormat_Marshal(t *testing.T) {
+	event := testEvent("user", "user.human.added")
+	creationDate, err := event.CreationDate().MarshalJSON()
+	require.NoError(t, err)
+	exported := `{"instanceID":"instance-id","aggregateType":"user","aggregateID":"agg-id","resourceOwner":"org-id","eventType":"user.human.added","sequence":15,"creationDate":` + string(creationDate) + `,"editorUser":"editor-user","version":"v1","payload":{"userName":"username"}}`
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: FormatJSON,
+			want:   exported,
+		},
+		{
+			format: FormatCloudEvents,
+			want:   `{"specversion":"1.0","id":"15","source":"zitadel/instances/instance-id","type":"user.human.added","subject":"user/agg-id","time":` + string(creationDate) + `,"datacontenttype":"application/json","data":` + exported + `}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := tt.format.Marshal(event)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestFormat_Marshal_secrets(t *testing.T) {
+	cryptoValue := `{"cryptoType":1,"algorithm":"bcrypt","keyID":"key-id","crypted":"JDJhJDEw"}`
+	tests := []struct {
+		name          string
+		aggregateType string
+		eventType     string
+		data          string
+		wantPayload   bool
+	}{
+		{
+			name:          "human added",
+			aggregateType: "user",
+			eventType:     "user.human.added",
+			data:          `{"userName":"username","secret":` + cryptoValue + `,"changeRequired":true}`,
+			wantPayload:   true,
+		},
+		{
+			name:          "password changed",
+			aggregateType: "user",
+			eventType:     "user.human.password.changed",
+			data:          `{"secret":` + cryptoValue + `,"changeRequired":false}`,
+			wantPayload:   true,
+		},
+		{
+			name:          "password code added",
+			aggregateType: "user",
+			eventType:     "user.human.password.code.added",
+			data:          `{"code":` + cryptoValue + `,"expiry":300}`,
+		},
+		{
+			name:          "machine secret set",
+			aggregateType: "user",
+			eventType:     "user.machine.secret.set",
+			data:          `{"clientSecret":` + cryptoValue + `}`,
+		},
+		{
+			name:          "key pair added",
+			aggregateType: "key_pair",
+			eventType:     "key_pair.added",
+			data:          `{"usage":0,"algorithm":"RS256","privateKey":{"key":` + cryptoValue + `},"publicKey":{"key":` + cryptoValue + `}}`,
+		},
+		{
+			name:          "webhook added",
+			aggregateType: "webhook",
+			eventType:     "webhook.added",
+			data:          `{"name":"crm","url":"https://crm.example.com","signingKey":` + cryptoValue + `}`,
+		},
+		{
+			name:          "device authorization added",
+			aggregateType: "device_auth",
+			eventType:     "device.authorization.added",
+			data:          `{"clientId":"client","deviceCodeHash":"aGFzaA","userCode":"ABCD-EFGH"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := eventstore.BaseEventFromRepo(&repository.Event{
+				AggregateID:   "agg-id",
+				AggregateType: repository.AggregateType(tt.aggregateType),
+				Type:          repository.EventType(tt.eventType),
+				Sequence:      15,
+				InstanceID:    "instance-id",
+				ResourceOwner: sql.NullString{String: "org-id", Valid: true},
+				Data:          []byte(tt.data),
+			})
+			for _, format := range []Format{FormatJSON, FormatCloudEvents} {
+				got, err := format.Marshal(event)
+				require.NoError(t, err)
+				assert.NotContains(t, string(got), "crypted")
+				assert.NotContains(t, string(got), "JDJhJDEw")
+				assert.NotContains(t, string(got), "ABCD-EFGH")
+			}
+			payload := eventFromEventstore(event).Payload
+			assert.Equal(t, tt.wantPayload, payload != nil)
+			for _, field := range []string{"secret", "code", "clientSecret", "privateKey", "publicKey", "signingKey", "deviceCodeHash", "userCode"} {
+				assert.NotContains(t, payload, field)
+			}
+		})
+	}
+}
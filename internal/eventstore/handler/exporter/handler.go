@@ -0,0 +1,156 @@
+package exporter
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// ProjectionPrefix prefixes the name of an exporter to identify its checkpoints in the current sequences
+// and its customization in the projections config
+const ProjectionPrefix = "exporter_"
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// aggregateTypes are exported if no aggregate types are configured,
+// the list is documented in the defaults.yaml and the event exporter docs.
+// Aggregates which mainly consist of keys, like key pairs and webhooks, aren't exported by default
+var aggregateTypes = []eventstore.AggregateType{
+	instance.AggregateType,
+	org.AggregateType,
+	project.AggregateType,
+	user.AggregateType,
+	usergrant.AggregateType,
+	action.AggregateType,
+	deviceauth.AggregateType,
+	quota.AggregateType,
+}
+
+type Config struct {
+	// Name identifies the checkpoints of the exporter, it must not be changed after the first start
+	Name string
+	// Format of the exported events, either json or cloudevents
+	Format Format
+	// AggregateTypes to export, the default aggregateTypes if empty
+	AggregateTypes []string
+	// EventTypes to export, all events of the aggregate types if empty
+	EventTypes []string
+	Sink       *SinkConfig
+}
+
+// Start creates the handlers of the configured exporters and starts them.
+// An exporter tails the events of each instance by their sequence, writes them to its sink
+// and stores the sequence of the last written event like a projection,
+// so events are written at least once
+func Start(ctx context.Context, customConfigs map[string]projection.CustomConfig, configs []*Config) error {
+	if err := validate(configs); err != nil {
+		return err
+	}
+	for _, config := range configs {
+		sink, err := config.Sink.NewSink()
+		if err != nil {
+			return err
+		}
+		name := ProjectionPrefix + config.Name
+		newExporter(ctx, projection.ApplyCustomConfig(customConfigs[name]), config, sink).Start()
+	}
+	return nil
+}
+
+func validate(configs []*Config) error {
+	names := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		if !namePattern.MatchString(config.Name) || names[config.Name] {
+			return errors.ThrowInvalidArgumentf(nil, "EXPOR-Wm4xq", "exporter name %q must be unique and only contain a-z, 0-9 and _", config.Name)
+		}
+		names[config.Name] = true
+		if !config.Format.Valid() {
+			return errors.ThrowInvalidArgumentf(nil, "EXPOR-Ja8ve", "format %q of exporter %s not supported", config.Format, config.Name)
+		}
+		if config.Sink == nil {
+			return errors.ThrowInvalidArgumentf(nil, "EXPOR-Ct2lm", "sink of exporter %s missing", config.Name)
+		}
+	}
+	return nil
+}
+
+type exporter struct {
+	crdb.StatementHandler
+	ctx        context.Context
+	format     Format
+	eventTypes []string
+	sink       Sink
+}
+
+func newExporter(
+	ctx context.Context,
+	handlerConfig crdb.StatementHandlerConfig,
+	config *Config,
+	sink Sink,
+) *exporter {
+	e := new(exporter)
+	handlerConfig.ProjectionName = ProjectionPrefix + config.Name
+	handlerConfig.Reducers = e.reducers(config.AggregateTypes)
+	e.StatementHandler = crdb.NewStatementHandler(ctx, handlerConfig)
+	e.ctx = ctx
+	e.format = config.Format
+	e.eventTypes = config.EventTypes
+	e.sink = sink
+	return e
+}
+
+func (e *exporter) reducers(configured []string) []handler.AggregateReducer {
+	types := aggregateTypes
+	if len(configured) > 0 {
+		types = make([]eventstore.AggregateType, len(configured))
+		for i, aggregateType := range configured {
+			types[i] = eventstore.AggregateType(aggregateType)
+		}
+	}
+	reducers := make([]handler.AggregateReducer, len(types))
+	for i, aggregateType := range types {
+		reducers[i] = handler.AggregateReducer{
+			Aggregate: aggregateType,
+			Reduce:    e.reduceEvent,
+		}
+	}
+	return reducers
+}
+
+// reduceEvent writes the event to the sink before the statement is returned,
+// if the write fails, the sequence is not updated and the event is written again on the next run
+func (e *exporter) reduceEvent(event eventstore.Event) (*handler.Statement, error) {
+	if len(e.eventTypes) > 0 && !contains(e.eventTypes, string(event.Type())) {
+		return crdb.NewNoOpStatement(event), nil
+	}
+	data, err := e.format.Marshal(event)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "EXPOR-Hs6ob", "unable to marshal event")
+	}
+	if err = e.sink.Write(e.ctx, event, data); err != nil {
+		return nil, errors.ThrowUnavailable(err, "EXPOR-Nq3fi", "unable to write event to sink")
+	}
+	return crdb.NewNoOpStatement(event), nil
+}
+
+func contains(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package exporter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func testEvent(aggregateType, eventType string) eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   "agg-id",
+		AggregateType: repository.AggregateType(aggregateType),
+		Type:          repository.EventType(eventType),
+		Sequence:      15,
+		InstanceID:    "instance-id",
+		ResourceOwner: sql.NullString{String: "org-id", Valid: true},
+		EditorUser:    "editor-user",
+		Version:       "v1",
+		Data:          []byte(`{"userName":"username"}`),
+	})
+}
+
+type testSink struct {
+	err     error
+	written [][]byte
+}
+
+func (s *testSink) Write(_ context.Context, _ eventstore.Event, data []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.written = append(s.written, data)
+	return nil
+}
+
+func Test_exporter_reduceEvent(t *testing.T) {
+	tests := []struct {
+		name        string
+		eventTypes  []string
+		sinkErr     error
+		wantWritten int
+		wantErr     func(error) bool
+	}{
+		{
+			name:        "written",
+			wantWritten: 1,
+		},
+		{
+			name:        "event type filtered",
+			eventTypes:  []string{"user.human.changed"},
+			wantWritten: 0,
+		},
+		{
+			name:        "event type subscribed",
+			eventTypes:  []string{"user.human.added"},
+			wantWritten: 1,
+		},
+		{
+			name:    "sink fails, sequence not updated",
+			sinkErr: errors.New("unavailable"),
+			wantErr: caos_errs.IsUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &testSink{err: tt.sinkErr}
+			e := &exporter{
+				ctx:        context.Background(),
+				format:     FormatJSON,
+				eventTypes: tt.eventTypes,
+				sink:       sink,
+			}
+			stmt, err := e.reduceEvent(testEvent("user", "user.human.added"))
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), err)
+				assert.Nil(t, stmt)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, stmt.IsNoop())
+			assert.Equal(t, uint64(15), stmt.Sequence)
+			assert.Equal(t, "instance-id", stmt.InstanceID)
+			assert.Len(t, sink.written, tt.wantWritten)
+		})
+	}
+}
+
+func Test_validate(t *testing.T) {
+	sink := &SinkConfig{Type: SinkTypeFile, File: &FileConfig{Path: "events"}}
+	tests := []struct {
+		name    string
+		configs []*Config
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			configs: []*Config{
+				{Name: "lake", Format: FormatJSON, Sink: sink},
+				{Name: "audit_2", Format: FormatCloudEvents, Sink: sink},
+			},
+		},
+		{
+			name: "duplicate name",
+			configs: []*Config{
+				{Name: "lake", Format: FormatJSON, Sink: sink},
+				{Name: "lake", Format: FormatJSON, Sink: sink},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			configs: []*Config{{Name: "Data Lake", Format: FormatJSON, Sink: sink}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid format",
+			configs: []*Config{{Name: "lake", Format: "avro", Sink: sink}},
+			wantErr: true,
+		},
+		{
+			name:    "sink missing",
+			configs: []*Config{{Name: "lake", Format: FormatJSON}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(tt.configs)
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsErrorInvalidArgument(err), err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	kafkaAccept      = "application/vnd.kafka.v2+json"
+)
+
+// KafkaConfig configures the Kafka REST API (v2) of a REST proxy,
+// e.g. the Confluent REST Proxy or the Redpanda HTTP Proxy
+type KafkaConfig struct {
+	// URL of the REST proxy
+	URL string
+	// Topic the events are produced to, the aggregate id is used as key
+	// so the events of an aggregate are written to the same partition
+	Topic string
+	// Username and Password for basic authentication, optional
+	Username string
+	Password string
+	// Timeout of a request to the REST proxy, 5s if not set
+	Timeout time.Duration
+}
+
+type kafkaSink struct {
+	endpoint string
+	username string
+	password string
+	client   *http.Client
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaOffsets struct {
+	Offsets []struct {
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+func NewKafkaSink(config *KafkaConfig) (Sink, error) {
+	if config.URL == "" || config.Topic == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "EXPOR-Vo8cz", "url and topic of kafka sink required")
+	}
+	return &kafkaSink{
+		endpoint: strings.TrimSuffix(config.URL, "/") + "/topics/" + url.PathEscape(config.Topic),
+		username: config.Username,
+		password: config.Password,
+		client:   &http.Client{Timeout: timeoutOrDefault(config.Timeout)},
+	}, nil
+}
+
+// Write produces the event to the topic,
+// it succeeds only if the REST proxy acknowledged the record without error
+func (s *kafkaSink) Write(ctx context.Context, event eventstore.Event, data []byte) error {
+	body, err := json.Marshal(&kafkaRecords{
+		Records: []kafkaRecord{{Key: event.Aggregate().ID, Value: data}},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", kafkaAccept)
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kafka rest proxy responded with status %d", resp.StatusCode)
+	}
+	offsets := new(kafkaOffsets)
+	if err = json.NewDecoder(resp.Body).Decode(offsets); err != nil {
+		return err
+	}
+	for _, offset := range offsets.Offsets {
+		if offset.ErrorCode != nil || offset.Error != nil {
+			return fmt.Errorf("kafka rest proxy rejected record: %s", stringValue(offset.Error))
+		}
+	}
+	return nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
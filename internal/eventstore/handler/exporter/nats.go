@@ -0,0 +1,285 @@
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type NATSConfig struct {
+	// URL of the server, nats://host:port or tls://host:port
+	URL string
+	// Subject prefix, the events are published to <Subject>.<instance id>.<event type>
+	Subject string
+	// JetStream waits for the acknowledgement of the stream capturing the subject,
+	// otherwise the event is only ensured to be received by the server
+	JetStream bool
+	// Token or Username and Password for authentication, optional
+	Token    string
+	Username string
+	Password string
+	// Timeout of connecting and publishing a single event, 5s if not set
+	Timeout time.Duration
+}
+
+// natsSink implements the parts of the NATS client protocol needed to publish events
+// (https://docs.nats.io/reference/reference-protocols/nats-protocol)
+type natsSink struct {
+	config  *NATSConfig
+	address string
+	tls     *tls.Config
+	timeout time.Duration
+
+	mu      sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+	inbox   string
+	replies uint64
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+	Token    string `json:"auth_token,omitempty"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+}
+
+type natsPubAck struct {
+	Stream string `json:"stream"`
+	Error  *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+func NewNATSSink(config *NATSConfig) (Sink, error) {
+	if config.URL == "" || config.Subject == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "EXPOR-Bd4sy", "url and subject of nats sink required")
+	}
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "EXPOR-Xi6nw", "url of nats sink invalid")
+	}
+	sink := &natsSink{
+		config:  config,
+		timeout: timeoutOrDefault(config.Timeout),
+		address: u.Host,
+	}
+	switch u.Scheme {
+	case "nats":
+	case "tls":
+		sink.tls = &tls.Config{ServerName: u.Hostname()}
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "EXPOR-Kr9ta", "scheme %q of nats sink not supported", u.Scheme)
+	}
+	return sink, nil
+}
+
+// Write publishes the event, the connection is established lazily and reset after an error
+func (s *natsSink) Write(ctx context.Context, event eventstore.Event, data []byte) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer func() {
+		if err != nil {
+			s.close()
+		}
+	}()
+	if s.conn == nil {
+		if err = s.connect(); err != nil {
+			return err
+		}
+	}
+	if err = s.setDeadline(ctx); err != nil {
+		return err
+	}
+	subject := s.config.Subject + "." + event.Aggregate().InstanceID + "." + string(event.Type())
+	if !s.config.JetStream {
+		if err = s.write(fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(data), data)); err != nil {
+			return err
+		}
+		return s.waitForPong()
+	}
+	s.replies++
+	reply := s.inbox + "." + strconv.FormatUint(s.replies, 10)
+	if err = s.write(fmt.Sprintf("PUB %s %s %d\r\n%s\r\n", subject, reply, len(data), data)); err != nil {
+		return err
+	}
+	return s.waitForAck(reply)
+}
+
+func (s *natsSink) connect() (err error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	if s.tls != nil {
+		s.conn, err = tls.DialWithDialer(dialer, "tcp", s.address, s.tls)
+	} else {
+		s.conn, err = dialer.Dial("tcp", s.address)
+	}
+	if err != nil {
+		return err
+	}
+	s.reader = bufio.NewReader(s.conn)
+	if err = s.setDeadline(context.Background()); err != nil {
+		return err
+	}
+	line, err := s.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("nats server sent unexpected %q instead of info", line)
+	}
+	connect, err := json.Marshal(&natsConnect{
+		Name:     "zitadel",
+		Lang:     "go",
+		Version:  "1.0.0",
+		Protocol: 1,
+		Token:    s.config.Token,
+		User:     s.config.Username,
+		Pass:     s.config.Password,
+	})
+	if err != nil {
+		return err
+	}
+	cmd := "CONNECT " + string(connect) + "\r\n"
+	if s.config.JetStream {
+		s.inbox, err = newInbox()
+		if err != nil {
+			return err
+		}
+		cmd += "SUB " + s.inbox + ".* 1\r\n"
+	}
+	if err = s.write(cmd + "PING\r\n"); err != nil {
+		return err
+	}
+	return s.waitForPong()
+}
+
+func (s *natsSink) close() {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.conn = nil
+	s.reader = nil
+}
+
+func (s *natsSink) setDeadline(ctx context.Context) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(s.timeout)
+	}
+	return s.conn.SetDeadline(deadline)
+}
+
+func (s *natsSink) write(cmd string) error {
+	_, err := io.WriteString(s.conn, cmd)
+	return err
+}
+
+func (s *natsSink) readLine() (string, error) {
+	line, err := s.reader.ReadString('\n')
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// next reads the next operation of the server, answers pings and returns errors sent by the server
+// payload is only set for messages
+func (s *natsSink) next() (op string, args []string, payload []byte, err error) {
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return "", nil, nil, err
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch op = strings.ToUpper(fields[0]); op {
+		case "PING":
+			if err = s.write("PONG\r\n"); err != nil {
+				return "", nil, nil, err
+			}
+		case "+OK", "INFO":
+		case "-ERR":
+			return "", nil, nil, fmt.Errorf("nats server responded with error: %s", strings.TrimPrefix(line, fields[0]+" "))
+		case "MSG":
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			if len(fields) < 4 {
+				return "", nil, nil, fmt.Errorf("nats server sent malformed message %q", line)
+			}
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return "", nil, nil, err
+			}
+			payload = make([]byte, size+2)
+			if _, err = io.ReadFull(s.reader, payload); err != nil {
+				return "", nil, nil, err
+			}
+			return op, fields[1:], payload[:size], nil
+		default:
+			return op, fields[1:], nil, nil
+		}
+	}
+}
+
+func (s *natsSink) waitForPong() error {
+	for {
+		op, _, _, err := s.next()
+		if err != nil {
+			return err
+		}
+		if op == "PONG" {
+			return nil
+		}
+	}
+}
+
+// waitForAck waits for the acknowledgement of the stream,
+// acknowledgements of previous publications which timed out are skipped
+func (s *natsSink) waitForAck(reply string) error {
+	for {
+		op, args, payload, err := s.next()
+		if err != nil {
+			return err
+		}
+		if op != "MSG" || args[0] != reply {
+			continue
+		}
+		ack := new(natsPubAck)
+		if err = json.Unmarshal(payload, ack); err != nil {
+			return err
+		}
+		if ack.Error != nil {
+			return fmt.Errorf("nats stream rejected event with code %d: %s", ack.Error.Code, ack.Error.Description)
+		}
+		if ack.Stream == "" {
+			return fmt.Errorf("nats sent invalid acknowledgement %q", payload)
+		}
+		return nil
+	}
+}
+
+func newInbox() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "_INBOX." + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,63 @@
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	SinkTypeFile  = "file"
+	SinkTypeKafka = "kafka"
+	SinkTypeNATS  = "nats"
+
+	// defaultTimeout is used by the kafka and nats sinks if no timeout is configured,
+	// so a sink which does not respond can't block the exporter forever
+	defaultTimeout = 5 * time.Second
+)
+
+// Sink receives the marshalled events,
+// Write must only return without error if the event is persisted by the sink
+type Sink interface {
+	Write(ctx context.Context, event eventstore.Event, data []byte) error
+}
+
+type SinkConfig struct {
+	// Type of the sink, either file, kafka or nats
+	Type  string
+	File  *FileConfig
+	Kafka *KafkaConfig
+	NATS  *NATSConfig
+}
+
+func (c *SinkConfig) NewSink() (Sink, error) {
+	switch c.Type {
+	case SinkTypeFile:
+		if c.File == nil {
+			break
+		}
+		return NewFileSink(c.File)
+	case SinkTypeKafka:
+		if c.Kafka == nil {
+			break
+		}
+		return NewKafkaSink(c.Kafka)
+	case SinkTypeNATS:
+		if c.NATS == nil {
+			break
+		}
+		return NewNATSSink(c.NATS)
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "EXPOR-Ud9rk", "sink type %q not supported", c.Type)
+	}
+	return nil, errors.ThrowInvalidArgumentf(nil, "EXPOR-Ly5gp", "config of sink type %s missing", c.Type)
+}
+
+func timeoutOrDefault(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return defaultTimeout
+	}
+	return timeout
+}
@@ -0,0 +1,189 @@
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := NewFileSink(&FileConfig{Path: path})
+	require.NoError(t, err)
+	event := testEvent("user", "user.human.added")
+	require.NoError(t, sink.Write(context.Background(), event, []byte(`{"sequence":1}`)))
+	require.NoError(t, sink.Write(context.Background(), event, []byte(`{"sequence":2}`)))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"sequence\":1}\n{\"sequence\":2}\n", string(content))
+}
+
+func TestKafkaSink(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  bool
+	}{
+		{
+			name:     "produced",
+			status:   http.StatusOK,
+			response: `{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null}]}`,
+		},
+		{
+			name:     "record rejected",
+			status:   http.StatusOK,
+			response: `{"offsets":[{"partition":null,"offset":null,"error_code":50002,"error":"Kafka error"}]}`,
+			wantErr:  true,
+		},
+		{
+			name:    "unavailable",
+			status:  http.StatusServiceUnavailable,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/topics/zitadel.events", r.URL.Path)
+				assert.Equal(t, kafkaContentType, r.Header.Get("Content-Type"))
+				username, password, _ := r.BasicAuth()
+				assert.Equal(t, "user", username)
+				assert.Equal(t, "pass", password)
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"records":[{"key":"agg-id","value":{"sequence":15}}]}`, string(body))
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.response)
+			}))
+			defer server.Close()
+
+			sink, err := NewKafkaSink(&KafkaConfig{URL: server.URL + "/", Topic: "zitadel.events", Username: "user", Password: "pass", Timeout: time.Second})
+			require.NoError(t, err)
+			err = sink.Write(context.Background(), testEvent("user", "user.human.added"), []byte(`{"sequence":15}`))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// fakeNATS serves a single connection and acknowledges publications with a reply subject like a stream
+type fakeNATS struct {
+	listener  net.Listener
+	token     string
+	published chan string
+}
+
+func newFakeNATS(t *testing.T, token string) *fakeNATS {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeNATS{listener: listener, token: token, published: make(chan string, 10)}
+	go f.serve()
+	t.Cleanup(func() { listener.Close() })
+	return f
+}
+
+func (f *fakeNATS) serve() {
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\"}\r\n")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "CONNECT":
+			connect := new(natsConnect)
+			json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), connect)
+			if connect.Token != f.token {
+				fmt.Fprint(conn, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "PUB":
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			io.ReadFull(reader, payload)
+			f.published <- fields[1] + " " + string(payload[:size])
+			if len(fields) == 4 {
+				ack := `{"stream":"EVENTS","seq":1}`
+				fmt.Fprintf(conn, "PING\r\nMSG %s 1 %d\r\n%s\r\n", fields[2], len(ack), ack)
+			}
+		}
+	}
+}
+
+func TestNATSSink(t *testing.T) {
+	event := testEvent("user", "user.human.added")
+	tests := []struct {
+		name      string
+		token     string
+		jetStream bool
+		wantErr   bool
+	}{
+		{
+			name:  "core nats",
+			token: "token",
+		},
+		{
+			name:      "jetstream",
+			token:     "token",
+			jetStream: true,
+		},
+		{
+			name:    "authorization violation",
+			token:   "wrong",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeNATS(t, "token")
+			sink, err := NewNATSSink(&NATSConfig{
+				URL:       "nats://" + server.listener.Addr().String(),
+				Subject:   "zitadel",
+				JetStream: tt.jetStream,
+				Token:     tt.token,
+				Timeout:   time.Second,
+			})
+			require.NoError(t, err)
+			err = sink.Write(context.Background(), event, []byte(`{"sequence":15}`))
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "Authorization Violation")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, `zitadel.instance-id.user.human.added {"sequence":15}`, <-server.published)
+		})
+	}
+}
+
+func TestNewNATSSink_invalidScheme(t *testing.T) {
+	_, err := NewNATSSink(&NATSConfig{URL: "http://localhost:4222", Subject: "zitadel"})
+	assert.Error(t, err)
+}
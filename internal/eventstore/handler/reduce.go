@@ -14,4 +14,7 @@ type EventReducer struct {
 type AggregateReducer struct {
 	Aggregate     eventstore.AggregateType
 	EventRedusers []EventReducer
+	// Reduce is called for all events of the aggregate
+	// which are not handled by one of the EventRedusers
+	Reduce Reduce
 }
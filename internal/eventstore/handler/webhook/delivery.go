@@ -0,0 +1,319 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	errs "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/eventpayload"
+)
+
+const (
+	// SignatureHeader contains the timestamp of the delivery and the signature of the body
+	// in the form of `t=<unix timestamp>,v1=<hex encoded HMAC-SHA256 of "<timestamp>.<body>">`
+	SignatureHeader = "ZITADEL-Signature"
+	// DeliveryHeader identifies the delivery, the value is the same for all attempts
+	DeliveryHeader = "ZITADEL-Delivery"
+)
+
+// Payload is the JSON body sent to the webhook,
+// it only contains the public fields of the event (see [eventpayload.Fields])
+type Payload struct {
+	InstanceID    string          `json:"instanceID"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateID"`
+	ResourceOwner string          `json:"resourceOwner"`
+	EventType     string          `json:"eventType"`
+	Sequence      uint64          `json:"sequence"`
+	CreationDate  time.Time       `json:"creationDate"`
+	EditorUser    string          `json:"editorUser"`
+	Version       string          `json:"version"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+func payloadFromEvent(event eventstore.Event) *Payload {
+	payload := &Payload{
+		InstanceID:    event.Aggregate().InstanceID,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		EventType:     string(event.Type()),
+		Sequence:      event.Sequence(),
+		CreationDate:  event.CreationDate(),
+		EditorUser:    event.EditorUser(),
+		Version:       string(event.Aggregate().Version),
+	}
+	if fields := eventpayload.Fields(event); fields != nil {
+		data, err := json.Marshal(fields)
+		logging.WithFields("eventType", event.Type()).OnError(err).Warn("unable to marshal event payload")
+		payload.Payload = data
+	}
+	return payload
+}
+
+// claimDeliveriesStmt reserves the pending deliveries of active webhooks due for the next attempt,
+// by moving the next attempt after the timeout of the request, so they are not sent by other workers simultaneously
+const claimDeliveriesStmt = "UPDATE " + projection.WebhookDeliveryTable + " AS d SET " + projection.WebhookDeliveryNextAttemptCol + " = $1" +
+	" FROM " + projection.WebhookTable + " AS w" +
+	" WHERE w." + projection.WebhookInstanceIDCol + " = d." + projection.WebhookDeliveryInstanceIDCol +
+	" AND w." + projection.WebhookIDCol + " = d." + projection.WebhookDeliveryWebhookIDCol +
+	" AND d." + projection.WebhookDeliveryStateCol + " = $2" +
+	" AND d." + projection.WebhookDeliveryNextAttemptCol + " <= $3" +
+	" AND (d." + projection.WebhookDeliveryInstanceIDCol + ", d." + projection.WebhookDeliveryWebhookIDCol + ", d." + projection.WebhookDeliveryEventSequenceCol + ") IN (" +
+	"SELECT p." + projection.WebhookDeliveryInstanceIDCol + ", p." + projection.WebhookDeliveryWebhookIDCol + ", p." + projection.WebhookDeliveryEventSequenceCol +
+	" FROM " + projection.WebhookDeliveryTable + " AS p" +
+	" JOIN " + projection.WebhookTable + " AS a" +
+	" ON a." + projection.WebhookInstanceIDCol + " = p." + projection.WebhookDeliveryInstanceIDCol +
+	" AND a." + projection.WebhookIDCol + " = p." + projection.WebhookDeliveryWebhookIDCol +
+	" WHERE a." + projection.WebhookStateCol + " = $4" +
+	" AND p." + projection.WebhookDeliveryStateCol + " = $2" +
+	" AND p." + projection.WebhookDeliveryNextAttemptCol + " <= $3" +
+	" ORDER BY p." + projection.WebhookDeliveryNextAttemptCol + " LIMIT $5)" +
+	" RETURNING d." + projection.WebhookDeliveryInstanceIDCol +
+	", d." + projection.WebhookDeliveryWebhookIDCol +
+	", d." + projection.WebhookDeliveryEventSequenceCol +
+	", d." + projection.WebhookDeliveryAttemptsCol +
+	", d." + projection.WebhookDeliveryPayloadCol +
+	", w." + projection.WebhookURLCol +
+	", w." + projection.WebhookSigningKeyCol
+
+const updateDeliveryStmt = "UPDATE " + projection.WebhookDeliveryTable + " SET " +
+	projection.WebhookDeliveryStateCol + " = $1, " +
+	projection.WebhookDeliveryAttemptsCol + " = $2, " +
+	projection.WebhookDeliveryStatusCodeCol + " = $3, " +
+	projection.WebhookDeliveryErrorCol + " = $4, " +
+	projection.WebhookDeliveryNextAttemptCol + " = $5" +
+	" WHERE " + projection.WebhookDeliveryInstanceIDCol + " = $6" +
+	" AND " + projection.WebhookDeliveryWebhookIDCol + " = $7" +
+	" AND " + projection.WebhookDeliveryEventSequenceCol + " = $8"
+
+// removeDeliveriesStmt removes a batch of the succeeded and failed deliveries finished before the retention,
+// the next attempt of a finished delivery is the time of its last attempt
+const removeDeliveriesStmt = "DELETE FROM " + projection.WebhookDeliveryTable +
+	" WHERE (" + projection.WebhookDeliveryInstanceIDCol + ", " + projection.WebhookDeliveryWebhookIDCol + ", " + projection.WebhookDeliveryEventSequenceCol + ") IN (" +
+	"SELECT " + projection.WebhookDeliveryInstanceIDCol + ", " + projection.WebhookDeliveryWebhookIDCol + ", " + projection.WebhookDeliveryEventSequenceCol +
+	" FROM " + projection.WebhookDeliveryTable +
+	" WHERE " + projection.WebhookDeliveryStateCol + " IN ($1, $2)" +
+	" AND " + projection.WebhookDeliveryNextAttemptCol + " < $3" +
+	" LIMIT $4)"
+
+type pendingDelivery struct {
+	instanceID string
+	webhookID  string
+	sequence   uint64
+	attempts   uint64
+	payload    []byte
+	url        string
+	signingKey *crypto.CryptoValue
+}
+
+type deliveryResult struct {
+	statusCode int
+	retry      bool
+	err        error
+}
+
+// work sends the pending deliveries in the configured interval until the context is done,
+// if a retention is configured, the finished deliveries older than the retention are removed in the cleanup interval
+func (h *webhookHandler) work(ctx context.Context) {
+	ticker := time.NewTicker(h.config.WorkerInterval)
+	defer ticker.Stop()
+	var cleanup <-chan time.Time
+	if h.config.DeliveryRetention > 0 && h.config.CleanupInterval > 0 {
+		cleanupTicker := time.NewTicker(h.config.CleanupInterval)
+		defer cleanupTicker.Stop()
+		cleanup = cleanupTicker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.sendPending(ctx)
+		case <-cleanup:
+			err := h.removeFinishedDeliveries(ctx, time.Now())
+			logging.OnError(err).Warn("unable to remove finished webhook deliveries")
+		}
+	}
+}
+
+// removeFinishedDeliveries removes the succeeded and failed deliveries older than the retention in batches of the bulk limit
+func (h *webhookHandler) removeFinishedDeliveries(ctx context.Context, now time.Time) error {
+	for {
+		result, err := h.client.ExecContext(ctx, removeDeliveriesStmt,
+			domain.WebhookDeliveryStateSucceeded,
+			domain.WebhookDeliveryStateFailed,
+			now.Add(-h.config.DeliveryRetention),
+			h.config.BulkLimit,
+		)
+		if err != nil {
+			return errors.ThrowInternal(err, "WEBHO-Rq4vd", "unable to remove deliveries")
+		}
+		removed, err := result.RowsAffected()
+		if err != nil {
+			return errors.ThrowInternal(err, "WEBHO-Tc7ek", "unable to remove deliveries")
+		}
+		if removed == 0 || uint64(removed) < h.config.BulkLimit {
+			return nil
+		}
+	}
+}
+
+func (h *webhookHandler) sendPending(ctx context.Context) {
+	deliveries, err := h.claimDeliveries(ctx, time.Now())
+	if err != nil {
+		logging.WithError(err).Warn("unable to claim webhook deliveries")
+		return
+	}
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *pendingDelivery) {
+			defer wg.Done()
+			result := h.attempt(ctx, delivery)
+			err := h.updateDelivery(ctx, delivery, result, time.Now())
+			logging.WithFields("instanceID", delivery.instanceID, "webhookID", delivery.webhookID, "sequence", delivery.sequence).
+				OnError(err).
+				Warn("unable to update webhook delivery")
+		}(delivery)
+	}
+	wg.Wait()
+}
+
+func (h *webhookHandler) claimDeliveries(ctx context.Context, now time.Time) (_ []*pendingDelivery, err error) {
+	rows, err := h.client.QueryContext(ctx, claimDeliveriesStmt,
+		now.Add(2*h.config.Timeout),
+		domain.WebhookDeliveryStatePending,
+		now,
+		domain.WebhookStateActive,
+		h.config.BulkLimit,
+	)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHO-Pw2kd", "unable to claim deliveries")
+	}
+	defer rows.Close()
+	deliveries := make([]*pendingDelivery, 0)
+	for rows.Next() {
+		delivery := new(pendingDelivery)
+		if err = rows.Scan(
+			&delivery.instanceID,
+			&delivery.webhookID,
+			&delivery.sequence,
+			&delivery.attempts,
+			&delivery.payload,
+			&delivery.url,
+			&delivery.signingKey,
+		); err != nil {
+			return nil, errors.ThrowInternal(err, "WEBHO-Ks9sl", "unable to scan delivery")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHO-Ls8vb", "unable to claim deliveries")
+	}
+	return deliveries, nil
+}
+
+// attempt sends the delivery to the webhook once,
+// the result defines if the delivery can be retried (network errors, responses with status 429 and 5xx)
+func (h *webhookHandler) attempt(ctx context.Context, delivery *pendingDelivery) (result deliveryResult) {
+	signingKey, err := crypto.Decrypt(delivery.signingKey, h.signingKeyEncryption)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	deliveryID := delivery.webhookID + ":" + strconv.FormatUint(delivery.sequence, 10)
+	result.statusCode, result.retry, result.err = h.post(ctx, delivery.url, deliveryID, signingKey, delivery.payload)
+	return result
+}
+
+func (h *webhookHandler) updateDelivery(ctx context.Context, delivery *pendingDelivery, result deliveryResult, now time.Time) error {
+	attempts := delivery.attempts + 1
+	state, nextAttempt := h.nextState(attempts, result, now)
+	var deliveryErr interface{}
+	if result.err != nil {
+		deliveryErr = result.err.Error()
+		logging.WithFields("instanceID", delivery.instanceID, "webhookID", delivery.webhookID, "sequence", delivery.sequence, "attempts", attempts).
+			WithError(result.err).
+			Info("webhook delivery attempt failed")
+	}
+	_, err := h.client.ExecContext(ctx, updateDeliveryStmt,
+		state,
+		attempts,
+		result.statusCode,
+		deliveryErr,
+		nextAttempt,
+		delivery.instanceID,
+		delivery.webhookID,
+		delivery.sequence,
+	)
+	if err != nil {
+		return errors.ThrowInternal(err, "WEBHO-Hd7xa", "unable to update delivery")
+	}
+	return nil
+}
+
+// nextState returns the state of the delivery after the attempt
+// and the time of the next attempt with an exponential backoff (if it is retried)
+func (h *webhookHandler) nextState(attempts uint64, result deliveryResult, now time.Time) (domain.WebhookDeliveryState, time.Time) {
+	if result.err == nil {
+		return domain.WebhookDeliveryStateSucceeded, now
+	}
+	if !result.retry || attempts >= h.config.MaxAttempts {
+		return domain.WebhookDeliveryStateFailed, now
+	}
+	backoff := h.config.InitialBackoff
+	for i := uint64(1); i < attempts && backoff < h.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > h.config.MaxBackoff {
+		backoff = h.config.MaxBackoff
+	}
+	return domain.WebhookDeliveryStatePending, now.Add(backoff)
+}
+
+func (h *webhookHandler) post(ctx context.Context, url, deliveryID string, signingKey, body []byte) (statusCode int, retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, sign(signingKey, time.Now(), body))
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return 0, !errs.Is(err, errTargetNotAllowed), err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return resp.StatusCode, retry, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+}
+
+// sign returns the value of the [SignatureHeader]
+func sign(key []byte, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
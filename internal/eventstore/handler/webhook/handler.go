@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	errs "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	webhook_repo "github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+type Config struct {
+	// Timeout of a single delivery attempt
+	Timeout time.Duration
+	// MaxAttempts is the amount of attempts to deliver an event, before the delivery is marked as failed
+	MaxAttempts uint64
+	// InitialBackoff is the time waited after the first failed attempt, it is doubled after every further attempt
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// WorkerInterval is the interval in which pending deliveries are sent
+	WorkerInterval time.Duration
+	// BulkLimit is the maximum amount of deliveries sent per interval
+	BulkLimit uint64
+	// DeliveryRetention is the time succeeded and failed deliveries are kept, 0 keeps them forever
+	DeliveryRetention time.Duration
+	// CleanupInterval is the interval in which the deliveries older than the retention are removed
+	CleanupInterval time.Duration
+}
+
+// queueDeliveriesStmt creates a pending delivery of the event for every active webhook subscribed to it:
+// webhooks of the instance receive the events of all organisations,
+// webhooks of an organisation only the events of its own resources.
+// Empty aggregate or event types match all events.
+// Only events which occurred after the webhook was added are delivered.
+const queueDeliveriesStmt = "INSERT INTO " + projection.WebhookDeliveryTable +
+	" (" + projection.WebhookDeliveryInstanceIDCol +
+	", " + projection.WebhookDeliveryWebhookIDCol +
+	", " + projection.WebhookDeliveryEventSequenceCol +
+	", " + projection.WebhookDeliveryCreationDateCol +
+	", " + projection.WebhookDeliveryAggregateTypeCol +
+	", " + projection.WebhookDeliveryAggregateIDCol +
+	", " + projection.WebhookDeliveryEventTypeCol +
+	", " + projection.WebhookDeliveryStateCol +
+	", " + projection.WebhookDeliveryNextAttemptCol +
+	", " + projection.WebhookDeliveryPayloadCol +
+	")" +
+	" SELECT w." + projection.WebhookInstanceIDCol + ", w." + projection.WebhookIDCol + ", $1, $2, $3, $4, $5, $6, $2, $7" +
+	" FROM " + projection.WebhookTable + " AS w" +
+	" WHERE w." + projection.WebhookInstanceIDCol + " = $8" +
+	" AND w." + projection.WebhookStateCol + " = $9" +
+	" AND w." + projection.WebhookOwnerRemovedCol + " = false" +
+	" AND w." + projection.WebhookCreationSequenceCol + " < $1" +
+	" AND (w." + projection.WebhookResourceOwnerCol + " = $8 OR w." + projection.WebhookResourceOwnerCol + " = $10)" +
+	" AND (w." + projection.WebhookAggregateTypesCol + " IS NULL OR cardinality(w." + projection.WebhookAggregateTypesCol + ") = 0 OR $3 = ANY(w." + projection.WebhookAggregateTypesCol + "))" +
+	" AND (w." + projection.WebhookEventTypesCol + " IS NULL OR cardinality(w." + projection.WebhookEventTypesCol + ") = 0 OR $5 = ANY(w." + projection.WebhookEventTypesCol + "))" +
+	" ON CONFLICT (" + projection.WebhookDeliveryInstanceIDCol + ", " + projection.WebhookDeliveryWebhookIDCol + ", " + projection.WebhookDeliveryEventSequenceCol + ") DO NOTHING"
+
+// Start creates the handler queuing the events for the registered webhooks and starts it
+// together with the worker sending the queued deliveries.
+// Every delivery is recorded in the deliveries table of the webhook projection
+func Start(ctx context.Context, customConfig projection.CustomConfig, config *Config, signingKeyEncryption crypto.EncryptionAlgorithm) {
+	h := newWebhookHandler(ctx, projection.ApplyCustomConfig(customConfig), config, signingKeyEncryption)
+	h.Start()
+	go h.work(ctx)
+}
+
+type webhookHandler struct {
+	crdb.StatementHandler
+	config               *Config
+	client               *database.DB
+	signingKeyEncryption crypto.EncryptionAlgorithm
+	httpClient           *http.Client
+}
+
+func newWebhookHandler(
+	ctx context.Context,
+	handlerConfig crdb.StatementHandlerConfig,
+	config *Config,
+	signingKeyEncryption crypto.EncryptionAlgorithm,
+) *webhookHandler {
+	h := new(webhookHandler)
+	handlerConfig.ProjectionName = projection.WebhookDeliveryTable
+	handlerConfig.Reducers = h.reducers()
+	h.StatementHandler = crdb.NewStatementHandler(ctx, handlerConfig)
+	h.config = config
+	h.client = handlerConfig.Client
+	h.signingKeyEncryption = signingKeyEncryption
+	h.httpClient = newHTTPClient(config.Timeout)
+	return h
+}
+
+var errTargetNotAllowed = errs.New("webhook target not allowed")
+
+// newHTTPClient returns the client delivering the events,
+// it doesn't follow redirects and refuses to connect to non-public addresses (see [domain.IsPublicIP]).
+// The address is checked after the host is resolved, so hosts resolving to internal addresses are refused as well
+func newHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: checkTarget,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect to the target instead of the dialer
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func checkTarget(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !domain.IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", errTargetNotAllowed, host)
+	}
+	return nil
+}
+
+// reducers handles all events of the subscribable aggregates (see [webhook_repo.SubscribableAggregateTypes])
+// events of the webhook aggregate itself are not sent
+func (h *webhookHandler) reducers() []handler.AggregateReducer {
+	reducers := make([]handler.AggregateReducer, len(webhook_repo.SubscribableAggregateTypes))
+	for i, aggregateType := range webhook_repo.SubscribableAggregateTypes {
+		reducers[i] = handler.AggregateReducer{
+			Aggregate: aggregateType,
+			Reduce:    h.reduceEvent,
+		}
+	}
+	return reducers
+}
+
+// reduceEvent only queues the deliveries of the event,
+// they are sent by the worker, so the projection is never blocked by slow or failing webhooks
+func (h *webhookHandler) reduceEvent(event eventstore.Event) (*handler.Statement, error) {
+	payload, err := json.Marshal(payloadFromEvent(event))
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHO-Fk2ms", "unable to marshal payload")
+	}
+	args := []interface{}{
+		event.Sequence(),
+		time.Now(),
+		event.Aggregate().Type,
+		event.Aggregate().ID,
+		event.Type(),
+		domain.WebhookDeliveryStatePending,
+		payload,
+		event.Aggregate().InstanceID,
+		domain.WebhookStateActive,
+		event.Aggregate().ResourceOwner,
+	}
+	return &handler.Statement{
+		AggregateType:    event.Aggregate().Type,
+		Sequence:         event.Sequence(),
+		PreviousSequence: event.PreviousAggregateTypeSequence(),
+		InstanceID:       event.Aggregate().InstanceID,
+		Execute: func(ex handler.Executer, _ string) error {
+			if _, err := ex.Exec(queueDeliveriesStmt, args...); err != nil {
+				return errors.ThrowInternal(err, "WEBHO-Ud3ms", "unable to queue deliveries")
+			}
+			return nil
+		},
+	}, nil
+}
@@ -0,0 +1,305 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func testEvent(aggregateType, eventType, resourceOwner string) eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   "agg-id",
+		AggregateType: repository.AggregateType(aggregateType),
+		Type:          repository.EventType(eventType),
+		Sequence:      15,
+		InstanceID:    "instance-id",
+		ResourceOwner: sql.NullString{String: resourceOwner, Valid: resourceOwner != ""},
+		EditorUser:    "editor-user",
+		Version:       "v1",
+		Data:          []byte(`{"userName":"username","secret":{"cryptoType":1,"algorithm":"bcrypt","crypted":"JDJhJDEw"}}`),
+	})
+}
+
+type testExecuter struct {
+	stmt string
+	args []interface{}
+}
+
+func (e *testExecuter) Exec(stmt string, args ...interface{}) (sql.Result, error) {
+	e.stmt = stmt
+	e.args = args
+	return nil, nil
+}
+
+func Test_webhookHandler_reduceEvent(t *testing.T) {
+	event := testEvent("user", "user.human.added", "org-id")
+	stmt, err := (&webhookHandler{}).reduceEvent(event)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(15), stmt.Sequence)
+	assert.Equal(t, "instance-id", stmt.InstanceID)
+
+	executer := new(testExecuter)
+	assert.NoError(t, stmt.Execute(executer, "projections.webhooks_deliveries"))
+	assert.Equal(t, "INSERT INTO projections.webhooks_deliveries"+
+		" (instance_id, webhook_id, event_sequence, creation_date, aggregate_type, aggregate_id, event_type, state, next_attempt, payload)"+
+		" SELECT w.instance_id, w.id, $1, $2, $3, $4, $5, $6, $2, $7"+
+		" FROM projections.webhooks AS w"+
+		" WHERE w.instance_id = $8"+
+		" AND w.state = $9"+
+		" AND w.owner_removed = false"+
+		" AND w.creation_sequence < $1"+
+		" AND (w.resource_owner = $8 OR w.resource_owner = $10)"+
+		" AND (w.aggregate_types IS NULL OR cardinality(w.aggregate_types) = 0 OR $3 = ANY(w.aggregate_types))"+
+		" AND (w.event_types IS NULL OR cardinality(w.event_types) = 0 OR $5 = ANY(w.event_types))"+
+		" ON CONFLICT (instance_id, webhook_id, event_sequence) DO NOTHING",
+		executer.stmt,
+	)
+	if assert.Len(t, executer.args, 10) {
+		assert.Equal(t, uint64(15), executer.args[0])
+		assert.Equal(t, eventstore.AggregateType("user"), executer.args[2])
+		assert.Equal(t, "agg-id", executer.args[3])
+		assert.Equal(t, eventstore.EventType("user.human.added"), executer.args[4])
+		assert.Equal(t, domain.WebhookDeliveryStatePending, executer.args[5])
+		payload := new(Payload)
+		assert.NoError(t, json.Unmarshal(executer.args[6].([]byte), payload))
+		assert.Equal(t, "user.human.added", payload.EventType)
+		assert.JSONEq(t, `{"userName":"username"}`, string(payload.Payload))
+		assert.Equal(t, "instance-id", executer.args[7])
+		assert.Equal(t, domain.WebhookStateActive, executer.args[8])
+		assert.Equal(t, "org-id", executer.args[9])
+	}
+}
+
+func Test_sign(t *testing.T) {
+	got := sign([]byte("key"), time.Unix(1672531200, 0), []byte(`{"eventType":"user.human.added"}`))
+	assert.Equal(t, "t=1672531200,v1=55074dc06e89c9417569cb9966cf3c204c8c63a86ec81250206e1ea0999f4481", got)
+}
+
+func Test_webhookHandler_attempt(t *testing.T) {
+	type res struct {
+		statusCode int
+		retry      bool
+		err        bool
+	}
+	tests := []struct {
+		name     string
+		response int
+		res      res
+	}{
+		{
+			name:     "success",
+			response: http.StatusOK,
+			res: res{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:     "server error, retry",
+			response: http.StatusInternalServerError,
+			res: res{
+				statusCode: http.StatusInternalServerError,
+				retry:      true,
+				err:        true,
+			},
+		},
+		{
+			name:     "too many requests, retry",
+			response: http.StatusTooManyRequests,
+			res: res{
+				statusCode: http.StatusTooManyRequests,
+				retry:      true,
+				err:        true,
+			},
+		},
+		{
+			name:     "client error, no retry",
+			response: http.StatusBadRequest,
+			res: res{
+				statusCode: http.StatusBadRequest,
+				err:        true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"eventType":"user.human.added"}`, string(body))
+				assert.Equal(t, "webhook-id:15", r.Header.Get(DeliveryHeader))
+				assert.NotEmpty(t, r.Header.Get(SignatureHeader))
+				w.WriteHeader(tt.response)
+			}))
+			defer server.Close()
+
+			h := &webhookHandler{
+				config:               &Config{MaxAttempts: 3},
+				signingKeyEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				httpClient:           server.Client(),
+			}
+			got := h.attempt(context.Background(), &pendingDelivery{
+				webhookID: "webhook-id",
+				sequence:  15,
+				payload:   []byte(`{"eventType":"user.human.added"}`),
+				url:       server.URL,
+				signingKey: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("key"),
+				},
+			})
+			assert.Equal(t, tt.res.statusCode, got.statusCode)
+			assert.Equal(t, tt.res.retry, got.retry)
+			assert.Equal(t, tt.res.err, got.err != nil)
+		})
+	}
+}
+
+func Test_newHTTPClient(t *testing.T) {
+	t.Run("internal address refused", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("request must not be sent")
+		}))
+		defer server.Close()
+
+		h := &webhookHandler{httpClient: newHTTPClient(time.Second)}
+		statusCode, retry, err := h.post(context.Background(), server.URL, "webhook-id:15", []byte("key"), []byte(`{}`))
+		assert.ErrorIs(t, err, errTargetNotAllowed)
+		assert.Equal(t, 0, statusCode)
+		assert.False(t, retry)
+	})
+	t.Run("redirect not followed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/redirected" {
+				t.Error("redirect must not be followed")
+			}
+			http.Redirect(w, r, "/redirected", http.StatusFound)
+		}))
+		defer server.Close()
+
+		client := newHTTPClient(time.Second)
+		// the dialer of the test server is used, as it listens on the loopback address
+		client.Transport = server.Client().Transport
+		h := &webhookHandler{httpClient: client}
+		statusCode, retry, err := h.post(context.Background(), server.URL, "webhook-id:15", []byte("key"), []byte(`{}`))
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusFound, statusCode)
+		assert.False(t, retry)
+	})
+}
+
+func Test_checkTarget(t *testing.T) {
+	assert.NoError(t, checkTarget("tcp", "203.0.113.10:443", nil))
+	assert.ErrorIs(t, checkTarget("tcp", "127.0.0.1:8080", nil), errTargetNotAllowed)
+	assert.ErrorIs(t, checkTarget("tcp", "169.254.169.254:80", nil), errTargetNotAllowed)
+	assert.ErrorIs(t, checkTarget("tcp6", "[fd00::1]:443", nil), errTargetNotAllowed)
+}
+
+func Test_webhookHandler_nextState(t *testing.T) {
+	now := time.Now()
+	h := &webhookHandler{
+		config: &Config{
+			MaxAttempts:    4,
+			InitialBackoff: time.Second,
+			MaxBackoff:     3 * time.Second,
+		},
+	}
+	tests := []struct {
+		name        string
+		attempts    uint64
+		result      deliveryResult
+		state       domain.WebhookDeliveryState
+		nextAttempt time.Time
+	}{
+		{
+			name:        "succeeded",
+			attempts:    1,
+			result:      deliveryResult{statusCode: http.StatusOK},
+			state:       domain.WebhookDeliveryStateSucceeded,
+			nextAttempt: now,
+		},
+		{
+			name:        "not retryable, failed",
+			attempts:    1,
+			result:      deliveryResult{statusCode: http.StatusBadRequest, err: io.EOF},
+			state:       domain.WebhookDeliveryStateFailed,
+			nextAttempt: now,
+		},
+		{
+			name:        "first retry, initial backoff",
+			attempts:    1,
+			result:      deliveryResult{retry: true, err: io.EOF},
+			state:       domain.WebhookDeliveryStatePending,
+			nextAttempt: now.Add(time.Second),
+		},
+		{
+			name:        "second retry, doubled backoff",
+			attempts:    2,
+			result:      deliveryResult{retry: true, err: io.EOF},
+			state:       domain.WebhookDeliveryStatePending,
+			nextAttempt: now.Add(2 * time.Second),
+		},
+		{
+			name:        "third retry, max backoff",
+			attempts:    3,
+			result:      deliveryResult{retry: true, err: io.EOF},
+			state:       domain.WebhookDeliveryStatePending,
+			nextAttempt: now.Add(3 * time.Second),
+		},
+		{
+			name:        "max attempts reached, failed",
+			attempts:    4,
+			result:      deliveryResult{retry: true, err: io.EOF},
+			state:       domain.WebhookDeliveryStateFailed,
+			nextAttempt: now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, nextAttempt := h.nextState(tt.attempts, tt.result, now)
+			assert.Equal(t, tt.state, state)
+			assert.Equal(t, tt.nextAttempt, nextAttempt)
+		})
+	}
+}
+
+func Test_webhookHandler_removeFinishedDeliveries(t *testing.T) {
+	now := time.Now()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	h := &webhookHandler{
+		config: &Config{
+			BulkLimit:         2,
+			DeliveryRetention: time.Hour,
+		},
+		client: &database.DB{DB: db},
+	}
+	// full batches are removed until less than the bulk limit is left
+	for _, removed := range []int64{2, 1} {
+		mock.ExpectExec(regexp.QuoteMeta(removeDeliveriesStmt)).
+			WithArgs(domain.WebhookDeliveryStateSucceeded, domain.WebhookDeliveryStateFailed, now.Add(-time.Hour), uint64(2)).
+			WillReturnResult(sqlmock.NewResult(0, removed))
+	}
+	assert.NoError(t, h.removeFinishedDeliveries(context.Background(), now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -59,6 +59,17 @@ type Event struct {
 	//InstanceID is the instance where this event belongs to
 	// use the ID of the instance
 	InstanceID string
+
+	//SequenceCheck rejects the event if the aggregate was changed concurrently
+	// if it's nil the event is pushed independent of the current state of the aggregate
+	SequenceCheck *SequenceCheck
+}
+
+//SequenceCheck is the precondition of an event:
+// the aggregate must not contain events of the event types with a higher sequence than Sequence
+type SequenceCheck struct {
+	Sequence   uint64
+	EventTypes []EventType
 }
 
 //EventType is the description of the change
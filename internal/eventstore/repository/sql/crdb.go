@@ -79,6 +79,15 @@ const (
 		"FROM previous_data " +
 		"RETURNING id, event_sequence, previous_aggregate_sequence, previous_aggregate_type_sequence, creation_date, resource_owner, instance_id"
 
+	//sequenceCheck selects if the aggregate contains events of the types newer than the checked sequence
+	sequenceCheck = "SELECT EXISTS (" +
+		"SELECT 1 FROM eventstore.events" +
+		" WHERE aggregate_type = $1 AND aggregate_id = $2" +
+		" AND (CASE WHEN $3::TEXT IS NULL THEN instance_id is null else instance_id = $3::TEXT END)" +
+		" AND event_type = ANY($4)" +
+		" AND event_sequence > $5" +
+		")"
+
 	uniqueInsert = `INSERT INTO eventstore.unique_constraints
 					(
 						unique_type,
@@ -117,6 +126,9 @@ func (db *CRDB) Push(ctx context.Context, events []*repository.Event, uniqueCons
 			previousAggregateTypeSequence Sequence
 		)
 		for _, event := range events {
+			if err := db.checkSequence(ctx, tx, event); err != nil {
+				return err
+			}
 			err := tx.QueryRowContext(ctx, crdbInsert,
 				event.Type,
 				event.AggregateType,
@@ -176,6 +188,33 @@ func (db *CRDB) CreateInstance(ctx context.Context, instanceID string) error {
 	return nil
 }
 
+// checkSequence rejects the event if the aggregate was changed after the checked sequence,
+// the transaction is serializable, so concurrent pushes of the checked events conflict
+func (db *CRDB) checkSequence(ctx context.Context, tx *sql.Tx, event *repository.Event) error {
+	if event.SequenceCheck == nil {
+		return nil
+	}
+	eventTypes := make(database.StringArray, len(event.SequenceCheck.EventTypes))
+	for i, eventType := range event.SequenceCheck.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+	var changed bool
+	err := tx.QueryRowContext(ctx, sequenceCheck,
+		event.AggregateType,
+		event.AggregateID,
+		event.InstanceID,
+		eventTypes,
+		event.SequenceCheck.Sequence,
+	).Scan(&changed)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "SQL-Qw8sd", "unable to check sequence")
+	}
+	if changed {
+		return caos_errs.ThrowPreconditionFailed(nil, "SQL-Fv3ol", "Errors.ConcurrentChange")
+	}
+	return nil
+}
+
 // handleUniqueConstraints adds or removes unique constraints
 func (db *CRDB) handleUniqueConstraints(ctx context.Context, tx *sql.Tx, uniqueConstraints ...*repository.UniqueConstraint) (err error) {
 	if len(uniqueConstraints) == 0 || (len(uniqueConstraints) == 1 && uniqueConstraints[0] == nil) {
@@ -41,7 +41,10 @@ func SubscribeAggregates(eventQueue chan Event, aggregates ...AggregateType) *Su
 //SubscribeEventTypes subscribes for the given event types
 // if no event types are provided the subscription is for all events of the aggregate
 func SubscribeEventTypes(eventQueue chan Event, types map[AggregateType][]EventType) *Subscription {
-	aggregates := make([]AggregateType, len(types))
+	aggregates := make([]AggregateType, 0, len(types))
+	for aggregate := range types {
+		aggregates = append(aggregates, aggregate)
+	}
 	sub := &Subscription{
 		Events: eventQueue,
 		types:  types,
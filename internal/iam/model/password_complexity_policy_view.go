@@ -7,13 +7,14 @@ import (
 )
 
 type PasswordComplexityPolicyView struct {
-	AggregateID  string
-	MinLength    uint64
-	HasLowercase bool
-	HasUppercase bool
-	HasNumber    bool
-	HasSymbol    bool
-	Default      bool
+	AggregateID   string
+	MinLength     uint64
+	HasLowercase  bool
+	HasUppercase  bool
+	HasNumber     bool
+	HasSymbol     bool
+	CheckBreached bool
+	Default       bool
 
 	CreationDate time.Time
 	ChangeDate   time.Time
@@ -0,0 +1,92 @@
+package apple
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"github.com/zitadel/oidc/v2/pkg/crypto"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+const (
+	issuer = "https://appleid.apple.com"
+	name   = "Apple"
+
+	// clientSecretLifetime is the validity of the generated client secret (JWT).
+	// Apple accepts up to 6 months, but since the secret is created for every provider instance,
+	// a short lifetime is sufficient.
+	clientSecretLifetime = time.Hour
+)
+
+var (
+	ErrInvalidPrivateKey = errors.New("invalid private key, expected PEM encoded PKCS8 private key")
+
+	_ idp.Provider = (*Provider)(nil)
+)
+
+// Provider is the [idp.Provider] implementation for Sign in with Apple
+type Provider struct {
+	*oidc.Provider
+}
+
+// New creates an Apple provider using the [oidc.Provider] (OIDC generic provider).
+// The client secret is a JWT signed with the provided private key (downloaded from the Apple developer portal).
+// Apple returns the response using the `form_post` response mode
+// and does not provide a userinfo endpoint, therefore the information of the id_token is used.
+func New(clientID, teamID, keyID, callbackURL string, privateKey []byte, scopes []string, options ...oidc.ProviderOpts) (*Provider, error) {
+	secret, err := clientSecretFromPrivateKey(privateKey, teamID, clientID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, oidc.WithResponseMode("form_post"))
+	rp, err := oidc.New(name, issuer, clientID, secret, callbackURL, scopes, oidc.DefaultMapper, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+// clientSecretFromPrivateKey creates the client secret JWT as described in
+// https://developer.apple.com/documentation/accountorganizationaldatasharing/creating-a-client-secret
+func clientSecretFromPrivateKey(key []byte, teamID, clientID, keyID string) (string, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return "", ErrInvalidPrivateKey
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.ES256,
+		Key:       &jose.JSONWebKey{Key: privateKey, KeyID: keyID},
+	}, &jose.SignerOptions{})
+	if err != nil {
+		return "", err
+	}
+	iat := time.Now()
+	return crypto.Sign(&clientSecretClaims{
+		Issuer:    teamID,
+		Subject:   clientID,
+		Audience:  issuer,
+		IssuedAt:  iat.Unix(),
+		ExpiresAt: iat.Add(clientSecretLifetime).Unix(),
+	}, signer)
+}
+
+// clientSecretClaims are the claims of the client secret JWT.
+// Apple requires the `aud` claim to be a single string and not an array.
+type clientSecretClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
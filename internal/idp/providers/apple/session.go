@@ -0,0 +1,91 @@
+package apple
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/oidc/v2/pkg/client/rp"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session extends the [oidc.Session] with the formValues returned from the callback.
+// This enables to parse the user's name, which Apple only returns as form param on the first login
+type Session struct {
+	*oidc.Session
+	UserFormValue string
+}
+
+type userFormValue struct {
+	Name userNamesFormValue `json:"name,omitempty"`
+}
+
+type userNamesFormValue struct {
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It will execute an OIDC code exchange if needed to retrieve the tokens,
+// extract the information from the id_token and if available also from the `user` form value.
+// Apple does not provide a userinfo endpoint, so it will not be called.
+// The information will be mapped into an [idp.User].
+func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
+	if s.Tokens == nil {
+		if s.Code == "" {
+			return nil, oidc.ErrCodeMissing
+		}
+		s.Tokens, err = rp.CodeExchange(ctx, s.Code, s.Provider.RelyingParty)
+		if err != nil {
+			return nil, err
+		}
+	}
+	oidcUser := oidc.NewUser(s.Tokens.IDTokenClaims)
+	formValue := userFormValue{}
+	if s.UserFormValue != "" {
+		if err = json.Unmarshal([]byte(s.UserFormValue), &formValue); err != nil {
+			return nil, err
+		}
+	}
+	return NewUser(oidcUser, formValue.Name), nil
+}
+
+func NewUser(user *oidc.User, names userNamesFormValue) *User {
+	return &User{User: user, firstName: names.FirstName, lastName: names.LastName}
+}
+
+// User extends the [oidc.User] with the first and last name, which Apple only returns
+// on the first login in the `user` form value and not as part of the id_token.
+type User struct {
+	*oidc.User
+	firstName string
+	lastName  string
+}
+
+// GetFirstName implements the [idp.User] interface.
+func (u *User) GetFirstName() string {
+	return u.firstName
+}
+
+// GetLastName implements the [idp.User] interface.
+func (u *User) GetLastName() string {
+	return u.lastName
+}
+
+// GetDisplayName implements the [idp.User] interface.
+// It returns the full name (if provided on the first login).
+func (u *User) GetDisplayName() string {
+	if u.firstName == "" || u.lastName == "" {
+		return u.firstName + u.lastName
+	}
+	return u.firstName + " " + u.lastName
+}
+
+// GetPreferredUsername implements the [idp.User] interface.
+// It returns the email, because Apple does not return a username.
+func (u *User) GetPreferredUsername() string {
+	return string(u.GetEmail())
+}
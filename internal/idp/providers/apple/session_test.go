@@ -0,0 +1,209 @@
+package apple
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	openid "github.com/zitadel/oidc/v2/pkg/oidc"
+	"golang.org/x/oauth2"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+func TestSession_FetchUser(t *testing.T) {
+	type fields struct {
+		clientID      string
+		teamID        string
+		keyID         string
+		privateKey    []byte
+		redirectURI   string
+		scopes        []string
+		authURL       string
+		code          string
+		tokens        *openid.Tokens
+		userFormValue string
+	}
+	type want struct {
+		err               func(error) bool
+		id                string
+		firstName         string
+		lastName          string
+		displayName       string
+		nickName          string
+		preferredUsername string
+		email             string
+		isEmailVerified   bool
+		phone             string
+		isPhoneVerified   bool
+		preferredLanguage language.Tag
+		avatarURL         string
+		profile           string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "unauthenticated session, error",
+			fields: fields{
+				clientID:    "clientID",
+				teamID:      "teamID",
+				keyID:       "keyID",
+				privateKey:  []byte(privateKey),
+				redirectURI: "redirectURI",
+				scopes:      []string{"openid"},
+				authURL:     "https://appleid.apple.com/auth/authorize?client_id=clientID&redirect_uri=redirectURI&response_mode=form_post&response_type=code&scope=openid&state=testState",
+				tokens:      nil,
+			},
+			want: want{
+				err: func(err error) bool {
+					return err == oidc.ErrCodeMissing
+				},
+			},
+		},
+		{
+			name: "invalid user form value, error",
+			fields: fields{
+				clientID:      "clientID",
+				teamID:        "teamID",
+				keyID:         "keyID",
+				privateKey:    []byte(privateKey),
+				redirectURI:   "redirectURI",
+				scopes:        []string{"openid"},
+				authURL:       "https://appleid.apple.com/auth/authorize?client_id=clientID&redirect_uri=redirectURI&response_mode=form_post&response_type=code&scope=openid&state=testState",
+				tokens:        tokens(),
+				userFormValue: "invalid",
+			},
+			want: want{
+				err: func(err error) bool {
+					return err != nil
+				},
+			},
+		},
+		{
+			name: "successful fetch, no user form value",
+			fields: fields{
+				clientID:    "clientID",
+				teamID:      "teamID",
+				keyID:       "keyID",
+				privateKey:  []byte(privateKey),
+				redirectURI: "redirectURI",
+				scopes:      []string{"openid"},
+				authURL:     "https://appleid.apple.com/auth/authorize?client_id=clientID&redirect_uri=redirectURI&response_mode=form_post&response_type=code&scope=openid&state=testState",
+				tokens:      tokens(),
+			},
+			want: want{
+				id:                "sub",
+				preferredUsername: "email",
+				email:             "email",
+				isEmailVerified:   true,
+				preferredLanguage: language.Und,
+			},
+		},
+		{
+			name: "successful fetch with user form value",
+			fields: fields{
+				clientID:      "clientID",
+				teamID:        "teamID",
+				keyID:         "keyID",
+				privateKey:    []byte(privateKey),
+				redirectURI:   "redirectURI",
+				scopes:        []string{"openid", "name", "email"},
+				authURL:       "https://appleid.apple.com/auth/authorize?client_id=clientID&redirect_uri=redirectURI&response_mode=form_post&response_type=code&scope=openid+name+email&state=testState",
+				tokens:        tokens(),
+				userFormValue: `{"name": {"firstName": "firstname", "lastName": "lastname"}, "email": "email"}`,
+			},
+			want: want{
+				id:                "sub",
+				firstName:         "firstname",
+				lastName:          "lastname",
+				displayName:       "firstname lastname",
+				preferredUsername: "email",
+				email:             "email",
+				isEmailVerified:   true,
+				preferredLanguage: language.Und,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			gock.New(issuer).
+				Get(openid.DiscoveryEndpoint).
+				Reply(200).
+				JSON(&openid.DiscoveryConfiguration{
+					Issuer:                issuer,
+					AuthorizationEndpoint: issuer + "/auth/authorize",
+					TokenEndpoint:         issuer + "/auth/token",
+					JwksURI:               issuer + "/auth/keys",
+				})
+			a := assert.New(t)
+
+			provider, err := New(tt.fields.clientID, tt.fields.teamID, tt.fields.keyID, tt.fields.redirectURI, tt.fields.privateKey, tt.fields.scopes)
+			require.NoError(t, err)
+
+			session := &Session{
+				Session: &oidc.Session{
+					Provider: provider.Provider,
+					AuthURL:  tt.fields.authURL,
+					Code:     tt.fields.code,
+					Tokens:   tt.fields.tokens,
+				},
+				UserFormValue: tt.fields.userFormValue,
+			}
+
+			user, err := session.FetchUser(context.Background())
+			if tt.want.err != nil {
+				a.True(tt.want.err(err), "unexpected error: %v", err)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.want.id, user.GetID())
+			a.Equal(tt.want.firstName, user.GetFirstName())
+			a.Equal(tt.want.lastName, user.GetLastName())
+			a.Equal(tt.want.displayName, user.GetDisplayName())
+			a.Equal(tt.want.nickName, user.GetNickname())
+			a.Equal(tt.want.preferredUsername, user.GetPreferredUsername())
+			a.Equal(domain.EmailAddress(tt.want.email), user.GetEmail())
+			a.Equal(tt.want.isEmailVerified, user.IsEmailVerified())
+			a.Equal(domain.PhoneNumber(tt.want.phone), user.GetPhone())
+			a.Equal(tt.want.isPhoneVerified, user.IsPhoneVerified())
+			a.Equal(tt.want.preferredLanguage, user.GetPreferredLanguage())
+			a.Equal(tt.want.avatarURL, user.GetAvatarURL())
+			a.Equal(tt.want.profile, user.GetProfile())
+		})
+	}
+}
+
+func tokens() *openid.Tokens {
+	claims := openid.NewIDTokenClaims(
+		issuer,
+		"sub",
+		[]string{"clientID"},
+		time.Now().Add(1*time.Hour),
+		time.Now().Add(-1*time.Second),
+		"nonce",
+		"",
+		nil,
+		"clientID",
+		0,
+	)
+	info := openid.NewUserInfo()
+	info.SetSubject("sub")
+	info.SetEmail("email", true)
+	claims.SetUserinfo(info)
+	return &openid.Tokens{
+		Token: &oauth2.Token{
+			AccessToken: "accessToken",
+			TokenType:   openid.BearerToken,
+		},
+		IDTokenClaims: claims,
+	}
+}
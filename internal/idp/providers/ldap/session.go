@@ -21,13 +21,19 @@ var _ idp.Session = (*Session)(nil)
 type Session struct {
 	Provider *Provider
 	loginUrl string
-	user     string
-	password string
+	// User is the value of the unique user attribute the user entered on the login page
+	User string
+	// Password is the password the user entered on the login page
+	Password string
 }
 
 func (s *Session) GetAuthURL() string {
 	return s.loginUrl
 }
+
+// FetchUser implements the [idp.Session] interface.
+// It searches the user with the unique user attribute, verifies the password by binding as the found user
+// and maps the attributes of the directory entry to the [User].
 func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
 	l, err := ldap.DialURL("ldap://" + s.Provider.host + ":" + s.Provider.port)
 	if err != nil {
@@ -52,8 +58,8 @@ func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
 	searchRequest := ldap.NewSearchRequest(
 		s.Provider.baseDN,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
-		fmt.Sprintf("(&(objectClass="+s.Provider.userObjectClass+")("+s.Provider.userUniqueAttribute+"=%s))", ldap.EscapeFilter(s.user)),
-		[]string{"dn"},
+		fmt.Sprintf("(&(objectClass="+s.Provider.userObjectClass+")("+s.Provider.userUniqueAttribute+"=%s))", ldap.EscapeFilter(s.User)),
+		s.Provider.attributes(),
 		nil,
 	)
 
@@ -67,33 +73,70 @@ func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
 
 	user := sr.Entries[0]
 	// Bind as the user to verify their password
-	err = l.Bind(user.DN, s.password)
+	err = l.Bind(user.DN, s.Password)
 	if err != nil {
 		return nil, err
 	}
+	return mapLDAPEntryToUser(user, s.Provider)
+}
+
+// attributes returns the list of all mapped attributes, which need to be returned by the search
+func (p *Provider) attributes() []string {
+	attributes := []string{"dn"}
+	for _, attribute := range []string{
+		p.idAttribute,
+		p.firstNameAttribute,
+		p.lastNameAttribute,
+		p.displayNameAttribute,
+		p.nickNameAttribute,
+		p.preferredUsernameAttribute,
+		p.emailAttribute,
+		p.emailVerifiedAttribute,
+		p.phoneAttribute,
+		p.phoneVerifiedAttribute,
+		p.preferredLanguageAttribute,
+		p.avatarURLAttribute,
+		p.profileAttribute,
+	} {
+		if attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
 
-	emailVerified, err := strconv.ParseBool(user.GetAttributeValue(s.Provider.emailVerifiedAttribute))
+func mapLDAPEntryToUser(entry *ldap.Entry, provider *Provider) (*User, error) {
+	emailVerified, err := parseBoolAttribute(entry, provider.emailVerifiedAttribute)
 	if err != nil {
 		return nil, err
 	}
-	phoneVerified, err := strconv.ParseBool(user.GetAttributeValue(s.Provider.phoneVerifiedAttribute))
+	phoneVerified, err := parseBoolAttribute(entry, provider.phoneVerifiedAttribute)
 	if err != nil {
 		return nil, err
 	}
-
 	return NewUser(
-		user.GetAttributeValue(s.Provider.idAttribute),
-		user.GetAttributeValue(s.Provider.firstNameAttribute),
-		user.GetAttributeValue(s.Provider.lastNameAttribute),
-		user.GetAttributeValue(s.Provider.displayNameAttribute),
-		user.GetAttributeValue(s.Provider.nickNameAttribute),
-		user.GetAttributeValue(s.Provider.preferredUsernameAttribute),
-		domain.EmailAddress(user.GetAttributeValue(s.Provider.emailAttribute)),
+		entry.GetAttributeValue(provider.idAttribute),
+		entry.GetAttributeValue(provider.firstNameAttribute),
+		entry.GetAttributeValue(provider.lastNameAttribute),
+		entry.GetAttributeValue(provider.displayNameAttribute),
+		entry.GetAttributeValue(provider.nickNameAttribute),
+		entry.GetAttributeValue(provider.preferredUsernameAttribute),
+		domain.EmailAddress(entry.GetAttributeValue(provider.emailAttribute)),
 		emailVerified,
-		domain.PhoneNumber(user.GetAttributeValue(s.Provider.phoneAttribute)),
+		domain.PhoneNumber(entry.GetAttributeValue(provider.phoneAttribute)),
 		phoneVerified,
-		language.Make(user.GetAttributeValue(s.Provider.preferredLanguageAttribute)),
-		user.GetAttributeValue(s.Provider.avatarURLAttribute),
-		user.GetAttributeValue(s.Provider.profileAttribute),
+		language.Make(entry.GetAttributeValue(provider.preferredLanguageAttribute)),
+		entry.GetAttributeValue(provider.avatarURLAttribute),
+		entry.GetAttributeValue(provider.profileAttribute),
 	), nil
 }
+
+// parseBoolAttribute parses the value of the attribute as bool,
+// unmapped or empty attributes are treated as false
+func parseBoolAttribute(entry *ldap.Entry, attribute string) (bool, error) {
+	value := entry.GetAttributeValue(attribute)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
@@ -0,0 +1,150 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func TestProvider_attributes(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ProviderOpts
+		want []string
+	}{
+		{
+			name: "default",
+			want: []string{"dn", "uid"},
+		},
+		{
+			name: "mapped attributes",
+			opts: []ProviderOpts{
+				WithCustomIDAttribute("id"),
+				WithFirstNameAttribute("givenName"),
+				WithLastNameAttribute("sn"),
+				WithEmailAttribute("mail"),
+				WithEmailVerifiedAttribute("mailVerified"),
+			},
+			want: []string{"dn", "id", "givenName", "sn", "mail", "mailVerified"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := New("ldap", "host", "base", "class", "uid", "admin", "password", "url", tt.opts...)
+			assert.Equal(t, tt.want, provider.attributes())
+		})
+	}
+}
+
+func TestSession_mapLDAPEntryToUser(t *testing.T) {
+	type args struct {
+		opts       []ProviderOpts
+		attributes map[string][]string
+	}
+	type want struct {
+		user *User
+		err  func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "invalid bool attribute, error",
+			args: args{
+				opts: []ProviderOpts{
+					WithEmailVerifiedAttribute("mailVerified"),
+				},
+				attributes: map[string][]string{
+					"uid":          {"id"},
+					"mailVerified": {"invalid"},
+				},
+			},
+			want: want{
+				err: func(err error) bool {
+					return err != nil
+				},
+			},
+		},
+		{
+			name: "unmapped attributes, ok",
+			args: args{
+				attributes: map[string][]string{
+					"uid":  {"id"},
+					"mail": {"email"},
+				},
+			},
+			want: want{
+				user: NewUser("id", "", "", "", "", "", "", false, "", false, language.Und, "", ""),
+			},
+		},
+		{
+			name: "all attributes, ok",
+			args: args{
+				opts: []ProviderOpts{
+					WithFirstNameAttribute("givenName"),
+					WithLastNameAttribute("sn"),
+					WithDisplayNameAttribute("displayName"),
+					WithNickNameAttribute("nickName"),
+					WithPreferredUsernameAttribute("username"),
+					WithEmailAttribute("mail"),
+					WithEmailVerifiedAttribute("mailVerified"),
+					WithPhoneAttribute("phone"),
+					WithPhoneVerifiedAttribute("phoneVerified"),
+					WithPreferredLanguageAttribute("lang"),
+					WithAvatarURLAttribute("avatar"),
+					WithProfileAttribute("profile"),
+				},
+				attributes: map[string][]string{
+					"uid":           {"id"},
+					"givenName":     {"first"},
+					"sn":            {"last"},
+					"displayName":   {"display"},
+					"nickName":      {"nick"},
+					"username":      {"username"},
+					"mail":          {"email"},
+					"mailVerified":  {"true"},
+					"phone":         {"phone"},
+					"phoneVerified": {"FALSE"},
+					"lang":          {"de"},
+					"avatar":        {"avatar"},
+					"profile":       {"profile"},
+				},
+			},
+			want: want{
+				user: NewUser(
+					"id",
+					"first",
+					"last",
+					"display",
+					"nick",
+					"username",
+					domain.EmailAddress("email"),
+					true,
+					domain.PhoneNumber("phone"),
+					false,
+					language.German,
+					"avatar",
+					"profile",
+				),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := New("ldap", "host", "base", "class", "uid", "admin", "password", "url", tt.args.opts...)
+			user, err := mapLDAPEntryToUser(ldap.NewEntry("cn=user,dc=example,dc=com", tt.args.attributes), provider)
+			if tt.want.err != nil {
+				assert.True(t, tt.want.err(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.user, user)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/oidc/v2/pkg/client/rp"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"golang.org/x/oauth2"
 
 	"github.com/zitadel/zitadel/internal/idp"
 )
@@ -77,6 +78,15 @@ func WithSelectAccount() ProviderOpts {
 	}
 }
 
+// WithResponseMode sets the `response_mode` param in the auth request
+func WithResponseMode(mode oidc.ResponseMode) ProviderOpts {
+	return func(p *Provider) {
+		p.authOptions = append(p.authOptions, func() []oauth2.AuthCodeOption {
+			return []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("response_mode", string(mode))}
+		})
+	}
+}
+
 type UserInfoMapper func(info oidc.UserInfo) idp.User
 
 var DefaultMapper UserInfoMapper = func(info oidc.UserInfo) idp.User {
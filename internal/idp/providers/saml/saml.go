@@ -0,0 +1,414 @@
+package saml
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+	"github.com/zitadel/saml/pkg/provider/xml/xml_dsig"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const (
+	BindingRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	NameIDFormatPersistent  = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	NameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	protocolNamespace = "urn:oasis:names:tc:SAML:2.0:protocol"
+	samlVersion       = "2.0"
+)
+
+var (
+	ErrNoSSOService       = errors.New("no single sign-on service for the binding found in the metadata")
+	ErrNoSigningKey       = errors.New("signed requests need a certificate and key")
+	ErrUnsupportedBinding = errors.New("unsupported binding")
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for a generic SAML 2.0 identity provider,
+// where ZITADEL acts as service provider
+type Provider struct {
+	name              string
+	entityID          string
+	acsURL            string
+	metadata          *md.EntityDescriptorType
+	certificate       []byte
+	key               *rsa.PrivateKey
+	binding           string
+	withSignedRequest bool
+	nameIDFormat      string
+	clockSkew         time.Duration
+
+	isLinkingAllowed  bool
+	isCreationAllowed bool
+	isAutoCreation    bool
+	isAutoUpdate      bool
+
+	idAttribute string
+}
+
+type ProviderOpts func(provider *Provider)
+
+// WithLinkingAllowed allows end users to link the federated user to an existing one.
+func WithLinkingAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isLinkingAllowed = true
+	}
+}
+
+// WithCreationAllowed allows end users to create a new user using the federated information.
+func WithCreationAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isCreationAllowed = true
+	}
+}
+
+// WithAutoCreation enables that federated users are automatically created if not already existing.
+func WithAutoCreation() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoCreation = true
+	}
+}
+
+// WithAutoUpdate enables that information retrieved from the provider is automatically used to update
+// the existing user on each authentication.
+func WithAutoUpdate() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoUpdate = true
+	}
+}
+
+// WithBinding configures the binding used to send the AuthnRequest to the identity provider,
+// default is the HTTP-Redirect binding
+func WithBinding(binding string) ProviderOpts {
+	return func(p *Provider) {
+		p.binding = binding
+	}
+}
+
+// WithSignedRequest enables that the AuthnRequest is signed with the key of the service provider
+func WithSignedRequest() ProviderOpts {
+	return func(p *Provider) {
+		p.withSignedRequest = true
+	}
+}
+
+// WithNameIDFormat configures the NameIDPolicy requested in the AuthnRequest,
+// default is persistent
+func WithNameIDFormat(format string) ProviderOpts {
+	return func(p *Provider) {
+		p.nameIDFormat = format
+	}
+}
+
+// WithCustomIDAttribute configures to map the SAML attribute to the user id, default is the NameID of the subject
+func WithCustomIDAttribute(name string) ProviderOpts {
+	return func(p *Provider) {
+		p.idAttribute = name
+	}
+}
+
+// WithClockSkew configures the tolerated time difference when validating the conditions of an assertion,
+// default is 1 minute
+func WithClockSkew(skew time.Duration) ProviderOpts {
+	return func(p *Provider) {
+		p.clockSkew = skew
+	}
+}
+
+// New creates a generic SAML provider.
+// The metadata is the XML metadata of the identity provider,
+// the certificate and key (PEM encoded) are used to sign requests and are published in the metadata of the service provider.
+func New(
+	name,
+	entityID,
+	acsURL string,
+	metadata,
+	certificate,
+	key []byte,
+	options ...ProviderOpts,
+) (*Provider, error) {
+	entityDescriptor, err := saml_xml.ParseMetadataXmlIntoStruct(metadata)
+	if err != nil {
+		return nil, err
+	}
+	provider := &Provider{
+		name:         name,
+		entityID:     entityID,
+		acsURL:       acsURL,
+		metadata:     entityDescriptor,
+		certificate:  certificate,
+		binding:      BindingRedirect,
+		nameIDFormat: NameIDFormatPersistent,
+		clockSkew:    time.Minute,
+	}
+	for _, option := range options {
+		option(provider)
+	}
+	if len(key) > 0 {
+		block, _ := pem.Decode(key)
+		if block == nil {
+			return nil, ErrNoSigningKey
+		}
+		provider.key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if provider.binding != BindingRedirect && provider.binding != BindingPost {
+		return nil, ErrUnsupportedBinding
+	}
+	if provider.withSignedRequest && (provider.key == nil || len(provider.certificate) == 0) {
+		return nil, ErrNoSigningKey
+	}
+	if _, err = provider.ssoLocation(); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// Name implements the [idp.Provider] interface
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// RequestID can be passed as param to [Provider.BeginAuth] to set the ID of the AuthnRequest.
+// The identity provider will return it as InResponseTo, which is verified by the [Session].
+type RequestID string
+
+// BeginAuth implements the [idp.Provider] interface.
+// It will create a [Session] with an AuthnRequest, either encoded in the AuthURL (HTTP-Redirect binding)
+// or as form values to be posted to the AuthURL (HTTP-POST binding).
+func (p *Provider) BeginAuth(ctx context.Context, state string, params ...any) (idp.Session, error) {
+	requestID := "id-" + state
+	for _, param := range params {
+		if id, ok := param.(RequestID); ok {
+			requestID = string(id)
+		}
+	}
+	location, err := p.ssoLocation()
+	if err != nil {
+		return nil, err
+	}
+	request := p.authnRequest(requestID, location)
+	session := &Session{
+		Provider:       p,
+		RequestID:      requestID,
+		RequestBinding: p.binding,
+	}
+	if p.binding == BindingPost {
+		session.AuthURL = location
+		session.RequestForm, err = p.postForm(request, state)
+		return session, err
+	}
+	session.AuthURL, err = p.redirectURL(request, location, state)
+	return session, err
+}
+
+// IsLinkingAllowed implements the [idp.Provider] interface.
+func (p *Provider) IsLinkingAllowed() bool {
+	return p.isLinkingAllowed
+}
+
+// IsCreationAllowed implements the [idp.Provider] interface.
+func (p *Provider) IsCreationAllowed() bool {
+	return p.isCreationAllowed
+}
+
+// IsAutoCreation implements the [idp.Provider] interface.
+func (p *Provider) IsAutoCreation() bool {
+	return p.isAutoCreation
+}
+
+// IsAutoUpdate implements the [idp.Provider] interface.
+func (p *Provider) IsAutoUpdate() bool {
+	return p.isAutoUpdate
+}
+
+// Metadata returns the XML metadata of ZITADEL as service provider,
+// which has to be registered on the identity provider.
+func (p *Provider) Metadata() ([]byte, error) {
+	descriptor := &md.SPSSODescriptorType{
+		AuthnRequestsSigned:        boolString(p.withSignedRequest),
+		WantAssertionsSigned:       "true",
+		ProtocolSupportEnumeration: protocolNamespace,
+		NameIDFormat:               []string{p.nameIDFormat},
+		AssertionConsumerService: []md.IndexedEndpointType{{
+			Index:     "0",
+			IsDefault: "true",
+			Binding:   BindingPost,
+			Location:  p.acsURL,
+		}},
+	}
+	if len(p.certificate) > 0 {
+		cert, err := certificateData(p.certificate)
+		if err != nil {
+			return nil, err
+		}
+		descriptor.KeyDescriptor = []md.KeyDescriptorType{{
+			Use: md.KeyTypesSigning,
+			KeyInfo: xml_dsig.KeyInfoType{
+				X509Data: []xml_dsig.X509DataType{{X509Certificate: cert}},
+			},
+		}}
+	}
+	return xml.MarshalIndent(&md.EntityDescriptorType{
+		EntityID:        md.EntityIDType(p.entityID),
+		SPSSODescriptor: descriptor,
+	}, "", "  ")
+}
+
+func (p *Provider) ssoLocation() (string, error) {
+	if p.metadata.IDPSSODescriptor == nil {
+		return "", ErrNoSSOService
+	}
+	for _, service := range p.metadata.IDPSSODescriptor.SingleSignOnService {
+		if service.Binding == p.binding {
+			return service.Location, nil
+		}
+	}
+	return "", ErrNoSSOService
+}
+
+func (p *Provider) authnRequest(id, destination string) *samlp.AuthnRequestType {
+	return &samlp.AuthnRequestType{
+		Id:                          id,
+		Version:                     samlVersion,
+		IssueInstant:                time.Now().UTC().Format(time.RFC3339),
+		Destination:                 destination,
+		ProtocolBinding:             BindingPost,
+		AssertionConsumerServiceURL: p.acsURL,
+		Issuer: &saml.NameIDType{
+			XMLName: xml.Name{Space: "urn:oasis:names:tc:SAML:2.0:assertion", Local: "Issuer"},
+			Text:    p.entityID,
+		},
+		NameIDPolicy: &samlp.NameIDPolicyType{
+			Format:      p.nameIDFormat,
+			AllowCreate: true,
+		},
+	}
+}
+
+func (p *Provider) redirectURL(request *samlp.AuthnRequestType, location, relayState string) (string, error) {
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := saml_xml.DeflateAndBase64(data)
+	if err != nil {
+		return "", err
+	}
+	// the signature is created over the query in the exact order defined by the specification
+	query := "SAMLRequest=" + url.QueryEscape(string(encoded))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	if p.withSignedRequest {
+		signingContext, err := p.signingContext()
+		if err != nil {
+			return "", err
+		}
+		query += "&SigAlg=" + url.QueryEscape(signingContext.GetSignatureMethodIdentifier())
+		sig, err := signature.CreateRedirect(signingContext, query)
+		if err != nil {
+			return "", err
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	}
+	authURL, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	if authURL.RawQuery != "" {
+		query = authURL.RawQuery + "&" + query
+	}
+	authURL.RawQuery = query
+	return authURL.String(), nil
+}
+
+func (p *Provider) postForm(request *samlp.AuthnRequestType, relayState string) (url.Values, error) {
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	if p.withSignedRequest {
+		data, err = p.signEnveloped(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	values := url.Values{}
+	values.Set("SAMLRequest", base64.StdEncoding.EncodeToString(data))
+	if relayState != "" {
+		values.Set("RelayState", relayState)
+	}
+	return values, nil
+}
+
+func (p *Provider) signEnveloped(data []byte) ([]byte, error) {
+	signingContext, err := p.signingContext()
+	if err != nil {
+		return nil, err
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	signed, err := signingContext.SignEnveloped(doc.Root())
+	if err != nil {
+		return nil, err
+	}
+	doc.SetRoot(signed)
+	return doc.WriteToBytes()
+}
+
+func (p *Provider) signingContext() (*dsig.SigningContext, error) {
+	block, _ := pem.Decode(p.certificate)
+	if block == nil {
+		return nil, ErrNoSigningKey
+	}
+	return signature.GetSigningContext(
+		tls.Certificate{Certificate: [][]byte{block.Bytes}, PrivateKey: p.key},
+		dsig.RSASHA256SignatureMethod,
+	)
+}
+
+func (p *Provider) idpCertificates() ([]*x509.Certificate, error) {
+	if p.metadata.IDPSSODescriptor == nil {
+		return nil, ErrNoSSOService
+	}
+	return signature.ParseCertificates(saml_xml.GetCertsFromKeyDescriptors(p.metadata.IDPSSODescriptor.KeyDescriptor))
+}
+
+func certificateData(certificate []byte) (string, error) {
+	block, _ := pem.Decode(certificate)
+	if block == nil {
+		return "", ErrNoSigningKey
+	}
+	return base64.StdEncoding.EncodeToString(block.Bytes), nil
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
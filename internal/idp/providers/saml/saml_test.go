@@ -0,0 +1,305 @@
+package saml
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+const (
+	testEntityID    = "https://zitadel.cloud/ui/login/externalidp/saml/metadata"
+	testACS         = "https://zitadel.cloud/ui/login/externalidp/saml/acs"
+	testIDPEntityID = "https://idp.example.com/metadata"
+	testIDPSSO      = "https://idp.example.com/sso"
+)
+
+func testCertificate(t *testing.T) (key, certificate []byte) {
+	t.Helper()
+	privateKey, _, cert, err := crypto.GenerateCACertificate(2048, &crypto.CertificateInformations{
+		SerialNumber: big.NewInt(1),
+		CommonName:   "test",
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	})
+	require.NoError(t, err)
+	return crypto.PrivateKeyToBytes(privateKey), cert
+}
+
+func testMetadata(t *testing.T, certificate []byte) []byte {
+	t.Helper()
+	cert, err := certificateData(certificate)
+	require.NoError(t, err)
+	return []byte(fmt.Sprintf(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="%s">
+  <IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <KeyDescriptor use="signing">
+      <KeyInfo xmlns="http://www.w3.org/2000/09/xmldsig#">
+        <X509Data>
+          <X509Certificate>%s</X509Certificate>
+        </X509Data>
+      </KeyInfo>
+    </KeyDescriptor>
+    <SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="%[3]s"/>
+    <SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="%[3]s"/>
+  </IDPSSODescriptor>
+</EntityDescriptor>`, testIDPEntityID, cert, testIDPSSO))
+}
+
+func TestProvider_New(t *testing.T) {
+	key, certificate := testCertificate(t)
+	type args struct {
+		metadata    []byte
+		certificate []byte
+		key         []byte
+		opts        []ProviderOpts
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+		err     error
+	}{
+		{
+			name: "invalid metadata",
+			args: args{
+				metadata: []byte("invalid"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "no sso service",
+			args: args{
+				metadata: []byte(`<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="idp"></EntityDescriptor>`),
+			},
+			err: ErrNoSSOService,
+		},
+		{
+			name: "unsupported binding",
+			args: args{
+				metadata: testMetadata(t, certificate),
+				opts:     []ProviderOpts{WithBinding("urn:oasis:names:tc:SAML:2.0:bindings:SOAP")},
+			},
+			err: ErrUnsupportedBinding,
+		},
+		{
+			name: "signed request without key",
+			args: args{
+				metadata: testMetadata(t, certificate),
+				opts:     []ProviderOpts{WithSignedRequest()},
+			},
+			err: ErrNoSigningKey,
+		},
+		{
+			name: "ok",
+			args: args{
+				metadata:    testMetadata(t, certificate),
+				certificate: certificate,
+				key:         key,
+				opts:        []ProviderOpts{WithSignedRequest()},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("saml", testEntityID, testACS, tt.args.metadata, tt.args.certificate, tt.args.key, tt.args.opts...)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestProvider_BeginAuth(t *testing.T) {
+	key, certificate := testCertificate(t)
+	type fields struct {
+		opts []ProviderOpts
+	}
+	type want struct {
+		binding     string
+		query       []string
+		formValues  []string
+		requestID   string
+		authURLBase string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		params []any
+		want   want
+	}{
+		{
+			name: "redirect binding",
+			want: want{
+				binding:     BindingRedirect,
+				query:       []string{"SAMLRequest", "RelayState"},
+				requestID:   "id-testState",
+				authURLBase: testIDPSSO,
+			},
+		},
+		{
+			name: "redirect binding, signed with custom request id",
+			fields: fields{
+				opts: []ProviderOpts{WithSignedRequest()},
+			},
+			params: []any{RequestID("id-custom")},
+			want: want{
+				binding:     BindingRedirect,
+				query:       []string{"SAMLRequest", "RelayState", "SigAlg", "Signature"},
+				requestID:   "id-custom",
+				authURLBase: testIDPSSO,
+			},
+		},
+		{
+			name: "post binding, signed",
+			fields: fields{
+				opts: []ProviderOpts{WithBinding(BindingPost), WithSignedRequest()},
+			},
+			want: want{
+				binding:     BindingPost,
+				formValues:  []string{"SAMLRequest", "RelayState"},
+				requestID:   "id-testState",
+				authURLBase: testIDPSSO,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			r := require.New(t)
+
+			provider, err := New("saml", testEntityID, testACS, testMetadata(t, certificate), certificate, key, tt.fields.opts...)
+			r.NoError(err)
+
+			session, err := provider.BeginAuth(context.Background(), "testState", tt.params...)
+			r.NoError(err)
+
+			samlSession, ok := session.(*Session)
+			r.True(ok)
+			a.Equal(tt.want.binding, samlSession.RequestBinding)
+			a.Equal(tt.want.requestID, samlSession.RequestID)
+
+			authURL, err := url.Parse(session.GetAuthURL())
+			r.NoError(err)
+			a.Equal(tt.want.authURLBase, authURL.Scheme+"://"+authURL.Host+authURL.Path)
+			for _, param := range tt.want.query {
+				a.NotEmpty(authURL.Query().Get(param), param)
+			}
+			for _, param := range tt.want.formValues {
+				a.NotEmpty(samlSession.RequestForm.Get(param), param)
+			}
+			if tt.want.binding == BindingPost {
+				request, err := base64.StdEncoding.DecodeString(samlSession.RequestForm.Get("SAMLRequest"))
+				r.NoError(err)
+				a.Contains(string(request), tt.want.requestID)
+				a.Contains(string(request), "SignatureValue")
+			}
+		})
+	}
+}
+
+func TestProvider_Options(t *testing.T) {
+	_, certificate := testCertificate(t)
+	type fields struct {
+		name string
+		opts []ProviderOpts
+	}
+	type want struct {
+		name         string
+		linking      bool
+		creation     bool
+		autoCreation bool
+		autoUpdate   bool
+		binding      string
+		nameIDFormat string
+		idAttribute  string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "default",
+			fields: fields{
+				name: "saml",
+			},
+			want: want{
+				name:         "saml",
+				binding:      BindingRedirect,
+				nameIDFormat: NameIDFormatPersistent,
+			},
+		},
+		{
+			name: "all true",
+			fields: fields{
+				name: "saml",
+				opts: []ProviderOpts{
+					WithLinkingAllowed(),
+					WithCreationAllowed(),
+					WithAutoCreation(),
+					WithAutoUpdate(),
+					WithBinding(BindingPost),
+					WithNameIDFormat(NameIDFormatUnspecified),
+					WithCustomIDAttribute("uid"),
+				},
+			},
+			want: want{
+				name:         "saml",
+				linking:      true,
+				creation:     true,
+				autoCreation: true,
+				autoUpdate:   true,
+				binding:      BindingPost,
+				nameIDFormat: NameIDFormatUnspecified,
+				idAttribute:  "uid",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			provider, err := New(tt.fields.name, testEntityID, testACS, testMetadata(t, certificate), nil, nil, tt.fields.opts...)
+			require.NoError(t, err)
+
+			a.Equal(tt.want.name, provider.Name())
+			a.Equal(tt.want.linking, provider.IsLinkingAllowed())
+			a.Equal(tt.want.creation, provider.IsCreationAllowed())
+			a.Equal(tt.want.autoCreation, provider.IsAutoCreation())
+			a.Equal(tt.want.autoUpdate, provider.IsAutoUpdate())
+			a.Equal(tt.want.binding, provider.binding)
+			a.Equal(tt.want.nameIDFormat, provider.nameIDFormat)
+			a.Equal(tt.want.idAttribute, provider.idAttribute)
+		})
+	}
+}
+
+func TestProvider_Metadata(t *testing.T) {
+	_, certificate := testCertificate(t)
+	provider, err := New("saml", testEntityID, testACS, testMetadata(t, certificate), certificate, nil)
+	require.NoError(t, err)
+
+	metadata, err := provider.Metadata()
+	require.NoError(t, err)
+
+	cert, err := certificateData(certificate)
+	require.NoError(t, err)
+	assert.Contains(t, string(metadata), `entityID="`+testEntityID+`"`)
+	assert.Contains(t, string(metadata), `Location="`+testACS+`"`)
+	assert.Contains(t, string(metadata), cert)
+}
@@ -0,0 +1,339 @@
+package saml
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+const (
+	statusSuccess      = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	confirmationBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+)
+
+var (
+	ErrResponseMissing     = errors.New("no SAML response provided")
+	ErrEncryptedAssertion  = errors.New("encrypted assertions are not supported")
+	ErrMultipleAssertions  = errors.New("SAML response must contain exactly one assertion")
+	ErrMissingSignature    = errors.New("neither response nor assertion is signed")
+	ErrStatusNotSuccess    = errors.New("SAML response status is not success")
+	ErrInvalidInResponseTo = errors.New("SAML response does not belong to the request")
+	ErrInvalidIssuer       = errors.New("SAML response is not issued by the identity provider")
+	ErrInvalidDestination  = errors.New("SAML response is not addressed to this service provider")
+	ErrInvalidAudience     = errors.New("SAML assertion is not intended for this service provider")
+	ErrAssertionExpired    = errors.New("SAML assertion is expired or not yet valid")
+	ErrMissingSubject      = errors.New("SAML assertion contains no valid subject")
+	ErrMissingAssertionID  = errors.New("SAML assertion has no ID")
+	ErrMissingExpiration   = errors.New("SAML assertion has no expiration")
+	ErrAssertionReplayed   = errors.New("SAML assertion was already used")
+)
+
+// UsedAssertions stores the ids of the used assertions until they expire,
+// so an assertion can't be replayed.
+type UsedAssertions interface {
+	// Use marks the assertion as used until the expiration and returns false if it was already used.
+	Use(ctx context.Context, id string, expiration time.Time) (bool, error)
+}
+
+var _ idp.Session = (*Session)(nil)
+
+// Session is the [idp.Session] implementation for the SAML provider.
+type Session struct {
+	Provider *Provider
+	AuthURL  string
+
+	// RequestBinding is the binding used to send the AuthnRequest.
+	// In case of the HTTP-POST binding, the RequestForm has to be posted to the AuthURL.
+	RequestBinding string
+	RequestForm    url.Values
+	RequestID      string
+
+	// Response is the base64 encoded SAMLResponse received on the assertion consumer service
+	Response  string
+	Assertion *saml.AssertionType
+
+	// UsedAssertions rejects assertions which were already used, if set
+	UsedAssertions UsedAssertions
+}
+
+// GetAuthURL implements the [idp.Session] interface.
+func (s *Session) GetAuthURL() string {
+	return s.AuthURL
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It will validate the signature and conditions of the received SAMLResponse
+// and map the attributes of the assertion into an [idp.User].
+// If UsedAssertions is set, the assertion is marked as used, so the same response can't be used again.
+func (s *Session) FetchUser(ctx context.Context) (idp.User, error) {
+	if s.Assertion == nil {
+		if err := s.validateResponse(); err != nil {
+			return nil, err
+		}
+		if err := s.useAssertion(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return s.Provider.mapUser(s.Assertion)
+}
+
+// ResponseRequestID returns the (unverified) InResponseTo of a base64 encoded SAMLResponse,
+// which allows to restore the state of the request before the response is validated by the [Session].
+func ResponseRequestID(response string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(response)
+	if err != nil {
+		return "", err
+	}
+	samlResponse := new(samlp.ResponseType)
+	if err = xml.Unmarshal(data, samlResponse); err != nil {
+		return "", err
+	}
+	return samlResponse.InResponseTo, nil
+}
+
+func (s *Session) validateResponse() error {
+	if s.Response == "" {
+		return ErrResponseMissing
+	}
+	data, err := base64.StdEncoding.DecodeString(s.Response)
+	if err != nil {
+		return err
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(data); err != nil {
+		return err
+	}
+	root := doc.Root()
+	if root == nil {
+		return ErrResponseMissing
+	}
+	if root.FindElement("./EncryptedAssertion") != nil {
+		return ErrEncryptedAssertion
+	}
+	if len(root.FindElements("./Assertion")) > 1 {
+		return ErrMultipleAssertions
+	}
+	certificates, err := s.Provider.idpCertificates()
+	if err != nil {
+		return err
+	}
+	validationContext := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certificates})
+	validationContext.IdAttribute = "ID"
+
+	// only the validated (signed) elements must be used, the rest of the document could have been manipulated
+	response := new(samlp.ResponseType)
+	var assertion *saml.AssertionType
+	if root.FindElement("./Signature") != nil {
+		validated, err := validateElement(validationContext, root)
+		if err != nil {
+			return err
+		}
+		if err = unmarshalElement(validated, response); err != nil {
+			return err
+		}
+		if validated.FindElement("./Assertion") == nil {
+			return ErrMissingSubject
+		}
+		assertion = &response.Assertion
+	}
+	if assertionElement := root.FindElement("./Assertion"); assertionElement != nil && assertionElement.FindElement("./Signature") != nil {
+		validated, err := validateElement(validationContext, assertionElement)
+		if err != nil {
+			return err
+		}
+		assertion = new(saml.AssertionType)
+		if err = unmarshalElement(validated, assertion); err != nil {
+			return err
+		}
+	}
+	if assertion == nil {
+		return ErrMissingSignature
+	}
+	if root.FindElement("./Signature") == nil {
+		// the unsigned response is only used for the status and header information
+		if err = xml.Unmarshal(data, response); err != nil {
+			return err
+		}
+	}
+	if err = s.validateResponseHeader(response); err != nil {
+		return err
+	}
+	if err = s.validateAssertion(assertion, time.Now()); err != nil {
+		return err
+	}
+	s.Assertion = assertion
+	return nil
+}
+
+// useAssertion marks the validated assertion as used until it expires
+func (s *Session) useAssertion(ctx context.Context) error {
+	if s.UsedAssertions == nil {
+		return nil
+	}
+	expiration, ok := assertionExpiration(s.Assertion)
+	if !ok {
+		return ErrMissingExpiration
+	}
+	unused, err := s.UsedAssertions.Use(ctx, s.Assertion.Id, expiration.Add(s.Provider.clockSkew))
+	if err != nil {
+		return err
+	}
+	if !unused {
+		return ErrAssertionReplayed
+	}
+	return nil
+}
+
+func (s *Session) validateResponseHeader(response *samlp.ResponseType) error {
+	if response.Status.StatusCode.Value != statusSuccess {
+		return ErrStatusNotSuccess
+	}
+	if response.InResponseTo != s.RequestID {
+		return ErrInvalidInResponseTo
+	}
+	if response.Destination != "" && response.Destination != s.Provider.acsURL {
+		return ErrInvalidDestination
+	}
+	if response.Issuer != nil && response.Issuer.Text != "" && response.Issuer.Text != string(s.Provider.metadata.EntityID) {
+		return ErrInvalidIssuer
+	}
+	return nil
+}
+
+func (s *Session) validateAssertion(assertion *saml.AssertionType, now time.Time) error {
+	if strings.TrimSpace(assertion.Issuer.Text) != string(s.Provider.metadata.EntityID) {
+		return ErrInvalidIssuer
+	}
+	if assertion.Id == "" {
+		return ErrMissingAssertionID
+	}
+	if assertion.Subject == nil || assertion.Subject.NameID == nil {
+		return ErrMissingSubject
+	}
+	if err := s.validateSubjectConfirmation(assertion.Subject, now); err != nil {
+		return err
+	}
+	if assertion.Conditions == nil {
+		return nil
+	}
+	if !inTimeRange(assertion.Conditions.NotBefore, assertion.Conditions.NotOnOrAfter, now, s.Provider.clockSkew) {
+		return ErrAssertionExpired
+	}
+	for _, restriction := range assertion.Conditions.AudienceRestriction {
+		if !containsAudience(restriction.Audience, s.Provider.entityID) {
+			return ErrInvalidAudience
+		}
+	}
+	return nil
+}
+
+func (s *Session) validateSubjectConfirmation(subject *saml.SubjectType, now time.Time) error {
+	for _, confirmation := range subject.SubjectConfirmation {
+		if confirmation.Method != confirmationBearer {
+			continue
+		}
+		data := confirmation.SubjectConfirmationData
+		if data == nil {
+			return nil
+		}
+		if data.InResponseTo != "" && data.InResponseTo != s.RequestID {
+			return ErrInvalidInResponseTo
+		}
+		if data.Recipient != "" && data.Recipient != s.Provider.acsURL {
+			return ErrInvalidDestination
+		}
+		if !inTimeRange(data.NotBefore, data.NotOnOrAfter, now, s.Provider.clockSkew) {
+			return ErrAssertionExpired
+		}
+		return nil
+	}
+	return ErrMissingSubject
+}
+
+func validateElement(validationContext *dsig.ValidationContext, el *etree.Element) (*etree.Element, error) {
+	ctx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = ctx.SubContext(el)
+	if err != nil {
+		return nil, err
+	}
+	detached, err := etreeutils.NSDetatch(ctx, el)
+	if err != nil {
+		return nil, err
+	}
+	return validationContext.Validate(detached)
+}
+
+func unmarshalElement(el *etree.Element, v any) error {
+	doc := etree.NewDocument()
+	doc.SetRoot(el.Copy())
+	data, err := doc.WriteToBytes()
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(data, v)
+}
+
+func inTimeRange(notBefore, notOnOrAfter string, now time.Time, skew time.Duration) bool {
+	if notBefore != "" {
+		t, err := time.Parse(time.RFC3339, notBefore)
+		if err != nil || now.Add(skew).Before(t) {
+			return false
+		}
+	}
+	if notOnOrAfter != "" {
+		t, err := time.Parse(time.RFC3339, notOnOrAfter)
+		if err != nil || !now.Add(-skew).Before(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// assertionExpiration returns the earliest NotOnOrAfter of the conditions and bearer subject confirmations
+func assertionExpiration(assertion *saml.AssertionType) (expiration time.Time, ok bool) {
+	notOnOrAfters := make([]string, 0, 2)
+	if assertion.Conditions != nil {
+		notOnOrAfters = append(notOnOrAfters, assertion.Conditions.NotOnOrAfter)
+	}
+	if assertion.Subject != nil {
+		for _, confirmation := range assertion.Subject.SubjectConfirmation {
+			if confirmation.Method == confirmationBearer && confirmation.SubjectConfirmationData != nil {
+				notOnOrAfters = append(notOnOrAfters, confirmation.SubjectConfirmationData.NotOnOrAfter)
+			}
+		}
+	}
+	for _, notOnOrAfter := range notOnOrAfters {
+		t, err := time.Parse(time.RFC3339, notOnOrAfter)
+		if err != nil {
+			continue
+		}
+		if !ok || t.Before(expiration) {
+			expiration, ok = t, true
+		}
+	}
+	return expiration, ok
+}
+
+func containsAudience(audiences []string, entityID string) bool {
+	for _, audience := range audiences {
+		if strings.TrimSpace(audience) == entityID {
+			return true
+		}
+	}
+	return false
+}
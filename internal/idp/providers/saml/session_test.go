@@ -0,0 +1,279 @@
+package saml
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+type testResponse struct {
+	inResponseTo string
+	audience     string
+	notOnOrAfter time.Time
+	signResponse bool
+	signAssert   bool
+}
+
+func (r testResponse) encode(t *testing.T, key, certificate []byte) string {
+	t.Helper()
+	now := time.Now().UTC()
+	assertion := fmt.Sprintf(`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="assertion-id" Version="2.0" IssueInstant="%[1]s">
+  <saml:Issuer>%[2]s</saml:Issuer>
+  <saml:Subject>
+    <saml:NameID Format="urn:oasis:names:tc:SAML:2.0:nameid-format:persistent">subject</saml:NameID>
+    <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+      <saml:SubjectConfirmationData InResponseTo="%[3]s" NotOnOrAfter="%[4]s" Recipient="%[5]s"/>
+    </saml:SubjectConfirmation>
+  </saml:Subject>
+  <saml:Conditions NotBefore="%[1]s" NotOnOrAfter="%[4]s">
+    <saml:AudienceRestriction>
+      <saml:Audience>%[6]s</saml:Audience>
+    </saml:AudienceRestriction>
+  </saml:Conditions>
+  <saml:AttributeStatement>
+    <saml:Attribute Name="http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname"><saml:AttributeValue>first</saml:AttributeValue></saml:Attribute>
+    <saml:Attribute Name="http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname"><saml:AttributeValue>last</saml:AttributeValue></saml:Attribute>
+    <saml:Attribute Name="urn:oid:0.9.2342.19200300.100.1.3" FriendlyName="mail"><saml:AttributeValue>email@example.com</saml:AttributeValue></saml:Attribute>
+    <saml:Attribute Name="locale"><saml:AttributeValue>de</saml:AttributeValue></saml:Attribute>
+  </saml:AttributeStatement>
+</saml:Assertion>`, now.Format(time.RFC3339), testIDPEntityID, r.inResponseTo, r.notOnOrAfter.Format(time.RFC3339), testACS, r.audience)
+	if r.signAssert {
+		assertion = sign(t, key, certificate, assertion)
+	}
+	response := fmt.Sprintf(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="response-id" InResponseTo="%s" Version="2.0" IssueInstant="%s" Destination="%s">
+  <saml:Issuer>%s</saml:Issuer>
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>
+  %s
+</samlp:Response>`, r.inResponseTo, now.Format(time.RFC3339), testACS, testIDPEntityID, assertion)
+	if r.signResponse {
+		response = sign(t, key, certificate, response)
+	}
+	return base64.StdEncoding.EncodeToString([]byte(response))
+}
+
+func sign(t *testing.T, key, certificate []byte, data string) string {
+	t.Helper()
+	privateKey, err := crypto.BytesToPrivateKey(key)
+	require.NoError(t, err)
+	block, _ := pem.Decode(certificate)
+	signingContext, err := signature.GetSigningContext(tls.Certificate{Certificate: [][]byte{block.Bytes}, PrivateKey: privateKey}, dsig.RSASHA256SignatureMethod)
+	require.NoError(t, err)
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromString(data))
+	signed, err := signingContext.SignEnveloped(doc.Root())
+	require.NoError(t, err)
+	doc.SetRoot(signed)
+	signedData, err := doc.WriteToString()
+	require.NoError(t, err)
+	return signedData
+}
+
+func TestSession_FetchUser(t *testing.T) {
+	key, certificate := testCertificate(t)
+	otherKey, otherCertificate := testCertificate(t)
+	type fields struct {
+		requestID string
+		response  func() string
+	}
+	type want struct {
+		err       error
+		wantErr   bool
+		id        string
+		firstName string
+		lastName  string
+		email     domain.EmailAddress
+		language  language.Tag
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "missing response",
+			fields: fields{
+				requestID: "id-state",
+				response:  func() string { return "" },
+			},
+			want: want{
+				err: ErrResponseMissing,
+			},
+		},
+		{
+			name: "unsigned response",
+			fields: fields{
+				requestID: "id-state",
+				response: func() string {
+					return testResponse{inResponseTo: "id-state", audience: testEntityID, notOnOrAfter: time.Now().Add(time.Minute)}.encode(t, key, certificate)
+				},
+			},
+			want: want{
+				err: ErrMissingSignature,
+			},
+		},
+		{
+			name: "signed by other key",
+			fields: fields{
+				requestID: "id-state",
+				response: func() string {
+					return testResponse{inResponseTo: "id-state", audience: testEntityID, notOnOrAfter: time.Now().Add(time.Minute), signAssert: true}.encode(t, otherKey, otherCertificate)
+				},
+			},
+			want: want{
+				wantErr: true,
+			},
+		},
+		{
+			name: "invalid in response to",
+			fields: fields{
+				requestID: "id-state",
+				response: func() string {
+					return testResponse{inResponseTo: "id-other", audience: testEntityID, notOnOrAfter: time.Now().Add(time.Minute), signAssert: true}.encode(t, key, certificate)
+				},
+			},
+			want: want{
+				err: ErrInvalidInResponseTo,
+			},
+		},
+		{
+			name: "invalid audience",
+			fields: fields{
+				requestID: "id-state",
+				response: func() string {
+					return testResponse{inResponseTo: "id-state", audience: "other", notOnOrAfter: time.Now().Add(time.Minute), signAssert: true}.encode(t, key, certificate)
+				},
+			},
+			want: want{
+				err: ErrInvalidAudience,
+			},
+		},
+		{
+			name: "expired",
+			fields: fields{
+				requestID: "id-state",
+				response: func() string {
+					return testResponse{inResponseTo: "id-state", audience: testEntityID, notOnOrAfter: time.Now().Add(-time.Hour), signAssert: true}.encode(t, key, certificate)
+				},
+			},
+			want: want{
+				err: ErrAssertionExpired,
+			},
+		},
+		{
+			name: "signed assertion",
+			fields: fields{
+				requestID: "id-state",
+				response: func() string {
+					return testResponse{inResponseTo: "id-state", audience: testEntityID, notOnOrAfter: time.Now().Add(time.Minute), signAssert: true}.encode(t, key, certificate)
+				},
+			},
+			want: want{
+				id:        "subject",
+				firstName: "first",
+				lastName:  "last",
+				email:     "email@example.com",
+				language:  language.German,
+			},
+		},
+		{
+			name: "signed response",
+			fields: fields{
+				requestID: "id-state",
+				response: func() string {
+					return testResponse{inResponseTo: "id-state", audience: testEntityID, notOnOrAfter: time.Now().Add(time.Minute), signResponse: true}.encode(t, key, certificate)
+				},
+			},
+			want: want{
+				id:        "subject",
+				firstName: "first",
+				lastName:  "last",
+				email:     "email@example.com",
+				language:  language.German,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			r := require.New(t)
+
+			provider, err := New("saml", testEntityID, testACS, testMetadata(t, certificate), nil, nil)
+			r.NoError(err)
+
+			session := &Session{
+				Provider:  provider,
+				RequestID: tt.fields.requestID,
+				Response:  tt.fields.response(),
+			}
+			user, err := session.FetchUser(context.Background())
+			if tt.want.err != nil {
+				a.ErrorIs(err, tt.want.err)
+				return
+			}
+			if tt.want.wantErr {
+				a.Error(err)
+				return
+			}
+			r.NoError(err)
+			a.Equal(tt.want.id, user.GetID())
+			a.Equal(tt.want.firstName, user.GetFirstName())
+			a.Equal(tt.want.lastName, user.GetLastName())
+			a.Equal(tt.want.email, user.GetEmail())
+			a.Equal(tt.want.language, user.GetPreferredLanguage())
+			a.False(user.IsEmailVerified())
+		})
+	}
+}
+
+type usedAssertions map[string]time.Time
+
+func (u usedAssertions) Use(_ context.Context, id string, expiration time.Time) (bool, error) {
+	if _, ok := u[id]; ok {
+		return false, nil
+	}
+	u[id] = expiration
+	return true, nil
+}
+
+func TestSession_FetchUser_replay(t *testing.T) {
+	key, certificate := testCertificate(t)
+	r := require.New(t)
+
+	provider, err := New("saml", testEntityID, testACS, testMetadata(t, certificate), nil, nil)
+	r.NoError(err)
+	notOnOrAfter := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+	response := testResponse{inResponseTo: "id-state", audience: testEntityID, notOnOrAfter: notOnOrAfter, signAssert: true}.encode(t, key, certificate)
+	used := make(usedAssertions)
+
+	session := &Session{Provider: provider, RequestID: "id-state", Response: response, UsedAssertions: used}
+	_, err = session.FetchUser(context.Background())
+	r.NoError(err)
+	assert.Equal(t, notOnOrAfter.Add(provider.clockSkew), used["assertion-id"])
+
+	replayed := &Session{Provider: provider, RequestID: "id-state", Response: response, UsedAssertions: used}
+	_, err = replayed.FetchUser(context.Background())
+	assert.ErrorIs(t, err, ErrAssertionReplayed)
+}
+
+func TestResponseRequestID(t *testing.T) {
+	key, certificate := testCertificate(t)
+	response := testResponse{inResponseTo: "id-state", audience: testEntityID, notOnOrAfter: time.Now().Add(time.Minute), signAssert: true}.encode(t, key, certificate)
+
+	id, err := ResponseRequestID(response)
+	require.NoError(t, err)
+	assert.Equal(t, "id-state", id)
+}
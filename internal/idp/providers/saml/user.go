@@ -0,0 +1,133 @@
+package saml
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// the attribute names are checked in order: the short names, the claims used by ADFS / Azure AD and the OIDs of eduPerson / inetOrgPerson (Shibboleth)
+var (
+	firstNameAttributes         = []string{"firstName", "givenName", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname", "urn:oid:2.5.4.42"}
+	lastNameAttributes          = []string{"lastName", "surname", "sn", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname", "urn:oid:2.5.4.4"}
+	displayNameAttributes       = []string{"displayName", "name", "http://schemas.microsoft.com/identity/claims/displayname", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name", "urn:oid:2.16.840.1.113730.3.1.241"}
+	nickNameAttributes          = []string{"nickName", "nickname"}
+	preferredUsernameAttributes = []string{"username", "preferredUsername", "uid", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn", "urn:oid:0.9.2342.19200300.100.1.1"}
+	emailAttributes             = []string{"email", "mail", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress", "urn:oid:0.9.2342.19200300.100.1.3"}
+	emailVerifiedAttributes     = []string{"emailVerified", "email_verified"}
+	phoneAttributes             = []string{"phone", "telephoneNumber", "mobile", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/mobilephone", "urn:oid:2.5.4.20"}
+	phoneVerifiedAttributes     = []string{"phoneVerified", "phone_verified"}
+	preferredLanguageAttributes = []string{"preferredLanguage", "locale", "urn:oid:2.16.840.1.113730.3.1.39"}
+	avatarURLAttributes         = []string{"avatarURL", "picture"}
+	profileAttributes           = []string{"profile", "website"}
+)
+
+// User is the [idp.User] mapped from the attributes of a SAML assertion.
+type User struct {
+	ID         string
+	Attributes map[string][]string
+}
+
+func (p *Provider) mapUser(assertion *saml.AssertionType) (*User, error) {
+	user := &User{
+		ID:         strings.TrimSpace(assertion.Subject.NameID.Text),
+		Attributes: make(map[string][]string),
+	}
+	for _, statement := range assertion.AttributeStatement {
+		for _, attribute := range statement.Attribute {
+			values := make([]string, len(attribute.AttributeValue))
+			for i, value := range attribute.AttributeValue {
+				values[i] = strings.TrimSpace(value)
+			}
+			user.Attributes[attribute.Name] = append(user.Attributes[attribute.Name], values...)
+			if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+				user.Attributes[attribute.FriendlyName] = append(user.Attributes[attribute.FriendlyName], values...)
+			}
+		}
+	}
+	if p.idAttribute != "" {
+		user.ID = user.GetAttribute(p.idAttribute)
+	}
+	if user.ID == "" {
+		return nil, ErrMissingSubject
+	}
+	return user, nil
+}
+
+// GetAttribute returns the first value of the attribute or an empty string if it's not set.
+func (u *User) GetAttribute(name string) string {
+	if values := u.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (u *User) firstAttribute(names []string) string {
+	for _, name := range names {
+		if value := u.GetAttribute(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (u *User) boolAttribute(names []string) bool {
+	verified, _ := strconv.ParseBool(u.firstAttribute(names))
+	return verified
+}
+
+func (u *User) GetID() string {
+	return u.ID
+}
+
+func (u *User) GetFirstName() string {
+	return u.firstAttribute(firstNameAttributes)
+}
+
+func (u *User) GetLastName() string {
+	return u.firstAttribute(lastNameAttributes)
+}
+
+func (u *User) GetDisplayName() string {
+	return u.firstAttribute(displayNameAttributes)
+}
+
+func (u *User) GetNickname() string {
+	return u.firstAttribute(nickNameAttributes)
+}
+
+func (u *User) GetPreferredUsername() string {
+	return u.firstAttribute(preferredUsernameAttributes)
+}
+
+func (u *User) GetEmail() domain.EmailAddress {
+	return domain.EmailAddress(u.firstAttribute(emailAttributes))
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.boolAttribute(emailVerifiedAttributes)
+}
+
+func (u *User) GetPhone() domain.PhoneNumber {
+	return domain.PhoneNumber(u.firstAttribute(phoneAttributes))
+}
+
+func (u *User) IsPhoneVerified() bool {
+	return u.boolAttribute(phoneVerifiedAttributes)
+}
+
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Make(u.firstAttribute(preferredLanguageAttributes))
+}
+
+func (u *User) GetAvatarURL() string {
+	return u.firstAttribute(avatarURLAttributes)
+}
+
+func (u *User) GetProfile() string {
+	return u.firstAttribute(profileAttributes)
+}
@@ -0,0 +1,69 @@
+package vonage
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const (
+	smsEndpoint    = "https://rest.nexmo.com/sms/json"
+	statusSuccess  = "0"
+	requestTimeout = 10 * time.Second
+)
+
+type smsResponse struct {
+	Messages []struct {
+		MessageID string `json:"message-id"`
+		Status    string `json:"status"`
+		ErrorText string `json:"error-text"`
+	} `json:"messages"`
+}
+
+func InitVonageChannel(config Config) channels.NotificationChannel {
+	client := &http.Client{Timeout: requestTimeout}
+
+	logging.Debug("successfully initialized vonage sms channel")
+
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		vonageMsg, ok := message.(*messages.SMS)
+		if !ok {
+			return caos_errs.ThrowInternal(nil, "VONAG-Hs2fw", "message is not SMS")
+		}
+		resp, err := client.PostForm(smsEndpoint, url.Values{
+			"api_key":    {config.APIKey},
+			"api_secret": {config.APISecret},
+			"from":       {vonageMsg.SenderPhoneNumber},
+			"to":         {vonageMsg.RecipientPhoneNumber},
+			"text":       {vonageMsg.GetContent()},
+		})
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "VONAG-Ks3fq", "could not send message")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return caos_errs.ThrowInternalf(nil, "VONAG-Ns2wd", "could not send message: status %d", resp.StatusCode)
+		}
+		response := new(smsResponse)
+		if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+			return caos_errs.ThrowInternal(err, "VONAG-Wq2sf", "could not parse response")
+		}
+		if len(response.Messages) == 0 {
+			return caos_errs.ThrowInternal(nil, "VONAG-Qs3ge", "could not send message: no message accepted")
+		}
+		for _, m := range response.Messages {
+			if m.Status != statusSuccess {
+				return caos_errs.ThrowInternalf(nil, "VONAG-Pz3fs", "could not send message: %s", m.ErrorText)
+			}
+			logging.WithFields("message_id", m.MessageID, "status", m.Status).Debug("sms sent")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,11 @@
+package vonage
+
+type Config struct {
+	APIKey       string
+	APISecret    string
+	SenderNumber string
+}
+
+func (v *Config) IsValid() bool {
+	return v.APIKey != "" && v.APISecret != "" && v.SenderNumber != ""
+}
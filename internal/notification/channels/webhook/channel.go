@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const requestTimeout = 10 * time.Second
+
+func InitWebhookChannel(config Config) channels.NotificationChannel {
+	client := &http.Client{Timeout: requestTimeout}
+
+	logging.Debug("successfully initialized webhook sms channel")
+
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		smsMsg, ok := message.(*messages.SMS)
+		if !ok {
+			return caos_errs.ThrowInternal(nil, "WEBH-Ks2fq", "message is not SMS")
+		}
+		body, err := requestBody(config, smsMsg)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequest(http.MethodPost, config.Endpoint, bytes.NewReader(body))
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "WEBH-Ps2gw", "could not create request")
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for key, value := range config.Headers {
+			req.Header.Set(key, value)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return caos_errs.ThrowInternal(err, "WEBH-Dj3fs", "could not send message")
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return caos_errs.ThrowInternalf(nil, "WEBH-Lw2fa", "could not send message: status %d", resp.StatusCode)
+		}
+		logging.WithFields("status", resp.StatusCode).Debug("sms sent")
+		return nil
+	})
+}
+
+// requestBody renders the configured body template,
+// if none is configured the message is sent as json
+func requestBody(config Config, msg *messages.SMS) ([]byte, error) {
+	if config.BodyTemplate == "" {
+		body, err := json.Marshal(msg)
+		if err != nil {
+			return nil, caos_errs.ThrowInternal(err, "WEBH-Nw3sf", "could not marshal message")
+		}
+		return body, nil
+	}
+	tmpl, err := config.template()
+	if err != nil {
+		return nil, caos_errs.ThrowInternal(err, "WEBH-Fs3wq", "could not parse body template")
+	}
+	body := new(bytes.Buffer)
+	if err = tmpl.Execute(body, msg); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "WEBH-Gw2fs", "could not execute body template")
+	}
+	return body.Bytes(), nil
+}
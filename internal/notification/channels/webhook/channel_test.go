@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+func Test_requestBody(t *testing.T) {
+	msg := &messages.SMS{
+		SenderPhoneNumber:    "+41791234567",
+		RecipientPhoneNumber: "+41797654321",
+		Content:              `your code is "123"`,
+	}
+	tests := []struct {
+		name    string
+		config  Config
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "no template",
+			config: Config{},
+			want:   `{"SenderPhoneNumber":"+41791234567","RecipientPhoneNumber":"+41797654321","Content":"your code is \"123\""}`,
+		},
+		{
+			name:   "template",
+			config: Config{BodyTemplate: `{"to":{{json .RecipientPhoneNumber}},"text":{{json .Content}}}`},
+			want:   `{"to":"+41797654321","text":"your code is \"123\""}`,
+		},
+		{
+			name:    "invalid template",
+			config:  Config{BodyTemplate: `{{.Content`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := requestBody(tt.config, msg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
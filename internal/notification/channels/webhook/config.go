@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/url"
+	"text/template"
+)
+
+type Config struct {
+	Endpoint     string
+	Headers      map[string]string
+	BodyTemplate string
+	SenderNumber string
+}
+
+func (w *Config) IsValid() bool {
+	if w.Endpoint == "" {
+		return false
+	}
+	endpoint, err := url.ParseRequestURI(w.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return false
+	}
+	_, err = w.template()
+	return err == nil
+}
+
+// template parses the body template of the request,
+// the fields of messages.SMS can be used (e.g. {{json .Content}})
+func (w *Config) template() (*template.Template, error) {
+	return template.New("body").Funcs(template.FuncMap{"json": toJSON}).Parse(w.BodyTemplate)
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_IsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   bool
+	}{
+		{
+			name:   "https endpoint",
+			config: Config{Endpoint: "https://sms.example.com/send"},
+			want:   true,
+		},
+		{
+			name:   "no endpoint",
+			config: Config{},
+			want:   false,
+		},
+		{
+			name:   "relative endpoint",
+			config: Config{Endpoint: "/send"},
+			want:   false,
+		},
+		{
+			name:   "unsupported scheme",
+			config: Config{Endpoint: "file:///etc/passwd"},
+			want:   false,
+		},
+		{
+			name:   "invalid template",
+			config: Config{Endpoint: "https://sms.example.com/send", BodyTemplate: "{{.Content"},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.IsValid())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"time"
 
@@ -22,6 +23,9 @@ import (
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/vonage"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
+	"github.com/zitadel/zitadel/internal/notification/senders"
 	_ "github.com/zitadel/zitadel/internal/notification/statik"
 	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/query"
@@ -141,6 +145,14 @@ func (p *notificationsProjection) reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordChangedType,
 					Reduce: p.reducePasswordChanged,
 				},
+				{
+					Event:  user.HumanMFAOTPSMSCodeAddedType,
+					Reduce: p.reduceOTPSMSCodeAdded,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailCodeAddedType,
+					Reduce: p.reduceOTPEmailCodeAdded,
+				},
 			},
 		},
 	}
@@ -326,11 +338,11 @@ func (p *notificationsProjection) reducePasswordCodeAdded(event eventstore.Event
 		p.assetsPrefix(ctx),
 	)
 	if e.NotificationType == domain.NotificationTypeSms {
-		notify = types.SendSMSTwilio(
+		notify = types.SendSMS(
 			ctx,
 			translator,
 			notifyUser,
-			p.getTwilioConfig,
+			p.getSMSConfig,
 			p.getFileSystemProvider,
 			p.getLogProvider,
 			colors,
@@ -572,11 +584,11 @@ func (p *notificationsProjection) reducePhoneCodeAdded(event eventstore.Event) (
 	if err != nil {
 		return nil, err
 	}
-	err = types.SendSMSTwilio(
+	err = types.SendSMS(
 		ctx,
 		translator,
 		notifyUser,
-		p.getTwilioConfig,
+		p.getSMSConfig,
 		p.getFileSystemProvider,
 		p.getLogProvider,
 		colors,
@@ -592,6 +604,124 @@ func (p *notificationsProjection) reducePhoneCodeAdded(event eventstore.Event) (
 	return crdb.NewNoOpStatement(e), nil
 }
 
+func (p *notificationsProjection) reduceOTPSMSCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanOTPSMSCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Fs3gh", "reduce.wrong.event.type %s", user.HumanMFAOTPSMSCodeAddedType)
+	}
+	ctx := setNotificationContext(event.Aggregate())
+	alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		user.HumanMFAOTPSMSCodeAddedType, user.HumanMFAOTPSMSCodeSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, p.userDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := p.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := p.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifySMSOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := p.origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendSMS(
+		ctx,
+		translator,
+		notifyUser,
+		p.getSMSConfig,
+		p.getFileSystemProvider,
+		p.getLogProvider,
+		colors,
+		p.assetsPrefix(ctx),
+	).SendOTPSMSCode(notifyUser, origin, code)
+	if err != nil {
+		return nil, err
+	}
+	err = p.commands.HumanOTPSMSCodeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
+func (p *notificationsProjection) reduceOTPEmailCodeAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanOTPEmailCodeAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Jd2fg", "reduce.wrong.event.type %s", user.HumanMFAOTPEmailCodeAddedType)
+	}
+	ctx := setNotificationContext(event.Aggregate())
+	alreadyHandled, err := p.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, nil,
+		user.HumanMFAOTPEmailCodeAddedType, user.HumanMFAOTPEmailCodeSentType)
+	if err != nil {
+		return nil, err
+	}
+	if alreadyHandled {
+		return crdb.NewNoOpStatement(e), nil
+	}
+	code, err := crypto.DecryptString(e.Code, p.userDataCrypto)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := p.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := p.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyUser, err := p.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID, false)
+	if err != nil {
+		return nil, err
+	}
+	translator, err := p.getTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.VerifyEmailOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, origin, err := p.origin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = types.SendEmail(
+		ctx,
+		string(template.Template),
+		translator,
+		notifyUser,
+		p.getSMTPConfig,
+		p.getFileSystemProvider,
+		p.getLogProvider,
+		colors,
+		p.assetsPrefix(ctx),
+	).SendOTPEmailCode(notifyUser, origin, code)
+	if err != nil {
+		return nil, err
+	}
+	err = p.commands.HumanOTPEmailCodeSent(ctx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return crdb.NewNoOpStatement(e), nil
+}
+
 func (p *notificationsProjection) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
 	if event.CreationDate().Add(expiry).Before(time.Now().UTC()) {
 		return true, nil
@@ -638,8 +768,8 @@ func (p *notificationsProjection) getSMTPConfig(ctx context.Context) (*smtp.Conf
 	}, nil
 }
 
-// Read iam twilio config
-func (p *notificationsProjection) getTwilioConfig(ctx context.Context) (*twilio.Config, error) {
+// Read the active sms provider config of the instance
+func (p *notificationsProjection) getSMSConfig(ctx context.Context) (*senders.SMSConfig, error) {
 	active, err := query.NewSMSProviderStateQuery(domain.SMSConfigStateActive)
 	if err != nil {
 		return nil, err
@@ -648,18 +778,52 @@ func (p *notificationsProjection) getTwilioConfig(ctx context.Context) (*twilio.
 	if err != nil {
 		return nil, err
 	}
-	if config.TwilioConfig == nil {
-		return nil, errors.ThrowNotFound(nil, "HANDLER-8nfow", "Errors.SMS.Twilio.NotFound")
-	}
-	token, err := crypto.DecryptString(config.TwilioConfig.Token, p.smsTokenCrypto)
-	if err != nil {
-		return nil, err
+	switch {
+	case config.TwilioConfig != nil:
+		token, err := crypto.DecryptString(config.TwilioConfig.Token, p.smsTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &senders.SMSConfig{
+			Twilio: &twilio.Config{
+				SID:          config.TwilioConfig.SID,
+				Token:        token,
+				SenderNumber: config.TwilioConfig.SenderNumber,
+			},
+		}, nil
+	case config.HTTPConfig != nil:
+		headers := make(map[string]string)
+		if config.HTTPConfig.Headers != nil {
+			decrypted, err := crypto.Decrypt(config.HTTPConfig.Headers, p.smsTokenCrypto)
+			if err != nil {
+				return nil, err
+			}
+			if err = json.Unmarshal(decrypted, &headers); err != nil {
+				return nil, errors.ThrowInternal(err, "HANDLER-Kw3fs", "Errors.Internal")
+			}
+		}
+		return &senders.SMSConfig{
+			Webhook: &webhook.Config{
+				Endpoint:     config.HTTPConfig.Endpoint,
+				Headers:      headers,
+				BodyTemplate: config.HTTPConfig.BodyTemplate,
+				SenderNumber: config.HTTPConfig.SenderNumber,
+			},
+		}, nil
+	case config.VonageConfig != nil:
+		apiSecret, err := crypto.DecryptString(config.VonageConfig.APISecret, p.smsTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &senders.SMSConfig{
+			Vonage: &vonage.Config{
+				APIKey:       config.VonageConfig.APIKey,
+				APISecret:    apiSecret,
+				SenderNumber: config.VonageConfig.SenderNumber,
+			},
+		}, nil
 	}
-	return &twilio.Config{
-		SID:          config.TwilioConfig.SID,
-		Token:        token,
-		SenderNumber: config.TwilioConfig.SenderNumber,
-	}, nil
+	return nil, errors.ThrowNotFound(nil, "HANDLER-8nfow", "Errors.SMSConfig.NotFound")
 }
 
 // Read iam filesystem provider config
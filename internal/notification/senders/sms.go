@@ -7,12 +7,43 @@ import (
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/vonage"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 )
 
-func SMSChannels(ctx context.Context, twilioConfig *twilio.Config, getFileSystemProvider func(ctx context.Context) (*fs.Config, error), getLogProvider func(ctx context.Context) (*log.Config, error)) (chain *Chain, err error) {
+// SMSConfig is the active sms provider of an instance,
+// exactly one of the provider configs is set
+type SMSConfig struct {
+	Twilio  *twilio.Config
+	Webhook *webhook.Config
+	Vonage  *vonage.Config
+}
+
+func (c *SMSConfig) SenderNumber() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.Twilio != nil:
+		return c.Twilio.SenderNumber
+	case c.Webhook != nil:
+		return c.Webhook.SenderNumber
+	case c.Vonage != nil:
+		return c.Vonage.SenderNumber
+	}
+	return ""
+}
+
+func SMSChannels(ctx context.Context, smsConfig *SMSConfig, getFileSystemProvider func(ctx context.Context) (*fs.Config, error), getLogProvider func(ctx context.Context) (*log.Config, error)) (chain *Chain, err error) {
 	channels := make([]channels.NotificationChannel, 0, 3)
-	if twilioConfig != nil {
-		channels = append(channels, twilio.InitTwilioChannel(*twilioConfig))
+	if smsConfig != nil {
+		switch {
+		case smsConfig.Twilio != nil:
+			channels = append(channels, twilio.InitTwilioChannel(*smsConfig.Twilio))
+		case smsConfig.Webhook != nil:
+			channels = append(channels, webhook.InitWebhookChannel(*smsConfig.Webhook))
+		case smsConfig.Vonage != nil:
+			channels = append(channels, vonage.InitVonageChannel(*smsConfig.Vonage))
+		}
 	}
 	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
 	return chainChannels(channels...), nil
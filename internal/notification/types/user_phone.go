@@ -8,20 +8,16 @@ import (
 	caos_errors "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/messages"
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
-func generateSms(ctx context.Context, user *query.NotifyUser, content string, getTwilioProvider func(ctx context.Context) (*twilio.Config, error), getFileSystemProvider func(ctx context.Context) (*fs.Config, error), getLogProvider func(ctx context.Context) (*log.Config, error), lastPhone bool) error {
-	number := ""
-	twilioConfig, err := getTwilioProvider(ctx)
-	if err == nil {
-		number = twilioConfig.SenderNumber
-	}
+func generateSms(ctx context.Context, user *query.NotifyUser, content string, getSMSProvider func(ctx context.Context) (*senders.SMSConfig, error), getFileSystemProvider func(ctx context.Context) (*fs.Config, error), getLogProvider func(ctx context.Context) (*log.Config, error), lastPhone bool) error {
+	smsConfig, err := getSMSProvider(ctx)
+	logging.OnError(err).Debug("no active sms provider")
 	message := &messages.SMS{
-		SenderPhoneNumber:    number,
+		SenderPhoneNumber:    smsConfig.SenderNumber(),
 		RecipientPhoneNumber: user.VerifiedPhone,
 		Content:              content,
 	}
@@ -29,7 +25,7 @@ func generateSms(ctx context.Context, user *query.NotifyUser, content string, ge
 		message.RecipientPhoneNumber = user.LastPhone
 	}
 
-	channelChain, err := senders.SMSChannels(ctx, twilioConfig, getFileSystemProvider, getLogProvider)
+	channelChain, err := senders.SMSChannels(ctx, smsConfig, getFileSystemProvider, getLogProvider)
 	logging.OnError(err).Error("could not create sms channel")
 
 	if channelChain.Len() == 0 {
@@ -0,0 +1,201 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	flowEventTriggersTable = table{
+		name:          projection.FlowEventTriggerTable,
+		instanceIDCol: projection.FlowEventTriggerInstanceIDCol,
+	}
+	FlowEventTriggersColumnEventType = Column{
+		name:  projection.FlowEventTriggerEventTypeCol,
+		table: flowEventTriggersTable,
+	}
+	FlowEventTriggersColumnChangeDate = Column{
+		name:  projection.FlowEventTriggerChangeDateCol,
+		table: flowEventTriggersTable,
+	}
+	FlowEventTriggersColumnSequence = Column{
+		name:  projection.FlowEventTriggerSequenceCol,
+		table: flowEventTriggersTable,
+	}
+	FlowEventTriggersColumnResourceOwner = Column{
+		name:  projection.FlowEventTriggerResourceOwnerCol,
+		table: flowEventTriggersTable,
+	}
+	FlowEventTriggersColumnInstanceID = Column{
+		name:  projection.FlowEventTriggerInstanceIDCol,
+		table: flowEventTriggersTable,
+	}
+	FlowEventTriggersColumnTriggerSequence = Column{
+		name:  projection.FlowEventTriggerActionTriggerSequenceCol,
+		table: flowEventTriggersTable,
+	}
+	FlowEventTriggersColumnActionID = Column{
+		name:  projection.FlowEventTriggerActionIDCol,
+		table: flowEventTriggersTable,
+	}
+	FlowEventTriggersColumnOwnerRemoved = Column{
+		name:  projection.FlowEventTriggerOwnerRemovedCol,
+		table: flowEventTriggersTable,
+	}
+)
+
+// EventTriggerActions are the actions bound to an event type,
+// Sequence is the sequence of the event which bound the actions
+type EventTriggerActions struct {
+	EventType     string
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+
+	Actions []*Action
+}
+
+// SearchEventTriggerActions returns the actions bound to event types of the organisation, ordered by event type
+func (q *Queries) SearchEventTriggerActions(ctx context.Context, orgID string, withOwnerRemoved bool) (_ []*EventTriggerActions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		FlowEventTriggersColumnResourceOwner.identifier(): orgID,
+		FlowEventTriggersColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	return q.searchEventTriggerActions(ctx, eq, withOwnerRemoved)
+}
+
+// GetActiveEventTriggerActions returns the active actions bound to the event type,
+// nil is returned if no action is bound
+func (q *Queries) GetActiveEventTriggerActions(ctx context.Context, eventType, orgID string, withOwnerRemoved bool) (_ *EventTriggerActions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		FlowEventTriggersColumnEventType.identifier():     eventType,
+		FlowEventTriggersColumnResourceOwner.identifier(): orgID,
+		FlowEventTriggersColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		ActionColumnState.identifier():                    domain.ActionStateActive,
+	}
+	triggers, err := q.searchEventTriggerActions(ctx, eq, withOwnerRemoved)
+	if err != nil || len(triggers) == 0 {
+		return nil, err
+	}
+	return triggers[0], nil
+}
+
+func (q *Queries) searchEventTriggerActions(ctx context.Context, eq sq.Eq, withOwnerRemoved bool) (_ []*EventTriggerActions, err error) {
+	if !withOwnerRemoved {
+		eq[FlowEventTriggersColumnOwnerRemoved.identifier()] = false
+	}
+	query, scan := prepareEventTriggerActionsQuery(ctx, q.client)
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Wd8ok", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ch3vi", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func prepareEventTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*EventTriggerActions, error)) {
+	return sq.Select(
+			ActionColumnID.identifier(),
+			ActionColumnCreationDate.identifier(),
+			ActionColumnChangeDate.identifier(),
+			ActionColumnResourceOwner.identifier(),
+			ActionColumnState.identifier(),
+			ActionColumnSequence.identifier(),
+			ActionColumnName.identifier(),
+			ActionColumnScript.identifier(),
+			ActionColumnAllowedToFail.identifier(),
+			ActionColumnTimeout.identifier(),
+			FlowEventTriggersColumnEventType.identifier(),
+			FlowEventTriggersColumnChangeDate.identifier(),
+			FlowEventTriggersColumnSequence.identifier(),
+			FlowEventTriggersColumnResourceOwner.identifier(),
+		).
+			From(flowEventTriggersTable.name).
+			LeftJoin(join(ActionColumnID, FlowEventTriggersColumnActionID)+db.Timetravel(call.Took(ctx))).
+			OrderBy(FlowEventTriggersColumnEventType.identifier(), FlowEventTriggersColumnTriggerSequence.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*EventTriggerActions, error) {
+			triggers := make([]*EventTriggerActions, 0)
+			var trigger *EventTriggerActions
+			for rows.Next() {
+				var (
+					actionID            sql.NullString
+					actionCreationDate  sql.NullTime
+					actionChangeDate    sql.NullTime
+					actionResourceOwner sql.NullString
+					actionState         sql.NullInt32
+					actionSequence      sql.NullInt64
+					actionName          sql.NullString
+					actionScript        sql.NullString
+					actionAllowedToFail sql.NullBool
+					actionTimeout       sql.NullInt64
+
+					current = new(EventTriggerActions)
+				)
+				err := rows.Scan(
+					&actionID,
+					&actionCreationDate,
+					&actionChangeDate,
+					&actionResourceOwner,
+					&actionState,
+					&actionSequence,
+					&actionName,
+					&actionScript,
+					&actionAllowedToFail,
+					&actionTimeout,
+					&current.EventType,
+					&current.ChangeDate,
+					&current.Sequence,
+					&current.ResourceOwner,
+				)
+				if err != nil {
+					return nil, err
+				}
+				if trigger == nil || trigger.EventType != current.EventType {
+					trigger = current
+					triggers = append(triggers, trigger)
+				}
+				if !actionID.Valid {
+					continue
+				}
+				trigger.Actions = append(trigger.Actions, &Action{
+					ID:            actionID.String,
+					CreationDate:  actionCreationDate.Time,
+					ChangeDate:    actionChangeDate.Time,
+					ResourceOwner: actionResourceOwner.String,
+					State:         domain.ActionState(actionState.Int32),
+					Sequence:      uint64(actionSequence.Int64),
+					Name:          actionName.String,
+					Script:        actionScript.String,
+					AllowedToFail: actionAllowedToFail.Bool,
+					timeout:       time.Duration(actionTimeout.Int64),
+				})
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Lr5xb", "Errors.Query.CloseRows")
+			}
+
+			return triggers, nil
+		}
+}
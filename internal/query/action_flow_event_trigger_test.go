@@ -0,0 +1,212 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	prepareEventTriggerActionsStmt = `SELECT projections.actions3.id,` +
+		` projections.actions3.creation_date,` +
+		` projections.actions3.change_date,` +
+		` projections.actions3.resource_owner,` +
+		` projections.actions3.action_state,` +
+		` projections.actions3.sequence,` +
+		` projections.actions3.name,` +
+		` projections.actions3.script,` +
+		` projections.actions3.allowed_to_fail,` +
+		` projections.actions3.timeout,` +
+		` projections.flow_event_triggers.event_type,` +
+		` projections.flow_event_triggers.change_date,` +
+		` projections.flow_event_triggers.sequence,` +
+		` projections.flow_event_triggers.resource_owner` +
+		` FROM projections.flow_event_triggers` +
+		` LEFT JOIN projections.actions3 ON projections.flow_event_triggers.action_id = projections.actions3.id AND projections.flow_event_triggers.instance_id = projections.actions3.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.flow_event_triggers.event_type, projections.flow_event_triggers.trigger_sequence`
+	prepareEventTriggerActionsCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"state",
+		"sequence",
+		"name",
+		"script",
+		"allowed_to_fail",
+		"timeout",
+		"event_type",
+		"change_date",
+		"sequence",
+		"resource_owner",
+	}
+)
+
+func Test_EventTriggerActionsPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareEventTriggerActionsQuery no result",
+			prepare: prepareEventTriggerActionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareEventTriggerActionsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*EventTriggerActions{},
+		},
+		{
+			name:    "prepareEventTriggerActionsQuery grouped by event type",
+			prepare: prepareEventTriggerActionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareEventTriggerActionsStmt),
+					prepareEventTriggerActionsCols,
+					[][]driver.Value{
+						{
+							"action-id-1",
+							testNow,
+							testNow,
+							"ro",
+							domain.ActionStateActive,
+							uint64(20211115),
+							"action-name-1",
+							"script",
+							true,
+							10000000000,
+							"org.added",
+							testNow,
+							uint64(20211109),
+							"ro",
+						},
+						{
+							"action-id-1",
+							testNow,
+							testNow,
+							"ro",
+							domain.ActionStateActive,
+							uint64(20211115),
+							"action-name-1",
+							"script",
+							true,
+							10000000000,
+							"user.removed",
+							testNow,
+							uint64(20211110),
+							"ro",
+						},
+						{
+							"action-id-2",
+							testNow,
+							testNow,
+							"ro",
+							domain.ActionStateInactive,
+							uint64(20211115),
+							"action-name-2",
+							"script",
+							false,
+							5000000000,
+							"user.removed",
+							testNow,
+							uint64(20211110),
+							"ro",
+						},
+					},
+				),
+			},
+			object: []*EventTriggerActions{
+				{
+					EventType:     "org.added",
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211109,
+					Actions: []*Action{
+						{
+							ID:            "action-id-1",
+							CreationDate:  testNow,
+							ChangeDate:    testNow,
+							ResourceOwner: "ro",
+							State:         domain.ActionStateActive,
+							Sequence:      20211115,
+							Name:          "action-name-1",
+							Script:        "script",
+							AllowedToFail: true,
+							timeout:       10 * time.Second,
+						},
+					},
+				},
+				{
+					EventType:     "user.removed",
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211110,
+					Actions: []*Action{
+						{
+							ID:            "action-id-1",
+							CreationDate:  testNow,
+							ChangeDate:    testNow,
+							ResourceOwner: "ro",
+							State:         domain.ActionStateActive,
+							Sequence:      20211115,
+							Name:          "action-name-1",
+							Script:        "script",
+							AllowedToFail: true,
+							timeout:       10 * time.Second,
+						},
+						{
+							ID:            "action-id-2",
+							CreationDate:  testNow,
+							ChangeDate:    testNow,
+							ResourceOwner: "ro",
+							State:         domain.ActionStateInactive,
+							Sequence:      20211115,
+							Name:          "action-name-2",
+							Script:        "script",
+							AllowedToFail: false,
+							timeout:       5 * time.Second,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareEventTriggerActionsQuery sql err",
+			prepare: prepareEventTriggerActionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareEventTriggerActionsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
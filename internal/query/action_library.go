@@ -0,0 +1,200 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	actionLibraryTable = table{
+		name:          projection.ActionLibraryTable,
+		instanceIDCol: projection.ActionLibraryInstanceIDCol,
+	}
+	ActionLibraryColumnName = Column{
+		name:  projection.ActionLibraryNameCol,
+		table: actionLibraryTable,
+	}
+	ActionLibraryColumnVersion = Column{
+		name:  projection.ActionLibraryVersionCol,
+		table: actionLibraryTable,
+	}
+	ActionLibraryColumnCreationDate = Column{
+		name:  projection.ActionLibraryCreationDateCol,
+		table: actionLibraryTable,
+	}
+	ActionLibraryColumnChangeDate = Column{
+		name:  projection.ActionLibraryChangeDateCol,
+		table: actionLibraryTable,
+	}
+	ActionLibraryColumnResourceOwner = Column{
+		name:  projection.ActionLibraryResourceOwnerCol,
+		table: actionLibraryTable,
+	}
+	ActionLibraryColumnInstanceID = Column{
+		name:  projection.ActionLibraryInstanceIDCol,
+		table: actionLibraryTable,
+	}
+	ActionLibraryColumnSequence = Column{
+		name:  projection.ActionLibrarySequenceCol,
+		table: actionLibraryTable,
+	}
+	ActionLibraryColumnScript = Column{
+		name:  projection.ActionLibraryScriptCol,
+		table: actionLibraryTable,
+	}
+	ActionLibraryColumnOwnerRemoved = Column{
+		name:  projection.ActionLibraryOwnerRemovedCol,
+		table: actionLibraryTable,
+	}
+)
+
+// ActionLibrary is a version of a library which can be required by the actions of an organisation,
+// the script is only set if a single version is queried
+type ActionLibrary struct {
+	Name          string
+	Version       uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	Script        string
+}
+
+// SearchActionLibraries returns all versions of the libraries of the organisation ordered by name and version
+func (q *Queries) SearchActionLibraries(ctx context.Context, orgID string, withOwnerRemoved bool) (_ []*ActionLibrary, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		ActionLibraryColumnResourceOwner.identifier(): orgID,
+		ActionLibraryColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[ActionLibraryColumnOwnerRemoved.identifier()] = false
+	}
+	query, scan := prepareActionLibrariesQuery(ctx, q.client)
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Gs4ib", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Vp7cj", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// ActionLibrariesSequence returns the latest sequence of the action libraries of the instance,
+// it changes as soon as a library of any organisation of the instance is added, changed or removed
+func (q *Queries) ActionLibrariesSequence(ctx context.Context) (*LatestSequence, error) {
+	return q.latestSequence(ctx, actionLibraryTable)
+}
+
+// GetActionLibrary returns the version of the library including the script,
+// the latest version is returned if the version is 0
+func (q *Queries) GetActionLibrary(ctx context.Context, name string, version uint64, orgID string) (_ *ActionLibrary, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		ActionLibraryColumnName.identifier():          name,
+		ActionLibraryColumnResourceOwner.identifier(): orgID,
+		ActionLibraryColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		ActionLibraryColumnOwnerRemoved.identifier():  false,
+	}
+	if version > 0 {
+		eq[ActionLibraryColumnVersion.identifier()] = version
+	}
+	query, scan := prepareActionLibraryQuery(ctx, q.client)
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Lx3nw", "Errors.Query.InvalidRequest")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func prepareActionLibraryQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*ActionLibrary, error)) {
+	return sq.Select(
+			ActionLibraryColumnName.identifier(),
+			ActionLibraryColumnVersion.identifier(),
+			ActionLibraryColumnCreationDate.identifier(),
+			ActionLibraryColumnChangeDate.identifier(),
+			ActionLibraryColumnResourceOwner.identifier(),
+			ActionLibraryColumnSequence.identifier(),
+			ActionLibraryColumnScript.identifier(),
+		).
+			From(actionLibraryTable.identifier() + db.Timetravel(call.Took(ctx))).
+			OrderBy(ActionLibraryColumnVersion.identifier() + " DESC").
+			Limit(1).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*ActionLibrary, error) {
+			library := new(ActionLibrary)
+			err := row.Scan(
+				&library.Name,
+				&library.Version,
+				&library.CreationDate,
+				&library.ChangeDate,
+				&library.ResourceOwner,
+				&library.Sequence,
+				&library.Script,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Zb5ek", "Errors.Action.Library.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Yt9ha", "Errors.Internal")
+			}
+			return library, nil
+		}
+}
+
+func prepareActionLibrariesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*ActionLibrary, error)) {
+	return sq.Select(
+			ActionLibraryColumnName.identifier(),
+			ActionLibraryColumnVersion.identifier(),
+			ActionLibraryColumnCreationDate.identifier(),
+			ActionLibraryColumnChangeDate.identifier(),
+			ActionLibraryColumnResourceOwner.identifier(),
+			ActionLibraryColumnSequence.identifier(),
+		).
+			From(actionLibraryTable.identifier()+db.Timetravel(call.Took(ctx))).
+			OrderBy(ActionLibraryColumnName.identifier(), ActionLibraryColumnVersion.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*ActionLibrary, error) {
+			libraries := make([]*ActionLibrary, 0)
+			for rows.Next() {
+				library := new(ActionLibrary)
+				err := rows.Scan(
+					&library.Name,
+					&library.Version,
+					&library.CreationDate,
+					&library.ChangeDate,
+					&library.ResourceOwner,
+					&library.Sequence,
+				)
+				if err != nil {
+					return nil, err
+				}
+				libraries = append(libraries, library)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Fo6rd", "Errors.Query.CloseRows")
+			}
+
+			return libraries, nil
+		}
+}
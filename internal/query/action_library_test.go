@@ -0,0 +1,206 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareActionLibraryStmt = `SELECT projections.action_libraries.name,` +
+		` projections.action_libraries.version,` +
+		` projections.action_libraries.creation_date,` +
+		` projections.action_libraries.change_date,` +
+		` projections.action_libraries.resource_owner,` +
+		` projections.action_libraries.sequence,` +
+		` projections.action_libraries.script` +
+		` FROM projections.action_libraries AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.action_libraries.version DESC LIMIT 1`
+	prepareActionLibraryCols = []string{
+		"name",
+		"version",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"script",
+	}
+	prepareActionLibrariesStmt = `SELECT projections.action_libraries.name,` +
+		` projections.action_libraries.version,` +
+		` projections.action_libraries.creation_date,` +
+		` projections.action_libraries.change_date,` +
+		` projections.action_libraries.resource_owner,` +
+		` projections.action_libraries.sequence` +
+		` FROM projections.action_libraries AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.action_libraries.name, projections.action_libraries.version`
+	prepareActionLibrariesCols = []string{
+		"name",
+		"version",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+	}
+)
+
+func Test_ActionLibraryPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionLibraryQuery no result",
+			prepare: prepareActionLibraryQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionLibraryStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !caos_errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ActionLibrary)(nil),
+		},
+		{
+			name:    "prepareActionLibraryQuery found",
+			prepare: prepareActionLibraryQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareActionLibraryStmt),
+					prepareActionLibraryCols,
+					[]driver.Value{
+						"helpers",
+						uint64(2),
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211109),
+						"module.exports = {}",
+					},
+				),
+			},
+			object: &ActionLibrary{
+				Name:          "helpers",
+				Version:       2,
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211109,
+				Script:        "module.exports = {}",
+			},
+		},
+		{
+			name:    "prepareActionLibraryQuery sql err",
+			prepare: prepareActionLibraryQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionLibraryStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareActionLibrariesQuery no result",
+			prepare: prepareActionLibrariesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionLibrariesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*ActionLibrary{},
+		},
+		{
+			name:    "prepareActionLibrariesQuery multiple results",
+			prepare: prepareActionLibrariesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionLibrariesStmt),
+					prepareActionLibrariesCols,
+					[][]driver.Value{
+						{
+							"helpers",
+							uint64(1),
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+						},
+						{
+							"helpers",
+							uint64(2),
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211110),
+						},
+					},
+				),
+			},
+			object: []*ActionLibrary{
+				{
+					Name:          "helpers",
+					Version:       1,
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211109,
+				},
+				{
+					Name:          "helpers",
+					Version:       2,
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211110,
+				},
+			},
+		},
+		{
+			name:    "prepareActionLibrariesQuery sql err",
+			prepare: prepareActionLibrariesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionLibrariesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	actionSecretsTable = table{
+		name:          projection.ActionSecretTable,
+		instanceIDCol: projection.ActionSecretInstanceIDCol,
+	}
+	ActionSecretColumnName = Column{
+		name:  projection.ActionSecretNameCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnCreationDate = Column{
+		name:  projection.ActionSecretCreationDateCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnChangeDate = Column{
+		name:  projection.ActionSecretChangeDateCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnResourceOwner = Column{
+		name:  projection.ActionSecretResourceOwnerCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnInstanceID = Column{
+		name:  projection.ActionSecretInstanceIDCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnSequence = Column{
+		name:  projection.ActionSecretSequenceCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnValue = Column{
+		name:  projection.ActionSecretValueCol,
+		table: actionSecretsTable,
+	}
+	ActionSecretColumnOwnerRemoved = Column{
+		name:  projection.ActionSecretOwnerRemovedCol,
+		table: actionSecretsTable,
+	}
+)
+
+// ActionSecret describes a secret of the actions of an organisation,
+// the value is only readable by the actions
+type ActionSecret struct {
+	Name          string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+}
+
+// SearchActionSecrets returns the secrets of the organisation ordered by name
+func (q *Queries) SearchActionSecrets(ctx context.Context, orgID string, withOwnerRemoved bool) (_ []*ActionSecret, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		ActionSecretColumnResourceOwner.identifier(): orgID,
+		ActionSecretColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[ActionSecretColumnOwnerRemoved.identifier()] = false
+	}
+	query, scan := prepareActionSecretsQuery(ctx, q.client)
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ej5ma", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Qv8tc", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// GetActionSecretValue returns the encrypted value of the secret
+func (q *Queries) GetActionSecretValue(ctx context.Context, name, orgID string) (_ *crypto.CryptoValue, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareActionSecretValueQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		ActionSecretColumnName.identifier():          name,
+		ActionSecretColumnResourceOwner.identifier(): orgID,
+		ActionSecretColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		ActionSecretColumnOwnerRemoved.identifier():  false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Fo2we", "Errors.Query.InvalidRequest")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func prepareActionSecretsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*ActionSecret, error)) {
+	return sq.Select(
+			ActionSecretColumnName.identifier(),
+			ActionSecretColumnCreationDate.identifier(),
+			ActionSecretColumnChangeDate.identifier(),
+			ActionSecretColumnResourceOwner.identifier(),
+			ActionSecretColumnSequence.identifier(),
+		).
+			From(actionSecretsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			OrderBy(ActionSecretColumnName.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*ActionSecret, error) {
+			secrets := make([]*ActionSecret, 0)
+			for rows.Next() {
+				secret := new(ActionSecret)
+				err := rows.Scan(
+					&secret.Name,
+					&secret.CreationDate,
+					&secret.ChangeDate,
+					&secret.ResourceOwner,
+					&secret.Sequence,
+				)
+				if err != nil {
+					return nil, err
+				}
+				secrets = append(secrets, secret)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Wu3ax", "Errors.Query.CloseRows")
+			}
+
+			return secrets, nil
+		}
+}
+
+func prepareActionSecretValueQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*crypto.CryptoValue, error)) {
+	return sq.Select(
+			ActionSecretColumnValue.identifier(),
+		).
+			From(actionSecretsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*crypto.CryptoValue, error) {
+			value := new(crypto.CryptoValue)
+			err := row.Scan(value)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Bk9pe", "Errors.Action.Secret.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Xr4hj", "Errors.Internal")
+			}
+			return value, nil
+		}
+}
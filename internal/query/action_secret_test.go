@@ -0,0 +1,179 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareActionSecretsStmt = `SELECT projections.action_secrets.name,` +
+		` projections.action_secrets.creation_date,` +
+		` projections.action_secrets.change_date,` +
+		` projections.action_secrets.resource_owner,` +
+		` projections.action_secrets.sequence` +
+		` FROM projections.action_secrets AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.action_secrets.name`
+	prepareActionSecretsCols = []string{
+		"name",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+	}
+	prepareActionSecretValueStmt = `SELECT projections.action_secrets.value` +
+		` FROM projections.action_secrets AS OF SYSTEM TIME '-1 ms'`
+	prepareActionSecretValueCols = []string{
+		"value",
+	}
+)
+
+func Test_ActionSecretPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionSecretsQuery no result",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionSecretsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*ActionSecret{},
+		},
+		{
+			name:    "prepareActionSecretsQuery multiple results",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionSecretsStmt),
+					prepareActionSecretsCols,
+					[][]driver.Value{
+						{
+							"apiKey",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+						},
+						{
+							"token",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211110),
+						},
+					},
+				),
+			},
+			object: []*ActionSecret{
+				{
+					Name:          "apiKey",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211109,
+				},
+				{
+					Name:          "token",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211110,
+				},
+			},
+		},
+		{
+			name:    "prepareActionSecretsQuery sql err",
+			prepare: prepareActionSecretsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionSecretsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareActionSecretValueQuery no result",
+			prepare: prepareActionSecretValueQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionSecretValueStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !caos_errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*crypto.CryptoValue)(nil),
+		},
+		{
+			name:    "prepareActionSecretValueQuery found",
+			prepare: prepareActionSecretValueQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareActionSecretValueStmt),
+					prepareActionSecretValueCols,
+					[]driver.Value{
+						[]byte(`{"CryptoType":0,"Algorithm":"enc","KeyID":"id","Crypted":"c2VjcmV0"}`),
+					},
+				),
+			},
+			object: &crypto.CryptoValue{
+				CryptoType: crypto.TypeEncryption,
+				Algorithm:  "enc",
+				KeyID:      "id",
+				Crypted:    []byte("secret"),
+			},
+		},
+		{
+			name:    "prepareActionSecretValueQuery sql err",
+			prepare: prepareActionSecretValueQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionSecretValueStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	actionStorageTable = table{
+		name:          projection.ActionStorageTable,
+		instanceIDCol: projection.ActionStorageInstanceIDCol,
+	}
+	ActionStorageColumnKey = Column{
+		name:  projection.ActionStorageKeyCol,
+		table: actionStorageTable,
+	}
+	ActionStorageColumnCreationDate = Column{
+		name:  projection.ActionStorageCreationDateCol,
+		table: actionStorageTable,
+	}
+	ActionStorageColumnChangeDate = Column{
+		name:  projection.ActionStorageChangeDateCol,
+		table: actionStorageTable,
+	}
+	ActionStorageColumnResourceOwner = Column{
+		name:  projection.ActionStorageResourceOwnerCol,
+		table: actionStorageTable,
+	}
+	ActionStorageColumnInstanceID = Column{
+		name:  projection.ActionStorageInstanceIDCol,
+		table: actionStorageTable,
+	}
+	ActionStorageColumnSequence = Column{
+		name:  projection.ActionStorageSequenceCol,
+		table: actionStorageTable,
+	}
+	ActionStorageColumnValue = Column{
+		name:  projection.ActionStorageValueCol,
+		table: actionStorageTable,
+	}
+	ActionStorageColumnOwnerRemoved = Column{
+		name:  projection.ActionStorageOwnerRemovedCol,
+		table: actionStorageTable,
+	}
+)
+
+// ActionStorageValue is a value stored by the actions of an organisation
+type ActionStorageValue struct {
+	Key           string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	Value         string
+}
+
+// SearchActionStorage returns the values stored by the actions of the organisation ordered by key
+func (q *Queries) SearchActionStorage(ctx context.Context, orgID string, withOwnerRemoved bool) (_ []*ActionStorageValue, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		ActionStorageColumnResourceOwner.identifier(): orgID,
+		ActionStorageColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[ActionStorageColumnOwnerRemoved.identifier()] = false
+	}
+	query, scan := prepareActionStorageListQuery(ctx, q.client)
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ha7vu", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Tm2qi", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+func (q *Queries) GetActionStorageValue(ctx context.Context, key, orgID string) (_ *ActionStorageValue, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareActionStorageQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		ActionStorageColumnKey.identifier():           key,
+		ActionStorageColumnResourceOwner.identifier(): orgID,
+		ActionStorageColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+		ActionStorageColumnOwnerRemoved.identifier():  false,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Jd6sy", "Errors.Query.InvalidRequest")
+	}
+
+	row := q.client.QueryRowContext(ctx, stmt, args...)
+	return scan(row)
+}
+
+func prepareActionStorageQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*ActionStorageValue, error)) {
+	return sq.Select(
+			ActionStorageColumnKey.identifier(),
+			ActionStorageColumnCreationDate.identifier(),
+			ActionStorageColumnChangeDate.identifier(),
+			ActionStorageColumnResourceOwner.identifier(),
+			ActionStorageColumnSequence.identifier(),
+			ActionStorageColumnValue.identifier(),
+		).
+			From(actionStorageTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*ActionStorageValue, error) {
+			value := new(ActionStorageValue)
+			err := row.Scan(
+				&value.Key,
+				&value.CreationDate,
+				&value.ChangeDate,
+				&value.ResourceOwner,
+				&value.Sequence,
+				&value.Value,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ri5nf", "Errors.Action.Storage.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Mc1oz", "Errors.Internal")
+			}
+			return value, nil
+		}
+}
+
+func prepareActionStorageListQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*ActionStorageValue, error)) {
+	return sq.Select(
+			ActionStorageColumnKey.identifier(),
+			ActionStorageColumnCreationDate.identifier(),
+			ActionStorageColumnChangeDate.identifier(),
+			ActionStorageColumnResourceOwner.identifier(),
+			ActionStorageColumnSequence.identifier(),
+			ActionStorageColumnValue.identifier(),
+		).
+			From(actionStorageTable.identifier() + db.Timetravel(call.Took(ctx))).
+			OrderBy(ActionStorageColumnKey.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*ActionStorageValue, error) {
+			values := make([]*ActionStorageValue, 0)
+			for rows.Next() {
+				value := new(ActionStorageValue)
+				err := rows.Scan(
+					&value.Key,
+					&value.CreationDate,
+					&value.ChangeDate,
+					&value.ResourceOwner,
+					&value.Sequence,
+					&value.Value,
+				)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Pe8gl", "Errors.Query.CloseRows")
+			}
+
+			return values, nil
+		}
+}
@@ -0,0 +1,187 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareActionStorageStmt = `SELECT projections.action_storage.key,` +
+		` projections.action_storage.creation_date,` +
+		` projections.action_storage.change_date,` +
+		` projections.action_storage.resource_owner,` +
+		` projections.action_storage.sequence,` +
+		` projections.action_storage.value` +
+		` FROM projections.action_storage AS OF SYSTEM TIME '-1 ms'`
+	prepareActionStorageListStmt = prepareActionStorageStmt +
+		` ORDER BY projections.action_storage.key`
+	prepareActionStorageCols = []string{
+		"key",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"value",
+	}
+)
+
+func Test_ActionStoragePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionStorageQuery no result",
+			prepare: prepareActionStorageQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionStorageStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !caos_errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ActionStorageValue)(nil),
+		},
+		{
+			name:    "prepareActionStorageQuery found",
+			prepare: prepareActionStorageQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareActionStorageStmt),
+					prepareActionStorageCols,
+					[]driver.Value{
+						"counter",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211109),
+						"42",
+					},
+				),
+			},
+			object: &ActionStorageValue{
+				Key:           "counter",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211109,
+				Value:         "42",
+			},
+		},
+		{
+			name:    "prepareActionStorageQuery sql err",
+			prepare: prepareActionStorageQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionStorageStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareActionStorageListQuery no result",
+			prepare: prepareActionStorageListQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionStorageListStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*ActionStorageValue{},
+		},
+		{
+			name:    "prepareActionStorageListQuery multiple results",
+			prepare: prepareActionStorageListQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionStorageListStmt),
+					prepareActionStorageCols,
+					[][]driver.Value{
+						{
+							"counter",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							"42",
+						},
+						{
+							"last-sync",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211110),
+							"2021-11-10",
+						},
+					},
+				),
+			},
+			object: []*ActionStorageValue{
+				{
+					Key:           "counter",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211109,
+					Value:         "42",
+				},
+				{
+					Key:           "last-sync",
+					CreationDate:  testNow,
+					ChangeDate:    testNow,
+					ResourceOwner: "ro",
+					Sequence:      20211110,
+					Value:         "2021-11-10",
+				},
+			},
+		},
+		{
+			name:    "prepareActionStorageListQuery sql err",
+			prepare: prepareActionStorageListQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionStorageListStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
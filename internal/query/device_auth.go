@@ -0,0 +1,217 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	deviceAuthTable = table{
+		name:          projection.DeviceAuthTable,
+		instanceIDCol: projection.DeviceAuthInstanceIDCol,
+	}
+	DeviceAuthColumnID = Column{
+		name:  projection.DeviceAuthIDCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnCreationDate = Column{
+		name:  projection.DeviceAuthCreationDateCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnChangeDate = Column{
+		name:  projection.DeviceAuthChangeDateCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnInstanceID = Column{
+		name:  projection.DeviceAuthInstanceIDCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnSequence = Column{
+		name:  projection.DeviceAuthSequenceCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnState = Column{
+		name:  projection.DeviceAuthStateCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnClientID = Column{
+		name:  projection.DeviceAuthClientIDCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnDeviceCodeHash = Column{
+		name:  projection.DeviceAuthDeviceCodeHashCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnUserCode = Column{
+		name:  projection.DeviceAuthUserCodeCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnExpires = Column{
+		name:  projection.DeviceAuthExpiresCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnScopes = Column{
+		name:  projection.DeviceAuthScopesCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnUserID = Column{
+		name:  projection.DeviceAuthUserIDCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnUserOrgID = Column{
+		name:  projection.DeviceAuthUserOrgIDCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnUserAgentID = Column{
+		name:  projection.DeviceAuthUserAgentIDCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnAudience = Column{
+		name:  projection.DeviceAuthAudienceCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnAuthMethodsReferences = Column{
+		name:  projection.DeviceAuthAuthMethodsReferencesCol,
+		table: deviceAuthTable,
+	}
+	DeviceAuthColumnAuthTime = Column{
+		name:  projection.DeviceAuthAuthTimeCol,
+		table: deviceAuthTable,
+	}
+)
+
+type DeviceAuth struct {
+	ID           string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	State        domain.DeviceAuthState
+
+	ClientID string
+	UserCode string
+	Expires  time.Time
+	Scopes   database.StringArray
+
+	UserID                string
+	UserOrgID             string
+	UserAgentID           string
+	Audience              database.StringArray
+	AuthMethodsReferences database.StringArray
+	AuthTime              time.Time
+}
+
+// Expired returns if the device authorization can no longer be approved or used to retrieve tokens
+func (d *DeviceAuth) Expired() bool {
+	return d.Expires.Before(time.Now())
+}
+
+// DeviceAuthByDeviceCode returns the device authorization of the client by the (hash of the) device code,
+// which is used by the device to poll the token endpoint
+func (q *Queries) DeviceAuthByDeviceCode(ctx context.Context, shouldTriggerBulk bool, clientID, deviceCode string) (_ *DeviceAuth, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.deviceAuth(ctx, shouldTriggerBulk, sq.Eq{
+		DeviceAuthColumnClientID.identifier():       clientID,
+		DeviceAuthColumnDeviceCodeHash.identifier(): domain.DeviceCodeHash(deviceCode),
+		DeviceAuthColumnInstanceID.identifier():     authz.GetInstance(ctx).InstanceID(),
+	})
+}
+
+// DeviceAuthByUserCode returns the device authorization by the user code,
+// which is entered by the user on the login UI
+func (q *Queries) DeviceAuthByUserCode(ctx context.Context, shouldTriggerBulk bool, userCode string) (_ *DeviceAuth, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.deviceAuth(ctx, shouldTriggerBulk, sq.Eq{
+		DeviceAuthColumnUserCode.identifier():   userCode,
+		DeviceAuthColumnState.identifier():      domain.DeviceAuthStateInitiated,
+		DeviceAuthColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	})
+}
+
+func (q *Queries) deviceAuth(ctx context.Context, shouldTriggerBulk bool, eq sq.Eq) (*DeviceAuth, error) {
+	if shouldTriggerBulk {
+		projection.DeviceAuthProjection.Trigger(ctx)
+	}
+
+	stmt, scan := prepareDeviceAuthQuery(ctx, q.client)
+	query, args, err := stmt.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ne8sa", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareDeviceAuthQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*DeviceAuth, error)) {
+	return sq.Select(
+			DeviceAuthColumnID.identifier(),
+			DeviceAuthColumnCreationDate.identifier(),
+			DeviceAuthColumnChangeDate.identifier(),
+			DeviceAuthColumnSequence.identifier(),
+			DeviceAuthColumnState.identifier(),
+			DeviceAuthColumnClientID.identifier(),
+			DeviceAuthColumnUserCode.identifier(),
+			DeviceAuthColumnExpires.identifier(),
+			DeviceAuthColumnScopes.identifier(),
+			DeviceAuthColumnUserID.identifier(),
+			DeviceAuthColumnUserOrgID.identifier(),
+			DeviceAuthColumnUserAgentID.identifier(),
+			DeviceAuthColumnAudience.identifier(),
+			DeviceAuthColumnAuthMethodsReferences.identifier(),
+			DeviceAuthColumnAuthTime.identifier(),
+		).From(deviceAuthTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*DeviceAuth, error) {
+			deviceAuth := new(DeviceAuth)
+			var (
+				userID      sql.NullString
+				userOrgID   sql.NullString
+				userAgentID sql.NullString
+				authTime    sql.NullTime
+			)
+			err := row.Scan(
+				&deviceAuth.ID,
+				&deviceAuth.CreationDate,
+				&deviceAuth.ChangeDate,
+				&deviceAuth.Sequence,
+				&deviceAuth.State,
+				&deviceAuth.ClientID,
+				&deviceAuth.UserCode,
+				&deviceAuth.Expires,
+				&deviceAuth.Scopes,
+				&userID,
+				&userOrgID,
+				&userAgentID,
+				&deviceAuth.Audience,
+				&deviceAuth.AuthMethodsReferences,
+				&authTime,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ps9dk", "Errors.DeviceAuth.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Zk3wa", "Errors.Internal")
+			}
+			deviceAuth.UserID = userID.String
+			deviceAuth.UserOrgID = userOrgID.String
+			deviceAuth.UserAgentID = userAgentID.String
+			deviceAuth.AuthTime = authTime.Time
+			return deviceAuth, nil
+		}
+}
@@ -0,0 +1,187 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareDeviceAuthStmt = `SELECT projections.device_authorizations.id,` +
+		` projections.device_authorizations.creation_date,` +
+		` projections.device_authorizations.change_date,` +
+		` projections.device_authorizations.sequence,` +
+		` projections.device_authorizations.state,` +
+		` projections.device_authorizations.client_id,` +
+		` projections.device_authorizations.user_code,` +
+		` projections.device_authorizations.expires,` +
+		` projections.device_authorizations.scopes,` +
+		` projections.device_authorizations.user_id,` +
+		` projections.device_authorizations.user_org_id,` +
+		` projections.device_authorizations.user_agent_id,` +
+		` projections.device_authorizations.audience,` +
+		` projections.device_authorizations.auth_methods_references,` +
+		` projections.device_authorizations.auth_time` +
+		` FROM projections.device_authorizations` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareDeviceAuthCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"state",
+		"client_id",
+		"user_code",
+		"expires",
+		"scopes",
+		"user_id",
+		"user_org_id",
+		"user_agent_id",
+		"audience",
+		"auth_methods_references",
+		"auth_time",
+	}
+)
+
+func Test_DeviceAuthPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareDeviceAuthQuery no result",
+			prepare: prepareDeviceAuthQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareDeviceAuthStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*DeviceAuth)(nil),
+		},
+		{
+			name:    "prepareDeviceAuthQuery initiated",
+			prepare: prepareDeviceAuthQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareDeviceAuthStmt),
+					prepareDeviceAuthCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.DeviceAuthStateInitiated,
+						"client-id",
+						"ABCD-EFGH",
+						testNow,
+						database.StringArray{"openid"},
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &DeviceAuth{
+				ID:           "id",
+				CreationDate: testNow,
+				ChangeDate:   testNow,
+				Sequence:     20211109,
+				State:        domain.DeviceAuthStateInitiated,
+				ClientID:     "client-id",
+				UserCode:     "ABCD-EFGH",
+				Expires:      testNow,
+				Scopes:       database.StringArray{"openid"},
+			},
+		},
+		{
+			name:    "prepareDeviceAuthQuery approved",
+			prepare: prepareDeviceAuthQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareDeviceAuthStmt),
+					prepareDeviceAuthCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.DeviceAuthStateApproved,
+						"client-id",
+						"ABCD-EFGH",
+						testNow,
+						database.StringArray{"openid"},
+						"user-id",
+						"org-id",
+						"agent-id",
+						database.StringArray{"client-id", "project-id"},
+						database.StringArray{"pwd"},
+						testNow,
+					},
+				),
+			},
+			object: &DeviceAuth{
+				ID:                    "id",
+				CreationDate:          testNow,
+				ChangeDate:            testNow,
+				Sequence:              20211109,
+				State:                 domain.DeviceAuthStateApproved,
+				ClientID:              "client-id",
+				UserCode:              "ABCD-EFGH",
+				Expires:               testNow,
+				Scopes:                database.StringArray{"openid"},
+				UserID:                "user-id",
+				UserOrgID:             "org-id",
+				UserAgentID:           "agent-id",
+				Audience:              database.StringArray{"client-id", "project-id"},
+				AuthMethodsReferences: database.StringArray{"pwd"},
+				AuthTime:              testNow,
+			},
+		},
+		{
+			name:    "prepareDeviceAuthQuery sql err",
+			prepare: prepareDeviceAuthQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareDeviceAuthStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
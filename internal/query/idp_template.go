@@ -44,6 +44,8 @@ type IDPTemplate struct {
 	*GitLabSelfHostedIDPTemplate
 	*GoogleIDPTemplate
 	*LDAPIDPTemplate
+	*SAMLIDPTemplate
+	*AppleIDPTemplate
 }
 
 type IDPTemplates struct {
@@ -140,6 +142,24 @@ type LDAPIDPTemplate struct {
 	idp.LDAPAttributes
 }
 
+type SAMLIDPTemplate struct {
+	IDPID             string
+	Metadata          []byte
+	Key               *crypto.CryptoValue
+	Certificate       []byte
+	Binding           string
+	WithSignedRequest bool
+}
+
+type AppleIDPTemplate struct {
+	IDPID      string
+	ClientID   string
+	TeamID     string
+	KeyID      string
+	PrivateKey *crypto.CryptoValue
+	Scopes     database.StringArray
+}
+
 var (
 	idpTemplateTable = table{
 		name:          projection.IDPTemplateTable,
@@ -601,6 +621,76 @@ var (
 	}
 )
 
+var (
+	samlIdpTemplateTable = table{
+		name:          projection.IDPTemplateSAMLTable,
+		instanceIDCol: projection.IDPTemplateInstanceIDCol,
+	}
+	SAMLIDCol = Column{
+		name:  projection.SAMLIDCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLInstanceIDCol = Column{
+		name:  projection.SAMLInstanceIDCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLMetadataCol = Column{
+		name:  projection.SAMLMetadataCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLKeyCol = Column{
+		name:  projection.SAMLKeyCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLCertificateCol = Column{
+		name:  projection.SAMLCertificateCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLBindingCol = Column{
+		name:  projection.SAMLBindingCol,
+		table: samlIdpTemplateTable,
+	}
+	SAMLWithSignedRequestCol = Column{
+		name:  projection.SAMLWithSignedRequestCol,
+		table: samlIdpTemplateTable,
+	}
+)
+
+var (
+	appleIdpTemplateTable = table{
+		name:          projection.IDPTemplateAppleTable,
+		instanceIDCol: projection.IDPTemplateInstanceIDCol,
+	}
+	AppleIDCol = Column{
+		name:  projection.AppleIDCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleInstanceIDCol = Column{
+		name:  projection.AppleInstanceIDCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleClientIDCol = Column{
+		name:  projection.AppleClientIDCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleTeamIDCol = Column{
+		name:  projection.AppleTeamIDCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleKeyIDCol = Column{
+		name:  projection.AppleKeyIDCol,
+		table: appleIdpTemplateTable,
+	}
+	ApplePrivateKeyCol = Column{
+		name:  projection.ApplePrivateKeyCol,
+		table: appleIdpTemplateTable,
+	}
+	AppleScopesCol = Column{
+		name:  projection.AppleScopesCol,
+		table: appleIdpTemplateTable,
+	}
+)
+
 // IDPTemplateByID searches for the requested id
 func (q *Queries) IDPTemplateByID(ctx context.Context, shouldTriggerBulk bool, id string, withOwnerRemoved bool, queries ...SearchQuery) (_ *IDPTemplate, err error) {
 	ctx, span := tracing.NewSpan(ctx)
@@ -793,6 +883,18 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			LDAPPreferredLanguageAttributeCol.identifier(),
 			LDAPAvatarURLAttributeCol.identifier(),
 			LDAPProfileAttributeCol.identifier(),
+			SAMLIDCol.identifier(),
+			SAMLMetadataCol.identifier(),
+			SAMLKeyCol.identifier(),
+			SAMLCertificateCol.identifier(),
+			SAMLBindingCol.identifier(),
+			SAMLWithSignedRequestCol.identifier(),
+			AppleIDCol.identifier(),
+			AppleClientIDCol.identifier(),
+			AppleTeamIDCol.identifier(),
+			AppleKeyIDCol.identifier(),
+			ApplePrivateKeyCol.identifier(),
+			AppleScopesCol.identifier(),
 		).From(idpTemplateTable.identifier()).
 			LeftJoin(join(OAuthIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(OIDCIDCol, IDPTemplateIDCol)).
@@ -803,7 +905,9 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			LeftJoin(join(GitLabIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GitLabSelfHostedIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
-			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(AppleIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*IDPTemplate, error) {
 			idpTemplate := new(IDPTemplate)
@@ -891,6 +995,20 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 			ldapAvatarURLAttribute := sql.NullString{}
 			ldapProfileAttribute := sql.NullString{}
 
+			samlID := sql.NullString{}
+			var samlMetadata []byte
+			samlKey := new(crypto.CryptoValue)
+			var samlCertificate []byte
+			samlBinding := sql.NullString{}
+			samlWithSignedRequest := sql.NullBool{}
+
+			appleID := sql.NullString{}
+			appleClientID := sql.NullString{}
+			appleTeamID := sql.NullString{}
+			appleKeyID := sql.NullString{}
+			applePrivateKey := new(crypto.CryptoValue)
+			appleScopes := database.StringArray{}
+
 			err := row.Scan(
 				&idpTemplate.ID,
 				&idpTemplate.ResourceOwner,
@@ -986,6 +1104,20 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 				&ldapPreferredLanguageAttribute,
 				&ldapAvatarURLAttribute,
 				&ldapProfileAttribute,
+				// saml
+				&samlID,
+				&samlMetadata,
+				&samlKey,
+				&samlCertificate,
+				&samlBinding,
+				&samlWithSignedRequest,
+				// apple
+				&appleID,
+				&appleClientID,
+				&appleTeamID,
+				&appleKeyID,
+				&applePrivateKey,
+				&appleScopes,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -1109,6 +1241,26 @@ func prepareIDPTemplateByIDQuery(ctx context.Context, db prepareDatabase) (sq.Se
 					},
 				}
 			}
+			if samlID.Valid {
+				idpTemplate.SAMLIDPTemplate = &SAMLIDPTemplate{
+					IDPID:             samlID.String,
+					Metadata:          samlMetadata,
+					Key:               samlKey,
+					Certificate:       samlCertificate,
+					Binding:           samlBinding.String,
+					WithSignedRequest: samlWithSignedRequest.Bool,
+				}
+			}
+			if appleID.Valid {
+				idpTemplate.AppleIDPTemplate = &AppleIDPTemplate{
+					IDPID:      appleID.String,
+					ClientID:   appleClientID.String,
+					TeamID:     appleTeamID.String,
+					KeyID:      appleKeyID.String,
+					PrivateKey: applePrivateKey,
+					Scopes:     appleScopes,
+				}
+			}
 
 			return idpTemplate, nil
 		}
@@ -1210,6 +1362,18 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			LDAPPreferredLanguageAttributeCol.identifier(),
 			LDAPAvatarURLAttributeCol.identifier(),
 			LDAPProfileAttributeCol.identifier(),
+			SAMLIDCol.identifier(),
+			SAMLMetadataCol.identifier(),
+			SAMLKeyCol.identifier(),
+			SAMLCertificateCol.identifier(),
+			SAMLBindingCol.identifier(),
+			SAMLWithSignedRequestCol.identifier(),
+			AppleIDCol.identifier(),
+			AppleClientIDCol.identifier(),
+			AppleTeamIDCol.identifier(),
+			AppleKeyIDCol.identifier(),
+			ApplePrivateKeyCol.identifier(),
+			AppleScopesCol.identifier(),
 			countColumn.identifier(),
 		).From(idpTemplateTable.identifier()).
 			LeftJoin(join(OAuthIDCol, IDPTemplateIDCol)).
@@ -1221,7 +1385,9 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			LeftJoin(join(GitLabIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GitLabSelfHostedIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
-			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(AppleIDCol, IDPTemplateIDCol) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*IDPTemplates, error) {
 			templates := make([]*IDPTemplate, 0)
@@ -1312,6 +1478,20 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				ldapAvatarURLAttribute := sql.NullString{}
 				ldapProfileAttribute := sql.NullString{}
 
+				samlID := sql.NullString{}
+				var samlMetadata []byte
+				samlKey := new(crypto.CryptoValue)
+				var samlCertificate []byte
+				samlBinding := sql.NullString{}
+				samlWithSignedRequest := sql.NullBool{}
+
+				appleID := sql.NullString{}
+				appleClientID := sql.NullString{}
+				appleTeamID := sql.NullString{}
+				appleKeyID := sql.NullString{}
+				applePrivateKey := new(crypto.CryptoValue)
+				appleScopes := database.StringArray{}
+
 				err := rows.Scan(
 					&idpTemplate.ID,
 					&idpTemplate.ResourceOwner,
@@ -1407,6 +1587,20 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&ldapPreferredLanguageAttribute,
 					&ldapAvatarURLAttribute,
 					&ldapProfileAttribute,
+					// saml
+					&samlID,
+					&samlMetadata,
+					&samlKey,
+					&samlCertificate,
+					&samlBinding,
+					&samlWithSignedRequest,
+					// apple
+					&appleID,
+					&appleClientID,
+					&appleTeamID,
+					&appleKeyID,
+					&applePrivateKey,
+					&appleScopes,
 					&count,
 				)
 
@@ -1529,6 +1723,26 @@ func prepareIDPTemplatesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 						},
 					}
 				}
+				if samlID.Valid {
+					idpTemplate.SAMLIDPTemplate = &SAMLIDPTemplate{
+						IDPID:             samlID.String,
+						Metadata:          samlMetadata,
+						Key:               samlKey,
+						Certificate:       samlCertificate,
+						Binding:           samlBinding.String,
+						WithSignedRequest: samlWithSignedRequest.Bool,
+					}
+				}
+				if appleID.Valid {
+					idpTemplate.AppleIDPTemplate = &AppleIDPTemplate{
+						IDPID:      appleID.String,
+						ClientID:   appleClientID.String,
+						TeamID:     appleTeamID.String,
+						KeyID:      appleKeyID.String,
+						PrivateKey: applePrivateKey,
+						Scopes:     appleScopes,
+					}
+				}
 				templates = append(templates, idpTemplate)
 			}
 
@@ -15,111 +15,127 @@ import (
 )
 
 var (
-	idpTemplateQuery = `SELECT projections.idp_templates4.id,` +
-		` projections.idp_templates4.resource_owner,` +
-		` projections.idp_templates4.creation_date,` +
-		` projections.idp_templates4.change_date,` +
-		` projections.idp_templates4.sequence,` +
-		` projections.idp_templates4.state,` +
-		` projections.idp_templates4.name,` +
-		` projections.idp_templates4.type,` +
-		` projections.idp_templates4.owner_type,` +
-		` projections.idp_templates4.is_creation_allowed,` +
-		` projections.idp_templates4.is_linking_allowed,` +
-		` projections.idp_templates4.is_auto_creation,` +
-		` projections.idp_templates4.is_auto_update,` +
+	idpTemplateQuery = `SELECT projections.idp_templates6.id,` +
+		` projections.idp_templates6.resource_owner,` +
+		` projections.idp_templates6.creation_date,` +
+		` projections.idp_templates6.change_date,` +
+		` projections.idp_templates6.sequence,` +
+		` projections.idp_templates6.state,` +
+		` projections.idp_templates6.name,` +
+		` projections.idp_templates6.type,` +
+		` projections.idp_templates6.owner_type,` +
+		` projections.idp_templates6.is_creation_allowed,` +
+		` projections.idp_templates6.is_linking_allowed,` +
+		` projections.idp_templates6.is_auto_creation,` +
+		` projections.idp_templates6.is_auto_update,` +
 		// oauth
-		` projections.idp_templates4_oauth2.idp_id,` +
-		` projections.idp_templates4_oauth2.client_id,` +
-		` projections.idp_templates4_oauth2.client_secret,` +
-		` projections.idp_templates4_oauth2.authorization_endpoint,` +
-		` projections.idp_templates4_oauth2.token_endpoint,` +
-		` projections.idp_templates4_oauth2.user_endpoint,` +
-		` projections.idp_templates4_oauth2.scopes,` +
-		` projections.idp_templates4_oauth2.id_attribute,` +
+		` projections.idp_templates6_oauth2.idp_id,` +
+		` projections.idp_templates6_oauth2.client_id,` +
+		` projections.idp_templates6_oauth2.client_secret,` +
+		` projections.idp_templates6_oauth2.authorization_endpoint,` +
+		` projections.idp_templates6_oauth2.token_endpoint,` +
+		` projections.idp_templates6_oauth2.user_endpoint,` +
+		` projections.idp_templates6_oauth2.scopes,` +
+		` projections.idp_templates6_oauth2.id_attribute,` +
 		// oidc
-		` projections.idp_templates4_oidc.idp_id,` +
-		` projections.idp_templates4_oidc.issuer,` +
-		` projections.idp_templates4_oidc.client_id,` +
-		` projections.idp_templates4_oidc.client_secret,` +
-		` projections.idp_templates4_oidc.scopes,` +
-		` projections.idp_templates4_oidc.id_token_mapping,` +
+		` projections.idp_templates6_oidc.idp_id,` +
+		` projections.idp_templates6_oidc.issuer,` +
+		` projections.idp_templates6_oidc.client_id,` +
+		` projections.idp_templates6_oidc.client_secret,` +
+		` projections.idp_templates6_oidc.scopes,` +
+		` projections.idp_templates6_oidc.id_token_mapping,` +
 		// jwt
-		` projections.idp_templates4_jwt.idp_id,` +
-		` projections.idp_templates4_jwt.issuer,` +
-		` projections.idp_templates4_jwt.jwt_endpoint,` +
-		` projections.idp_templates4_jwt.keys_endpoint,` +
-		` projections.idp_templates4_jwt.header_name,` +
+		` projections.idp_templates6_jwt.idp_id,` +
+		` projections.idp_templates6_jwt.issuer,` +
+		` projections.idp_templates6_jwt.jwt_endpoint,` +
+		` projections.idp_templates6_jwt.keys_endpoint,` +
+		` projections.idp_templates6_jwt.header_name,` +
 		// azure
-		` projections.idp_templates4_azure.idp_id,` +
-		` projections.idp_templates4_azure.client_id,` +
-		` projections.idp_templates4_azure.client_secret,` +
-		` projections.idp_templates4_azure.scopes,` +
-		` projections.idp_templates4_azure.tenant,` +
-		` projections.idp_templates4_azure.is_email_verified,` +
+		` projections.idp_templates6_azure.idp_id,` +
+		` projections.idp_templates6_azure.client_id,` +
+		` projections.idp_templates6_azure.client_secret,` +
+		` projections.idp_templates6_azure.scopes,` +
+		` projections.idp_templates6_azure.tenant,` +
+		` projections.idp_templates6_azure.is_email_verified,` +
 		// github
-		` projections.idp_templates4_github.idp_id,` +
-		` projections.idp_templates4_github.client_id,` +
-		` projections.idp_templates4_github.client_secret,` +
-		` projections.idp_templates4_github.scopes,` +
+		` projections.idp_templates6_github.idp_id,` +
+		` projections.idp_templates6_github.client_id,` +
+		` projections.idp_templates6_github.client_secret,` +
+		` projections.idp_templates6_github.scopes,` +
 		// github enterprise
-		` projections.idp_templates4_github_enterprise.idp_id,` +
-		` projections.idp_templates4_github_enterprise.client_id,` +
-		` projections.idp_templates4_github_enterprise.client_secret,` +
-		` projections.idp_templates4_github_enterprise.authorization_endpoint,` +
-		` projections.idp_templates4_github_enterprise.token_endpoint,` +
-		` projections.idp_templates4_github_enterprise.user_endpoint,` +
-		` projections.idp_templates4_github_enterprise.scopes,` +
+		` projections.idp_templates6_github_enterprise.idp_id,` +
+		` projections.idp_templates6_github_enterprise.client_id,` +
+		` projections.idp_templates6_github_enterprise.client_secret,` +
+		` projections.idp_templates6_github_enterprise.authorization_endpoint,` +
+		` projections.idp_templates6_github_enterprise.token_endpoint,` +
+		` projections.idp_templates6_github_enterprise.user_endpoint,` +
+		` projections.idp_templates6_github_enterprise.scopes,` +
 		// gitlab
-		` projections.idp_templates4_gitlab.idp_id,` +
-		` projections.idp_templates4_gitlab.client_id,` +
-		` projections.idp_templates4_gitlab.client_secret,` +
-		` projections.idp_templates4_gitlab.scopes,` +
+		` projections.idp_templates6_gitlab.idp_id,` +
+		` projections.idp_templates6_gitlab.client_id,` +
+		` projections.idp_templates6_gitlab.client_secret,` +
+		` projections.idp_templates6_gitlab.scopes,` +
 		// gitlab self hosted
-		` projections.idp_templates4_gitlab_self_hosted.idp_id,` +
-		` projections.idp_templates4_gitlab_self_hosted.issuer,` +
-		` projections.idp_templates4_gitlab_self_hosted.client_id,` +
-		` projections.idp_templates4_gitlab_self_hosted.client_secret,` +
-		` projections.idp_templates4_gitlab_self_hosted.scopes,` +
+		` projections.idp_templates6_gitlab_self_hosted.idp_id,` +
+		` projections.idp_templates6_gitlab_self_hosted.issuer,` +
+		` projections.idp_templates6_gitlab_self_hosted.client_id,` +
+		` projections.idp_templates6_gitlab_self_hosted.client_secret,` +
+		` projections.idp_templates6_gitlab_self_hosted.scopes,` +
 		// google
-		` projections.idp_templates4_google.idp_id,` +
-		` projections.idp_templates4_google.client_id,` +
-		` projections.idp_templates4_google.client_secret,` +
-		` projections.idp_templates4_google.scopes,` +
+		` projections.idp_templates6_google.idp_id,` +
+		` projections.idp_templates6_google.client_id,` +
+		` projections.idp_templates6_google.client_secret,` +
+		` projections.idp_templates6_google.scopes,` +
 		// ldap
-		` projections.idp_templates4_ldap.idp_id,` +
-		` projections.idp_templates4_ldap.host,` +
-		` projections.idp_templates4_ldap.port,` +
-		` projections.idp_templates4_ldap.tls,` +
-		` projections.idp_templates4_ldap.base_dn,` +
-		` projections.idp_templates4_ldap.user_object_class,` +
-		` projections.idp_templates4_ldap.user_unique_attribute,` +
-		` projections.idp_templates4_ldap.admin,` +
-		` projections.idp_templates4_ldap.password,` +
-		` projections.idp_templates4_ldap.id_attribute,` +
-		` projections.idp_templates4_ldap.first_name_attribute,` +
-		` projections.idp_templates4_ldap.last_name_attribute,` +
-		` projections.idp_templates4_ldap.display_name_attribute,` +
-		` projections.idp_templates4_ldap.nick_name_attribute,` +
-		` projections.idp_templates4_ldap.preferred_username_attribute,` +
-		` projections.idp_templates4_ldap.email_attribute,` +
-		` projections.idp_templates4_ldap.email_verified,` +
-		` projections.idp_templates4_ldap.phone_attribute,` +
-		` projections.idp_templates4_ldap.phone_verified_attribute,` +
-		` projections.idp_templates4_ldap.preferred_language_attribute,` +
-		` projections.idp_templates4_ldap.avatar_url_attribute,` +
-		` projections.idp_templates4_ldap.profile_attribute` +
-		` FROM projections.idp_templates4` +
-		` LEFT JOIN projections.idp_templates4_oauth2 ON projections.idp_templates4.id = projections.idp_templates4_oauth2.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_oauth2.instance_id` +
-		` LEFT JOIN projections.idp_templates4_oidc ON projections.idp_templates4.id = projections.idp_templates4_oidc.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_oidc.instance_id` +
-		` LEFT JOIN projections.idp_templates4_jwt ON projections.idp_templates4.id = projections.idp_templates4_jwt.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_jwt.instance_id` +
-		` LEFT JOIN projections.idp_templates4_azure ON projections.idp_templates4.id = projections.idp_templates4_azure.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_azure.instance_id` +
-		` LEFT JOIN projections.idp_templates4_github ON projections.idp_templates4.id = projections.idp_templates4_github.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_github.instance_id` +
-		` LEFT JOIN projections.idp_templates4_github_enterprise ON projections.idp_templates4.id = projections.idp_templates4_github_enterprise.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_github_enterprise.instance_id` +
-		` LEFT JOIN projections.idp_templates4_gitlab ON projections.idp_templates4.id = projections.idp_templates4_gitlab.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_gitlab.instance_id` +
-		` LEFT JOIN projections.idp_templates4_gitlab_self_hosted ON projections.idp_templates4.id = projections.idp_templates4_gitlab_self_hosted.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_gitlab_self_hosted.instance_id` +
-		` LEFT JOIN projections.idp_templates4_google ON projections.idp_templates4.id = projections.idp_templates4_google.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_google.instance_id` +
-		` LEFT JOIN projections.idp_templates4_ldap ON projections.idp_templates4.id = projections.idp_templates4_ldap.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_ldap.instance_id` +
+		` projections.idp_templates6_ldap.idp_id,` +
+		` projections.idp_templates6_ldap.host,` +
+		` projections.idp_templates6_ldap.port,` +
+		` projections.idp_templates6_ldap.tls,` +
+		` projections.idp_templates6_ldap.base_dn,` +
+		` projections.idp_templates6_ldap.user_object_class,` +
+		` projections.idp_templates6_ldap.user_unique_attribute,` +
+		` projections.idp_templates6_ldap.admin,` +
+		` projections.idp_templates6_ldap.password,` +
+		` projections.idp_templates6_ldap.id_attribute,` +
+		` projections.idp_templates6_ldap.first_name_attribute,` +
+		` projections.idp_templates6_ldap.last_name_attribute,` +
+		` projections.idp_templates6_ldap.display_name_attribute,` +
+		` projections.idp_templates6_ldap.nick_name_attribute,` +
+		` projections.idp_templates6_ldap.preferred_username_attribute,` +
+		` projections.idp_templates6_ldap.email_attribute,` +
+		` projections.idp_templates6_ldap.email_verified,` +
+		` projections.idp_templates6_ldap.phone_attribute,` +
+		` projections.idp_templates6_ldap.phone_verified_attribute,` +
+		` projections.idp_templates6_ldap.preferred_language_attribute,` +
+		` projections.idp_templates6_ldap.avatar_url_attribute,` +
+		` projections.idp_templates6_ldap.profile_attribute,` +
+		// saml
+		` projections.idp_templates6_saml.idp_id,` +
+		` projections.idp_templates6_saml.metadata,` +
+		` projections.idp_templates6_saml.key,` +
+		` projections.idp_templates6_saml.certificate,` +
+		` projections.idp_templates6_saml.binding,` +
+		` projections.idp_templates6_saml.with_signed_request,` +
+		// apple
+		` projections.idp_templates6_apple.idp_id,` +
+		` projections.idp_templates6_apple.client_id,` +
+		` projections.idp_templates6_apple.team_id,` +
+		` projections.idp_templates6_apple.key_id,` +
+		` projections.idp_templates6_apple.private_key,` +
+		` projections.idp_templates6_apple.scopes` +
+		` FROM projections.idp_templates6` +
+		` LEFT JOIN projections.idp_templates6_oauth2 ON projections.idp_templates6.id = projections.idp_templates6_oauth2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oauth2.instance_id` +
+		` LEFT JOIN projections.idp_templates6_oidc ON projections.idp_templates6.id = projections.idp_templates6_oidc.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oidc.instance_id` +
+		` LEFT JOIN projections.idp_templates6_jwt ON projections.idp_templates6.id = projections.idp_templates6_jwt.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_jwt.instance_id` +
+		` LEFT JOIN projections.idp_templates6_azure ON projections.idp_templates6.id = projections.idp_templates6_azure.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_azure.instance_id` +
+		` LEFT JOIN projections.idp_templates6_github ON projections.idp_templates6.id = projections.idp_templates6_github.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github.instance_id` +
+		` LEFT JOIN projections.idp_templates6_github_enterprise ON projections.idp_templates6.id = projections.idp_templates6_github_enterprise.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github_enterprise.instance_id` +
+		` LEFT JOIN projections.idp_templates6_gitlab ON projections.idp_templates6.id = projections.idp_templates6_gitlab.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab.instance_id` +
+		` LEFT JOIN projections.idp_templates6_gitlab_self_hosted ON projections.idp_templates6.id = projections.idp_templates6_gitlab_self_hosted.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab_self_hosted.instance_id` +
+		` LEFT JOIN projections.idp_templates6_google ON projections.idp_templates6.id = projections.idp_templates6_google.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_google.instance_id` +
+		` LEFT JOIN projections.idp_templates6_ldap ON projections.idp_templates6.id = projections.idp_templates6_ldap.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap.instance_id` +
+		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
+		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	idpTemplateCols = []string{
 		"id",
@@ -216,113 +232,143 @@ var (
 		"preferred_language_attribute",
 		"avatar_url_attribute",
 		"profile_attribute",
+		// saml config
+		"idp_id",
+		"metadata",
+		"key",
+		"certificate",
+		"binding",
+		"with_signed_request",
+		// apple config
+		"idp_id",
+		"client_id",
+		"team_id",
+		"key_id",
+		"private_key",
+		"scopes",
 	}
-	idpTemplatesQuery = `SELECT projections.idp_templates4.id,` +
-		` projections.idp_templates4.resource_owner,` +
-		` projections.idp_templates4.creation_date,` +
-		` projections.idp_templates4.change_date,` +
-		` projections.idp_templates4.sequence,` +
-		` projections.idp_templates4.state,` +
-		` projections.idp_templates4.name,` +
-		` projections.idp_templates4.type,` +
-		` projections.idp_templates4.owner_type,` +
-		` projections.idp_templates4.is_creation_allowed,` +
-		` projections.idp_templates4.is_linking_allowed,` +
-		` projections.idp_templates4.is_auto_creation,` +
-		` projections.idp_templates4.is_auto_update,` +
+	idpTemplatesQuery = `SELECT projections.idp_templates6.id,` +
+		` projections.idp_templates6.resource_owner,` +
+		` projections.idp_templates6.creation_date,` +
+		` projections.idp_templates6.change_date,` +
+		` projections.idp_templates6.sequence,` +
+		` projections.idp_templates6.state,` +
+		` projections.idp_templates6.name,` +
+		` projections.idp_templates6.type,` +
+		` projections.idp_templates6.owner_type,` +
+		` projections.idp_templates6.is_creation_allowed,` +
+		` projections.idp_templates6.is_linking_allowed,` +
+		` projections.idp_templates6.is_auto_creation,` +
+		` projections.idp_templates6.is_auto_update,` +
 		// oauth
-		` projections.idp_templates4_oauth2.idp_id,` +
-		` projections.idp_templates4_oauth2.client_id,` +
-		` projections.idp_templates4_oauth2.client_secret,` +
-		` projections.idp_templates4_oauth2.authorization_endpoint,` +
-		` projections.idp_templates4_oauth2.token_endpoint,` +
-		` projections.idp_templates4_oauth2.user_endpoint,` +
-		` projections.idp_templates4_oauth2.scopes,` +
-		` projections.idp_templates4_oauth2.id_attribute,` +
+		` projections.idp_templates6_oauth2.idp_id,` +
+		` projections.idp_templates6_oauth2.client_id,` +
+		` projections.idp_templates6_oauth2.client_secret,` +
+		` projections.idp_templates6_oauth2.authorization_endpoint,` +
+		` projections.idp_templates6_oauth2.token_endpoint,` +
+		` projections.idp_templates6_oauth2.user_endpoint,` +
+		` projections.idp_templates6_oauth2.scopes,` +
+		` projections.idp_templates6_oauth2.id_attribute,` +
 		// oidc
-		` projections.idp_templates4_oidc.idp_id,` +
-		` projections.idp_templates4_oidc.issuer,` +
-		` projections.idp_templates4_oidc.client_id,` +
-		` projections.idp_templates4_oidc.client_secret,` +
-		` projections.idp_templates4_oidc.scopes,` +
-		` projections.idp_templates4_oidc.id_token_mapping,` +
+		` projections.idp_templates6_oidc.idp_id,` +
+		` projections.idp_templates6_oidc.issuer,` +
+		` projections.idp_templates6_oidc.client_id,` +
+		` projections.idp_templates6_oidc.client_secret,` +
+		` projections.idp_templates6_oidc.scopes,` +
+		` projections.idp_templates6_oidc.id_token_mapping,` +
 		// jwt
-		` projections.idp_templates4_jwt.idp_id,` +
-		` projections.idp_templates4_jwt.issuer,` +
-		` projections.idp_templates4_jwt.jwt_endpoint,` +
-		` projections.idp_templates4_jwt.keys_endpoint,` +
-		` projections.idp_templates4_jwt.header_name,` +
+		` projections.idp_templates6_jwt.idp_id,` +
+		` projections.idp_templates6_jwt.issuer,` +
+		` projections.idp_templates6_jwt.jwt_endpoint,` +
+		` projections.idp_templates6_jwt.keys_endpoint,` +
+		` projections.idp_templates6_jwt.header_name,` +
 		// azure
-		` projections.idp_templates4_azure.idp_id,` +
-		` projections.idp_templates4_azure.client_id,` +
-		` projections.idp_templates4_azure.client_secret,` +
-		` projections.idp_templates4_azure.scopes,` +
-		` projections.idp_templates4_azure.tenant,` +
-		` projections.idp_templates4_azure.is_email_verified,` +
+		` projections.idp_templates6_azure.idp_id,` +
+		` projections.idp_templates6_azure.client_id,` +
+		` projections.idp_templates6_azure.client_secret,` +
+		` projections.idp_templates6_azure.scopes,` +
+		` projections.idp_templates6_azure.tenant,` +
+		` projections.idp_templates6_azure.is_email_verified,` +
 		// github
-		` projections.idp_templates4_github.idp_id,` +
-		` projections.idp_templates4_github.client_id,` +
-		` projections.idp_templates4_github.client_secret,` +
-		` projections.idp_templates4_github.scopes,` +
+		` projections.idp_templates6_github.idp_id,` +
+		` projections.idp_templates6_github.client_id,` +
+		` projections.idp_templates6_github.client_secret,` +
+		` projections.idp_templates6_github.scopes,` +
 		// github enterprise
-		` projections.idp_templates4_github_enterprise.idp_id,` +
-		` projections.idp_templates4_github_enterprise.client_id,` +
-		` projections.idp_templates4_github_enterprise.client_secret,` +
-		` projections.idp_templates4_github_enterprise.authorization_endpoint,` +
-		` projections.idp_templates4_github_enterprise.token_endpoint,` +
-		` projections.idp_templates4_github_enterprise.user_endpoint,` +
-		` projections.idp_templates4_github_enterprise.scopes,` +
+		` projections.idp_templates6_github_enterprise.idp_id,` +
+		` projections.idp_templates6_github_enterprise.client_id,` +
+		` projections.idp_templates6_github_enterprise.client_secret,` +
+		` projections.idp_templates6_github_enterprise.authorization_endpoint,` +
+		` projections.idp_templates6_github_enterprise.token_endpoint,` +
+		` projections.idp_templates6_github_enterprise.user_endpoint,` +
+		` projections.idp_templates6_github_enterprise.scopes,` +
 		// gitlab
-		` projections.idp_templates4_gitlab.idp_id,` +
-		` projections.idp_templates4_gitlab.client_id,` +
-		` projections.idp_templates4_gitlab.client_secret,` +
-		` projections.idp_templates4_gitlab.scopes,` +
+		` projections.idp_templates6_gitlab.idp_id,` +
+		` projections.idp_templates6_gitlab.client_id,` +
+		` projections.idp_templates6_gitlab.client_secret,` +
+		` projections.idp_templates6_gitlab.scopes,` +
 		// gitlab self hosted
-		` projections.idp_templates4_gitlab_self_hosted.idp_id,` +
-		` projections.idp_templates4_gitlab_self_hosted.issuer,` +
-		` projections.idp_templates4_gitlab_self_hosted.client_id,` +
-		` projections.idp_templates4_gitlab_self_hosted.client_secret,` +
-		` projections.idp_templates4_gitlab_self_hosted.scopes,` +
+		` projections.idp_templates6_gitlab_self_hosted.idp_id,` +
+		` projections.idp_templates6_gitlab_self_hosted.issuer,` +
+		` projections.idp_templates6_gitlab_self_hosted.client_id,` +
+		` projections.idp_templates6_gitlab_self_hosted.client_secret,` +
+		` projections.idp_templates6_gitlab_self_hosted.scopes,` +
 		// google
-		` projections.idp_templates4_google.idp_id,` +
-		` projections.idp_templates4_google.client_id,` +
-		` projections.idp_templates4_google.client_secret,` +
-		` projections.idp_templates4_google.scopes,` +
+		` projections.idp_templates6_google.idp_id,` +
+		` projections.idp_templates6_google.client_id,` +
+		` projections.idp_templates6_google.client_secret,` +
+		` projections.idp_templates6_google.scopes,` +
 		// ldap
-		` projections.idp_templates4_ldap.idp_id,` +
-		` projections.idp_templates4_ldap.host,` +
-		` projections.idp_templates4_ldap.port,` +
-		` projections.idp_templates4_ldap.tls,` +
-		` projections.idp_templates4_ldap.base_dn,` +
-		` projections.idp_templates4_ldap.user_object_class,` +
-		` projections.idp_templates4_ldap.user_unique_attribute,` +
-		` projections.idp_templates4_ldap.admin,` +
-		` projections.idp_templates4_ldap.password,` +
-		` projections.idp_templates4_ldap.id_attribute,` +
-		` projections.idp_templates4_ldap.first_name_attribute,` +
-		` projections.idp_templates4_ldap.last_name_attribute,` +
-		` projections.idp_templates4_ldap.display_name_attribute,` +
-		` projections.idp_templates4_ldap.nick_name_attribute,` +
-		` projections.idp_templates4_ldap.preferred_username_attribute,` +
-		` projections.idp_templates4_ldap.email_attribute,` +
-		` projections.idp_templates4_ldap.email_verified,` +
-		` projections.idp_templates4_ldap.phone_attribute,` +
-		` projections.idp_templates4_ldap.phone_verified_attribute,` +
-		` projections.idp_templates4_ldap.preferred_language_attribute,` +
-		` projections.idp_templates4_ldap.avatar_url_attribute,` +
-		` projections.idp_templates4_ldap.profile_attribute,` +
+		` projections.idp_templates6_ldap.idp_id,` +
+		` projections.idp_templates6_ldap.host,` +
+		` projections.idp_templates6_ldap.port,` +
+		` projections.idp_templates6_ldap.tls,` +
+		` projections.idp_templates6_ldap.base_dn,` +
+		` projections.idp_templates6_ldap.user_object_class,` +
+		` projections.idp_templates6_ldap.user_unique_attribute,` +
+		` projections.idp_templates6_ldap.admin,` +
+		` projections.idp_templates6_ldap.password,` +
+		` projections.idp_templates6_ldap.id_attribute,` +
+		` projections.idp_templates6_ldap.first_name_attribute,` +
+		` projections.idp_templates6_ldap.last_name_attribute,` +
+		` projections.idp_templates6_ldap.display_name_attribute,` +
+		` projections.idp_templates6_ldap.nick_name_attribute,` +
+		` projections.idp_templates6_ldap.preferred_username_attribute,` +
+		` projections.idp_templates6_ldap.email_attribute,` +
+		` projections.idp_templates6_ldap.email_verified,` +
+		` projections.idp_templates6_ldap.phone_attribute,` +
+		` projections.idp_templates6_ldap.phone_verified_attribute,` +
+		` projections.idp_templates6_ldap.preferred_language_attribute,` +
+		` projections.idp_templates6_ldap.avatar_url_attribute,` +
+		` projections.idp_templates6_ldap.profile_attribute,` +
+		// saml
+		` projections.idp_templates6_saml.idp_id,` +
+		` projections.idp_templates6_saml.metadata,` +
+		` projections.idp_templates6_saml.key,` +
+		` projections.idp_templates6_saml.certificate,` +
+		` projections.idp_templates6_saml.binding,` +
+		` projections.idp_templates6_saml.with_signed_request,` +
+		// apple
+		` projections.idp_templates6_apple.idp_id,` +
+		` projections.idp_templates6_apple.client_id,` +
+		` projections.idp_templates6_apple.team_id,` +
+		` projections.idp_templates6_apple.key_id,` +
+		` projections.idp_templates6_apple.private_key,` +
+		` projections.idp_templates6_apple.scopes,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.idp_templates4` +
-		` LEFT JOIN projections.idp_templates4_oauth2 ON projections.idp_templates4.id = projections.idp_templates4_oauth2.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_oauth2.instance_id` +
-		` LEFT JOIN projections.idp_templates4_oidc ON projections.idp_templates4.id = projections.idp_templates4_oidc.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_oidc.instance_id` +
-		` LEFT JOIN projections.idp_templates4_jwt ON projections.idp_templates4.id = projections.idp_templates4_jwt.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_jwt.instance_id` +
-		` LEFT JOIN projections.idp_templates4_azure ON projections.idp_templates4.id = projections.idp_templates4_azure.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_azure.instance_id` +
-		` LEFT JOIN projections.idp_templates4_github ON projections.idp_templates4.id = projections.idp_templates4_github.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_github.instance_id` +
-		` LEFT JOIN projections.idp_templates4_github_enterprise ON projections.idp_templates4.id = projections.idp_templates4_github_enterprise.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_github_enterprise.instance_id` +
-		` LEFT JOIN projections.idp_templates4_gitlab ON projections.idp_templates4.id = projections.idp_templates4_gitlab.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_gitlab.instance_id` +
-		` LEFT JOIN projections.idp_templates4_gitlab_self_hosted ON projections.idp_templates4.id = projections.idp_templates4_gitlab_self_hosted.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_gitlab_self_hosted.instance_id` +
-		` LEFT JOIN projections.idp_templates4_google ON projections.idp_templates4.id = projections.idp_templates4_google.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_google.instance_id` +
-		` LEFT JOIN projections.idp_templates4_ldap ON projections.idp_templates4.id = projections.idp_templates4_ldap.idp_id AND projections.idp_templates4.instance_id = projections.idp_templates4_ldap.instance_id` +
+		` FROM projections.idp_templates6` +
+		` LEFT JOIN projections.idp_templates6_oauth2 ON projections.idp_templates6.id = projections.idp_templates6_oauth2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oauth2.instance_id` +
+		` LEFT JOIN projections.idp_templates6_oidc ON projections.idp_templates6.id = projections.idp_templates6_oidc.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oidc.instance_id` +
+		` LEFT JOIN projections.idp_templates6_jwt ON projections.idp_templates6.id = projections.idp_templates6_jwt.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_jwt.instance_id` +
+		` LEFT JOIN projections.idp_templates6_azure ON projections.idp_templates6.id = projections.idp_templates6_azure.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_azure.instance_id` +
+		` LEFT JOIN projections.idp_templates6_github ON projections.idp_templates6.id = projections.idp_templates6_github.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github.instance_id` +
+		` LEFT JOIN projections.idp_templates6_github_enterprise ON projections.idp_templates6.id = projections.idp_templates6_github_enterprise.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github_enterprise.instance_id` +
+		` LEFT JOIN projections.idp_templates6_gitlab ON projections.idp_templates6.id = projections.idp_templates6_gitlab.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab.instance_id` +
+		` LEFT JOIN projections.idp_templates6_gitlab_self_hosted ON projections.idp_templates6.id = projections.idp_templates6_gitlab_self_hosted.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab_self_hosted.instance_id` +
+		` LEFT JOIN projections.idp_templates6_google ON projections.idp_templates6.id = projections.idp_templates6_google.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_google.instance_id` +
+		` LEFT JOIN projections.idp_templates6_ldap ON projections.idp_templates6.id = projections.idp_templates6_ldap.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap.instance_id` +
+		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
+		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	idpTemplatesCols = []string{
 		"id",
@@ -419,6 +465,20 @@ var (
 		"preferred_language_attribute",
 		"avatar_url_attribute",
 		"profile_attribute",
+		// saml config
+		"idp_id",
+		"metadata",
+		"key",
+		"certificate",
+		"binding",
+		"with_signed_request",
+		// apple config
+		"idp_id",
+		"client_id",
+		"team_id",
+		"key_id",
+		"private_key",
+		"scopes",
 		"count",
 	}
 )
@@ -554,6 +614,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -685,6 +759,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -814,6 +902,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -942,6 +1044,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1069,6 +1185,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1196,6 +1326,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1324,6 +1468,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1451,6 +1609,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"lang",
 						"avatar",
 						"profile",
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1496,7 +1668,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareIDPTemplateByIDQuery no config",
+			name:    "prepareIDPTemplateByIDQuery saml idp",
 			prepare: prepareIDPTemplateByIDQuery,
 			want: want{
 				sqlExpectations: mockQuery(
@@ -1510,7 +1682,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						uint64(20211109),
 						domain.IDPConfigStateActive,
 						"idp-name",
-						domain.IDPTypeLDAP,
+						domain.IDPTypeSAML,
 						domain.IdentityProviderTypeOrg,
 						true,
 						true,
@@ -1597,6 +1769,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						"idp-id",
+						[]byte("metadata"),
+						nil,
+						[]byte("certificate"),
+						"binding",
+						true,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1608,41 +1794,327 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 				ID:                "idp-id",
 				State:             domain.IDPStateActive,
 				Name:              "idp-name",
-				Type:              domain.IDPTypeLDAP,
+				Type:              domain.IDPTypeSAML,
 				OwnerType:         domain.IdentityProviderTypeOrg,
 				IsCreationAllowed: true,
 				IsLinkingAllowed:  true,
 				IsAutoCreation:    true,
 				IsAutoUpdate:      true,
+				SAMLIDPTemplate: &SAMLIDPTemplate{
+					IDPID:             "idp-id",
+					Metadata:          []byte("metadata"),
+					Key:               nil,
+					Certificate:       []byte("certificate"),
+					Binding:           "binding",
+					WithSignedRequest: true,
+				},
 			},
 		},
 		{
-			name:    "prepareIDPTemplateByIDQuery sql err",
+			name:    "prepareIDPTemplateByIDQuery apple idp",
 			prepare: prepareIDPTemplateByIDQuery,
 			want: want{
-				sqlExpectations: mockQueryErr(
+				sqlExpectations: mockQuery(
 					regexp.QuoteMeta(idpTemplateQuery),
-					sql.ErrConnDone,
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeApple,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google config
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						"idp-id",
+						"client_id",
+						"team_id",
+						"key_id",
+						nil,
+						database.StringArray{"name", "email"},
+					},
 				),
-				err: func(err error) (error, bool) {
-					if !errors.Is(err, sql.ErrConnDone) {
-						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
-					}
-					return nil, true
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeApple,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AppleIDPTemplate: &AppleIDPTemplate{
+					IDPID:      "idp-id",
+					ClientID:   "client_id",
+					TeamID:     "team_id",
+					KeyID:      "key_id",
+					PrivateKey: nil,
+					Scopes:     []string{"name", "email"},
 				},
 			},
-			object: nil,
 		},
 		{
-			name:    "prepareIDPTemplatesQuery no result",
-			prepare: prepareIDPTemplatesQuery,
+			name:    "prepareIDPTemplateByIDQuery no config",
+			prepare: prepareIDPTemplateByIDQuery,
 			want: want{
-				sqlExpectations: mockQueries(
-					regexp.QuoteMeta(idpTemplatesQuery),
-					nil,
-					nil,
-				),
-				err: func(err error) (error, bool) {
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeLDAP,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google config
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeLDAP,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery sql err",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(idpTemplateQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareIDPTemplatesQuery no result",
+			prepare: prepareIDPTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(idpTemplatesQuery),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
 					if !errs.IsNotFound(err) {
 						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
 					}
@@ -1754,6 +2226,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							"lang",
 							"avatar",
 							"profile",
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1909,6 +2395,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -2039,6 +2539,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							"lang",
 							"avatar",
 							"profile",
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-google",
@@ -2135,6 +2649,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-oauth",
@@ -2231,6 +2759,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-oidc",
@@ -2327,6 +2869,20 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-jwt",
@@ -2423,13 +2979,247 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+						{
+							"idp-id-saml",
+							"ro",
+							testNow,
+							testNow,
+							uint64(20211109),
+							domain.IDPConfigStateActive,
+							"idp-name",
+							domain.IDPTypeSAML,
+							domain.IdentityProviderTypeOrg,
+							true,
+							true,
+							true,
+							true,
+							// oauth
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// jwt
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// azure
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// github
+							nil,
+							nil,
+							nil,
+							nil,
+							// github enterprise
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab self hosted
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// google
+							nil,
+							nil,
+							nil,
+							nil,
+							// ldap config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							"idp-id-saml",
+							[]byte("metadata"),
+							nil,
+							[]byte("certificate"),
+							"binding",
+							true,
+							// apple config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+						{
+							"idp-id-apple",
+							"ro",
+							testNow,
+							testNow,
+							uint64(20211109),
+							domain.IDPConfigStateActive,
+							"idp-name",
+							domain.IDPTypeApple,
+							domain.IdentityProviderTypeOrg,
+							true,
+							true,
+							true,
+							true,
+							// oauth
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// jwt
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// azure
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// github
+							nil,
+							nil,
+							nil,
+							nil,
+							// github enterprise
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab
+							nil,
+							nil,
+							nil,
+							nil,
+							// gitlab self hosted
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// google
+							nil,
+							nil,
+							nil,
+							nil,
+							// ldap config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// apple config
+							"idp-id-apple",
+							"client_id",
+							"team_id",
+							"key_id",
+							nil,
+							database.StringArray{"name", "email"},
 						},
 					},
 				),
 			},
 			object: &IDPTemplates{
 				SearchResponse: SearchResponse{
-					Count: 5,
+					Count: 7,
 				},
 				Templates: []*IDPTemplate{
 					{
@@ -2564,6 +3354,52 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							HeaderName:   "header",
 						},
 					},
+					{
+						CreationDate:      testNow,
+						ChangeDate:        testNow,
+						Sequence:          20211109,
+						ResourceOwner:     "ro",
+						ID:                "idp-id-saml",
+						State:             domain.IDPStateActive,
+						Name:              "idp-name",
+						Type:              domain.IDPTypeSAML,
+						OwnerType:         domain.IdentityProviderTypeOrg,
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+						SAMLIDPTemplate: &SAMLIDPTemplate{
+							IDPID:             "idp-id-saml",
+							Metadata:          []byte("metadata"),
+							Key:               nil,
+							Certificate:       []byte("certificate"),
+							Binding:           "binding",
+							WithSignedRequest: true,
+						},
+					},
+					{
+						CreationDate:      testNow,
+						ChangeDate:        testNow,
+						Sequence:          20211109,
+						ResourceOwner:     "ro",
+						ID:                "idp-id-apple",
+						State:             domain.IDPStateActive,
+						Name:              "idp-name",
+						Type:              domain.IDPTypeApple,
+						OwnerType:         domain.IdentityProviderTypeOrg,
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+						AppleIDPTemplate: &AppleIDPTemplate{
+							IDPID:      "idp-id-apple",
+							ClientID:   "client_id",
+							TeamID:     "team_id",
+							KeyID:      "key_id",
+							PrivateKey: nil,
+							Scopes:     []string{"name", "email"},
+						},
+					},
 				},
 			},
 		},
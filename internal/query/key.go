@@ -2,7 +2,6 @@ package query
 
 import (
 	"context"
-	"crypto/rsa"
 	"database/sql"
 	"time"
 
@@ -86,20 +85,35 @@ func (k *privateKey) Key() *crypto.CryptoValue {
 	return k.privateKey
 }
 
-type rsaPublicKey struct {
+type publicKey struct {
 	key
 	expiry    time.Time
-	publicKey *rsa.PublicKey
+	publicKey interface{}
 }
 
-func (r *rsaPublicKey) Expiry() time.Time {
+func (r *publicKey) Expiry() time.Time {
 	return r.expiry
 }
 
-func (r *rsaPublicKey) Key() interface{} {
+func (r *publicKey) Key() interface{} {
 	return r.publicKey
 }
 
+type SigningKeys struct {
+	SearchResponse
+	Keys []*SigningKey
+}
+
+type SigningKey struct {
+	ID               string
+	CreationDate     time.Time
+	Sequence         uint64
+	Algorithm        string
+	PrivateKeyExpiry time.Time
+	PublicKeyExpiry  time.Time
+	State            domain.KeyState
+}
+
 var (
 	keyTable = table{
 		name:          projection.KeyProjectionTable,
@@ -244,6 +258,59 @@ func (q *Queries) ActivePrivateSigningKey(ctx context.Context, t time.Time) (_ *
 	return keys, nil
 }
 
+// SigningKeys returns the published signing keys of the instance ordered by their expiry,
+// the state of the keys is computed at time t
+func (q *Queries) SigningKeys(ctx context.Context, t time.Time) (_ *SigningKeys, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareSigningKeysQuery(ctx, q.client)
+	if t.IsZero() {
+		t = time.Now()
+	}
+	query, args, err := stmt.Where(
+		sq.And{
+			sq.Eq{
+				KeyColUse.identifier():        domain.KeyUsageSigning,
+				KeyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			},
+			sq.Gt{KeyPublicColExpiry.identifier(): t},
+		}).OrderBy(KeyPrivateColExpiry.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Zb4nq", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Pw7xe", "Errors.Internal")
+	}
+	keys, err := scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	keys.setStates(t)
+	keys.LatestSequence, err = q.latestSequence(ctx, keyTable)
+	if !errors.IsNotFound(err) {
+		return keys, err
+	}
+	return keys, nil
+}
+
+// setStates computes the states of the keys ordered by their private key expiry,
+// the first key not yet retired is the active one
+func (k *SigningKeys) setStates(t time.Time) {
+	var activeExpiry time.Time
+	for _, key := range k.Keys {
+		if key.PrivateKeyExpiry.After(t.Add(domain.SigningKeyGracefulPeriod)) {
+			activeExpiry = key.PrivateKeyExpiry
+			break
+		}
+	}
+	for _, key := range k.Keys {
+		key.State = domain.SigningKeyState(key.PrivateKeyExpiry, activeExpiry, t)
+	}
+}
+
 func preparePublicKeysQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*PublicKeys, error)) {
 	return sq.Select(
 			KeyColID.identifier(),
@@ -263,7 +330,7 @@ func preparePublicKeysQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			keys := make([]PublicKey, 0)
 			var count uint64
 			for rows.Next() {
-				k := new(rsaPublicKey)
+				k := new(publicKey)
 				var keyValue []byte
 				err := rows.Scan(
 					&k.id,
@@ -280,7 +347,7 @@ func preparePublicKeysQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				if err != nil {
 					return nil, err
 				}
-				k.publicKey, err = crypto.BytesToPublicKey(keyValue)
+				k.publicKey, err = crypto.BytesToSigningPublicKey(keyValue)
 				if err != nil {
 					return nil, err
 				}
@@ -350,3 +417,49 @@ func preparePrivateKeysQuery(ctx context.Context, db prepareDatabase) (sq.Select
 			}, nil
 		}
 }
+
+func prepareSigningKeysQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*SigningKeys, error)) {
+	return sq.Select(
+			KeyColID.identifier(),
+			KeyColCreationDate.identifier(),
+			KeyColSequence.identifier(),
+			KeyColAlgorithm.identifier(),
+			KeyPrivateColExpiry.identifier(),
+			KeyPublicColExpiry.identifier(),
+			countColumn.identifier(),
+		).From(keyTable.identifier()).
+			LeftJoin(join(KeyPrivateColID, KeyColID)).
+			LeftJoin(join(KeyPublicColID, KeyColID) + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*SigningKeys, error) {
+			keys := make([]*SigningKey, 0)
+			var count uint64
+			for rows.Next() {
+				k := new(SigningKey)
+				err := rows.Scan(
+					&k.ID,
+					&k.CreationDate,
+					&k.Sequence,
+					&k.Algorithm,
+					&k.PrivateKeyExpiry,
+					&k.PublicKeyExpiry,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				keys = append(keys, k)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Tz8wk", "Errors.Query.CloseRows")
+			}
+
+			return &SigningKeys{
+				Keys: keys,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -9,6 +9,9 @@ import (
 	"math/big"
 	"regexp"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -55,6 +58,27 @@ var (
 		` FROM projections.keys4` +
 		` LEFT JOIN projections.keys4_private ON projections.keys4.id = projections.keys4_private.id AND projections.keys4.instance_id = projections.keys4_private.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms' `
+
+	prepareSigningKeysStmt = `SELECT projections.keys4.id,` +
+		` projections.keys4.creation_date,` +
+		` projections.keys4.sequence,` +
+		` projections.keys4.algorithm,` +
+		` projections.keys4_private.expiry,` +
+		` projections.keys4_public.expiry,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.keys4` +
+		` LEFT JOIN projections.keys4_private ON projections.keys4.id = projections.keys4_private.id AND projections.keys4.instance_id = projections.keys4_private.instance_id` +
+		` LEFT JOIN projections.keys4_public ON projections.keys4.id = projections.keys4_public.id AND projections.keys4.instance_id = projections.keys4_public.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms' `
+	prepareSigningKeysCols = []string{
+		"id",
+		"creation_date",
+		"sequence",
+		"algorithm",
+		"expiry",
+		"expiry",
+		"count",
+	}
 )
 
 func Test_KeyPrepares(t *testing.T) {
@@ -113,7 +137,7 @@ func Test_KeyPrepares(t *testing.T) {
 					Count: 1,
 				},
 				Keys: []PublicKey{
-					&rsaPublicKey{
+					&publicKey{
 						key: key{
 							id:            "key-id",
 							creationDate:  testNow,
@@ -232,6 +256,70 @@ func Test_KeyPrepares(t *testing.T) {
 			},
 			object: nil,
 		},
+		{
+			name:    "prepareSigningKeysQuery no result",
+			prepare: prepareSigningKeysQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSigningKeysStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &SigningKeys{Keys: []*SigningKey{}},
+		},
+		{
+			name:    "prepareSigningKeysQuery found",
+			prepare: prepareSigningKeysQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSigningKeysStmt),
+					prepareSigningKeysCols,
+					[][]driver.Value{
+						{
+							"key-id",
+							testNow,
+							uint64(20211109),
+							"ES256",
+							testNow,
+							testNow,
+						},
+					},
+				),
+			},
+			object: &SigningKeys{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Keys: []*SigningKey{
+					{
+						ID:               "key-id",
+						CreationDate:     testNow,
+						Sequence:         20211109,
+						Algorithm:        "ES256",
+						PrivateKeyExpiry: testNow,
+						PublicKeyExpiry:  testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSigningKeysQuery sql err",
+			prepare: prepareSigningKeysQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSigningKeysStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -240,6 +328,27 @@ func Test_KeyPrepares(t *testing.T) {
 	}
 }
 
+func TestSigningKeys_setStates(t *testing.T) {
+	now := time.Now()
+	keys := &SigningKeys{
+		Keys: []*SigningKey{
+			{ID: "retired", PrivateKeyExpiry: now.Add(5 * time.Minute)},
+			{ID: "active", PrivateKeyExpiry: now.Add(time.Hour)},
+			{ID: "next", PrivateKeyExpiry: now.Add(7 * time.Hour)},
+		},
+	}
+	keys.setStates(now)
+	states := make(map[string]domain.KeyState, len(keys.Keys))
+	for _, key := range keys.Keys {
+		states[key.ID] = key.State
+	}
+	assert.Equal(t, map[string]domain.KeyState{
+		"retired": domain.KeyStateRetired,
+		"active":  domain.KeyStateActive,
+		"next":    domain.KeyStateNext,
+	}, states)
+}
+
 func fromBase16(base16 string) *big.Int {
 	i, ok := new(big.Int).SetString(base16, 16)
 	if !ok {
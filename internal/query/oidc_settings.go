@@ -60,6 +60,18 @@ var (
 		name:  projection.OIDCSettingsColumnRefreshTokenExpiration,
 		table: oidcSettingsTable,
 	}
+	OIDCSettingsColumnSigningKeyAlgorithm = Column{
+		name:  projection.OIDCSettingsColumnSigningKeyAlgorithm,
+		table: oidcSettingsTable,
+	}
+	OIDCSettingsColumnSigningKeyLifetime = Column{
+		name:  projection.OIDCSettingsColumnSigningKeyLifetime,
+		table: oidcSettingsTable,
+	}
+	OIDCSettingsColumnSigningKeyOverlap = Column{
+		name:  projection.OIDCSettingsColumnSigningKeyOverlap,
+		table: oidcSettingsTable,
+	}
 )
 
 type OIDCSettings struct {
@@ -73,6 +85,10 @@ type OIDCSettings struct {
 	IdTokenLifetime            time.Duration
 	RefreshTokenIdleExpiration time.Duration
 	RefreshTokenExpiration     time.Duration
+
+	SigningKeyAlgorithm string
+	SigningKeyLifetime  time.Duration
+	SigningKeyOverlap   time.Duration
 }
 
 func (q *Queries) OIDCSettingsByAggID(ctx context.Context, aggregateID string) (_ *OIDCSettings, err error) {
@@ -102,7 +118,10 @@ func prepareOIDCSettingsQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			OIDCSettingsColumnAccessTokenLifetime.identifier(),
 			OIDCSettingsColumnIdTokenLifetime.identifier(),
 			OIDCSettingsColumnRefreshTokenIdleExpiration.identifier(),
-			OIDCSettingsColumnRefreshTokenExpiration.identifier()).
+			OIDCSettingsColumnRefreshTokenExpiration.identifier(),
+			OIDCSettingsColumnSigningKeyAlgorithm.identifier(),
+			OIDCSettingsColumnSigningKeyLifetime.identifier(),
+			OIDCSettingsColumnSigningKeyOverlap.identifier()).
 			From(oidcSettingsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*OIDCSettings, error) {
@@ -117,6 +136,9 @@ func prepareOIDCSettingsQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				&oidcSettings.IdTokenLifetime,
 				&oidcSettings.RefreshTokenIdleExpiration,
 				&oidcSettings.RefreshTokenExpiration,
+				&oidcSettings.SigningKeyAlgorithm,
+				&oidcSettings.SigningKeyLifetime,
+				&oidcSettings.SigningKeyOverlap,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -26,6 +26,7 @@ type PasswordAgePolicy struct {
 
 	ExpireWarnDays uint64
 	MaxAgeDays     uint64
+	HistoryCount   uint64
 
 	IsDefault bool
 }
@@ -67,6 +68,10 @@ var (
 		name:  projection.AgePolicyMaxAgeDaysCol,
 		table: passwordAgeTable,
 	}
+	PasswordAgeColHistoryCount = Column{
+		name:  projection.AgePolicyHistoryCountCol,
+		table: passwordAgeTable,
+	}
 	PasswordAgeColIsDefault = Column{
 		name:  projection.AgePolicyIsDefaultCol,
 		table: passwordAgeTable,
@@ -142,6 +147,7 @@ func preparePasswordAgePolicyQuery(ctx context.Context, db prepareDatabase) (sq.
 			PasswordAgeColResourceOwner.identifier(),
 			PasswordAgeColWarnDays.identifier(),
 			PasswordAgeColMaxAge.identifier(),
+			PasswordAgeColHistoryCount.identifier(),
 			PasswordAgeColIsDefault.identifier(),
 			PasswordAgeColState.identifier(),
 		).
@@ -157,6 +163,7 @@ func preparePasswordAgePolicyQuery(ctx context.Context, db prepareDatabase) (sq.
 				&policy.ResourceOwner,
 				&policy.ExpireWarnDays,
 				&policy.MaxAgeDays,
+				&policy.HistoryCount,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -13,16 +13,17 @@ import (
 )
 
 var (
-	preparePasswordAgePolicyStmt = `SELECT projections.password_age_policies2.id,` +
-		` projections.password_age_policies2.sequence,` +
-		` projections.password_age_policies2.creation_date,` +
-		` projections.password_age_policies2.change_date,` +
-		` projections.password_age_policies2.resource_owner,` +
-		` projections.password_age_policies2.expire_warn_days,` +
-		` projections.password_age_policies2.max_age_days,` +
-		` projections.password_age_policies2.is_default,` +
-		` projections.password_age_policies2.state` +
-		` FROM projections.password_age_policies2` +
+	preparePasswordAgePolicyStmt = `SELECT projections.password_age_policies3.id,` +
+		` projections.password_age_policies3.sequence,` +
+		` projections.password_age_policies3.creation_date,` +
+		` projections.password_age_policies3.change_date,` +
+		` projections.password_age_policies3.resource_owner,` +
+		` projections.password_age_policies3.expire_warn_days,` +
+		` projections.password_age_policies3.max_age_days,` +
+		` projections.password_age_policies3.history_count,` +
+		` projections.password_age_policies3.is_default,` +
+		` projections.password_age_policies3.state` +
+		` FROM projections.password_age_policies3` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	preparePasswordAgePolicyCols = []string{
 		"id",
@@ -32,6 +33,7 @@ var (
 		"resource_owner",
 		"expire_warn_days",
 		"max_age_days",
+		"history_count",
 		"is_default",
 		"state",
 	}
@@ -81,6 +83,7 @@ func Test_PasswordAgePolicyPrepares(t *testing.T) {
 						"ro",
 						10,
 						20,
+						5,
 						true,
 						domain.PolicyStateActive,
 					},
@@ -95,6 +98,7 @@ func Test_PasswordAgePolicyPrepares(t *testing.T) {
 				State:          domain.PolicyStateActive,
 				ExpireWarnDays: 10,
 				MaxAgeDays:     20,
+				HistoryCount:   5,
 				IsDefault:      true,
 			},
 		},
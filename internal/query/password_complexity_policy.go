@@ -24,11 +24,12 @@ type PasswordComplexityPolicy struct {
 	ResourceOwner string
 	State         domain.PolicyState
 
-	MinLength    uint64
-	HasLowercase bool
-	HasUppercase bool
-	HasNumber    bool
-	HasSymbol    bool
+	MinLength     uint64
+	HasLowercase  bool
+	HasUppercase  bool
+	HasNumber     bool
+	HasSymbol     bool
+	CheckBreached bool
 
 	IsDefault bool
 }
@@ -135,6 +136,10 @@ var (
 		name:  projection.ComplexityPolicyHasSymbolCol,
 		table: passwordComplexityTable,
 	}
+	PasswordComplexityColCheckBreached = Column{
+		name:  projection.ComplexityPolicyCheckBreachedCol,
+		table: passwordComplexityTable,
+	}
 	PasswordComplexityColIsDefault = Column{
 		name:  projection.ComplexityPolicyIsDefaultCol,
 		table: passwordComplexityTable,
@@ -161,6 +166,7 @@ func preparePasswordComplexityPolicyQuery(ctx context.Context, db prepareDatabas
 			PasswordComplexityColHasUpperCase.identifier(),
 			PasswordComplexityColHasNumber.identifier(),
 			PasswordComplexityColHasSymbol.identifier(),
+			PasswordComplexityColCheckBreached.identifier(),
 			PasswordComplexityColIsDefault.identifier(),
 			PasswordComplexityColState.identifier(),
 		).
@@ -179,6 +185,7 @@ func preparePasswordComplexityPolicyQuery(ctx context.Context, db prepareDatabas
 				&policy.HasUppercase,
 				&policy.HasNumber,
 				&policy.HasSymbol,
+				&policy.CheckBreached,
 				&policy.IsDefault,
 				&policy.State,
 			)
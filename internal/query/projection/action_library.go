@@ -0,0 +1,139 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	ActionLibraryTable = "projections.action_libraries"
+
+	ActionLibraryNameCol          = "name"
+	ActionLibraryVersionCol       = "version"
+	ActionLibraryCreationDateCol  = "creation_date"
+	ActionLibraryChangeDateCol    = "change_date"
+	ActionLibraryResourceOwnerCol = "resource_owner"
+	ActionLibraryInstanceIDCol    = "instance_id"
+	ActionLibrarySequenceCol      = "sequence"
+	ActionLibraryScriptCol        = "script"
+	ActionLibraryOwnerRemovedCol  = "owner_removed"
+)
+
+type actionLibraryProjection struct {
+	crdb.StatementHandler
+}
+
+func newActionLibraryProjection(ctx context.Context, config crdb.StatementHandlerConfig) *actionLibraryProjection {
+	p := new(actionLibraryProjection)
+	config.ProjectionName = ActionLibraryTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ActionLibraryNameCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionLibraryVersionCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ActionLibraryCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionLibraryChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionLibraryResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionLibraryInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionLibrarySequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ActionLibraryScriptCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionLibraryOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(ActionLibraryInstanceIDCol, ActionLibraryResourceOwnerCol, ActionLibraryNameCol, ActionLibraryVersionCol),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{ActionLibraryOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *actionLibraryProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.ActionLibrarySetEventType,
+					Reduce: p.reduceLibrarySet,
+				},
+				{
+					Event:  org.ActionLibraryRemovedEventType,
+					Reduce: p.reduceLibraryRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ActionLibraryInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *actionLibraryProjection) reduceLibrarySet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionLibrarySetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Rv5yk", "reduce.wrong.event.type %s", org.ActionLibrarySetEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionLibraryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(ActionLibraryResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(ActionLibraryNameCol, e.Name),
+			handler.NewCol(ActionLibraryVersionCol, e.Version),
+			handler.NewCol(ActionLibraryCreationDateCol, e.CreationDate()),
+			handler.NewCol(ActionLibraryChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionLibrarySequenceCol, e.Sequence()),
+			handler.NewCol(ActionLibraryScriptCol, e.Script),
+		},
+	), nil
+}
+
+func (p *actionLibraryProjection) reduceLibraryRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionLibraryRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Kc2xo", "reduce.wrong.event.type %s", org.ActionLibraryRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ActionLibraryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(ActionLibraryResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCond(ActionLibraryNameCol, e.Name),
+		},
+	), nil
+}
+
+func (p *actionLibraryProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Dw7gn", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionLibraryChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionLibrarySequenceCol, e.Sequence()),
+			handler.NewCol(ActionLibraryOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionLibraryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(ActionLibraryResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,141 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	ActionSecretTable = "projections.action_secrets"
+
+	ActionSecretNameCol          = "name"
+	ActionSecretCreationDateCol  = "creation_date"
+	ActionSecretChangeDateCol    = "change_date"
+	ActionSecretResourceOwnerCol = "resource_owner"
+	ActionSecretInstanceIDCol    = "instance_id"
+	ActionSecretSequenceCol      = "sequence"
+	ActionSecretValueCol         = "value"
+	ActionSecretOwnerRemovedCol  = "owner_removed"
+)
+
+type actionSecretProjection struct {
+	crdb.StatementHandler
+}
+
+func newActionSecretProjection(ctx context.Context, config crdb.StatementHandlerConfig) *actionSecretProjection {
+	p := new(actionSecretProjection)
+	config.ProjectionName = ActionSecretTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ActionSecretNameCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionSecretCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionSecretChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionSecretResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionSecretInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionSecretSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ActionSecretValueCol, crdb.ColumnTypeJSONB),
+			crdb.NewColumn(ActionSecretOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(ActionSecretInstanceIDCol, ActionSecretResourceOwnerCol, ActionSecretNameCol),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{ActionSecretOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *actionSecretProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.ActionSecretSetEventType,
+					Reduce: p.reduceSecretSet,
+				},
+				{
+					Event:  org.ActionSecretRemovedEventType,
+					Reduce: p.reduceSecretRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ActionSecretInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *actionSecretProjection) reduceSecretSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionSecretSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Nf6wa", "reduce.wrong.event.type %s", org.ActionSecretSetEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionSecretInstanceIDCol, nil),
+			handler.NewCol(ActionSecretResourceOwnerCol, nil),
+			handler.NewCol(ActionSecretNameCol, e.Name),
+		},
+		[]handler.Column{
+			handler.NewCol(ActionSecretInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(ActionSecretResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(ActionSecretNameCol, e.Name),
+			handler.NewCol(ActionSecretCreationDateCol, e.CreationDate()),
+			handler.NewCol(ActionSecretChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionSecretSequenceCol, e.Sequence()),
+			handler.NewCol(ActionSecretValueCol, e.Value),
+		},
+	), nil
+}
+
+func (p *actionSecretProjection) reduceSecretRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionSecretRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tk2ds", "reduce.wrong.event.type %s", org.ActionSecretRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ActionSecretInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(ActionSecretResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCond(ActionSecretNameCol, e.Name),
+		},
+	), nil
+}
+
+func (p *actionSecretProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Jy4bn", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionSecretChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionSecretSequenceCol, e.Sequence()),
+			handler.NewCol(ActionSecretOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionSecretInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(ActionSecretResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
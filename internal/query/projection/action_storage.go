@@ -0,0 +1,141 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	ActionStorageTable = "projections.action_storage"
+
+	ActionStorageKeyCol           = "key"
+	ActionStorageCreationDateCol  = "creation_date"
+	ActionStorageChangeDateCol    = "change_date"
+	ActionStorageResourceOwnerCol = "resource_owner"
+	ActionStorageInstanceIDCol    = "instance_id"
+	ActionStorageSequenceCol      = "sequence"
+	ActionStorageValueCol         = "value"
+	ActionStorageOwnerRemovedCol  = "owner_removed"
+)
+
+type actionStorageProjection struct {
+	crdb.StatementHandler
+}
+
+func newActionStorageProjection(ctx context.Context, config crdb.StatementHandlerConfig) *actionStorageProjection {
+	p := new(actionStorageProjection)
+	config.ProjectionName = ActionStorageTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ActionStorageKeyCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionStorageCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionStorageChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionStorageResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionStorageInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionStorageSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ActionStorageValueCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionStorageOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(ActionStorageInstanceIDCol, ActionStorageResourceOwnerCol, ActionStorageKeyCol),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{ActionStorageOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *actionStorageProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.ActionStorageSetEventType,
+					Reduce: p.reduceStorageSet,
+				},
+				{
+					Event:  org.ActionStorageRemovedEventType,
+					Reduce: p.reduceStorageRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ActionStorageInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *actionStorageProjection) reduceStorageSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionStorageSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gm5ie", "reduce.wrong.event.type %s", org.ActionStorageSetEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionStorageInstanceIDCol, nil),
+			handler.NewCol(ActionStorageResourceOwnerCol, nil),
+			handler.NewCol(ActionStorageKeyCol, e.Key),
+		},
+		[]handler.Column{
+			handler.NewCol(ActionStorageInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(ActionStorageResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(ActionStorageKeyCol, e.Key),
+			handler.NewCol(ActionStorageCreationDateCol, e.CreationDate()),
+			handler.NewCol(ActionStorageChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionStorageSequenceCol, e.Sequence()),
+			handler.NewCol(ActionStorageValueCol, e.Value),
+		},
+	), nil
+}
+
+func (p *actionStorageProjection) reduceStorageRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.ActionStorageRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Bw8rk", "reduce.wrong.event.type %s", org.ActionStorageRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ActionStorageInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(ActionStorageResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCond(ActionStorageKeyCol, e.Key),
+		},
+	), nil
+}
+
+func (p *actionStorageProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Os3lv", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionStorageChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionStorageSequenceCol, e.Sequence()),
+			handler.NewCol(ActionStorageOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionStorageInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(ActionStorageResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
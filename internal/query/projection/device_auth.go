@@ -0,0 +1,188 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	DeviceAuthTable                    = "projections.device_authorizations"
+	DeviceAuthIDCol                    = "id"
+	DeviceAuthCreationDateCol          = "creation_date"
+	DeviceAuthChangeDateCol            = "change_date"
+	DeviceAuthInstanceIDCol            = "instance_id"
+	DeviceAuthSequenceCol              = "sequence"
+	DeviceAuthStateCol                 = "state"
+	DeviceAuthClientIDCol              = "client_id"
+	DeviceAuthDeviceCodeHashCol        = "device_code_hash"
+	DeviceAuthUserCodeCol              = "user_code"
+	DeviceAuthExpiresCol               = "expires"
+	DeviceAuthScopesCol                = "scopes"
+	DeviceAuthUserIDCol                = "user_id"
+	DeviceAuthUserOrgIDCol             = "user_org_id"
+	DeviceAuthUserAgentIDCol           = "user_agent_id"
+	DeviceAuthAudienceCol              = "audience"
+	DeviceAuthAuthMethodsReferencesCol = "auth_methods_references"
+	DeviceAuthAuthTimeCol              = "auth_time"
+)
+
+type deviceAuthProjection struct {
+	crdb.StatementHandler
+}
+
+func newDeviceAuthProjection(ctx context.Context, config crdb.StatementHandlerConfig) *deviceAuthProjection {
+	p := new(deviceAuthProjection)
+	config.ProjectionName = DeviceAuthTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(DeviceAuthIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(DeviceAuthCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(DeviceAuthChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(DeviceAuthInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(DeviceAuthSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(DeviceAuthStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(DeviceAuthClientIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(DeviceAuthDeviceCodeHashCol, crdb.ColumnTypeText),
+			crdb.NewColumn(DeviceAuthUserCodeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(DeviceAuthExpiresCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(DeviceAuthScopesCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(DeviceAuthUserIDCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(DeviceAuthUserOrgIDCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(DeviceAuthUserAgentIDCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(DeviceAuthAudienceCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(DeviceAuthAuthMethodsReferencesCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(DeviceAuthAuthTimeCol, crdb.ColumnTypeTimestamp, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(DeviceAuthInstanceIDCol, DeviceAuthIDCol),
+			crdb.WithIndex(crdb.NewIndex("device_code_hash", []string{DeviceAuthDeviceCodeHashCol})),
+			crdb.WithIndex(crdb.NewIndex("user_code", []string{DeviceAuthUserCodeCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *deviceAuthProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: deviceauth.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  deviceauth.AddedEventType,
+					Reduce: p.reduceDeviceAuthAdded,
+				},
+				{
+					Event:  deviceauth.ApprovedEventType,
+					Reduce: p.reduceDeviceAuthApproved,
+				},
+				{
+					Event:  deviceauth.DeniedEventType,
+					Reduce: p.reduceDeviceAuthDenied,
+				},
+				{
+					Event:  deviceauth.RemovedEventType,
+					Reduce: p.reduceDeviceAuthRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(DeviceAuthInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *deviceAuthProjection) reduceDeviceAuthAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*deviceauth.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Kd8sl", "reduce.wrong.event.type %s", deviceauth.AddedEventType)
+	}
+	return crdb.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(DeviceAuthIDCol, e.Aggregate().ID),
+			handler.NewCol(DeviceAuthCreationDateCol, e.CreationDate()),
+			handler.NewCol(DeviceAuthChangeDateCol, e.CreationDate()),
+			handler.NewCol(DeviceAuthInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(DeviceAuthSequenceCol, e.Sequence()),
+			handler.NewCol(DeviceAuthStateCol, domain.DeviceAuthStateInitiated),
+			handler.NewCol(DeviceAuthClientIDCol, e.ClientID),
+			handler.NewCol(DeviceAuthDeviceCodeHashCol, e.DeviceCodeHash),
+			handler.NewCol(DeviceAuthUserCodeCol, e.UserCode),
+			handler.NewCol(DeviceAuthExpiresCol, e.Expires),
+			handler.NewCol(DeviceAuthScopesCol, database.StringArray(e.Scopes)),
+		},
+	), nil
+}
+
+func (p *deviceAuthProjection) reduceDeviceAuthApproved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*deviceauth.ApprovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pw0ds", "reduce.wrong.event.type %s", deviceauth.ApprovedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(DeviceAuthChangeDateCol, e.CreationDate()),
+			handler.NewCol(DeviceAuthSequenceCol, e.Sequence()),
+			handler.NewCol(DeviceAuthStateCol, domain.DeviceAuthStateApproved),
+			handler.NewCol(DeviceAuthUserIDCol, e.UserID),
+			handler.NewCol(DeviceAuthUserOrgIDCol, e.UserOrgID),
+			handler.NewCol(DeviceAuthUserAgentIDCol, e.UserAgentID),
+			handler.NewCol(DeviceAuthAudienceCol, database.StringArray(e.Audience)),
+			handler.NewCol(DeviceAuthAuthMethodsReferencesCol, database.StringArray(e.AuthMethodsReferences)),
+			handler.NewCol(DeviceAuthAuthTimeCol, e.AuthTime),
+		},
+		[]handler.Condition{
+			handler.NewCond(DeviceAuthIDCol, e.Aggregate().ID),
+			handler.NewCond(DeviceAuthInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *deviceAuthProjection) reduceDeviceAuthDenied(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*deviceauth.DeniedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Hs2ka", "reduce.wrong.event.type %s", deviceauth.DeniedEventType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(DeviceAuthChangeDateCol, e.CreationDate()),
+			handler.NewCol(DeviceAuthSequenceCol, e.Sequence()),
+			handler.NewCol(DeviceAuthStateCol, domain.DeviceAuthStateDenied),
+		},
+		[]handler.Condition{
+			handler.NewCond(DeviceAuthIDCol, e.Aggregate().ID),
+			handler.NewCond(DeviceAuthInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *deviceAuthProjection) reduceDeviceAuthRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*deviceauth.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Vb3sq", "reduce.wrong.event.type %s", deviceauth.RemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(DeviceAuthIDCol, e.Aggregate().ID),
+			handler.NewCond(DeviceAuthInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
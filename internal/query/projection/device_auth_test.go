@@ -0,0 +1,208 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/deviceauth"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestDeviceAuthProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceDeviceAuthAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(deviceauth.AddedEventType),
+					deviceauth.AggregateType,
+					[]byte(`{
+	"clientId": "client-id",
+	"deviceCodeHash": "device-code-hash",
+	"userCode": "ABCD-EFGH",
+	"expires": "2023-01-01T12:00:00Z",
+	"scopes": ["openid"]
+}`),
+				), deviceauth.AddedEventMapper),
+			},
+			reduce: (&deviceAuthProjection{}).reduceDeviceAuthAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("device_auth"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.device_authorizations (id, creation_date, change_date, instance_id, sequence, state, client_id, device_code_hash, user_code, expires, scopes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"instance-id",
+								uint64(15),
+								domain.DeviceAuthStateInitiated,
+								"client-id",
+								"device-code-hash",
+								"ABCD-EFGH",
+								anyArg{},
+								database.StringArray{"openid"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeviceAuthApproved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(deviceauth.ApprovedEventType),
+					deviceauth.AggregateType,
+					[]byte(`{
+	"userId": "user-id",
+	"userOrgId": "org-id",
+	"userAgentId": "agent-id",
+	"audience": ["client-id", "project-id"],
+	"authMethodsReferences": ["pwd"],
+	"authTime": "2023-01-01T11:00:00Z"
+}`),
+				), deviceauth.ApprovedEventMapper),
+			},
+			reduce: (&deviceAuthProjection{}).reduceDeviceAuthApproved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("device_auth"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.device_authorizations SET (change_date, sequence, state, user_id, user_org_id, user_agent_id, audience, auth_methods_references, auth_time) = ($1, $2, $3, $4, $5, $6, $7, $8, $9) WHERE (id = $10) AND (instance_id = $11)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.DeviceAuthStateApproved,
+								"user-id",
+								"org-id",
+								"agent-id",
+								database.StringArray{"client-id", "project-id"},
+								database.StringArray{"pwd"},
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeviceAuthDenied",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(deviceauth.DeniedEventType),
+					deviceauth.AggregateType,
+					nil,
+				), deviceauth.DeniedEventMapper),
+			},
+			reduce: (&deviceAuthProjection{}).reduceDeviceAuthDenied,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("device_auth"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.device_authorizations SET (change_date, sequence, state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.DeviceAuthStateDenied,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeviceAuthRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(deviceauth.RemovedEventType),
+					deviceauth.AggregateType,
+					nil,
+				), deviceauth.RemovedEventMapper),
+			},
+			reduce: (&deviceAuthProjection{}).reduceDeviceAuthRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("device_auth"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.device_authorizations WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(DeviceAuthInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.device_authorizations WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, DeviceAuthTable, tt.want)
+		})
+	}
+}
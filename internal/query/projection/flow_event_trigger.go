@@ -0,0 +1,127 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	FlowEventTriggerTable                    = "projections.flow_event_triggers"
+	FlowEventTriggerEventTypeCol             = "event_type"
+	FlowEventTriggerChangeDateCol            = "change_date"
+	FlowEventTriggerSequenceCol              = "sequence"
+	FlowEventTriggerResourceOwnerCol         = "resource_owner"
+	FlowEventTriggerInstanceIDCol            = "instance_id"
+	FlowEventTriggerActionTriggerSequenceCol = "trigger_sequence"
+	FlowEventTriggerActionIDCol              = "action_id"
+	FlowEventTriggerOwnerRemovedCol          = "owner_removed"
+)
+
+type flowEventTriggerProjection struct {
+	crdb.StatementHandler
+}
+
+func newFlowEventTriggerProjection(ctx context.Context, config crdb.StatementHandlerConfig) *flowEventTriggerProjection {
+	p := new(flowEventTriggerProjection)
+	config.ProjectionName = FlowEventTriggerTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(FlowEventTriggerEventTypeCol, crdb.ColumnTypeText),
+			crdb.NewColumn(FlowEventTriggerChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(FlowEventTriggerSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(FlowEventTriggerResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(FlowEventTriggerInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(FlowEventTriggerActionTriggerSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(FlowEventTriggerActionIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(FlowEventTriggerOwnerRemovedCol, crdb.ColumnTypeBool, crdb.Default(false)),
+		},
+			crdb.NewPrimaryKey(FlowEventTriggerInstanceIDCol, FlowEventTriggerEventTypeCol, FlowEventTriggerResourceOwnerCol, FlowEventTriggerActionIDCol),
+			crdb.WithIndex(crdb.NewIndex("owner_removed", []string{FlowEventTriggerOwnerRemovedCol})),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *flowEventTriggerProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.EventTriggerActionsSetEventType,
+					Reduce: p.reduceEventTriggerActionsSet,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(FlowEventTriggerInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *flowEventTriggerProjection) reduceEventTriggerActionsSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.EventTriggerActionsSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Pv7gd", "reduce.wrong.event.type %s", org.EventTriggerActionsSetEventType)
+	}
+	stmts := make([]func(reader eventstore.Event) crdb.Exec, len(e.ActionIDs)+1)
+	stmts[0] = crdb.AddDeleteStatement(
+		[]handler.Condition{
+			handler.NewCond(FlowEventTriggerEventTypeCol, e.EventType),
+			handler.NewCond(FlowEventTriggerResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCond(FlowEventTriggerInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	)
+	for i, id := range e.ActionIDs {
+		stmts[i+1] = crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(FlowEventTriggerResourceOwnerCol, e.Aggregate().ResourceOwner),
+				handler.NewCol(FlowEventTriggerInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCol(FlowEventTriggerEventTypeCol, e.EventType),
+				handler.NewCol(FlowEventTriggerChangeDateCol, e.CreationDate()),
+				handler.NewCol(FlowEventTriggerSequenceCol, e.Sequence()),
+				handler.NewCol(FlowEventTriggerActionIDCol, id),
+				handler.NewCol(FlowEventTriggerActionTriggerSequenceCol, i),
+			},
+		)
+	}
+	return crdb.NewMultiStatement(e, stmts...), nil
+}
+
+func (p *flowEventTriggerProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ug5mh", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(FlowEventTriggerChangeDateCol, e.CreationDate()),
+			handler.NewCol(FlowEventTriggerSequenceCol, e.Sequence()),
+			handler.NewCol(FlowEventTriggerOwnerRemovedCol, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(FlowEventTriggerInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(FlowEventTriggerResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
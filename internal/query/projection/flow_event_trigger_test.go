@@ -0,0 +1,174 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestFlowEventTriggerProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceEventTriggerActionsSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.EventTriggerActionsSetEventType),
+					org.AggregateType,
+					[]byte(`{"eventType": "user.removed", "actionIDs": ["id1", "id2"]}`),
+				), org.EventTriggerActionsSetEventMapper),
+			},
+			reduce: (&flowEventTriggerProjection{}).reduceEventTriggerActionsSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.flow_event_triggers WHERE (event_type = $1) AND (resource_owner = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								eventstore.EventType("user.removed"),
+								"ro-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.flow_event_triggers (resource_owner, instance_id, event_type, change_date, sequence, action_id, trigger_sequence) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedArgs: []interface{}{
+								"ro-id",
+								"instance-id",
+								eventstore.EventType("user.removed"),
+								anyArg{},
+								uint64(15),
+								"id1",
+								0,
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.flow_event_triggers (resource_owner, instance_id, event_type, change_date, sequence, action_id, trigger_sequence) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedArgs: []interface{}{
+								"ro-id",
+								"instance-id",
+								eventstore.EventType("user.removed"),
+								anyArg{},
+								uint64(15),
+								"id2",
+								1,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceEventTriggerActionsSet, removed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.EventTriggerActionsSetEventType),
+					org.AggregateType,
+					[]byte(`{"eventType": "user.removed"}`),
+				), org.EventTriggerActionsSetEventMapper),
+			},
+			reduce: (&flowEventTriggerProjection{}).reduceEventTriggerActionsSet,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.flow_event_triggers WHERE (event_type = $1) AND (resource_owner = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								eventstore.EventType("user.removed"),
+								"ro-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org.reduceOwnerRemoved",
+			reduce: (&flowEventTriggerProjection{}).reduceOwnerRemoved,
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.flow_event_triggers SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(FlowEventTriggerInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.flow_event_triggers WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, FlowEventTriggerTable, tt.want)
+		})
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 const (
-	OIDCSettingsProjectionTable = "projections.oidc_settings2"
+	OIDCSettingsProjectionTable = "projections.oidc_settings3"
 
 	OIDCSettingsColumnAggregateID                = "aggregate_id"
 	OIDCSettingsColumnCreationDate               = "creation_date"
@@ -23,6 +23,9 @@ const (
 	OIDCSettingsColumnIdTokenLifetime            = "id_token_lifetime"
 	OIDCSettingsColumnRefreshTokenIdleExpiration = "refresh_token_idle_expiration"
 	OIDCSettingsColumnRefreshTokenExpiration     = "refresh_token_expiration"
+	OIDCSettingsColumnSigningKeyAlgorithm        = "signing_key_algorithm"
+	OIDCSettingsColumnSigningKeyLifetime         = "signing_key_lifetime"
+	OIDCSettingsColumnSigningKeyOverlap          = "signing_key_overlap"
 )
 
 type oidcSettingsProjection struct {
@@ -45,6 +48,9 @@ func newOIDCSettingsProjection(ctx context.Context, config crdb.StatementHandler
 			crdb.NewColumn(OIDCSettingsColumnIdTokenLifetime, crdb.ColumnTypeInt64),
 			crdb.NewColumn(OIDCSettingsColumnRefreshTokenIdleExpiration, crdb.ColumnTypeInt64),
 			crdb.NewColumn(OIDCSettingsColumnRefreshTokenExpiration, crdb.ColumnTypeInt64),
+			crdb.NewColumn(OIDCSettingsColumnSigningKeyAlgorithm, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(OIDCSettingsColumnSigningKeyLifetime, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(OIDCSettingsColumnSigningKeyOverlap, crdb.ColumnTypeInt64, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(OIDCSettingsColumnInstanceID, OIDCSettingsColumnAggregateID),
 		),
@@ -93,6 +99,9 @@ func (p *oidcSettingsProjection) reduceOIDCSettingsAdded(event eventstore.Event)
 			handler.NewCol(OIDCSettingsColumnIdTokenLifetime, e.IdTokenLifetime),
 			handler.NewCol(OIDCSettingsColumnRefreshTokenIdleExpiration, e.RefreshTokenIdleExpiration),
 			handler.NewCol(OIDCSettingsColumnRefreshTokenExpiration, e.RefreshTokenExpiration),
+			handler.NewCol(OIDCSettingsColumnSigningKeyAlgorithm, e.SigningKeyAlgorithm),
+			handler.NewCol(OIDCSettingsColumnSigningKeyLifetime, e.SigningKeyLifetime),
+			handler.NewCol(OIDCSettingsColumnSigningKeyOverlap, e.SigningKeyOverlap),
 		},
 	), nil
 }
@@ -103,7 +112,7 @@ func (p *oidcSettingsProjection) reduceOIDCSettingsChanged(event eventstore.Even
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-8JJ2d", "reduce.wrong.event.type %s", instance.OIDCSettingsChangedEventType)
 	}
 
-	columns := make([]handler.Column, 0, 6)
+	columns := make([]handler.Column, 0, 9)
 	columns = append(columns,
 		handler.NewCol(OIDCSettingsColumnChangeDate, e.CreationDate()),
 		handler.NewCol(OIDCSettingsColumnSequence, e.Sequence()),
@@ -120,6 +129,15 @@ func (p *oidcSettingsProjection) reduceOIDCSettingsChanged(event eventstore.Even
 	if e.RefreshTokenExpiration != nil {
 		columns = append(columns, handler.NewCol(OIDCSettingsColumnRefreshTokenExpiration, *e.RefreshTokenExpiration))
 	}
+	if e.SigningKeyAlgorithm != nil {
+		columns = append(columns, handler.NewCol(OIDCSettingsColumnSigningKeyAlgorithm, *e.SigningKeyAlgorithm))
+	}
+	if e.SigningKeyLifetime != nil {
+		columns = append(columns, handler.NewCol(OIDCSettingsColumnSigningKeyLifetime, *e.SigningKeyLifetime))
+	}
+	if e.SigningKeyOverlap != nil {
+		columns = append(columns, handler.NewCol(OIDCSettingsColumnSigningKeyOverlap, *e.SigningKeyOverlap))
+	}
 	return crdb.NewUpdateStatement(
 		e,
 		columns,
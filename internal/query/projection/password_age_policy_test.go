@@ -30,7 +30,8 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 					org.AggregateType,
 					[]byte(`{
 						"expireWarnDays": 10,
-						"maxAgeDays": 13
+						"maxAgeDays": 13,
+						"historyCount": 5
 }`),
 				), org.PasswordAgePolicyAddedEventMapper),
 			},
@@ -42,7 +43,7 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.password_age_policies2 (creation_date, change_date, sequence, id, state, expire_warn_days, max_age_days, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.password_age_policies3 (creation_date, change_date, sequence, id, state, expire_warn_days, max_age_days, history_count, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -51,6 +52,7 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 								domain.PolicyStateActive,
 								uint64(10),
 								uint64(13),
+								uint64(5),
 								false,
 								"ro-id",
 								"instance-id",
@@ -69,7 +71,8 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 					org.AggregateType,
 					[]byte(`{
 						"expireWarnDays": 10,
-						"maxAgeDays": 13
+						"maxAgeDays": 13,
+						"historyCount": 5
 		}`),
 				), org.PasswordAgePolicyChangedEventMapper),
 			},
@@ -80,12 +83,13 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.password_age_policies2 SET (change_date, sequence, expire_warn_days, max_age_days) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedStmt: "UPDATE projections.password_age_policies3 SET (change_date, sequence, expire_warn_days, max_age_days, history_count) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								uint64(10),
 								uint64(13),
+								uint64(5),
 								"agg-id",
 								"instance-id",
 							},
@@ -111,7 +115,7 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.password_age_policies2 WHERE (id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.password_age_policies3 WHERE (id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -138,7 +142,7 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.password_age_policies2 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.password_age_policies3 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -156,7 +160,8 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 					instance.AggregateType,
 					[]byte(`{
 						"expireWarnDays": 10,
-						"maxAgeDays": 13
+						"maxAgeDays": 13,
+						"historyCount": 5
 					}`),
 				), instance.PasswordAgePolicyAddedEventMapper),
 			},
@@ -167,7 +172,7 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.password_age_policies2 (creation_date, change_date, sequence, id, state, expire_warn_days, max_age_days, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.password_age_policies3 (creation_date, change_date, sequence, id, state, expire_warn_days, max_age_days, history_count, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -176,6 +181,7 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 								domain.PolicyStateActive,
 								uint64(10),
 								uint64(13),
+								uint64(5),
 								true,
 								"ro-id",
 								"instance-id",
@@ -194,7 +200,8 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 					instance.AggregateType,
 					[]byte(`{
 						"expireWarnDays": 10,
-						"maxAgeDays": 13
+						"maxAgeDays": 13,
+						"historyCount": 5
 					}`),
 				), instance.PasswordAgePolicyChangedEventMapper),
 			},
@@ -205,12 +212,13 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.password_age_policies2 SET (change_date, sequence, expire_warn_days, max_age_days) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedStmt: "UPDATE projections.password_age_policies3 SET (change_date, sequence, expire_warn_days, max_age_days, history_count) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								uint64(10),
 								uint64(13),
+								uint64(5),
 								"agg-id",
 								"instance-id",
 							},
@@ -236,7 +244,7 @@ func TestPasswordAgeProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.password_age_policies2 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedStmt: "UPDATE projections.password_age_policies3 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
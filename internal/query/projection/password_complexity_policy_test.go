@@ -33,7 +33,8 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 	"hasLowercase": true,
 	"hasUppercase": true,
 	"HasNumber": true,
-	"HasSymbol": true
+	"HasSymbol": true,
+	"checkBreached": true
 }`),
 				), org.PasswordComplexityPolicyAddedEventMapper),
 			},
@@ -45,7 +46,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.password_complexity_policies2 (creation_date, change_date, sequence, id, state, min_length, has_lowercase, has_uppercase, has_symbol, has_number, resource_owner, instance_id, is_default) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedStmt: "INSERT INTO projections.password_complexity_policies3 (creation_date, change_date, sequence, id, state, min_length, has_lowercase, has_uppercase, has_symbol, has_number, check_breached, resource_owner, instance_id, is_default) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -57,6 +58,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 								true,
 								true,
 								true,
+								true,
 								"ro-id",
 								"instance-id",
 								false,
@@ -78,7 +80,8 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 			"hasLowercase": true,
 			"hasUppercase": true,
 			"HasNumber": true,
-			"HasSymbol": true
+			"HasSymbol": true,
+			"checkBreached": true
 		}`),
 				), org.PasswordComplexityPolicyChangedEventMapper),
 			},
@@ -89,7 +92,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.password_complexity_policies2 SET (change_date, sequence, min_length, has_lowercase, has_uppercase, has_symbol, has_number) = ($1, $2, $3, $4, $5, $6, $7) WHERE (id = $8) AND (instance_id = $9)",
+							expectedStmt: "UPDATE projections.password_complexity_policies3 SET (change_date, sequence, min_length, has_lowercase, has_uppercase, has_symbol, has_number, check_breached) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (id = $9) AND (instance_id = $10)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -98,6 +101,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 								true,
 								true,
 								true,
+								true,
 								"agg-id",
 								"instance-id",
 							},
@@ -123,7 +127,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.password_complexity_policies2 WHERE (id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.password_complexity_policies3 WHERE (id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -150,7 +154,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.password_complexity_policies2 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.password_complexity_policies3 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -171,7 +175,8 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 			"hasLowercase": true,
 			"hasUppercase": true,
 			"HasNumber": true,
-			"HasSymbol": true
+			"HasSymbol": true,
+			"checkBreached": true
 					}`),
 				), instance.PasswordComplexityPolicyAddedEventMapper),
 			},
@@ -182,7 +187,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.password_complexity_policies2 (creation_date, change_date, sequence, id, state, min_length, has_lowercase, has_uppercase, has_symbol, has_number, resource_owner, instance_id, is_default) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedStmt: "INSERT INTO projections.password_complexity_policies3 (creation_date, change_date, sequence, id, state, min_length, has_lowercase, has_uppercase, has_symbol, has_number, check_breached, resource_owner, instance_id, is_default) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -194,6 +199,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 								true,
 								true,
 								true,
+								true,
 								"ro-id",
 								"instance-id",
 								true,
@@ -215,7 +221,8 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 			"hasLowercase": true,
 			"hasUppercase": true,
 			"HasNumber": true,
-			"HasSymbol": true
+			"HasSymbol": true,
+			"checkBreached": true
 					}`),
 				), instance.PasswordComplexityPolicyChangedEventMapper),
 			},
@@ -226,7 +233,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.password_complexity_policies2 SET (change_date, sequence, min_length, has_lowercase, has_uppercase, has_symbol, has_number) = ($1, $2, $3, $4, $5, $6, $7) WHERE (id = $8) AND (instance_id = $9)",
+							expectedStmt: "UPDATE projections.password_complexity_policies3 SET (change_date, sequence, min_length, has_lowercase, has_uppercase, has_symbol, has_number, check_breached) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (id = $9) AND (instance_id = $10)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -235,6 +242,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 								true,
 								true,
 								true,
+								true,
 								"agg-id",
 								"instance-id",
 							},
@@ -260,7 +268,7 @@ func TestPasswordComplexityProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.password_complexity_policies2 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedStmt: "UPDATE projections.password_complexity_policies3 SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
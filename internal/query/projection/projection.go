@@ -22,6 +22,10 @@ var (
 	OrgMetadataProjection               *orgMetadataProjection
 	ActionProjection                    *actionProjection
 	FlowProjection                      *flowProjection
+	FlowEventTriggerProjection          *flowEventTriggerProjection
+	ActionSecretProjection              *actionSecretProjection
+	ActionStorageProjection             *actionStorageProjection
+	ActionLibraryProjection             *actionLibraryProjection
 	ProjectProjection                   *projectProjection
 	PasswordComplexityProjection        *passwordComplexityProjection
 	PasswordAgeProjection               *passwordAgeProjection
@@ -62,6 +66,8 @@ var (
 	KeyProjection                       *keyProjection
 	SecurityPolicyProjection            *securityPolicyProjection
 	NotificationPolicyProjection        *notificationPolicyProjection
+	WebhookProjection                   *webhookProjection
+	DeviceAuthProjection                *deviceAuthProjection
 	NotificationsProjection             interface{}
 )
 
@@ -97,6 +103,10 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	OrgMetadataProjection = newOrgMetadataProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_metadata"]))
 	ActionProjection = newActionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["actions"]))
 	FlowProjection = newFlowProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["flows"]))
+	FlowEventTriggerProjection = newFlowEventTriggerProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["flow_event_triggers"]))
+	ActionSecretProjection = newActionSecretProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["action_secrets"]))
+	ActionStorageProjection = newActionStorageProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["action_storage"]))
+	ActionLibraryProjection = newActionLibraryProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["action_libraries"]))
 	ProjectProjection = newProjectProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["projects"]))
 	PasswordComplexityProjection = newPasswordComplexityProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_complexities"]))
 	PasswordAgeProjection = newPasswordAgeProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_age_policy"]))
@@ -137,6 +147,8 @@ func Create(ctx context.Context, sqlClient *database.DB, es *eventstore.Eventsto
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
+	WebhookProjection = newWebhookProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["webhooks"]))
+	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_authorizations"]))
 	newProjectionsList()
 	return nil
 }
@@ -189,6 +201,10 @@ func newProjectionsList() {
 		OrgMetadataProjection,
 		ActionProjection,
 		FlowProjection,
+		FlowEventTriggerProjection,
+		ActionSecretProjection,
+		ActionStorageProjection,
+		ActionLibraryProjection,
 		ProjectProjection,
 		PasswordComplexityProjection,
 		PasswordAgeProjection,
@@ -229,5 +245,7 @@ func newProjectionsList() {
 		KeyProjection,
 		SecurityPolicyProjection,
 		NotificationPolicyProjection,
+		WebhookProjection,
+		DeviceAuthProjection,
 	}
 }
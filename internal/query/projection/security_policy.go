@@ -11,13 +11,19 @@ import (
 )
 
 const (
-	SecurityPolicyProjectionTable      = "projections.security_policies"
-	SecurityPolicyColumnInstanceID     = "instance_id"
-	SecurityPolicyColumnCreationDate   = "creation_date"
-	SecurityPolicyColumnChangeDate     = "change_date"
-	SecurityPolicyColumnSequence       = "sequence"
-	SecurityPolicyColumnEnabled        = "enabled"
-	SecurityPolicyColumnAllowedOrigins = "origins"
+	SecurityPolicyProjectionTable              = "projections.security_policies3"
+	SecurityPolicyColumnInstanceID             = "instance_id"
+	SecurityPolicyColumnCreationDate           = "creation_date"
+	SecurityPolicyColumnChangeDate             = "change_date"
+	SecurityPolicyColumnSequence               = "sequence"
+	SecurityPolicyColumnEnabled                = "enabled"
+	SecurityPolicyColumnAllowedOrigins         = "origins"
+	SecurityPolicyColumnTokenExchangeClientIDs = "token_exchange_client_ids"
+	SecurityPolicyColumnImpersonationClientIDs = "impersonation_client_ids"
+
+	SecurityPolicyColumnLoginRateLimitMaxAttemptsPerIP        = "login_rate_limit_max_attempts_per_ip"
+	SecurityPolicyColumnLoginRateLimitMaxAttemptsPerLoginName = "login_rate_limit_max_attempts_per_login_name"
+	SecurityPolicyColumnLoginRateLimitWindow                  = "login_rate_limit_window"
 )
 
 type securityPolicyProjection struct {
@@ -36,6 +42,11 @@ func newSecurityPolicyProjection(ctx context.Context, config crdb.StatementHandl
 			crdb.NewColumn(SecurityPolicyColumnSequence, crdb.ColumnTypeInt64),
 			crdb.NewColumn(SecurityPolicyColumnEnabled, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(SecurityPolicyColumnAllowedOrigins, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(SecurityPolicyColumnTokenExchangeClientIDs, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(SecurityPolicyColumnImpersonationClientIDs, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(SecurityPolicyColumnLoginRateLimitMaxAttemptsPerIP, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(SecurityPolicyColumnLoginRateLimitMaxAttemptsPerLoginName, crdb.ColumnTypeInt64, crdb.Default(0)),
+			crdb.NewColumn(SecurityPolicyColumnLoginRateLimitWindow, crdb.ColumnTypeInt64, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(SecurityPolicyColumnInstanceID),
 		),
@@ -79,6 +90,21 @@ func (p *securityPolicyProjection) reduceSecurityPolicySet(event eventstore.Even
 	if e.AllowedOrigins != nil {
 		changes = append(changes, handler.NewCol(SecurityPolicyColumnAllowedOrigins, e.AllowedOrigins))
 	}
+	if e.TokenExchangeClientIDs != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnTokenExchangeClientIDs, e.TokenExchangeClientIDs))
+	}
+	if e.ImpersonationClientIDs != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnImpersonationClientIDs, e.ImpersonationClientIDs))
+	}
+	if e.LoginRateLimitMaxAttemptsPerIP != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnLoginRateLimitMaxAttemptsPerIP, *e.LoginRateLimitMaxAttemptsPerIP))
+	}
+	if e.LoginRateLimitMaxAttemptsPerLoginName != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnLoginRateLimitMaxAttemptsPerLoginName, *e.LoginRateLimitMaxAttemptsPerLoginName))
+	}
+	if e.LoginRateLimitWindow != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnLoginRateLimitWindow, *e.LoginRateLimitWindow))
+	}
 	return crdb.NewUpsertStatement(
 		e,
 		[]handler.Column{
@@ -12,8 +12,10 @@ import (
 )
 
 const (
-	SMSConfigProjectionTable = "projections.sms_configs2"
+	SMSConfigProjectionTable = "projections.sms_configs3"
 	SMSTwilioTable           = SMSConfigProjectionTable + "_" + smsTwilioTableSuffix
+	SMSHTTPTable             = SMSConfigProjectionTable + "_" + smsHTTPTableSuffix
+	SMSVonageTable           = SMSConfigProjectionTable + "_" + smsVonageTableSuffix
 
 	SMSColumnID            = "id"
 	SMSColumnAggregateID   = "aggregate_id"
@@ -30,6 +32,21 @@ const (
 	SMSTwilioConfigColumnSID          = "sid"
 	SMSTwilioConfigColumnSenderNumber = "sender_number"
 	SMSTwilioConfigColumnToken        = "token"
+
+	smsHTTPTableSuffix              = "http"
+	SMSHTTPConfigColumnSMSID        = "sms_id"
+	SMSHTTPColumnInstanceID         = "instance_id"
+	SMSHTTPConfigColumnEndpoint     = "endpoint"
+	SMSHTTPConfigColumnHeaders      = "headers"
+	SMSHTTPConfigColumnBodyTemplate = "body_template"
+	SMSHTTPConfigColumnSenderNumber = "sender_number"
+
+	smsVonageTableSuffix              = "vonage"
+	SMSVonageConfigColumnSMSID        = "sms_id"
+	SMSVonageColumnInstanceID         = "instance_id"
+	SMSVonageConfigColumnAPIKey       = "api_key"
+	SMSVonageConfigColumnAPISecret    = "api_secret"
+	SMSVonageConfigColumnSenderNumber = "sender_number"
 )
 
 type smsConfigProjection struct {
@@ -64,6 +81,29 @@ func newSMSConfigProjection(ctx context.Context, config crdb.StatementHandlerCon
 			smsTwilioTableSuffix,
 			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys()),
 		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(SMSHTTPConfigColumnSMSID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPConfigColumnEndpoint, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSHTTPConfigColumnHeaders, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(SMSHTTPConfigColumnBodyTemplate, crdb.ColumnTypeText, crdb.Default("")),
+			crdb.NewColumn(SMSHTTPConfigColumnSenderNumber, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(SMSHTTPColumnInstanceID, SMSHTTPConfigColumnSMSID),
+			smsHTTPTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys()),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(SMSVonageConfigColumnSMSID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSVonageColumnInstanceID, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSVonageConfigColumnAPIKey, crdb.ColumnTypeText),
+			crdb.NewColumn(SMSVonageConfigColumnAPISecret, crdb.ColumnTypeJSONB),
+			crdb.NewColumn(SMSVonageConfigColumnSenderNumber, crdb.ColumnTypeText),
+		},
+			crdb.NewPrimaryKey(SMSVonageColumnInstanceID, SMSVonageConfigColumnSMSID),
+			smsVonageTableSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys()),
+		),
 	)
 	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
 	return p
@@ -86,6 +126,30 @@ func (p *smsConfigProjection) reducers() []handler.AggregateReducer {
 					Event:  instance.SMSConfigTwilioTokenChangedEventType,
 					Reduce: p.reduceSMSConfigTwilioTokenChanged,
 				},
+				{
+					Event:  instance.SMSConfigHTTPAddedEventType,
+					Reduce: p.reduceSMSConfigHTTPAdded,
+				},
+				{
+					Event:  instance.SMSConfigHTTPChangedEventType,
+					Reduce: p.reduceSMSConfigHTTPChanged,
+				},
+				{
+					Event:  instance.SMSConfigHTTPHeadersChangedEventType,
+					Reduce: p.reduceSMSConfigHTTPHeadersChanged,
+				},
+				{
+					Event:  instance.SMSConfigVonageAddedEventType,
+					Reduce: p.reduceSMSConfigVonageAdded,
+				},
+				{
+					Event:  instance.SMSConfigVonageChangedEventType,
+					Reduce: p.reduceSMSConfigVonageChanged,
+				},
+				{
+					Event:  instance.SMSConfigVonageAPISecretChangedEventType,
+					Reduce: p.reduceSMSConfigVonageAPISecretChanged,
+				},
 				{
 					Event:  instance.SMSConfigActivatedEventType,
 					Reduce: p.reduceSMSConfigActivated,
@@ -209,6 +273,180 @@ func (p *smsConfigProjection) reduceSMSConfigTwilioTokenChanged(event eventstore
 	), nil
 }
 
+func (p *smsConfigProjection) reduceSMSConfigHTTPAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Hw3sf", "reduce.wrong.event.type %s", instance.SMSConfigHTTPAddedEventType)
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		addSMSConfigStatement(e, e.ID),
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCol(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSHTTPConfigColumnEndpoint, e.Endpoint),
+				handler.NewCol(SMSHTTPConfigColumnHeaders, e.Headers),
+				handler.NewCol(SMSHTTPConfigColumnBodyTemplate, e.BodyTemplate),
+				handler.NewCol(SMSHTTPConfigColumnSenderNumber, e.SenderNumber),
+			},
+			crdb.WithTableSuffix(smsHTTPTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigHTTPChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Kd2fw", "reduce.wrong.event.type %s", instance.SMSConfigHTTPChangedEventType)
+	}
+	columns := make([]handler.Column, 0)
+	if e.Endpoint != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnEndpoint, *e.Endpoint))
+	}
+	if e.BodyTemplate != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnBodyTemplate, *e.BodyTemplate))
+	}
+	if e.SenderNumber != nil {
+		columns = append(columns, handler.NewCol(SMSHTTPConfigColumnSenderNumber, *e.SenderNumber))
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(smsHTTPTableSuffix),
+		),
+		updateSMSConfigStatement(e, e.ID),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigHTTPHeadersChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigHTTPHeadersChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Wq3sd", "reduce.wrong.event.type %s", instance.SMSConfigHTTPHeadersChangedEventType)
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSHTTPConfigColumnHeaders, e.Headers),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSHTTPConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(smsHTTPTableSuffix),
+		),
+		updateSMSConfigStatement(e, e.ID),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigVonageAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigVonageAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Gs3fq", "reduce.wrong.event.type %s", instance.SMSConfigVonageAddedEventType)
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		addSMSConfigStatement(e, e.ID),
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSVonageConfigColumnSMSID, e.ID),
+				handler.NewCol(SMSVonageColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSVonageConfigColumnAPIKey, e.APIKey),
+				handler.NewCol(SMSVonageConfigColumnAPISecret, e.APISecret),
+				handler.NewCol(SMSVonageConfigColumnSenderNumber, e.SenderNumber),
+			},
+			crdb.WithTableSuffix(smsVonageTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigVonageChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigVonageChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ps2fw", "reduce.wrong.event.type %s", instance.SMSConfigVonageChangedEventType)
+	}
+	columns := make([]handler.Column, 0)
+	if e.APIKey != nil {
+		columns = append(columns, handler.NewCol(SMSVonageConfigColumnAPIKey, *e.APIKey))
+	}
+	if e.SenderNumber != nil {
+		columns = append(columns, handler.NewCol(SMSVonageConfigColumnSenderNumber, *e.SenderNumber))
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSVonageConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSVonageColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(smsVonageTableSuffix),
+		),
+		updateSMSConfigStatement(e, e.ID),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigVonageAPISecretChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.SMSConfigVonageAPISecretChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lq3fd", "reduce.wrong.event.type %s", instance.SMSConfigVonageAPISecretChangedEventType)
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSVonageConfigColumnAPISecret, e.APISecret),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSVonageConfigColumnSMSID, e.ID),
+				handler.NewCond(SMSVonageColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(smsVonageTableSuffix),
+		),
+		updateSMSConfigStatement(e, e.ID),
+	), nil
+}
+
+func addSMSConfigStatement(e eventstore.Event, id string) func(eventstore.Event) crdb.Exec {
+	return crdb.AddCreateStatement(
+		[]handler.Column{
+			handler.NewCol(SMSColumnID, id),
+			handler.NewCol(SMSColumnAggregateID, e.Aggregate().ID),
+			handler.NewCol(SMSColumnCreationDate, e.CreationDate()),
+			handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+			handler.NewCol(SMSColumnSequence, e.Sequence()),
+		},
+	)
+}
+
+func updateSMSConfigStatement(e eventstore.Event, id string) func(eventstore.Event) crdb.Exec {
+	return crdb.AddUpdateStatement(
+		[]handler.Column{
+			handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMSColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMSColumnID, id),
+			handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	)
+}
+
 func (p *smsConfigProjection) reduceSMSConfigActivated(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*instance.SMSConfigActivatedEvent)
 	if !ok {
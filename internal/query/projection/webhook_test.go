@@ -0,0 +1,294 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/webhook"
+)
+
+func TestWebhookProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceWebhookAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.AddedEventType),
+					webhook.AggregateType,
+					[]byte(`{
+	"name": "name",
+	"url": "https://example.com/hook",
+	"aggregateTypes": ["user"],
+	"eventTypes": ["user.human.added"],
+	"signingKey": {
+        "cryptoType": 0,
+        "algorithm": "enc",
+        "keyId": "key-id"
+    }
+}`),
+				), webhook.AddedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.webhooks (id, creation_date, change_date, resource_owner, instance_id, sequence, creation_sequence, name, url, aggregate_types, event_types, signing_key, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								uint64(15),
+								uint64(15),
+								"name",
+								"https://example.com/hook",
+								database.StringArray{"user"},
+								database.StringArray{"user.human.added"},
+								anyArg{},
+								domain.WebhookStateActive,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWebhookChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.ChangedEventType),
+					webhook.AggregateType,
+					[]byte(`{"name": "name2", "url": "https://example.com/hook2", "eventTypes": []}`),
+				), webhook.ChangedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.webhooks SET (change_date, sequence, name, url, event_types) = ($1, $2, $3, $4, $5) WHERE (id = $6) AND (instance_id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"name2",
+								"https://example.com/hook2",
+								database.StringArray{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWebhookSigningKeyChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.SigningKeyChangedEventType),
+					webhook.AggregateType,
+					[]byte(`{"signingKey": {"cryptoType": 0, "algorithm": "enc", "keyId": "key-id"}}`),
+				), webhook.SigningKeyChangedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookSigningKeyChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.webhooks SET (change_date, sequence, signing_key) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWebhookDeactivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.DeactivatedEventType),
+					webhook.AggregateType,
+					nil,
+				), webhook.DeactivatedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookDeactivated,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.webhooks SET (change_date, sequence, state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.WebhookStateInactive,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWebhookReactivated",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.ReactivatedEventType),
+					webhook.AggregateType,
+					nil,
+				), webhook.ReactivatedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookReactivated,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.webhooks SET (change_date, sequence, state) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.WebhookStateActive,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWebhookRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(webhook.RemovedEventType),
+					webhook.AggregateType,
+					nil,
+				), webhook.RemovedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceWebhookRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("webhook"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webhooks WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&webhookProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.webhooks SET (change_date, sequence, owner_removed) = ($1, $2, $3) WHERE (instance_id = $4) AND (resource_owner = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.InstanceRemovedEventType),
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(WebhookInstanceIDCol),
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.webhooks WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, WebhookTable, tt.want)
+		})
+	}
+}
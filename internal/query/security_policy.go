@@ -44,6 +44,26 @@ var (
 		name:  projection.SecurityPolicyColumnAllowedOrigins,
 		table: securityPolicyTable,
 	}
+	SecurityPolicyColumnTokenExchangeClientIDs = Column{
+		name:  projection.SecurityPolicyColumnTokenExchangeClientIDs,
+		table: securityPolicyTable,
+	}
+	SecurityPolicyColumnImpersonationClientIDs = Column{
+		name:  projection.SecurityPolicyColumnImpersonationClientIDs,
+		table: securityPolicyTable,
+	}
+	SecurityPolicyColumnLoginRateLimitMaxAttemptsPerIP = Column{
+		name:  projection.SecurityPolicyColumnLoginRateLimitMaxAttemptsPerIP,
+		table: securityPolicyTable,
+	}
+	SecurityPolicyColumnLoginRateLimitMaxAttemptsPerLoginName = Column{
+		name:  projection.SecurityPolicyColumnLoginRateLimitMaxAttemptsPerLoginName,
+		table: securityPolicyTable,
+	}
+	SecurityPolicyColumnLoginRateLimitWindow = Column{
+		name:  projection.SecurityPolicyColumnLoginRateLimitWindow,
+		table: securityPolicyTable,
+	}
 )
 
 type SecurityPolicy struct {
@@ -53,8 +73,33 @@ type SecurityPolicy struct {
 	ResourceOwner string
 	Sequence      uint64
 
-	Enabled        bool
-	AllowedOrigins database.StringArray
+	Enabled                bool
+	AllowedOrigins         database.StringArray
+	TokenExchangeClientIDs database.StringArray
+	ImpersonationClientIDs database.StringArray
+
+	LoginRateLimitMaxAttemptsPerIP        uint64
+	LoginRateLimitMaxAttemptsPerLoginName uint64
+	LoginRateLimitWindow                  time.Duration
+}
+
+// TokenExchangeAllowed returns if the client is allowed to use the token exchange grant
+func (p *SecurityPolicy) TokenExchangeAllowed(clientID string) bool {
+	return containsClientID(p.TokenExchangeClientIDs, clientID)
+}
+
+// ImpersonationAllowed returns if the client is allowed to impersonate users by token exchange
+func (p *SecurityPolicy) ImpersonationAllowed(clientID string) bool {
+	return containsClientID(p.ImpersonationClientIDs, clientID)
+}
+
+func containsClientID(clientIDs []string, clientID string) bool {
+	for _, id := range clientIDs {
+		if id == clientID {
+			return true
+		}
+	}
+	return false
 }
 
 func (q *Queries) SecurityPolicy(ctx context.Context) (*SecurityPolicy, error) {
@@ -78,7 +123,12 @@ func prepareSecurityPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 			SecurityPolicyColumnInstanceID.identifier(),
 			SecurityPolicyColumnSequence.identifier(),
 			SecurityPolicyColumnEnabled.identifier(),
-			SecurityPolicyColumnAllowedOrigins.identifier()).
+			SecurityPolicyColumnAllowedOrigins.identifier(),
+			SecurityPolicyColumnTokenExchangeClientIDs.identifier(),
+			SecurityPolicyColumnImpersonationClientIDs.identifier(),
+			SecurityPolicyColumnLoginRateLimitMaxAttemptsPerIP.identifier(),
+			SecurityPolicyColumnLoginRateLimitMaxAttemptsPerLoginName.identifier(),
+			SecurityPolicyColumnLoginRateLimitWindow.identifier()).
 			From(securityPolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SecurityPolicy, error) {
@@ -91,6 +141,11 @@ func prepareSecurityPolicyQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 				&securityPolicy.Sequence,
 				&securityPolicy.Enabled,
 				&securityPolicy.AllowedOrigins,
+				&securityPolicy.TokenExchangeClientIDs,
+				&securityPolicy.ImpersonationClientIDs,
+				&securityPolicy.LoginRateLimitMaxAttemptsPerIP,
+				&securityPolicy.LoginRateLimitMaxAttemptsPerLoginName,
+				&securityPolicy.LoginRateLimitWindow,
 			)
 			if err != nil && !errs.Is(err, sql.ErrNoRows) { // ignore not found errors
 				return nil, errors.ThrowInternal(err, "QUERY-Dfrt2", "Errors.Internal")
@@ -32,6 +32,8 @@ type SMSConfig struct {
 	Sequence      uint64
 
 	TwilioConfig *Twilio
+	HTTPConfig   *HTTP
+	VonageConfig *Vonage
 }
 
 type Twilio struct {
@@ -40,6 +42,19 @@ type Twilio struct {
 	SenderNumber string
 }
 
+type HTTP struct {
+	Endpoint     string
+	Headers      *crypto.CryptoValue
+	BodyTemplate string
+	SenderNumber string
+}
+
+type Vonage struct {
+	APIKey       string
+	APISecret    *crypto.CryptoValue
+	SenderNumber string
+}
+
 type SMSConfigsSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -115,6 +130,56 @@ var (
 	}
 )
 
+var (
+	smsHTTPConfigsTable = table{
+		name:          projection.SMSHTTPTable,
+		instanceIDCol: projection.SMSHTTPColumnInstanceID,
+	}
+	SMSHTTPConfigColumnSMSID = Column{
+		name:  projection.SMSHTTPConfigColumnSMSID,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnEndpoint = Column{
+		name:  projection.SMSHTTPConfigColumnEndpoint,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnHeaders = Column{
+		name:  projection.SMSHTTPConfigColumnHeaders,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnBodyTemplate = Column{
+		name:  projection.SMSHTTPConfigColumnBodyTemplate,
+		table: smsHTTPConfigsTable,
+	}
+	SMSHTTPConfigColumnSenderNumber = Column{
+		name:  projection.SMSHTTPConfigColumnSenderNumber,
+		table: smsHTTPConfigsTable,
+	}
+)
+
+var (
+	smsVonageConfigsTable = table{
+		name:          projection.SMSVonageTable,
+		instanceIDCol: projection.SMSVonageColumnInstanceID,
+	}
+	SMSVonageConfigColumnSMSID = Column{
+		name:  projection.SMSVonageConfigColumnSMSID,
+		table: smsVonageConfigsTable,
+	}
+	SMSVonageConfigColumnAPIKey = Column{
+		name:  projection.SMSVonageConfigColumnAPIKey,
+		table: smsVonageConfigsTable,
+	}
+	SMSVonageConfigColumnAPISecret = Column{
+		name:  projection.SMSVonageConfigColumnAPISecret,
+		table: smsVonageConfigsTable,
+	}
+	SMSVonageConfigColumnSenderNumber = Column{
+		name:  projection.SMSVonageConfigColumnSenderNumber,
+		table: smsVonageConfigsTable,
+	}
+)
+
 func (q *Queries) SMSProviderConfigByID(ctx context.Context, id string) (_ *SMSConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -198,13 +263,28 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			SMSTwilioConfigColumnSID.identifier(),
 			SMSTwilioConfigColumnToken.identifier(),
 			SMSTwilioConfigColumnSenderNumber.identifier(),
+
+			SMSHTTPConfigColumnSMSID.identifier(),
+			SMSHTTPConfigColumnEndpoint.identifier(),
+			SMSHTTPConfigColumnHeaders.identifier(),
+			SMSHTTPConfigColumnBodyTemplate.identifier(),
+			SMSHTTPConfigColumnSenderNumber.identifier(),
+
+			SMSVonageConfigColumnSMSID.identifier(),
+			SMSVonageConfigColumnAPIKey.identifier(),
+			SMSVonageConfigColumnAPISecret.identifier(),
+			SMSVonageConfigColumnSenderNumber.identifier(),
 		).From(smsConfigsTable.identifier()).
-			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSHTTPConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSVonageConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*SMSConfig, error) {
 			config := new(SMSConfig)
 
 			var (
 				twilioConfig = sqlTwilioConfig{}
+				httpConfig   = sqlHTTPConfig{}
+				vonageConfig = sqlVonageConfig{}
 			)
 
 			err := row.Scan(
@@ -220,6 +300,17 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 				&twilioConfig.sid,
 				&twilioConfig.token,
 				&twilioConfig.senderNumber,
+
+				&httpConfig.smsID,
+				&httpConfig.endpoint,
+				&httpConfig.headers,
+				&httpConfig.bodyTemplate,
+				&httpConfig.senderNumber,
+
+				&vonageConfig.smsID,
+				&vonageConfig.apiKey,
+				&vonageConfig.apiSecret,
+				&vonageConfig.senderNumber,
 			)
 
 			if err != nil {
@@ -230,6 +321,8 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			}
 
 			twilioConfig.set(config)
+			httpConfig.set(config)
+			vonageConfig.set(config)
 
 			return config, nil
 		}
@@ -249,9 +342,22 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			SMSTwilioConfigColumnSID.identifier(),
 			SMSTwilioConfigColumnToken.identifier(),
 			SMSTwilioConfigColumnSenderNumber.identifier(),
+
+			SMSHTTPConfigColumnSMSID.identifier(),
+			SMSHTTPConfigColumnEndpoint.identifier(),
+			SMSHTTPConfigColumnHeaders.identifier(),
+			SMSHTTPConfigColumnBodyTemplate.identifier(),
+			SMSHTTPConfigColumnSenderNumber.identifier(),
+
+			SMSVonageConfigColumnSMSID.identifier(),
+			SMSVonageConfigColumnAPIKey.identifier(),
+			SMSVonageConfigColumnAPISecret.identifier(),
+			SMSVonageConfigColumnSenderNumber.identifier(),
 			countColumn.identifier(),
 		).From(smsConfigsTable.identifier()).
-			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
+			LeftJoin(join(SMSTwilioConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSHTTPConfigColumnSMSID, SMSConfigColumnID)).
+			LeftJoin(join(SMSVonageConfigColumnSMSID, SMSConfigColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Rows) (*SMSConfigs, error) {
 			configs := &SMSConfigs{Configs: []*SMSConfig{}}
 
@@ -259,6 +365,8 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				config := new(SMSConfig)
 				var (
 					twilioConfig = sqlTwilioConfig{}
+					httpConfig   = sqlHTTPConfig{}
+					vonageConfig = sqlVonageConfig{}
 				)
 
 				err := row.Scan(
@@ -274,6 +382,17 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 					&twilioConfig.sid,
 					&twilioConfig.token,
 					&twilioConfig.senderNumber,
+
+					&httpConfig.smsID,
+					&httpConfig.endpoint,
+					&httpConfig.headers,
+					&httpConfig.bodyTemplate,
+					&httpConfig.senderNumber,
+
+					&vonageConfig.smsID,
+					&vonageConfig.apiKey,
+					&vonageConfig.apiSecret,
+					&vonageConfig.senderNumber,
 					&configs.Count,
 				)
 
@@ -282,6 +401,8 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				}
 
 				twilioConfig.set(config)
+				httpConfig.set(config)
+				vonageConfig.set(config)
 
 				configs.Configs = append(configs.Configs, config)
 			}
@@ -307,3 +428,41 @@ func (c sqlTwilioConfig) set(smsConfig *SMSConfig) {
 		SenderNumber: c.senderNumber.String,
 	}
 }
+
+type sqlHTTPConfig struct {
+	smsID        sql.NullString
+	endpoint     sql.NullString
+	headers      *crypto.CryptoValue
+	bodyTemplate sql.NullString
+	senderNumber sql.NullString
+}
+
+func (c sqlHTTPConfig) set(smsConfig *SMSConfig) {
+	if !c.smsID.Valid {
+		return
+	}
+	smsConfig.HTTPConfig = &HTTP{
+		Endpoint:     c.endpoint.String,
+		Headers:      c.headers,
+		BodyTemplate: c.bodyTemplate.String,
+		SenderNumber: c.senderNumber.String,
+	}
+}
+
+type sqlVonageConfig struct {
+	smsID        sql.NullString
+	apiKey       sql.NullString
+	apiSecret    *crypto.CryptoValue
+	senderNumber sql.NullString
+}
+
+func (c sqlVonageConfig) set(smsConfig *SMSConfig) {
+	if !c.smsID.Valid {
+		return
+	}
+	smsConfig.VonageConfig = &Vonage{
+		APIKey:       c.apiKey.String,
+		APISecret:    c.apiSecret,
+		SenderNumber: c.senderNumber.String,
+	}
+}
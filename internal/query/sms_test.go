@@ -14,38 +14,68 @@ import (
 )
 
 var (
-	expectedSMSConfigQuery = regexp.QuoteMeta(`SELECT projections.sms_configs2.id,` +
-		` projections.sms_configs2.aggregate_id,` +
-		` projections.sms_configs2.creation_date,` +
-		` projections.sms_configs2.change_date,` +
-		` projections.sms_configs2.resource_owner,` +
-		` projections.sms_configs2.state,` +
-		` projections.sms_configs2.sequence,` +
+	expectedSMSConfigQuery = regexp.QuoteMeta(`SELECT projections.sms_configs3.id,` +
+		` projections.sms_configs3.aggregate_id,` +
+		` projections.sms_configs3.creation_date,` +
+		` projections.sms_configs3.change_date,` +
+		` projections.sms_configs3.resource_owner,` +
+		` projections.sms_configs3.state,` +
+		` projections.sms_configs3.sequence,` +
 
 		// twilio config
-		` projections.sms_configs2_twilio.sms_id,` +
-		` projections.sms_configs2_twilio.sid,` +
-		` projections.sms_configs2_twilio.token,` +
-		` projections.sms_configs2_twilio.sender_number` +
-		` FROM projections.sms_configs2` +
-		` LEFT JOIN projections.sms_configs2_twilio ON projections.sms_configs2.id = projections.sms_configs2_twilio.sms_id AND projections.sms_configs2.instance_id = projections.sms_configs2_twilio.instance_id` +
+		` projections.sms_configs3_twilio.sms_id,` +
+		` projections.sms_configs3_twilio.sid,` +
+		` projections.sms_configs3_twilio.token,` +
+		` projections.sms_configs3_twilio.sender_number,` +
+
+		// http config
+		` projections.sms_configs3_http.sms_id,` +
+		` projections.sms_configs3_http.endpoint,` +
+		` projections.sms_configs3_http.headers,` +
+		` projections.sms_configs3_http.body_template,` +
+		` projections.sms_configs3_http.sender_number,` +
+
+		// vonage config
+		` projections.sms_configs3_vonage.sms_id,` +
+		` projections.sms_configs3_vonage.api_key,` +
+		` projections.sms_configs3_vonage.api_secret,` +
+		` projections.sms_configs3_vonage.sender_number` +
+		` FROM projections.sms_configs3` +
+		` LEFT JOIN projections.sms_configs3_twilio ON projections.sms_configs3.id = projections.sms_configs3_twilio.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_twilio.instance_id` +
+		` LEFT JOIN projections.sms_configs3_http ON projections.sms_configs3.id = projections.sms_configs3_http.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_http.instance_id` +
+		` LEFT JOIN projections.sms_configs3_vonage ON projections.sms_configs3.id = projections.sms_configs3_vonage.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_vonage.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedSMSConfigsQuery = regexp.QuoteMeta(`SELECT projections.sms_configs2.id,` +
-		` projections.sms_configs2.aggregate_id,` +
-		` projections.sms_configs2.creation_date,` +
-		` projections.sms_configs2.change_date,` +
-		` projections.sms_configs2.resource_owner,` +
-		` projections.sms_configs2.state,` +
-		` projections.sms_configs2.sequence,` +
+	expectedSMSConfigsQuery = regexp.QuoteMeta(`SELECT projections.sms_configs3.id,` +
+		` projections.sms_configs3.aggregate_id,` +
+		` projections.sms_configs3.creation_date,` +
+		` projections.sms_configs3.change_date,` +
+		` projections.sms_configs3.resource_owner,` +
+		` projections.sms_configs3.state,` +
+		` projections.sms_configs3.sequence,` +
 
 		// twilio config
-		` projections.sms_configs2_twilio.sms_id,` +
-		` projections.sms_configs2_twilio.sid,` +
-		` projections.sms_configs2_twilio.token,` +
-		` projections.sms_configs2_twilio.sender_number,` +
+		` projections.sms_configs3_twilio.sms_id,` +
+		` projections.sms_configs3_twilio.sid,` +
+		` projections.sms_configs3_twilio.token,` +
+		` projections.sms_configs3_twilio.sender_number,` +
+
+		// http config
+		` projections.sms_configs3_http.sms_id,` +
+		` projections.sms_configs3_http.endpoint,` +
+		` projections.sms_configs3_http.headers,` +
+		` projections.sms_configs3_http.body_template,` +
+		` projections.sms_configs3_http.sender_number,` +
+
+		// vonage config
+		` projections.sms_configs3_vonage.sms_id,` +
+		` projections.sms_configs3_vonage.api_key,` +
+		` projections.sms_configs3_vonage.api_secret,` +
+		` projections.sms_configs3_vonage.sender_number,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.sms_configs2` +
-		` LEFT JOIN projections.sms_configs2_twilio ON projections.sms_configs2.id = projections.sms_configs2_twilio.sms_id AND projections.sms_configs2.instance_id = projections.sms_configs2_twilio.instance_id` +
+		` FROM projections.sms_configs3` +
+		` LEFT JOIN projections.sms_configs3_twilio ON projections.sms_configs3.id = projections.sms_configs3_twilio.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_twilio.instance_id` +
+		` LEFT JOIN projections.sms_configs3_http ON projections.sms_configs3.id = projections.sms_configs3_http.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_http.instance_id` +
+		` LEFT JOIN projections.sms_configs3_vonage ON projections.sms_configs3.id = projections.sms_configs3_vonage.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_vonage.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	smsConfigCols = []string{
@@ -61,6 +91,17 @@ var (
 		"sid",
 		"token",
 		"sender-number",
+		// http config
+		"sms_id",
+		"endpoint",
+		"headers",
+		"body_template",
+		"sender_number",
+		// vonage config
+		"sms_id",
+		"api_key",
+		"api_secret",
+		"sender_number",
 	}
 	smsConfigsCols = append(smsConfigCols, "count")
 )
@@ -109,6 +150,17 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							"sid",
 							&crypto.CryptoValue{},
 							"sender-number",
+							// http config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// vonage config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -156,6 +208,17 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							"sid",
 							&crypto.CryptoValue{},
 							"sender-number",
+							// http config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// vonage config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"sms-id2",
@@ -166,10 +229,21 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 							domain.SMSConfigStateInactive,
 							uint64(20211109),
 							// twilio config
+							nil,
+							nil,
+							nil,
+							nil,
+							// http config
 							"sms-id2",
-							"sid2",
+							"https://sms.example.com",
 							&crypto.CryptoValue{},
+							"{{.Content}}",
 							"sender-number2",
+							// vonage config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -201,9 +275,10 @@ func Test_SMSConfigssPrepare(t *testing.T) {
 						ResourceOwner: "ro",
 						State:         domain.SMSConfigStateInactive,
 						Sequence:      20211109,
-						TwilioConfig: &Twilio{
-							SID:          "sid2",
-							Token:        &crypto.CryptoValue{},
+						HTTPConfig: &HTTP{
+							Endpoint:     "https://sms.example.com",
+							Headers:      &crypto.CryptoValue{},
+							BodyTemplate: "{{.Content}}",
 							SenderNumber: "sender-number2",
 						},
 					},
@@ -284,6 +359,17 @@ func Test_SMSConfigPrepare(t *testing.T) {
 						"sid",
 						&crypto.CryptoValue{},
 						"sender-number",
+						// http config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// vonage config
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
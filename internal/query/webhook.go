@@ -0,0 +1,464 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	webhookTable = table{
+		name:          projection.WebhookTable,
+		instanceIDCol: projection.WebhookInstanceIDCol,
+	}
+	WebhookColumnID = Column{
+		name:  projection.WebhookIDCol,
+		table: webhookTable,
+	}
+	WebhookColumnCreationDate = Column{
+		name:  projection.WebhookCreationDateCol,
+		table: webhookTable,
+	}
+	WebhookColumnChangeDate = Column{
+		name:  projection.WebhookChangeDateCol,
+		table: webhookTable,
+	}
+	WebhookColumnResourceOwner = Column{
+		name:  projection.WebhookResourceOwnerCol,
+		table: webhookTable,
+	}
+	WebhookColumnInstanceID = Column{
+		name:  projection.WebhookInstanceIDCol,
+		table: webhookTable,
+	}
+	WebhookColumnSequence = Column{
+		name:  projection.WebhookSequenceCol,
+		table: webhookTable,
+	}
+	WebhookColumnState = Column{
+		name:  projection.WebhookStateCol,
+		table: webhookTable,
+	}
+	WebhookColumnName = Column{
+		name:  projection.WebhookNameCol,
+		table: webhookTable,
+	}
+	WebhookColumnURL = Column{
+		name:  projection.WebhookURLCol,
+		table: webhookTable,
+	}
+	WebhookColumnAggregateTypes = Column{
+		name:  projection.WebhookAggregateTypesCol,
+		table: webhookTable,
+	}
+	WebhookColumnEventTypes = Column{
+		name:  projection.WebhookEventTypesCol,
+		table: webhookTable,
+	}
+	WebhookColumnSigningKey = Column{
+		name:  projection.WebhookSigningKeyCol,
+		table: webhookTable,
+	}
+	WebhookColumnOwnerRemoved = Column{
+		name:  projection.WebhookOwnerRemovedCol,
+		table: webhookTable,
+	}
+)
+
+var (
+	webhookDeliveryTable = table{
+		name:          projection.WebhookDeliveryTable,
+		instanceIDCol: projection.WebhookDeliveryInstanceIDCol,
+	}
+	WebhookDeliveryColumnWebhookID = Column{
+		name:  projection.WebhookDeliveryWebhookIDCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnInstanceID = Column{
+		name:  projection.WebhookDeliveryInstanceIDCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnCreationDate = Column{
+		name:  projection.WebhookDeliveryCreationDateCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnAggregateType = Column{
+		name:  projection.WebhookDeliveryAggregateTypeCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnAggregateID = Column{
+		name:  projection.WebhookDeliveryAggregateIDCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnEventType = Column{
+		name:  projection.WebhookDeliveryEventTypeCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnEventSequence = Column{
+		name:  projection.WebhookDeliveryEventSequenceCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnState = Column{
+		name:  projection.WebhookDeliveryStateCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnAttempts = Column{
+		name:  projection.WebhookDeliveryAttemptsCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnStatusCode = Column{
+		name:  projection.WebhookDeliveryStatusCodeCol,
+		table: webhookDeliveryTable,
+	}
+	WebhookDeliveryColumnError = Column{
+		name:  projection.WebhookDeliveryErrorCol,
+		table: webhookDeliveryTable,
+	}
+)
+
+type Webhooks struct {
+	SearchResponse
+	Webhooks []*Webhook
+}
+
+type Webhook struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.WebhookState
+	Sequence      uint64
+
+	Name           string
+	URL            string
+	AggregateTypes database.StringArray
+	EventTypes     database.StringArray
+	SigningKey     *crypto.CryptoValue
+}
+
+type WebhookSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *WebhookSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type WebhookDeliveries struct {
+	SearchResponse
+	Deliveries []*WebhookDelivery
+}
+
+type WebhookDelivery struct {
+	WebhookID     string
+	CreationDate  time.Time
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	EventSequence uint64
+	State         domain.WebhookDeliveryState
+	Attempts      uint64
+	StatusCode    uint64
+	Error         string
+}
+
+type WebhookDeliverySearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *WebhookDeliverySearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) SearchWebhooks(ctx context.Context, queries *WebhookSearchQueries, withOwnerRemoved bool) (webhooks *Webhooks, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareWebhooksQuery(ctx, q.client)
+	eq := sq.Eq{
+		WebhookColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[WebhookColumnOwnerRemoved.identifier()] = false
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Hb3sx", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Pq0mx", "Errors.Internal")
+	}
+	webhooks, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	webhooks.LatestSequence, err = q.latestSequence(ctx, webhookTable)
+	return webhooks, err
+}
+
+func (q *Queries) GetWebhookByID(ctx context.Context, id string, resourceOwner string, withOwnerRemoved bool) (_ *Webhook, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareWebhookQuery(ctx, q.client)
+	eq := sq.Eq{
+		WebhookColumnID.identifier():            id,
+		WebhookColumnResourceOwner.identifier(): resourceOwner,
+		WebhookColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}
+	if !withOwnerRemoved {
+		eq[WebhookColumnOwnerRemoved.identifier()] = false
+	}
+	query, args, err := stmt.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Jd8sw", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) SearchWebhookDeliveries(ctx context.Context, webhookID string, queries *WebhookDeliverySearchQueries) (deliveries *WebhookDeliveries, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareWebhookDeliveriesQuery(ctx, q.client)
+	eq := sq.Eq{
+		WebhookDeliveryColumnWebhookID.identifier():  webhookID,
+		WebhookDeliveryColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Vn2sq", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ze1kd", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
+// RetryWebhookDelivery sets a failed delivery back to pending, so it will be sent again by the webhook handler
+func (q *Queries) RetryWebhookDelivery(ctx context.Context, webhookID string, sequence uint64) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, args, err := sq.Update(projection.WebhookDeliveryTable).
+		SetMap(map[string]interface{}{
+			projection.WebhookDeliveryStateCol:       domain.WebhookDeliveryStatePending,
+			projection.WebhookDeliveryAttemptsCol:    0,
+			projection.WebhookDeliveryNextAttemptCol: time.Now(),
+		}).
+		Where(sq.Eq{
+			projection.WebhookDeliveryInstanceIDCol:    authz.GetInstance(ctx).InstanceID(),
+			projection.WebhookDeliveryWebhookIDCol:     webhookID,
+			projection.WebhookDeliveryEventSequenceCol: sequence,
+			projection.WebhookDeliveryStateCol:         domain.WebhookDeliveryStateFailed,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.ThrowInternal(err, "QUERY-Qe4ls", "Errors.Query.SQLStatement")
+	}
+	result, err := q.client.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return errors.ThrowInternal(err, "QUERY-Lw8sf", "Errors.Internal")
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return errors.ThrowNotFound(err, "QUERY-Bn5xa", "Errors.Webhook.DeliveryNotFailed")
+	}
+	return nil
+}
+
+func NewWebhookResourceOwnerQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnResourceOwner, id, TextEquals)
+}
+
+func NewWebhookNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnName, value, method)
+}
+
+func NewWebhookStateSearchQuery(value domain.WebhookState) (SearchQuery, error) {
+	return NewNumberQuery(WebhookColumnState, int(value), NumberEquals)
+}
+
+func NewWebhookIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(WebhookColumnID, id, TextEquals)
+}
+
+func NewWebhookDeliveryStateSearchQuery(value domain.WebhookDeliveryState) (SearchQuery, error) {
+	return NewNumberQuery(WebhookDeliveryColumnState, int(value), NumberEquals)
+}
+
+func prepareWebhooksQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*Webhooks, error)) {
+	return sq.Select(
+			WebhookColumnID.identifier(),
+			WebhookColumnCreationDate.identifier(),
+			WebhookColumnChangeDate.identifier(),
+			WebhookColumnResourceOwner.identifier(),
+			WebhookColumnSequence.identifier(),
+			WebhookColumnState.identifier(),
+			WebhookColumnName.identifier(),
+			WebhookColumnURL.identifier(),
+			WebhookColumnAggregateTypes.identifier(),
+			WebhookColumnEventTypes.identifier(),
+			WebhookColumnSigningKey.identifier(),
+			countColumn.identifier(),
+		).From(webhookTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Webhooks, error) {
+			webhooks := make([]*Webhook, 0)
+			var count uint64
+			for rows.Next() {
+				webhook := new(Webhook)
+				err := rows.Scan(
+					&webhook.ID,
+					&webhook.CreationDate,
+					&webhook.ChangeDate,
+					&webhook.ResourceOwner,
+					&webhook.Sequence,
+					&webhook.State,
+					&webhook.Name,
+					&webhook.URL,
+					&webhook.AggregateTypes,
+					&webhook.EventTypes,
+					&webhook.SigningKey,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				webhooks = append(webhooks, webhook)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ow2lf", "Errors.Query.CloseRows")
+			}
+
+			return &Webhooks{
+				Webhooks: webhooks,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareWebhookQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*Webhook, error)) {
+	return sq.Select(
+			WebhookColumnID.identifier(),
+			WebhookColumnCreationDate.identifier(),
+			WebhookColumnChangeDate.identifier(),
+			WebhookColumnResourceOwner.identifier(),
+			WebhookColumnSequence.identifier(),
+			WebhookColumnState.identifier(),
+			WebhookColumnName.identifier(),
+			WebhookColumnURL.identifier(),
+			WebhookColumnAggregateTypes.identifier(),
+			WebhookColumnEventTypes.identifier(),
+			WebhookColumnSigningKey.identifier(),
+		).From(webhookTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Webhook, error) {
+			webhook := new(Webhook)
+			err := row.Scan(
+				&webhook.ID,
+				&webhook.CreationDate,
+				&webhook.ChangeDate,
+				&webhook.ResourceOwner,
+				&webhook.Sequence,
+				&webhook.State,
+				&webhook.Name,
+				&webhook.URL,
+				&webhook.AggregateTypes,
+				&webhook.EventTypes,
+				&webhook.SigningKey,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Nw9ds", "Errors.Webhook.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ek3lq", "Errors.Internal")
+			}
+			return webhook, nil
+		}
+}
+
+func prepareWebhookDeliveriesQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*WebhookDeliveries, error)) {
+	return sq.Select(
+			WebhookDeliveryColumnWebhookID.identifier(),
+			WebhookDeliveryColumnCreationDate.identifier(),
+			WebhookDeliveryColumnAggregateType.identifier(),
+			WebhookDeliveryColumnAggregateID.identifier(),
+			WebhookDeliveryColumnEventType.identifier(),
+			WebhookDeliveryColumnEventSequence.identifier(),
+			WebhookDeliveryColumnState.identifier(),
+			WebhookDeliveryColumnAttempts.identifier(),
+			WebhookDeliveryColumnStatusCode.identifier(),
+			WebhookDeliveryColumnError.identifier(),
+			countColumn.identifier(),
+		).From(webhookDeliveryTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*WebhookDeliveries, error) {
+			deliveries := make([]*WebhookDelivery, 0)
+			var count uint64
+			for rows.Next() {
+				delivery := new(WebhookDelivery)
+				var deliveryErr sql.NullString
+				err := rows.Scan(
+					&delivery.WebhookID,
+					&delivery.CreationDate,
+					&delivery.AggregateType,
+					&delivery.AggregateID,
+					&delivery.EventType,
+					&delivery.EventSequence,
+					&delivery.State,
+					&delivery.Attempts,
+					&delivery.StatusCode,
+					&deliveryErr,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				delivery.Error = deliveryErr.String
+				deliveries = append(deliveries, delivery)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Gx7cm", "Errors.Query.CloseRows")
+			}
+
+			return &WebhookDeliveries{
+				Deliveries: deliveries,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
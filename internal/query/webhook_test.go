@@ -0,0 +1,361 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareWebhooksStmt = `SELECT projections.webhooks.id,` +
+		` projections.webhooks.creation_date,` +
+		` projections.webhooks.change_date,` +
+		` projections.webhooks.resource_owner,` +
+		` projections.webhooks.sequence,` +
+		` projections.webhooks.state,` +
+		` projections.webhooks.name,` +
+		` projections.webhooks.url,` +
+		` projections.webhooks.aggregate_types,` +
+		` projections.webhooks.event_types,` +
+		` projections.webhooks.signing_key,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.webhooks` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareWebhooksCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"name",
+		"url",
+		"aggregate_types",
+		"event_types",
+		"signing_key",
+		"count",
+	}
+
+	prepareWebhookStmt = `SELECT projections.webhooks.id,` +
+		` projections.webhooks.creation_date,` +
+		` projections.webhooks.change_date,` +
+		` projections.webhooks.resource_owner,` +
+		` projections.webhooks.sequence,` +
+		` projections.webhooks.state,` +
+		` projections.webhooks.name,` +
+		` projections.webhooks.url,` +
+		` projections.webhooks.aggregate_types,` +
+		` projections.webhooks.event_types,` +
+		` projections.webhooks.signing_key` +
+		` FROM projections.webhooks` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareWebhookCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"name",
+		"url",
+		"aggregate_types",
+		"event_types",
+		"signing_key",
+	}
+
+	prepareWebhookDeliveriesStmt = `SELECT projections.webhooks_deliveries.webhook_id,` +
+		` projections.webhooks_deliveries.creation_date,` +
+		` projections.webhooks_deliveries.aggregate_type,` +
+		` projections.webhooks_deliveries.aggregate_id,` +
+		` projections.webhooks_deliveries.event_type,` +
+		` projections.webhooks_deliveries.event_sequence,` +
+		` projections.webhooks_deliveries.state,` +
+		` projections.webhooks_deliveries.attempts,` +
+		` projections.webhooks_deliveries.status_code,` +
+		` projections.webhooks_deliveries.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.webhooks_deliveries` +
+		` AS OF SYSTEM TIME '-1 ms'`
+	prepareWebhookDeliveriesCols = []string{
+		"webhook_id",
+		"creation_date",
+		"aggregate_type",
+		"aggregate_id",
+		"event_type",
+		"event_sequence",
+		"state",
+		"attempts",
+		"status_code",
+		"error",
+		"count",
+	}
+)
+
+func Test_WebhookPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareWebhooksQuery no result",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhooksStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &Webhooks{Webhooks: []*Webhook{}},
+		},
+		{
+			name:    "prepareWebhooksQuery one result",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhooksStmt),
+					prepareWebhooksCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							"ro",
+							uint64(20211109),
+							domain.WebhookStateActive,
+							"webhook-name",
+							"https://example.com/hook",
+							database.StringArray{"user"},
+							database.StringArray{"user.human.added"},
+							nil,
+						},
+					},
+				),
+			},
+			object: &Webhooks{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Webhooks: []*Webhook{
+					{
+						ID:             "id",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						ResourceOwner:  "ro",
+						State:          domain.WebhookStateActive,
+						Sequence:       20211109,
+						Name:           "webhook-name",
+						URL:            "https://example.com/hook",
+						AggregateTypes: database.StringArray{"user"},
+						EventTypes:     database.StringArray{"user.human.added"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareWebhooksQuery sql err",
+			prepare: prepareWebhooksQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareWebhooksStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareWebhookQuery no result",
+			prepare: prepareWebhookQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhookStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Webhook)(nil),
+		},
+		{
+			name:    "prepareWebhookQuery found",
+			prepare: prepareWebhookQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareWebhookStmt),
+					prepareWebhookCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211109),
+						domain.WebhookStateInactive,
+						"webhook-name",
+						"https://example.com/hook",
+						nil,
+						nil,
+						[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"key-id","crypted":"YQ=="}`),
+					},
+				),
+			},
+			object: &Webhook{
+				ID:            "id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.WebhookStateInactive,
+				Sequence:      20211109,
+				Name:          "webhook-name",
+				URL:           "https://example.com/hook",
+				SigningKey: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "key-id",
+					Crypted:    []byte("a"),
+				},
+			},
+		},
+		{
+			name:    "prepareWebhookQuery sql err",
+			prepare: prepareWebhookQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareWebhookStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+		{
+			name:    "prepareWebhookDeliveriesQuery no result",
+			prepare: prepareWebhookDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhookDeliveriesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &WebhookDeliveries{Deliveries: []*WebhookDelivery{}},
+		},
+		{
+			name:    "prepareWebhookDeliveriesQuery multiple result",
+			prepare: prepareWebhookDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareWebhookDeliveriesStmt),
+					prepareWebhookDeliveriesCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							"user",
+							"user-id",
+							"user.human.added",
+							uint64(20211109),
+							domain.WebhookDeliveryStateSucceeded,
+							uint64(1),
+							uint64(200),
+							nil,
+						},
+						{
+							"id",
+							testNow,
+							"user",
+							"user-id",
+							"user.human.changed",
+							uint64(20211110),
+							domain.WebhookDeliveryStateFailed,
+							uint64(3),
+							uint64(500),
+							"status 500",
+						},
+					},
+				),
+			},
+			object: &WebhookDeliveries{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Deliveries: []*WebhookDelivery{
+					{
+						WebhookID:     "id",
+						CreationDate:  testNow,
+						AggregateType: "user",
+						AggregateID:   "user-id",
+						EventType:     "user.human.added",
+						EventSequence: 20211109,
+						State:         domain.WebhookDeliveryStateSucceeded,
+						Attempts:      1,
+						StatusCode:    200,
+					},
+					{
+						WebhookID:     "id",
+						CreationDate:  testNow,
+						AggregateType: "user",
+						AggregateID:   "user-id",
+						EventType:     "user.human.changed",
+						EventSequence: 20211110,
+						State:         domain.WebhookDeliveryStateFailed,
+						Attempts:      3,
+						StatusCode:    500,
+						Error:         "status 500",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareWebhookDeliveriesQuery sql err",
+			prepare: prepareWebhookDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareWebhookDeliveriesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package ratelimit
+
+import (
+	"time"
+
+	cache_config "github.com/zitadel/zitadel/internal/cache/config"
+)
+
+type Config struct {
+	// Enabled activates the counting of failed login attempts
+	Enabled bool
+	// MaxAttemptsPerIP are the failed attempts of a client ip until it's blocked,
+	// if the instance does not define its own (0 disables the limit)
+	MaxAttemptsPerIP uint64
+	// MaxAttemptsPerLoginName are the failed attempts on a login name (or client id) until it's blocked,
+	// if the instance does not define its own (0 disables the limit)
+	MaxAttemptsPerLoginName uint64
+	// Window is the duration the failed attempts are counted and a key is blocked,
+	// if the instance does not define its own
+	Window time.Duration
+	// Delay is the delay of a request, as soon as half of the max attempts are reached.
+	// It's doubled for every further failed attempt.
+	Delay time.Duration
+	// MaxDelay is the upper limit of the delay
+	MaxDelay time.Duration
+	// TrustedProxies is the count of the proxies in front of ZITADEL, which append the address of their peer to the X-Forwarded-For header.
+	// The client ip is taken from the X-Forwarded-For entry appended by the outermost of them.
+	// If it's 0, the X-Forwarded-For header is ignored, because it can be set by the client, and the address of the peer is used.
+	TrustedProxies uint
+	// Cache stores the counters of the failed attempts,
+	// a distributed cache (redis) shares the counters between all processes
+	Cache *cache_config.CacheConfig
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type UserQueries interface {
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, withOwnerRemoved bool, queries ...query.SearchQuery) (*query.User, error)
+}
+
+// ResetOnUnlock deletes the failed attempts of the login names of a user from the cache as soon as the user is unlocked,
+// so a user unlocked by an administrator is not blocked by the rate limit any longer
+func ResetOnUnlock(c cache.Cache, queries UserQueries) {
+	cache.InvalidateOnEvents(c, func(event eventstore.Event) []string {
+		return loginNameKeys(c, queries, event)
+	}, map[eventstore.AggregateType][]eventstore.EventType{
+		user.AggregateType: {user.UserUnlockedType},
+	})
+}
+
+// loginNameKeys returns the keys of the login names of the user of the event, which have failed attempts
+func loginNameKeys(c cache.Cache, queries UserQueries, event eventstore.Event) []string {
+	ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+	unlocked, err := queries.GetUserByID(ctx, false, event.Aggregate().ID, false)
+	if err != nil {
+		logging.WithFields("userID", event.Aggregate().ID).WithError(err).Warn("unable to get login names of unlocked user")
+		return nil
+	}
+	storage := NewCacheStorage(c)
+	keys := make([]string, 0, len(unlocked.LoginNames))
+	for _, loginName := range unlocked.LoginNames {
+		key := storageKey(ctx, LoginName(loginName))
+		if attempts, err := storage.Get(ctx, key, time.Now()); err != nil || attempts == nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
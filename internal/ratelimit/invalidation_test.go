@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockUserQueries struct {
+	loginNames []string
+}
+
+func (q *mockUserQueries) GetUserByID(context.Context, bool, string, bool, ...query.SearchQuery) (*query.User, error) {
+	return &query.User{LoginNames: q.loginNames}, nil
+}
+
+func Test_loginNameKeys(t *testing.T) {
+	c := memCache{
+		"instance-id:login_name:user@example.com": {Count: 3},
+		"instance-id:ip:127.0.0.1":                {Count: 3},
+	}
+	event := eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   "user-id",
+		AggregateType: "user",
+		InstanceID:    "instance-id",
+		Type:          "user.unlocked",
+	})
+	keys := loginNameKeys(c, &mockUserQueries{loginNames: []string{"User@example.com", "user@other.example.com"}}, event)
+	assert.Equal(t, []string{"instance-id:login_name:user@example.com"}, keys)
+}
@@ -0,0 +1,257 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type Queries interface {
+	SecurityPolicy(ctx context.Context) (*query.SecurityPolicy, error)
+}
+
+type Commands interface {
+	LoginRateLimitExceeded(ctx context.Context, keyType domain.RateLimitKeyType, key string, attempts uint64, blockedUntil time.Time) error
+}
+
+// Key identifies the counter of failed attempts
+type Key struct {
+	Type  domain.RateLimitKeyType
+	Value string
+}
+
+func IP(ip string) Key {
+	return Key{Type: domain.RateLimitKeyTypeIP, Value: ip}
+}
+
+// LoginName returns the key of a login name, which are case insensitive
+func LoginName(loginName string) Key {
+	return Key{Type: domain.RateLimitKeyTypeLoginName, Value: strings.ToLower(loginName)}
+}
+
+func ClientID(clientID string) Key {
+	return Key{Type: domain.RateLimitKeyTypeClientID, Value: clientID}
+}
+
+// Limiter throttles failed authentication attempts per instance.
+// Requests are delayed progressively, as soon as half of the max attempts of a key are reached
+// and blocked for the rest of the window, when all attempts are used up.
+// All methods can be called on a nil Limiter, which does not limit any request.
+type Limiter struct {
+	config   *Config
+	storage  Storage
+	queries  Queries
+	commands Commands
+	clock    clock.Clock
+}
+
+func New(config *Config, storage Storage, queries Queries, commands Commands, clock clock.Clock) *Limiter {
+	return &Limiter{
+		config:   config,
+		storage:  storage,
+		queries:  queries,
+		commands: commands,
+		clock:    clock,
+	}
+}
+
+// Check returns an error if one of the keys is blocked.
+// Otherwise, it delays the request depending on the failed attempts of the keys.
+func (l *Limiter) Check(ctx context.Context, keys ...Key) error {
+	if !l.enabled() {
+		return nil
+	}
+	policy := l.policy(ctx)
+	var delay time.Duration
+	for _, key := range validKeys(keys) {
+		maxAttempts := policy.maxAttempts(key.Type)
+		if maxAttempts == 0 {
+			continue
+		}
+		attempts, err := l.storage.Get(ctx, storageKey(ctx, key), l.clock.Now())
+		if err != nil {
+			logging.WithError(err).Warn("unable to get failed attempts")
+			continue
+		}
+		count := l.count(attempts)
+		if count >= maxAttempts {
+			return errors.ThrowResourceExhausted(nil, "RATEL-Hk3sf", "Errors.LoginRateLimit.Exceeded")
+		}
+		if d := l.delay(count, maxAttempts); d > delay {
+			delay = d
+		}
+	}
+	return l.sleep(ctx, delay)
+}
+
+// Failed counts a failed attempt for the keys.
+// If a key reaches the max attempts, it's blocked for the rest of the window, which is recorded as event.
+func (l *Limiter) Failed(ctx context.Context, keys ...Key) {
+	if !l.enabled() {
+		return
+	}
+	policy := l.policy(ctx)
+	for _, key := range validKeys(keys) {
+		maxAttempts := policy.maxAttempts(key.Type)
+		if maxAttempts == 0 {
+			continue
+		}
+		attempts, err := l.storage.Increment(ctx, storageKey(ctx, key), policy.window, l.clock.Now())
+		if err != nil {
+			logging.WithError(err).Warn("unable to count failed attempt")
+			continue
+		}
+		if attempts.Count != maxAttempts {
+			continue
+		}
+		err = l.commands.LoginRateLimitExceeded(ctx, key.Type, key.Value, attempts.Count, attempts.Expires)
+		logging.OnError(err).Warn("unable to record exceeded login rate limit")
+	}
+}
+
+// Succeeded resets the failed attempts of the keys.
+// Be aware to only pass keys of the authenticated subject (e.g. login name), but not the client ip.
+func (l *Limiter) Succeeded(ctx context.Context, keys ...Key) {
+	if !l.enabled() {
+		return
+	}
+	for _, key := range validKeys(keys) {
+		err := l.storage.Delete(ctx, storageKey(ctx, key))
+		logging.OnError(err).Warn("unable to reset failed attempts")
+	}
+}
+
+// ClientIP returns the key of the ip of the client of the request,
+// the X-Forwarded-For header is only respected for the configured trusted proxies
+func (l *Limiter) ClientIP(r *http.Request) Key {
+	return IP(http_util.ClientIPFromRequest(r, l.trustedProxies()))
+}
+
+// ClientIPFromCtx returns the key of the ip of the client of the request in the context,
+// the X-Forwarded-For header is only respected for the configured trusted proxies
+func (l *Limiter) ClientIPFromCtx(ctx context.Context) Key {
+	return IP(http_util.ClientIPFromCtx(ctx, l.trustedProxies()))
+}
+
+func (l *Limiter) enabled() bool {
+	return l != nil && l.config.Enabled
+}
+
+func (l *Limiter) trustedProxies() uint {
+	if l == nil || l.config == nil {
+		return 0
+	}
+	return l.config.TrustedProxies
+}
+
+// count returns the failed attempts of the current window
+func (l *Limiter) count(attempts *Attempts) uint64 {
+	if attempts == nil || !attempts.Expires.After(l.clock.Now()) {
+		return 0
+	}
+	return attempts.Count
+}
+
+// delay returns the delay for the next attempt,
+// which starts with the configured delay at half of the max attempts and doubles with every further failed attempt
+func (l *Limiter) delay(count, maxAttempts uint64) time.Duration {
+	threshold := maxAttempts / 2
+	if l.config.Delay <= 0 || count == 0 || count < threshold {
+		return 0
+	}
+	maxDelay := l.config.MaxDelay
+	if maxDelay < l.config.Delay {
+		maxDelay = l.config.Delay
+	}
+	delay := l.config.Delay
+	for i := threshold; i < count; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return delay
+}
+
+func (l *Limiter) sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := l.clock.Timer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.ThrowDeadlineExceeded(ctx.Err(), "RATEL-Gw2fa", "Errors.LoginRateLimit.Exceeded")
+	case <-timer.C:
+		return nil
+	}
+}
+
+type policy struct {
+	maxAttemptsPerIP        uint64
+	maxAttemptsPerLoginName uint64
+	window                  time.Duration
+}
+
+// policy returns the limits of the instance or the system defaults for all values not set by the instance
+func (l *Limiter) policy(ctx context.Context) *policy {
+	p := &policy{
+		maxAttemptsPerIP:        l.config.MaxAttemptsPerIP,
+		maxAttemptsPerLoginName: l.config.MaxAttemptsPerLoginName,
+		window:                  l.config.Window,
+	}
+	securityPolicy, err := l.queries.SecurityPolicy(ctx)
+	if err != nil {
+		logging.WithError(err).Warn("unable to get security policy, system defaults of login rate limit are used")
+		return p
+	}
+	if securityPolicy.LoginRateLimitMaxAttemptsPerIP > 0 {
+		p.maxAttemptsPerIP = securityPolicy.LoginRateLimitMaxAttemptsPerIP
+	}
+	if securityPolicy.LoginRateLimitMaxAttemptsPerLoginName > 0 {
+		p.maxAttemptsPerLoginName = securityPolicy.LoginRateLimitMaxAttemptsPerLoginName
+	}
+	if securityPolicy.LoginRateLimitWindow > 0 {
+		p.window = securityPolicy.LoginRateLimitWindow
+	}
+	return p
+}
+
+func (p *policy) maxAttempts(keyType domain.RateLimitKeyType) uint64 {
+	if p.window <= 0 {
+		return 0
+	}
+	switch keyType {
+	case domain.RateLimitKeyTypeIP:
+		return p.maxAttemptsPerIP
+	case domain.RateLimitKeyTypeLoginName, domain.RateLimitKeyTypeClientID:
+		return p.maxAttemptsPerLoginName
+	default:
+		return 0
+	}
+}
+
+func validKeys(keys []Key) []Key {
+	valid := make([]Key, 0, len(keys))
+	for _, key := range keys {
+		if key.Type.Valid() && key.Value != "" {
+			valid = append(valid, key)
+		}
+	}
+	return valid
+}
+
+func storageKey(ctx context.Context, key Key) string {
+	return cache.InstanceKey(authz.GetInstance(ctx).InstanceID(), key.Type.String()+":"+key.Value)
+}
@@ -0,0 +1,205 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type memCache map[string]Attempts
+
+func (c memCache) Set(key string, object interface{}) error {
+	c[key] = *object.(*Attempts)
+	return nil
+}
+
+func (c memCache) Get(key string, ptrToObject interface{}) error {
+	attempts, ok := c[key]
+	if !ok {
+		return errors.ThrowNotFound(nil, "TEST-Wf3sd", "not in cache")
+	}
+	*ptrToObject.(*Attempts) = attempts
+	return nil
+}
+
+func (c memCache) Delete(key string) error {
+	delete(c, key)
+	return nil
+}
+
+type mockQueries struct {
+	policy *query.SecurityPolicy
+}
+
+func (q *mockQueries) SecurityPolicy(context.Context) (*query.SecurityPolicy, error) {
+	return q.policy, nil
+}
+
+type exceeded struct {
+	keyType      domain.RateLimitKeyType
+	key          string
+	attempts     uint64
+	blockedUntil time.Time
+}
+
+type mockCommands struct {
+	exceeded []exceeded
+}
+
+func (c *mockCommands) LoginRateLimitExceeded(_ context.Context, keyType domain.RateLimitKeyType, key string, attempts uint64, blockedUntil time.Time) error {
+	c.exceeded = append(c.exceeded, exceeded{keyType, key, attempts, blockedUntil})
+	return nil
+}
+
+func newTestLimiter(config *Config, policy *query.SecurityPolicy) (*Limiter, *mockCommands, *clock.Mock) {
+	commands := new(mockCommands)
+	clock := clock.NewMock()
+	return New(config, NewCacheStorage(memCache{}), &mockQueries{policy: policy}, commands, clock), commands, clock
+}
+
+func TestLimiter_Failed(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	config := &Config{
+		Enabled:                 true,
+		MaxAttemptsPerIP:        5,
+		MaxAttemptsPerLoginName: 3,
+		Window:                  time.Minute,
+	}
+
+	t.Run("disabled, not blocked", func(t *testing.T) {
+		limiter, commands, _ := newTestLimiter(&Config{Window: time.Minute, MaxAttemptsPerLoginName: 1}, &query.SecurityPolicy{})
+		limiter.Failed(ctx, LoginName("user"))
+		assert.NoError(t, limiter.Check(ctx, LoginName("user")))
+		assert.Empty(t, commands.exceeded)
+	})
+	t.Run("nil limiter, not blocked", func(t *testing.T) {
+		var limiter *Limiter
+		limiter.Failed(ctx, LoginName("user"))
+		assert.NoError(t, limiter.Check(ctx, LoginName("user")))
+	})
+	t.Run("max attempts reached, blocked and recorded once", func(t *testing.T) {
+		limiter, commands, clock := newTestLimiter(config, &query.SecurityPolicy{})
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, limiter.Check(ctx, IP("127.0.0.1"), LoginName("User")))
+			limiter.Failed(ctx, IP("127.0.0.1"), LoginName("User"))
+		}
+		err := limiter.Check(ctx, IP("127.0.0.1"), LoginName("user"))
+		assert.True(t, errors.IsResourceExhausted(err))
+		assert.NoError(t, limiter.Check(ctx, IP("127.0.0.1"), LoginName("other")))
+		assert.Equal(t, []exceeded{
+			{domain.RateLimitKeyTypeLoginName, "user", 3, clock.Now().Add(time.Minute)},
+		}, commands.exceeded)
+	})
+	t.Run("window passed, not blocked", func(t *testing.T) {
+		limiter, _, clock := newTestLimiter(config, &query.SecurityPolicy{})
+		for i := 0; i < 3; i++ {
+			limiter.Failed(ctx, LoginName("user"))
+		}
+		assert.Error(t, limiter.Check(ctx, LoginName("user")))
+		clock.Add(time.Minute)
+		assert.NoError(t, limiter.Check(ctx, LoginName("user")))
+	})
+	t.Run("window passed, counting restarted", func(t *testing.T) {
+		limiter, commands, clock := newTestLimiter(config, &query.SecurityPolicy{})
+		for i := 0; i < 2; i++ {
+			limiter.Failed(ctx, LoginName("user"))
+		}
+		clock.Add(time.Minute)
+		for i := 0; i < 2; i++ {
+			limiter.Failed(ctx, LoginName("user"))
+		}
+		assert.NoError(t, limiter.Check(ctx, LoginName("user")))
+		assert.Empty(t, commands.exceeded)
+	})
+	t.Run("succeeded, counter reset", func(t *testing.T) {
+		limiter, _, _ := newTestLimiter(config, &query.SecurityPolicy{})
+		for i := 0; i < 2; i++ {
+			limiter.Failed(ctx, LoginName("user"))
+		}
+		limiter.Succeeded(ctx, LoginName("user"))
+		limiter.Failed(ctx, LoginName("user"))
+		assert.NoError(t, limiter.Check(ctx, LoginName("user")))
+	})
+	t.Run("instance policy, overwrites defaults", func(t *testing.T) {
+		limiter, _, _ := newTestLimiter(config, &query.SecurityPolicy{LoginRateLimitMaxAttemptsPerLoginName: 1})
+		limiter.Failed(ctx, LoginName("user"))
+		assert.Error(t, limiter.Check(ctx, LoginName("user")))
+	})
+	t.Run("other instance, not blocked", func(t *testing.T) {
+		limiter, _, _ := newTestLimiter(config, &query.SecurityPolicy{LoginRateLimitMaxAttemptsPerLoginName: 1})
+		limiter.Failed(ctx, LoginName("user"))
+		assert.NoError(t, limiter.Check(authz.WithInstanceID(context.Background(), "instance2"), LoginName("user")))
+	})
+}
+
+func TestLimiter_ClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies uint
+		forwardedFor   []string
+		want           string
+	}{
+		{
+			name:         "no trusted proxy, forwarded for ignored",
+			forwardedFor: []string{"10.0.0.1"},
+			want:         "192.168.0.1",
+		},
+		{
+			name:           "trusted proxy, appended entry",
+			trustedProxies: 1,
+			forwardedFor:   []string{"10.0.0.1, 10.0.0.2"},
+			want:           "10.0.0.2",
+		},
+		{
+			name:           "trusted proxies, entry of outermost proxy",
+			trustedProxies: 2,
+			forwardedFor:   []string{"10.0.0.1", "10.0.0.2, 10.0.0.3"},
+			want:           "10.0.0.2",
+		},
+		{
+			name:           "less entries than trusted proxies, peer",
+			trustedProxies: 2,
+			forwardedFor:   []string{"10.0.0.1"},
+			want:           "192.168.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.RemoteAddr = "192.168.0.1:1234"
+			for _, value := range tt.forwardedFor {
+				r.Header.Add("x-forwarded-for", value)
+			}
+			limiter := New(&Config{TrustedProxies: tt.trustedProxies}, nil, nil, nil, nil)
+			assert.Equal(t, IP(tt.want), limiter.ClientIP(r))
+		})
+	}
+}
+
+func TestLimiter_delay(t *testing.T) {
+	limiter := &Limiter{config: &Config{Delay: time.Second, MaxDelay: 10 * time.Second}}
+	tests := []struct {
+		count uint64
+		want  time.Duration
+	}{
+		{count: 0, want: 0},
+		{count: 4, want: 0},
+		{count: 5, want: time.Second},
+		{count: 6, want: 2 * time.Second},
+		{count: 8, want: 8 * time.Second},
+		{count: 9, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, limiter.delay(tt.count, 10), "count %d", tt.count)
+	}
+}
@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// Attempts are the failed attempts of a key in the window ending at Expires
+type Attempts struct {
+	Count   uint64
+	Expires time.Time
+}
+
+// Storage stores the failed attempts of the keys
+type Storage interface {
+	// Get returns the failed attempts of the key or nil if there are none
+	Get(ctx context.Context, key string, now time.Time) (*Attempts, error)
+	// Increment counts a failed attempt of the key,
+	// the window of the attempts is started by the first attempt or the first attempt after the window expired
+	Increment(ctx context.Context, key string, window time.Duration, now time.Time) (*Attempts, error)
+	// Delete removes the failed attempts of the key
+	Delete(ctx context.Context, key string) error
+}
+
+var _ Storage = (*CacheStorage)(nil)
+
+// CacheStorage stores the failed attempts in a cache.
+// If the cache implements [cache.Counter] (e.g. redis), the attempts are incremented atomically,
+// so they are shared correctly between the processes.
+// Otherwise, the updates are serialized within the current process
+type CacheStorage struct {
+	cache   cache.Cache
+	counter cache.Counter
+	mutex   sync.Mutex
+}
+
+func NewCacheStorage(c cache.Cache) *CacheStorage {
+	counter, _ := c.(cache.Counter)
+	return &CacheStorage{
+		cache:   c,
+		counter: counter,
+	}
+}
+
+func (s *CacheStorage) Get(_ context.Context, key string, now time.Time) (*Attempts, error) {
+	if s.counter != nil {
+		count, expiresIn, err := s.counter.Count(key)
+		if err != nil || count == 0 {
+			return nil, err
+		}
+		return &Attempts{Count: count, Expires: now.Add(expiresIn)}, nil
+	}
+	return s.get(key)
+}
+
+func (s *CacheStorage) get(key string) (*Attempts, error) {
+	attempts := new(Attempts)
+	err := s.cache.Get(key, attempts)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (s *CacheStorage) Increment(_ context.Context, key string, window time.Duration, now time.Time) (*Attempts, error) {
+	if s.counter != nil {
+		count, expiresIn, err := s.counter.Increment(key, window)
+		if err != nil {
+			return nil, err
+		}
+		return &Attempts{Count: count, Expires: now.Add(expiresIn)}, nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	attempts, err := s.get(key)
+	if err != nil {
+		return nil, err
+	}
+	if attempts == nil || !attempts.Expires.After(now) {
+		attempts = &Attempts{Expires: now.Add(window)}
+	}
+	attempts.Count++
+	return attempts, s.cache.Set(key, attempts)
+}
+
+func (s *CacheStorage) Delete(_ context.Context, key string) error {
+	if s.counter == nil {
+		attempts, err := s.get(key)
+		if err != nil || attempts == nil {
+			return err
+		}
+	}
+	return s.cache.Delete(key)
+}
@@ -0,0 +1,251 @@
+package deviceauth
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniqueUserCodeType = "device_auth_user_codes"
+	// UniqueDecisionType ensures a device authorization is only approved or denied once
+	UniqueDecisionType = "device_auth_decisions"
+	// UniqueRemovalType ensures a device authorization is only removed once,
+	// so the tokens are only issued once for the device code
+	UniqueRemovalType = "device_auth_removals"
+	eventTypePrefix   = eventstore.EventType("device.authorization.")
+	AddedEventType    = eventTypePrefix + "added"
+	ApprovedEventType = eventTypePrefix + "approved"
+	DeniedEventType   = eventTypePrefix + "denied"
+	RemovedEventType  = eventTypePrefix + "removed"
+)
+
+func NewAddUserCodeUniqueConstraint(userCode string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueUserCodeType,
+		userCode,
+		"Errors.DeviceAuth.AlreadyExists")
+}
+
+func NewRemoveUserCodeUniqueConstraint(userCode string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniqueUserCodeType,
+		userCode)
+}
+
+func NewAddDecisionUniqueConstraint(id string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueDecisionType,
+		id,
+		"Errors.DeviceAuth.AlreadyHandled")
+}
+
+func NewAddRemovalUniqueConstraint(id string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRemovalType,
+		id,
+		"Errors.DeviceAuth.NotFound")
+}
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID string `json:"clientId"`
+	// DeviceCodeHash is the hash of the device code (see [domain.DeviceCodeHash])
+	DeviceCodeHash string    `json:"deviceCodeHash"`
+	UserCode       string    `json:"userCode"`
+	Expires        time.Time `json:"expires"`
+	Scopes         []string  `json:"scopes,omitempty"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddUserCodeUniqueConstraint(e.UserCode)}
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	deviceCodeHash,
+	userCode string,
+	expires time.Time,
+	scopes []string,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedEventType,
+		),
+		ClientID:       clientID,
+		DeviceCodeHash: deviceCodeHash,
+		UserCode:       userCode,
+		Expires:        expires,
+		Scopes:         scopes,
+	}
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "DEVICEAUTH-Ao3ls", "unable to unmarshal device authorization added")
+	}
+
+	return e, nil
+}
+
+type ApprovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID                string    `json:"userId"`
+	UserOrgID             string    `json:"userOrgId,omitempty"`
+	UserAgentID           string    `json:"userAgentId,omitempty"`
+	Audience              []string  `json:"audience,omitempty"`
+	AuthMethodsReferences []string  `json:"authMethodsReferences,omitempty"`
+	AuthTime              time.Time `json:"authTime"`
+	userCode              string
+}
+
+func (e *ApprovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ApprovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{
+		NewRemoveUserCodeUniqueConstraint(e.userCode),
+		NewAddDecisionUniqueConstraint(e.Aggregate().ID),
+	}
+}
+
+func NewApprovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userCode,
+	userID,
+	userOrgID,
+	userAgentID string,
+	audience,
+	authMethodsReferences []string,
+	authTime time.Time,
+) *ApprovedEvent {
+	return &ApprovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApprovedEventType,
+		),
+		UserID:                userID,
+		UserOrgID:             userOrgID,
+		UserAgentID:           userAgentID,
+		Audience:              audience,
+		AuthMethodsReferences: authMethodsReferences,
+		AuthTime:              authTime,
+		userCode:              userCode,
+	}
+}
+
+func ApprovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ApprovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "DEVICEAUTH-Ls0vk", "unable to unmarshal device authorization approved")
+	}
+
+	return e, nil
+}
+
+type DeniedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	userCode string
+}
+
+func (e *DeniedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *DeniedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{
+		NewRemoveUserCodeUniqueConstraint(e.userCode),
+		NewAddDecisionUniqueConstraint(e.Aggregate().ID),
+	}
+}
+
+func NewDeniedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userCode string,
+) *DeniedEvent {
+	return &DeniedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeniedEventType,
+		),
+		userCode: userCode,
+	}
+}
+
+func DeniedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &DeniedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+// RemovedEvent is pushed as soon as the tokens have been issued to the device
+// or the device authorization is no longer needed (e.g. it expired).
+// The user code is only released if it was not already released by the approval or denial.
+// Concurrent removals (e.g. token requests with the same device code) fail, as the removal is unique.
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	userCode string
+}
+
+func (e *RemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	constraints := []*eventstore.EventUniqueConstraint{NewAddRemovalUniqueConstraint(e.Aggregate().ID)}
+	if e.userCode != "" {
+		constraints = append(constraints, NewRemoveUserCodeUniqueConstraint(e.userCode))
+	}
+	return constraints
+}
+
+func NewRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userCode string,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+		userCode: userCode,
+	}
+}
+
+func RemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -0,0 +1,10 @@
+package deviceauth
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedEventType, AddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ApprovedEventType, ApprovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, DeniedEventType, DeniedEventMapper).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper)
+}
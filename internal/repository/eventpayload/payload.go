@@ -0,0 +1,143 @@
+// Package eventpayload defines which fields of the events are passed to systems outside of ZITADEL,
+// like webhooks and actions triggered by events.
+// The fields are allowlisted per event type, so secrets, codes, hashes and encrypted values
+// of the events are never exposed, even if further fields are added to the events.
+package eventpayload
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+var (
+	humanFields   = []string{"userName", "firstName", "lastName", "nickName", "displayName", "preferredLanguage", "gender", "email", "phone", "country", "locality", "postalCode", "region", "streetAddress"}
+	profileFields = []string{"firstName", "lastName", "nickName", "displayName", "preferredLanguage", "gender"}
+	addressFields = []string{"country", "locality", "postalCode", "region", "streetAddress"}
+	machineFields = []string{"userName", "name", "description", "accessTokenType"}
+	grantFields   = []string{"userId", "projectId", "grantId", "roleKeys"}
+	memberFields  = []string{"userId", "roles"}
+	projectFields = []string{"name", "projectRoleAssertion", "projectRoleCheck", "hasProjectCheck", "privateLabelingSetting"}
+	roleFields    = []string{"key", "displayName", "group"}
+	actionFields  = []string{"name", "timeout", "allowedToFail"}
+	noFields      = []string{}
+)
+
+// publicFields contains the event types which are public and the fields of their payload
+var publicFields = map[eventstore.EventType][]string{
+	user.HumanAddedType:                     humanFields,
+	user.HumanRegisteredType:                humanFields,
+	user.HumanInitializedCheckSucceededType: noFields,
+	user.HumanProfileChangedType:            profileFields,
+	user.HumanEmailChangedType:              {"email"},
+	user.HumanEmailVerifiedType:             noFields,
+	user.HumanPhoneChangedType:              {"phone"},
+	user.HumanPhoneVerifiedType:             noFields,
+	user.HumanPhoneRemovedType:              noFields,
+	user.HumanAddressChangedType:            addressFields,
+	user.HumanPasswordChangedType:           {"changeRequired"},
+	user.HumanPasswordCheckSucceededType:    noFields,
+	user.HumanPasswordCheckFailedType:       noFields,
+	user.HumanSignedOutType:                 noFields,
+	user.HumanMFAOTPVerifiedType:            noFields,
+	user.HumanMFAOTPRemovedType:             noFields,
+	user.MachineAddedEventType:              machineFields,
+	user.MachineChangedEventType:            machineFields,
+	user.UserLockedType:                     noFields,
+	user.UserUnlockedType:                   noFields,
+	user.UserDeactivatedType:                noFields,
+	user.UserReactivatedType:                noFields,
+	user.UserRemovedType:                    noFields,
+	user.UserUserNameChangedType:            {"userName"},
+	user.UserIDPLinkAddedType:               {"idpConfigId", "displayName"},
+	user.UserIDPLinkRemovedType:             {"idpConfigId"},
+
+	usergrant.UserGrantAddedType:          grantFields,
+	usergrant.UserGrantChangedType:        {"roleKeys"},
+	usergrant.UserGrantCascadeChangedType: {"roleKeys"},
+	usergrant.UserGrantRemovedType:        noFields,
+	usergrant.UserGrantCascadeRemovedType: noFields,
+	usergrant.UserGrantDeactivatedType:    noFields,
+	usergrant.UserGrantReactivatedType:    noFields,
+
+	org.OrgAddedEventType:             {"name"},
+	org.OrgChangedEventType:           {"name"},
+	org.OrgDeactivatedEventType:       noFields,
+	org.OrgReactivatedEventType:       noFields,
+	org.OrgRemovedEventType:           noFields,
+	org.MemberAddedEventType:          memberFields,
+	org.MemberChangedEventType:        memberFields,
+	org.MemberRemovedEventType:        {"userId"},
+	org.MemberCascadeRemovedEventType: {"userId"},
+
+	project.ProjectAddedType:         projectFields,
+	project.ProjectChangedType:       projectFields,
+	project.ProjectDeactivatedType:   noFields,
+	project.ProjectReactivatedType:   noFields,
+	project.ProjectRemovedType:       {"name"},
+	project.RoleAddedType:            roleFields,
+	project.RoleChangedType:          roleFields,
+	project.RoleRemovedType:          {"key"},
+	project.MemberAddedType:          memberFields,
+	project.MemberChangedType:        memberFields,
+	project.MemberRemovedType:        {"userId"},
+	project.MemberCascadeRemovedType: {"userId"},
+
+	action.AddedEventType:       actionFields,
+	action.ChangedEventType:     actionFields,
+	action.DeactivatedEventType: noFields,
+	action.ReactivatedEventType: noFields,
+	action.RemovedEventType:     {"name"},
+}
+
+// IsPublic returns if the events of the type are passed to systems outside of ZITADEL
+func IsPublic(eventType eventstore.EventType) bool {
+	_, ok := publicFields[eventType]
+	return ok
+}
+
+// EventTypes returns the public event types sorted by name
+func EventTypes() []string {
+	types := make([]string, 0, len(publicFields))
+	for eventType := range publicFields {
+		types = append(types, string(eventType))
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Fields returns the public fields of the payload of the event,
+// it's nil if the event isn't public or the payload contains none of its public fields
+func Fields(event eventstore.Event) map[string]interface{} {
+	fields, ok := publicFields[event.Type()]
+	if !ok || len(fields) == 0 {
+		return nil
+	}
+	data := event.DataAsBytes()
+	if len(data) == 0 {
+		return nil
+	}
+	payload := make(map[string]interface{})
+	if err := json.Unmarshal(data, &payload); err != nil {
+		logging.WithError(err).WithField("eventType", event.Type()).Debug("unable to unmarshal event payload")
+		return nil
+	}
+	public := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if value, ok := payload[field]; ok {
+			public[field] = value
+		}
+	}
+	if len(public) == 0 {
+		return nil
+	}
+	return public
+}
@@ -0,0 +1,73 @@
+package eventpayload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+func testEvent(eventType eventstore.EventType, data string) eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		AggregateID:   "user-id",
+		AggregateType: "user",
+		Type:          repository.EventType(eventType),
+		Sequence:      15,
+		InstanceID:    "instance-id",
+		Data:          []byte(data),
+	})
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		event eventstore.Event
+		want  map[string]interface{}
+	}{
+		{
+			name: "human added without secret",
+			event: testEvent("user.human.added",
+				`{"userName":"username","firstName":"first","email":"user@example.com","secret":{"cryptoType":1,"algorithm":"bcrypt","crypted":"JDJhJDEw"},"changeRequired":true}`,
+			),
+			want: map[string]interface{}{
+				"userName":  "username",
+				"firstName": "first",
+				"email":     "user@example.com",
+			},
+		},
+		{
+			name:  "password changed without secret",
+			event: testEvent("user.human.password.changed", `{"secret":{"cryptoType":1,"algorithm":"argon2id","crypted":"JGFyZ29uMmlk"},"changeRequired":false,"userAgentID":"agent"}`),
+			want:  map[string]interface{}{"changeRequired": false},
+		},
+		{
+			name:  "event without public fields",
+			event: testEvent("user.locked", `{"lockoutDuration":1000}`),
+			want:  nil,
+		},
+		{
+			name:  "event not public",
+			event: testEvent("user.human.otp.sms.code.added", `{"code":{"cryptoType":0,"algorithm":"aes","crypted":"Y29kZQ=="},"expiry":300}`),
+			want:  nil,
+		},
+		{
+			name:  "invalid payload",
+			event: testEvent("user.human.added", `invalid`),
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Fields(tt.event))
+		})
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	assert.True(t, IsPublic("user.human.password.changed"))
+	assert.False(t, IsPublic("user.human.password.code.added"))
+	assert.False(t, IsPublic("user.machine.secret.set"))
+	assert.False(t, IsPublic("instance.smtp.config.password.changed"))
+}
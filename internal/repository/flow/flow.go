@@ -15,6 +15,8 @@ const (
 	TriggerActionsSetEventType            = triggerActionsPrefix + "set"
 	TriggerActionsCascadeRemovedEventType = triggerActionsPrefix + "cascade.removed"
 	FlowClearedEventType                  = eventTypePrefix + "cleared"
+	eventTriggerActionsPrefix             = eventTypePrefix + "event_trigger_actions."
+	EventTriggerActionsSetEventType       = eventTriggerActionsPrefix + "set"
 )
 
 type TriggerActionsSetEvent struct {
@@ -137,3 +139,45 @@ func FlowClearedEventMapper(event *repository.Event) (eventstore.Event, error) {
 
 	return e, nil
 }
+
+// EventTriggerActionsSetEvent binds the actions to the event type,
+// an empty list of actions removes the binding
+type EventTriggerActionsSetEvent struct {
+	eventstore.BaseEvent
+
+	EventType eventstore.EventType `json:"eventType"`
+	ActionIDs []string             `json:"actionIDs"`
+}
+
+func (e *EventTriggerActionsSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *EventTriggerActionsSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewEventTriggerActionsSetEvent(
+	base *eventstore.BaseEvent,
+	eventType eventstore.EventType,
+	actionIDs []string,
+) *EventTriggerActionsSetEvent {
+	return &EventTriggerActionsSetEvent{
+		BaseEvent: *base,
+		EventType: eventType,
+		ActionIDs: actionIDs,
+	}
+}
+
+func EventTriggerActionsSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &EventTriggerActionsSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "FLOW-Kq3ve", "unable to unmarshal event trigger actions")
+	}
+
+	return e, nil
+}
@@ -30,6 +30,10 @@ const (
 	GoogleIDPChangedEventType           eventstore.EventType = "instance.idp.google.changed"
 	LDAPIDPAddedEventType               eventstore.EventType = "instance.idp.ldap.added"
 	LDAPIDPChangedEventType             eventstore.EventType = "instance.idp.ldap.changed"
+	SAMLIDPAddedEventType               eventstore.EventType = "instance.idp.saml.added"
+	SAMLIDPChangedEventType             eventstore.EventType = "instance.idp.saml.changed"
+	AppleIDPAddedEventType              eventstore.EventType = "instance.idp.apple.added"
+	AppleIDPChangedEventType            eventstore.EventType = "instance.idp.apple.changed"
 	IDPRemovedEventType                 eventstore.EventType = "instance.idp.removed"
 )
 
@@ -833,6 +837,166 @@ func LDAPIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error
 	return &LDAPIDPChangedEvent{LDAPIDPChangedEvent: *e.(*idp.LDAPIDPChangedEvent)}, nil
 }
 
+type SAMLIDPAddedEvent struct {
+	idp.SAMLIDPAddedEvent
+}
+
+func NewSAMLIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name string,
+	metadata []byte,
+	key *crypto.CryptoValue,
+	certificate []byte,
+	binding string,
+	withSignedRequest bool,
+	options idp.Options,
+) *SAMLIDPAddedEvent {
+
+	return &SAMLIDPAddedEvent{
+		SAMLIDPAddedEvent: *idp.NewSAMLIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				SAMLIDPAddedEventType,
+			),
+			id,
+			name,
+			metadata,
+			key,
+			certificate,
+			binding,
+			withSignedRequest,
+			options,
+		),
+	}
+}
+
+func SAMLIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.SAMLIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLIDPAddedEvent{SAMLIDPAddedEvent: *e.(*idp.SAMLIDPAddedEvent)}, nil
+}
+
+type SAMLIDPChangedEvent struct {
+	idp.SAMLIDPChangedEvent
+}
+
+func NewSAMLIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.SAMLIDPChanges,
+) (*SAMLIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewSAMLIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SAMLIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &SAMLIDPChangedEvent{SAMLIDPChangedEvent: *changedEvent}, nil
+}
+
+func SAMLIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.SAMLIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SAMLIDPChangedEvent{SAMLIDPChangedEvent: *e.(*idp.SAMLIDPChangedEvent)}, nil
+}
+
+type AppleIDPAddedEvent struct {
+	idp.AppleIDPAddedEvent
+}
+
+func NewAppleIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID,
+	teamID,
+	keyID string,
+	privateKey *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *AppleIDPAddedEvent {
+
+	return &AppleIDPAddedEvent{
+		AppleIDPAddedEvent: *idp.NewAppleIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				AppleIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			teamID,
+			keyID,
+			privateKey,
+			scopes,
+			options,
+		),
+	}
+}
+
+func AppleIDPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.AppleIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppleIDPAddedEvent{AppleIDPAddedEvent: *e.(*idp.AppleIDPAddedEvent)}, nil
+}
+
+type AppleIDPChangedEvent struct {
+	idp.AppleIDPChangedEvent
+}
+
+func NewAppleIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.AppleIDPChanges,
+) (*AppleIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewAppleIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AppleIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &AppleIDPChangedEvent{AppleIDPChangedEvent: *changedEvent}, nil
+}
+
+func AppleIDPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idp.AppleIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppleIDPChangedEvent{AppleIDPChangedEvent: *e.(*idp.AppleIDPChangedEvent)}, nil
+}
+
 type IDPRemovedEvent struct {
 	idp.RemovedEvent
 }
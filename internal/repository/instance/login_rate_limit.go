@@ -0,0 +1,68 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	LoginRateLimitExceededEventType = instanceEventTypePrefix + "login.ratelimit.exceeded"
+)
+
+// LoginRateLimitExceededEvent is pushed once a client ip, login name or client id
+// reached the maximum failed attempts of the login rate limit and is blocked
+type LoginRateLimitExceededEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	KeyType      domain.RateLimitKeyType `json:"keyType"`
+	Key          string                  `json:"key"`
+	Attempts     uint64                  `json:"attempts"`
+	BlockedUntil time.Time               `json:"blockedUntil"`
+}
+
+func (e *LoginRateLimitExceededEvent) Data() interface{} {
+	return e
+}
+
+func (e *LoginRateLimitExceededEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLoginRateLimitExceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	keyType domain.RateLimitKeyType,
+	key string,
+	attempts uint64,
+	blockedUntil time.Time,
+) *LoginRateLimitExceededEvent {
+	return &LoginRateLimitExceededEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LoginRateLimitExceededEventType,
+		),
+		KeyType:      keyType,
+		Key:          key,
+		Attempts:     attempts,
+		BlockedUntil: blockedUntil,
+	}
+}
+
+func LoginRateLimitExceededEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &LoginRateLimitExceededEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Rl3fq", "unable to unmarshal login rate limit exceeded")
+	}
+
+	return e, nil
+}
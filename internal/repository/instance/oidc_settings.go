@@ -23,6 +23,10 @@ type OIDCSettingsAddedEvent struct {
 	IdTokenLifetime            time.Duration `json:"idTokenLifetime,omitempty"`
 	RefreshTokenIdleExpiration time.Duration `json:"refreshTokenIdleExpiration,omitempty"`
 	RefreshTokenExpiration     time.Duration `json:"refreshTokenExpiration,omitempty"`
+
+	SigningKeyAlgorithm string        `json:"signingKeyAlgorithm,omitempty"`
+	SigningKeyLifetime  time.Duration `json:"signingKeyLifetime,omitempty"`
+	SigningKeyOverlap   time.Duration `json:"signingKeyOverlap,omitempty"`
 }
 
 func NewOIDCSettingsAddedEvent(
@@ -32,6 +36,9 @@ func NewOIDCSettingsAddedEvent(
 	idTokenLifetime,
 	refreshTokenIdleExpiration,
 	refreshTokenExpiration time.Duration,
+	signingKeyAlgorithm string,
+	signingKeyLifetime,
+	signingKeyOverlap time.Duration,
 ) *OIDCSettingsAddedEvent {
 	return &OIDCSettingsAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -43,6 +50,9 @@ func NewOIDCSettingsAddedEvent(
 		IdTokenLifetime:            idTokenLifetime,
 		RefreshTokenIdleExpiration: refreshTokenIdleExpiration,
 		RefreshTokenExpiration:     refreshTokenExpiration,
+		SigningKeyAlgorithm:        signingKeyAlgorithm,
+		SigningKeyLifetime:         signingKeyLifetime,
+		SigningKeyOverlap:          signingKeyOverlap,
 	}
 }
 
@@ -73,6 +83,10 @@ type OIDCSettingsChangedEvent struct {
 	IdTokenLifetime            *time.Duration `json:"idTokenLifetime,omitempty"`
 	RefreshTokenIdleExpiration *time.Duration `json:"refreshTokenIdleExpiration,omitempty"`
 	RefreshTokenExpiration     *time.Duration `json:"refreshTokenExpiration,omitempty"`
+
+	SigningKeyAlgorithm *string        `json:"signingKeyAlgorithm,omitempty"`
+	SigningKeyLifetime  *time.Duration `json:"signingKeyLifetime,omitempty"`
+	SigningKeyOverlap   *time.Duration `json:"signingKeyOverlap,omitempty"`
 }
 
 func (e *OIDCSettingsChangedEvent) Data() interface{} {
@@ -130,6 +144,24 @@ func ChangeOIDCSettingsRefreshTokenExpiration(refreshTokenExpiration time.Durati
 	}
 }
 
+func ChangeOIDCSettingsSigningKeyAlgorithm(signingKeyAlgorithm string) func(event *OIDCSettingsChangedEvent) {
+	return func(e *OIDCSettingsChangedEvent) {
+		e.SigningKeyAlgorithm = &signingKeyAlgorithm
+	}
+}
+
+func ChangeOIDCSettingsSigningKeyLifetime(signingKeyLifetime time.Duration) func(event *OIDCSettingsChangedEvent) {
+	return func(e *OIDCSettingsChangedEvent) {
+		e.SigningKeyLifetime = &signingKeyLifetime
+	}
+}
+
+func ChangeOIDCSettingsSigningKeyOverlap(signingKeyOverlap time.Duration) func(event *OIDCSettingsChangedEvent) {
+	return func(e *OIDCSettingsChangedEvent) {
+		e.SigningKeyOverlap = &signingKeyOverlap
+	}
+}
+
 func OIDCSettingsChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &OIDCSettingsChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -3,6 +3,7 @@ package instance
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -17,8 +18,14 @@ const (
 type SecurityPolicySetEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Enabled        *bool     `json:"enabled,omitempty"`
-	AllowedOrigins *[]string `json:"allowedOrigins,omitempty"`
+	Enabled                *bool     `json:"enabled,omitempty"`
+	AllowedOrigins         *[]string `json:"allowedOrigins,omitempty"`
+	TokenExchangeClientIDs *[]string `json:"tokenExchangeClientIds,omitempty"`
+	ImpersonationClientIDs *[]string `json:"impersonationClientIds,omitempty"`
+
+	LoginRateLimitMaxAttemptsPerIP        *uint64        `json:"loginRateLimitMaxAttemptsPerIp,omitempty"`
+	LoginRateLimitMaxAttemptsPerLoginName *uint64        `json:"loginRateLimitMaxAttemptsPerLoginName,omitempty"`
+	LoginRateLimitWindow                  *time.Duration `json:"loginRateLimitWindow,omitempty"`
 }
 
 func NewSecurityPolicySetEvent(
@@ -59,6 +66,42 @@ func ChangeSecurityPolicyAllowedOrigins(allowedOrigins []string) func(event *Sec
 	}
 }
 
+func ChangeSecurityPolicyTokenExchangeClientIDs(clientIDs []string) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		if len(clientIDs) == 0 {
+			clientIDs = []string{}
+		}
+		e.TokenExchangeClientIDs = &clientIDs
+	}
+}
+
+func ChangeSecurityPolicyImpersonationClientIDs(clientIDs []string) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		if len(clientIDs) == 0 {
+			clientIDs = []string{}
+		}
+		e.ImpersonationClientIDs = &clientIDs
+	}
+}
+
+func ChangeSecurityPolicyLoginRateLimitMaxAttemptsPerIP(maxAttempts uint64) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		e.LoginRateLimitMaxAttemptsPerIP = &maxAttempts
+	}
+}
+
+func ChangeSecurityPolicyLoginRateLimitMaxAttemptsPerLoginName(maxAttempts uint64) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		e.LoginRateLimitMaxAttemptsPerLoginName = &maxAttempts
+	}
+}
+
+func ChangeSecurityPolicyLoginRateLimitWindow(window time.Duration) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		e.LoginRateLimitWindow = &window
+	}
+}
+
 func (e *SecurityPolicySetEvent) Data() interface{} {
 	return e
 }
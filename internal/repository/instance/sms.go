@@ -16,9 +16,11 @@ const (
 	SMSConfigTwilioAddedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "added"
 	SMSConfigTwilioChangedEventType      = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "changed"
 	SMSConfigTwilioTokenChangedEventType = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "token.changed"
-	SMSConfigActivatedEventType          = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "activated"
-	SMSConfigDeactivatedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "deactivated"
-	SMSConfigRemovedEventType            = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "removed"
+	// the activated, deactivated and removed events are used for all providers,
+	// their types keep the twilio prefix as they were pushed before the other providers existed
+	SMSConfigActivatedEventType   = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "activated"
+	SMSConfigDeactivatedEventType = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "deactivated"
+	SMSConfigRemovedEventType     = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "removed"
 )
 
 type SMSConfigTwilioAddedEvent struct {
@@ -185,7 +187,7 @@ type SMSConfigActivatedEvent struct {
 	ID                   string `json:"id,omitempty"`
 }
 
-func NewSMSConfigTwilioActivatedEvent(
+func NewSMSConfigActivatedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
 	id string,
@@ -214,7 +216,7 @@ func SMSConfigActivatedEventMapper(event *repository.Event) (eventstore.Event, e
 	}
 	err := json.Unmarshal(event.Data, smsConfigActivated)
 	if err != nil {
-		return nil, errors.ThrowInternal(err, "IAM-dn92f", "unable to unmarshal sms config twilio activated changed")
+		return nil, errors.ThrowInternal(err, "IAM-dn92f", "unable to unmarshal sms config activated changed")
 	}
 
 	return smsConfigActivated, nil
@@ -254,7 +256,7 @@ func SMSConfigDeactivatedEventMapper(event *repository.Event) (eventstore.Event,
 	}
 	err := json.Unmarshal(event.Data, smsConfigDeactivated)
 	if err != nil {
-		return nil, errors.ThrowInternal(err, "IAM-dn92f", "unable to unmarshal sms config twilio deactivated changed")
+		return nil, errors.ThrowInternal(err, "IAM-dn92f", "unable to unmarshal sms config deactivated changed")
 	}
 
 	return smsConfigDeactivated, nil
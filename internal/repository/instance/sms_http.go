@@ -0,0 +1,187 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	smsConfigHTTPPrefix                  = "http."
+	SMSConfigHTTPAddedEventType          = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "added"
+	SMSConfigHTTPChangedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "changed"
+	SMSConfigHTTPHeadersChangedEventType = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "headers.changed"
+)
+
+type SMSConfigHTTPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id,omitempty"`
+	Endpoint     string              `json:"endpoint,omitempty"`
+	Headers      *crypto.CryptoValue `json:"headers,omitempty"`
+	BodyTemplate string              `json:"bodyTemplate,omitempty"`
+	SenderNumber string              `json:"senderNumber,omitempty"`
+}
+
+func NewSMSConfigHTTPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	endpoint,
+	bodyTemplate,
+	senderNumber string,
+	headers *crypto.CryptoValue,
+) *SMSConfigHTTPAddedEvent {
+	return &SMSConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigHTTPAddedEventType,
+		),
+		ID:           id,
+		Endpoint:     endpoint,
+		Headers:      headers,
+		BodyTemplate: bodyTemplate,
+		SenderNumber: senderNumber,
+	}
+}
+
+func (e *SMSConfigHTTPAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SMSConfigHTTPAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func SMSConfigHTTPAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	smsConfigAdded := &SMSConfigHTTPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, smsConfigAdded)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Gm3sf", "unable to unmarshal sms config http added")
+	}
+
+	return smsConfigAdded, nil
+}
+
+type SMSConfigHTTPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string  `json:"id,omitempty"`
+	Endpoint     *string `json:"endpoint,omitempty"`
+	BodyTemplate *string `json:"bodyTemplate,omitempty"`
+	SenderNumber *string `json:"senderNumber,omitempty"`
+}
+
+func NewSMSConfigHTTPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []SMSConfigHTTPChanges,
+) (*SMSConfigHTTPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "IAM-Kw3fs", "Errors.NoChangesFound")
+	}
+	changeEvent := &SMSConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigHTTPChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SMSConfigHTTPChanges func(event *SMSConfigHTTPChangedEvent)
+
+func ChangeSMSConfigHTTPEndpoint(endpoint string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Endpoint = &endpoint
+	}
+}
+
+func ChangeSMSConfigHTTPBodyTemplate(bodyTemplate string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.BodyTemplate = &bodyTemplate
+	}
+}
+
+func ChangeSMSConfigHTTPSenderNumber(senderNumber string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.SenderNumber = &senderNumber
+	}
+}
+
+func (e *SMSConfigHTTPChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SMSConfigHTTPChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func SMSConfigHTTPChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	smsConfigChanged := &SMSConfigHTTPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, smsConfigChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Qw2fs", "unable to unmarshal sms config http changed")
+	}
+
+	return smsConfigChanged, nil
+}
+
+type SMSConfigHTTPHeadersChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID      string              `json:"id,omitempty"`
+	Headers *crypto.CryptoValue `json:"headers,omitempty"`
+}
+
+func NewSMSConfigHTTPHeadersChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	headers *crypto.CryptoValue,
+) *SMSConfigHTTPHeadersChangedEvent {
+	return &SMSConfigHTTPHeadersChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigHTTPHeadersChangedEventType,
+		),
+		ID:      id,
+		Headers: headers,
+	}
+}
+
+func (e *SMSConfigHTTPHeadersChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SMSConfigHTTPHeadersChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func SMSConfigHTTPHeadersChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	headersChanged := &SMSConfigHTTPHeadersChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, headersChanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Zs2fd", "unable to unmarshal sms config http headers changed")
+	}
+
+	return headersChanged, nil
+}
@@ -0,0 +1,106 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	actionLibraryEventTypePrefix  = orgEventTypePrefix + "action.library."
+	ActionLibrarySetEventType     = actionLibraryEventTypePrefix + "set"
+	ActionLibraryRemovedEventType = actionLibraryEventTypePrefix + "removed"
+)
+
+// ActionLibrarySetEvent adds a new version of the library,
+// the dependencies are the libraries required by the script
+type ActionLibrarySetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name         string   `json:"name"`
+	Version      uint64   `json:"version"`
+	Script       string   `json:"script"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+func (e *ActionLibrarySetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionLibrarySetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionLibrarySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name string,
+	version uint64,
+	script string,
+	dependencies []string,
+) *ActionLibrarySetEvent {
+	return &ActionLibrarySetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionLibrarySetEventType,
+		),
+		Name:         name,
+		Version:      version,
+		Script:       script,
+		Dependencies: dependencies,
+	}
+}
+
+func ActionLibrarySetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionLibrarySetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Ju7vd", "unable to unmarshal action library set")
+	}
+
+	return e, nil
+}
+
+// ActionLibraryRemovedEvent removes all versions of the library
+type ActionLibraryRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name string `json:"name"`
+}
+
+func (e *ActionLibraryRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionLibraryRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionLibraryRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, name string) *ActionLibraryRemovedEvent {
+	return &ActionLibraryRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionLibraryRemovedEventType,
+		),
+		Name: name,
+	}
+}
+
+func ActionLibraryRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionLibraryRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Xw2pf", "unable to unmarshal action library removed")
+	}
+
+	return e, nil
+}
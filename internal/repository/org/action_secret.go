@@ -0,0 +1,93 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	actionSecretEventTypePrefix  = orgEventTypePrefix + "action.secret."
+	ActionSecretSetEventType     = actionSecretEventTypePrefix + "set"
+	ActionSecretRemovedEventType = actionSecretEventTypePrefix + "removed"
+)
+
+type ActionSecretSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name  string              `json:"name"`
+	Value *crypto.CryptoValue `json:"value"`
+}
+
+func (e *ActionSecretSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionSecretSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionSecretSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, name string, value *crypto.CryptoValue) *ActionSecretSetEvent {
+	return &ActionSecretSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionSecretSetEventType,
+		),
+		Name:  name,
+		Value: value,
+	}
+}
+
+func ActionSecretSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionSecretSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Hs8qp", "unable to unmarshal action secret set")
+	}
+
+	return e, nil
+}
+
+type ActionSecretRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name string `json:"name"`
+}
+
+func (e *ActionSecretRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionSecretRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionSecretRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, name string) *ActionSecretRemovedEvent {
+	return &ActionSecretRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionSecretRemovedEventType,
+		),
+		Name: name,
+	}
+}
+
+func ActionSecretRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionSecretRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Vb3mz", "unable to unmarshal action secret removed")
+	}
+
+	return e, nil
+}
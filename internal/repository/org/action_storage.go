@@ -0,0 +1,92 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	actionStorageEventTypePrefix  = orgEventTypePrefix + "action.storage."
+	ActionStorageSetEventType     = actionStorageEventTypePrefix + "set"
+	ActionStorageRemovedEventType = actionStorageEventTypePrefix + "removed"
+)
+
+type ActionStorageSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (e *ActionStorageSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionStorageSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionStorageSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, key, value string) *ActionStorageSetEvent {
+	return &ActionStorageSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionStorageSetEventType,
+		),
+		Key:   key,
+		Value: value,
+	}
+}
+
+func ActionStorageSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionStorageSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Lq4te", "unable to unmarshal action storage set")
+	}
+
+	return e, nil
+}
+
+type ActionStorageRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key string `json:"key"`
+}
+
+func (e *ActionStorageRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionStorageRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewActionStorageRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, key string) *ActionStorageRemovedEvent {
+	return &ActionStorageRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionStorageRemovedEventType,
+		),
+		Key: key,
+	}
+}
+
+func ActionStorageRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionStorageRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ORG-Zc7wn", "unable to unmarshal action storage removed")
+	}
+
+	return e, nil
+}
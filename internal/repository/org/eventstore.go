@@ -98,10 +98,21 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, GoogleIDPChangedEventType, GoogleIDPChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LDAPIDPAddedEventType, LDAPIDPAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, LDAPIDPChangedEventType, LDAPIDPChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SAMLIDPChangedEventType, SAMLIDPChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, AppleIDPAddedEventType, AppleIDPAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, AppleIDPChangedEventType, AppleIDPChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, IDPRemovedEventType, IDPRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, TriggerActionsSetEventType, TriggerActionsSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, TriggerActionsCascadeRemovedEventType, TriggerActionsCascadeRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, FlowClearedEventType, FlowClearedEventMapper).
+		RegisterFilterEventMapper(AggregateType, EventTriggerActionsSetEventType, EventTriggerActionsSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, ActionSecretSetEventType, ActionSecretSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, ActionSecretRemovedEventType, ActionSecretRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ActionStorageSetEventType, ActionStorageSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, ActionStorageRemovedEventType, ActionStorageRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ActionLibrarySetEventType, ActionLibrarySetEventMapper).
+		RegisterFilterEventMapper(AggregateType, ActionLibraryRemovedEventType, ActionLibraryRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataSetType, MetadataSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataRemovedType, MetadataRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MetadataRemovedAllType, MetadataRemovedAllEventMapper).
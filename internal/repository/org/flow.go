@@ -13,6 +13,7 @@ var (
 	TriggerActionsSetEventType            = orgEventTypePrefix + flow.TriggerActionsSetEventType
 	TriggerActionsCascadeRemovedEventType = orgEventTypePrefix + flow.TriggerActionsCascadeRemovedEventType
 	FlowClearedEventType                  = orgEventTypePrefix + flow.FlowClearedEventType
+	EventTriggerActionsSetEventType       = orgEventTypePrefix + flow.EventTriggerActionsSetEventType
 )
 
 type TriggerActionsSetEvent struct {
@@ -104,3 +105,33 @@ func FlowClearedEventMapper(event *repository.Event) (eventstore.Event, error) {
 
 	return &FlowClearedEvent{FlowClearedEvent: *e.(*flow.FlowClearedEvent)}, nil
 }
+
+type EventTriggerActionsSetEvent struct {
+	flow.EventTriggerActionsSetEvent
+}
+
+func NewEventTriggerActionsSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	eventType eventstore.EventType,
+	actionIDs []string,
+) *EventTriggerActionsSetEvent {
+	return &EventTriggerActionsSetEvent{
+		EventTriggerActionsSetEvent: *flow.NewEventTriggerActionsSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				EventTriggerActionsSetEventType),
+			eventType,
+			actionIDs),
+	}
+}
+
+func EventTriggerActionsSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := flow.EventTriggerActionsSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventTriggerActionsSetEvent{EventTriggerActionsSetEvent: *e.(*flow.EventTriggerActionsSetEvent)}, nil
+}
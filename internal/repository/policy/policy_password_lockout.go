@@ -2,6 +2,7 @@ package policy
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 
@@ -18,8 +19,10 @@ const (
 type LockoutPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MaxPasswordAttempts uint64 `json:"maxPasswordAttempts,omitempty"`
-	ShowLockOutFailures bool   `json:"showLockOutFailures,omitempty"`
+	MaxPasswordAttempts uint64        `json:"maxPasswordAttempts,omitempty"`
+	MaxOTPAttempts      uint64        `json:"maxOTPAttempts,omitempty"`
+	ShowLockOutFailures bool          `json:"showLockOutFailures,omitempty"`
+	LockoutDuration     time.Duration `json:"lockoutDuration,omitempty"`
 }
 
 func (e *LockoutPolicyAddedEvent) Data() interface{} {
@@ -32,14 +35,18 @@ func (e *LockoutPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueC
 
 func NewLockoutPolicyAddedEvent(
 	base *eventstore.BaseEvent,
-	maxAttempts uint64,
+	maxPasswordAttempts,
+	maxOTPAttempts uint64,
 	showLockOutFailures bool,
+	lockoutDuration time.Duration,
 ) *LockoutPolicyAddedEvent {
 
 	return &LockoutPolicyAddedEvent{
 		BaseEvent:           *base,
-		MaxPasswordAttempts: maxAttempts,
+		MaxPasswordAttempts: maxPasswordAttempts,
+		MaxOTPAttempts:      maxOTPAttempts,
 		ShowLockOutFailures: showLockOutFailures,
+		LockoutDuration:     lockoutDuration,
 	}
 }
 
@@ -59,8 +66,10 @@ func LockoutPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, e
 type LockoutPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MaxPasswordAttempts *uint64 `json:"maxPasswordAttempts,omitempty"`
-	ShowLockOutFailures *bool   `json:"showLockOutFailures,omitempty"`
+	MaxPasswordAttempts *uint64        `json:"maxPasswordAttempts,omitempty"`
+	MaxOTPAttempts      *uint64        `json:"maxOTPAttempts,omitempty"`
+	ShowLockOutFailures *bool          `json:"showLockOutFailures,omitempty"`
+	LockoutDuration     *time.Duration `json:"lockoutDuration,omitempty"`
 }
 
 func (e *LockoutPolicyChangedEvent) Data() interface{} {
@@ -95,6 +104,18 @@ func ChangeMaxAttempts(maxAttempts uint64) func(*LockoutPolicyChangedEvent) {
 	}
 }
 
+func ChangeMaxOTPAttempts(maxAttempts uint64) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.MaxOTPAttempts = &maxAttempts
+	}
+}
+
+func ChangeLockoutDuration(lockoutDuration time.Duration) func(*LockoutPolicyChangedEvent) {
+	return func(e *LockoutPolicyChangedEvent) {
+		e.LockoutDuration = &lockoutDuration
+	}
+}
+
 func ChangeShowLockOutFailures(showLockOutFailures bool) func(*LockoutPolicyChangedEvent) {
 	return func(e *LockoutPolicyChangedEvent) {
 		e.ShowLockOutFailures = &showLockOutFailures
@@ -62,6 +62,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanPasswordChangeSentType, HumanPasswordChangeSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCheckSucceededType, HumanPasswordCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanPasswordCheckFailedType, HumanPasswordCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanPasswordHashUpdatedType, HumanPasswordHashUpdatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLinkAddedType, UserIDPLinkAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLinkRemovedType, UserIDPLinkRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserIDPLinkCascadeRemovedType, UserIDPLinkCascadeRemovedEventMapper).
@@ -87,6 +88,18 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPRemovedType, HumanOTPRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPCheckSucceededType, HumanOTPCheckSucceededEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanMFAOTPCheckFailedType, HumanOTPCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSAddedType, HumanOTPSMSAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSRemovedType, HumanOTPSMSRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSCodeAddedType, HumanOTPSMSCodeAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSCodeSentType, HumanOTPSMSCodeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSCheckSucceededType, HumanOTPSMSCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPSMSCheckFailedType, HumanOTPSMSCheckFailedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailAddedType, HumanOTPEmailAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailRemovedType, HumanOTPEmailRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailCodeAddedType, HumanOTPEmailCodeAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailCodeSentType, HumanOTPEmailCodeSentEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailCheckSucceededType, HumanOTPEmailCheckSucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, HumanMFAOTPEmailCheckFailedType, HumanOTPEmailCheckFailedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenAddedType, HumanU2FAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper).
 		RegisterFilterEventMapper(AggregateType, HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper).
@@ -115,6 +128,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, MachineKeyRemovedEventType, MachineKeyRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, PersonalAccessTokenAddedType, PersonalAccessTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, PersonalAccessTokenRemovedType, PersonalAccessTokenRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, UserImpersonatedType, UserImpersonatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineSecretSetType, MachineSecretSetEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineSecretRemovedType, MachineSecretRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, MachineSecretCheckSucceededType, MachineSecretCheckSucceededEventMapper).
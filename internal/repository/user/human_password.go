@@ -21,6 +21,7 @@ const (
 	HumanPasswordCodeSentType       = passwordEventPrefix + "code.sent"
 	HumanPasswordCheckSucceededType = passwordEventPrefix + "check.succeeded"
 	HumanPasswordCheckFailedType    = passwordEventPrefix + "check.failed"
+	HumanPasswordHashUpdatedType    = passwordEventPrefix + "hash.updated"
 )
 
 type HumanPasswordChangedEvent struct {
@@ -252,3 +253,46 @@ func HumanPasswordCheckFailedEventMapper(event *repository.Event) (eventstore.Ev
 
 	return humanAdded, nil
 }
+
+// HumanPasswordHashUpdatedEvent is pushed, if the password was rehashed with the current password hasher
+// (e.g. because of an upgrade of the algorithm or its cost). The password itself did not change.
+type HumanPasswordHashUpdatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Secret *crypto.CryptoValue `json:"secret,omitempty"`
+}
+
+func (e *HumanPasswordHashUpdatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanPasswordHashUpdatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanPasswordHashUpdatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	secret *crypto.CryptoValue,
+) *HumanPasswordHashUpdatedEvent {
+	return &HumanPasswordHashUpdatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPasswordHashUpdatedType,
+		),
+		Secret: secret,
+	}
+}
+
+func HumanPasswordHashUpdatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	hashUpdated := &HumanPasswordHashUpdatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, hashUpdated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Gk3sw", "unable to unmarshal human password hash updated")
+	}
+
+	return hashUpdated, nil
+}
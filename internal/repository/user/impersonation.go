@@ -0,0 +1,66 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UserImpersonatedType = userEventTypePrefix + "impersonated"
+)
+
+// UserImpersonatedEvent is pushed on the user (aggregate) a token was issued for by token exchange
+// in the name of another user (the actor), which is recorded in the act claim of the token
+type UserImpersonatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID       string `json:"tokenId,omitempty"`
+	ApplicationID string `json:"applicationId,omitempty"`
+	ActorUserID   string `json:"actorUserId,omitempty"`
+	ActorOrgID    string `json:"actorOrgId,omitempty"`
+}
+
+func (e *UserImpersonatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserImpersonatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserImpersonatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	applicationID,
+	actorUserID,
+	actorOrgID string,
+) *UserImpersonatedEvent {
+	return &UserImpersonatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserImpersonatedType,
+		),
+		TokenID:       tokenID,
+		ApplicationID: applicationID,
+		ActorUserID:   actorUserID,
+		ActorOrgID:    actorOrgID,
+	}
+}
+
+func UserImpersonatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	impersonated := &UserImpersonatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, impersonated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Gkw3s", "unable to unmarshal user impersonated")
+	}
+
+	return impersonated, nil
+}
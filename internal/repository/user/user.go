@@ -50,30 +50,42 @@ func NewRemoveUsernameUniqueConstraint(userName, resourceOwner string, userLogin
 
 type UserLockedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	// LockoutDuration after which the user is unlocked automatically, zero if the user stays locked
+	LockoutDuration time.Duration `json:"lockoutDuration,omitempty"`
 }
 
 func (e *UserLockedEvent) Data() interface{} {
-	return nil
+	return e
 }
 
 func (e *UserLockedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
 	return nil
 }
 
-func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserLockedEvent {
+func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate, lockoutDuration time.Duration) *UserLockedEvent {
 	return &UserLockedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
 			ctx,
 			aggregate,
 			UserLockedType,
 		),
+		LockoutDuration: lockoutDuration,
 	}
 }
 
 func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
-	return &UserLockedEvent{
+	lockedEvent := &UserLockedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	if len(event.Data) == 0 {
+		return lockedEvent, nil
+	}
+	err := json.Unmarshal(event.Data, lockedEvent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Lk3fw", "unable to unmarshal user locked")
+	}
+	return lockedEvent, nil
 }
 
 type UserUnlockedEvent struct {
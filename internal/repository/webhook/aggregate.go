@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+const (
+	AggregateType    = "webhook"
+	AggregateVersion = "v1"
+)
+
+// SubscribableAggregateTypes are the aggregate types of which the events are delivered to webhooks
+var SubscribableAggregateTypes = []eventstore.AggregateType{
+	instance.AggregateType,
+	org.AggregateType,
+	project.AggregateType,
+	user.AggregateType,
+	usergrant.AggregateType,
+	action.AggregateType,
+}
+
+// IsSubscribable reports if the events of the aggregate type are delivered to webhooks
+func IsSubscribable(aggregateType eventstore.AggregateType) bool {
+	for _, subscribable := range SubscribableAggregateTypes {
+		if subscribable == aggregateType {
+			return true
+		}
+	}
+	return false
+}
+
+type Aggregate struct {
+	eventstore.Aggregate
+}
+
+func NewAggregate(id, resourceOwner string) *Aggregate {
+	return &Aggregate{
+		Aggregate: eventstore.Aggregate{
+			Type:          AggregateType,
+			Version:       AggregateVersion,
+			ID:            id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
@@ -0,0 +1,12 @@
+package webhook
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedEventType, AddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ChangedEventType, ChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SigningKeyChangedEventType, SigningKeyChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, DeactivatedEventType, DeactivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ReactivatedEventType, ReactivatedEventMapper).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper)
+}
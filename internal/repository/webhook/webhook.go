@@ -0,0 +1,307 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	UniqueWebhookNameType      = "webhook_names"
+	eventTypePrefix            = eventstore.EventType("webhook.")
+	AddedEventType             = eventTypePrefix + "added"
+	ChangedEventType           = eventTypePrefix + "changed"
+	SigningKeyChangedEventType = eventTypePrefix + "signing_key.changed"
+	DeactivatedEventType       = eventTypePrefix + "deactivated"
+	ReactivatedEventType       = eventTypePrefix + "reactivated"
+	RemovedEventType           = eventTypePrefix + "removed"
+)
+
+func NewAddWebhookNameUniqueConstraint(webhookName, resourceOwner string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueWebhookNameType,
+		webhookName+":"+resourceOwner,
+		"Errors.Webhook.AlreadyExists")
+}
+
+func NewRemoveWebhookNameUniqueConstraint(webhookName, resourceOwner string) *eventstore.EventUniqueConstraint {
+	return eventstore.NewRemoveEventUniqueConstraint(
+		UniqueWebhookNameType,
+		webhookName+":"+resourceOwner)
+}
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name           string              `json:"name"`
+	URL            string              `json:"url"`
+	AggregateTypes []string            `json:"aggregateTypes,omitempty"`
+	EventTypes     []string            `json:"eventTypes,omitempty"`
+	SigningKey     *crypto.CryptoValue `json:"signingKey"`
+}
+
+func (e *AddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewAddWebhookNameUniqueConstraint(e.Name, e.Aggregate().ResourceOwner)}
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name,
+	url string,
+	aggregateTypes,
+	eventTypes []string,
+	signingKey *crypto.CryptoValue,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedEventType,
+		),
+		Name:           name,
+		URL:            url,
+		AggregateTypes: aggregateTypes,
+		EventTypes:     eventTypes,
+		SigningKey:     signingKey,
+	}
+}
+
+func AddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Pq3zu", "unable to unmarshal webhook added")
+	}
+
+	return e, nil
+}
+
+type ChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name           *string   `json:"name,omitempty"`
+	URL            *string   `json:"url,omitempty"`
+	AggregateTypes *[]string `json:"aggregateTypes,omitempty"`
+	EventTypes     *[]string `json:"eventTypes,omitempty"`
+	oldName        string
+}
+
+func (e *ChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *ChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	if e.oldName == "" {
+		return nil
+	}
+	return []*eventstore.EventUniqueConstraint{
+		NewRemoveWebhookNameUniqueConstraint(e.oldName, e.Aggregate().ResourceOwner),
+		NewAddWebhookNameUniqueConstraint(*e.Name, e.Aggregate().ResourceOwner),
+	}
+}
+
+func NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []WebhookChanges,
+) (*ChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "WEBHOOK-Mf0sd", "Errors.NoChangesFound")
+	}
+	changeEvent := &ChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ChangedEventType,
+		),
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type WebhookChanges func(event *ChangedEvent)
+
+func ChangeName(name, oldName string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Name = &name
+		e.oldName = oldName
+	}
+}
+
+func ChangeURL(url string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.URL = &url
+	}
+}
+
+func ChangeAggregateTypes(aggregateTypes []string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.AggregateTypes = &aggregateTypes
+	}
+}
+
+func ChangeEventTypes(eventTypes []string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.EventTypes = &eventTypes
+	}
+}
+
+func ChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Ajd2s", "unable to unmarshal webhook changed")
+	}
+
+	return e, nil
+}
+
+type SigningKeyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SigningKey *crypto.CryptoValue `json:"signingKey"`
+}
+
+func (e *SigningKeyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *SigningKeyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewSigningKeyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	signingKey *crypto.CryptoValue,
+) *SigningKeyChangedEvent {
+	return &SigningKeyChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SigningKeyChangedEventType,
+		),
+		SigningKey: signingKey,
+	}
+}
+
+func SigningKeyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &SigningKeyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "WEBHOOK-Xl2sd", "unable to unmarshal webhook signing key changed")
+	}
+
+	return e, nil
+}
+
+type DeactivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *DeactivatedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *DeactivatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewDeactivatedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *DeactivatedEvent {
+	return &DeactivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeactivatedEventType,
+		),
+	}
+}
+
+func DeactivatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &DeactivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type ReactivatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *ReactivatedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *ReactivatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewReactivatedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *ReactivatedEvent {
+	return &ReactivatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ReactivatedEventType,
+		),
+	}
+}
+
+func ReactivatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &ReactivatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	name string
+}
+
+func (e *RemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return []*eventstore.EventUniqueConstraint{NewRemoveWebhookNameUniqueConstraint(e.name, e.Aggregate().ResourceOwner)}
+}
+
+func NewRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name string,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RemovedEventType,
+		),
+		name: name,
+	}
+}
+
+func RemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
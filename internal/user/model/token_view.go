@@ -22,6 +22,8 @@ type TokenView struct {
 	PreferredLanguage string
 	RefreshTokenID    string
 	IsPAT             bool
+	ActorUserID       string
+	ActorOrgID        string
 }
 
 type TokenSearchRequest struct {
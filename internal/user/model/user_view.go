@@ -22,10 +22,20 @@ type UserView struct {
 	LastLogin          time.Time
 	PreferredLoginName string
 	LoginNames         []string
+	// LockedUntil is the end of a temporary lock, zero if the user is not locked or locked permanently
+	LockedUntil time.Time
 	*MachineView
 	*HumanView
 }
 
+// LockoutExpired returns true if the user was locked temporarily and the lockout duration has passed,
+// the lock itself is lifted by the next check of a factor
+func (u *UserView) LockoutExpired() bool {
+	return u.State == UserStateLocked &&
+		!u.LockedUntil.IsZero() &&
+		!u.LockedUntil.After(time.Now())
+}
+
 type HumanView struct {
 	PasswordSet              bool
 	PasswordInitRequired     bool
@@ -49,6 +59,8 @@ type HumanView struct {
 	Region                   string
 	StreetAddress            string
 	OTPState                 MFAState
+	OTPSMSAdded              bool
+	OTPEmailAdded            bool
 	U2FTokens                []*WebAuthNView
 	PasswordlessTokens       []*WebAuthNView
 	MFAMaxSetUp              domain.MFALevel
@@ -162,10 +174,17 @@ func (u *UserView) MFATypesSetupPossible(level domain.MFALevel, policy *domain.L
 					}
 				case domain.SecondFactorTypeU2F:
 					types = append(types, domain.MFATypeU2F)
+				case domain.SecondFactorTypeOTPSMS:
+					if !u.OTPSMSAdded && u.IsPhoneVerified {
+						types = append(types, domain.MFATypeOTPSMS)
+					}
+				case domain.SecondFactorTypeOTPEmail:
+					if !u.OTPEmailAdded && u.IsEmailVerified {
+						types = append(types, domain.MFATypeOTPEmail)
+					}
 				}
 			}
 		}
-		//PLANNED: add sms
 	}
 	return types
 }
@@ -189,14 +208,31 @@ func (u *UserView) MFATypesAllowed(level domain.MFALevel, policy *domain.LoginPo
 					if u.IsU2FReady() {
 						types = append(types, domain.MFATypeU2F)
 					}
+				case domain.SecondFactorTypeOTPSMS:
+					if u.IsOTPSMSReady() {
+						types = append(types, domain.MFATypeOTPSMS)
+					}
+				case domain.SecondFactorTypeOTPEmail:
+					if u.IsOTPEmailReady() {
+						types = append(types, domain.MFATypeOTPEmail)
+					}
 				}
 			}
 		}
-		//PLANNED: add sms
 	}
 	return types, required
 }
 
+// IsOTPSMSReady returns true if the user added otp sms and the phone (the codes are sent to) is still verified
+func (u *UserView) IsOTPSMSReady() bool {
+	return u.OTPSMSAdded && u.IsPhoneVerified
+}
+
+// IsOTPEmailReady returns true if the user added otp email and the email (the codes are sent to) is still verified
+func (u *UserView) IsOTPEmailReady() bool {
+	return u.OTPEmailAdded && u.IsEmailVerified
+}
+
 func (u *UserView) IsU2FReady() bool {
 	for _, token := range u.U2FTokens {
 		if token.State == MFAStateReady {
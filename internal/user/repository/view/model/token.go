@@ -40,6 +40,8 @@ type TokenView struct {
 	PreferredLanguage string               `json:"preferredLanguage" gorm:"column:preferred_language"`
 	RefreshTokenID    string               `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
 	IsPAT             bool                 `json:"-" gorm:"is_pat"`
+	ActorUserID       string               `json:"-" gorm:"column:actor_user_id"`
+	ActorOrgID        string               `json:"-" gorm:"column:actor_org_id"`
 	Deactivated       bool                 `json:"-" gorm:"-"`
 	InstanceID        string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
@@ -60,6 +62,8 @@ func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 		PreferredLanguage: token.PreferredLanguage,
 		RefreshTokenID:    token.RefreshTokenID,
 		IsPAT:             token.IsPAT,
+		ActorUserID:       token.ActorUserID,
+		ActorOrgID:        token.ActorOrgID,
 	}
 }
 
@@ -72,6 +76,8 @@ func (t *TokenView) AppendEventIfMyToken(event *es_models.Event) (err error) {
 		err = view.setData(event)
 	case user_repo.UserTokenRemovedType:
 		return t.appendTokenRemoved(event)
+	case user_repo.UserImpersonatedType:
+		return t.appendImpersonated(event)
 	case user_repo.HumanRefreshTokenRemovedType:
 		return t.appendRefreshTokenRemoved(event)
 	case user_repo.UserV1SignedOutType,
@@ -122,6 +128,8 @@ func (t *TokenView) AppendEvent(event *es_models.Event) error {
 		}
 		t.CreationDate = event.CreationDate
 		t.IsPAT = eventstore.EventType(event.Type) == user_repo.PersonalAccessTokenAddedType
+	case user_repo.UserImpersonatedType:
+		return t.setActor(event)
 	}
 	return nil
 }
@@ -140,6 +148,18 @@ func (t *TokenView) setData(event *es_models.Event) error {
 	return nil
 }
 
+// setActor sets the user the token was issued to by token exchange in the name of the token's user
+func (t *TokenView) setActor(event *es_models.Event) error {
+	impersonated := new(user_repo.UserImpersonatedEvent)
+	if err := json.Unmarshal(event.Data, impersonated); err != nil {
+		logging.WithError(err).Error("could not unmarshal event data")
+		return caos_errs.ThrowInternal(err, "MODEL-Hw3sf", "could not unmarshal event")
+	}
+	t.ActorUserID = impersonated.ActorUserID
+	t.ActorOrgID = impersonated.ActorOrgID
+	return nil
+}
+
 func agentIDFromSession(event *es_models.Event) (string, error) {
 	session := make(map[string]interface{})
 	if err := json.Unmarshal(event.Data, &session); err != nil {
@@ -160,6 +180,17 @@ func (t *TokenView) appendTokenRemoved(event *es_models.Event) error {
 	return nil
 }
 
+func (t *TokenView) appendImpersonated(event *es_models.Event) error {
+	impersonated, err := eventToMap(event)
+	if err != nil {
+		return err
+	}
+	if impersonated["tokenId"] == t.ID {
+		return t.setActor(event)
+	}
+	return nil
+}
+
 func (t *TokenView) appendRefreshTokenRemoved(event *es_models.Event) error {
 	refreshToken, err := eventToMap(event)
 	if err != nil {
@@ -55,6 +55,7 @@ type UserView struct {
 	Type               userType             `json:"-" gorm:"column:user_type"`
 	UserName           string               `json:"userName" gorm:"column:user_name"`
 	InstanceID         string               `json:"instanceID" gorm:"column:instance_id;primary_key"`
+	LockedUntil        time.Time            `json:"-" gorm:"column:locked_until"`
 	*MachineView
 	*HumanView
 }
@@ -89,6 +90,8 @@ type HumanView struct {
 	Region                   string         `json:"region" gorm:"column:region"`
 	StreetAddress            string         `json:"streetAddress" gorm:"column:street_address"`
 	OTPState                 int32          `json:"-" gorm:"column:otp_state"`
+	OTPSMSAdded              bool           `json:"-" gorm:"column:otp_sms_added"`
+	OTPEmailAdded            bool           `json:"-" gorm:"column:otp_email_added"`
 	U2FTokens                WebAuthNTokens `json:"-" gorm:"column:u2f_tokens"`
 	MFAMaxSetUp              int32          `json:"-" gorm:"column:mfa_max_set_up"`
 	MFAInitSkipped           time.Time      `json:"-" gorm:"column:mfa_init_skipped"`
@@ -152,6 +155,7 @@ func UserToModel(user *UserView) *model.UserView {
 		PreferredLoginName: user.PreferredLoginName,
 		LoginNames:         user.LoginNames,
 		Sequence:           user.Sequence,
+		LockedUntil:        user.LockedUntil,
 	}
 	if !user.HumanView.IsZero() {
 		userView.HumanView = &model.HumanView{
@@ -178,6 +182,8 @@ func UserToModel(user *UserView) *model.UserView {
 			Region:                   user.Region,
 			StreetAddress:            user.StreetAddress,
 			OTPState:                 model.MFAState(user.OTPState),
+			OTPSMSAdded:              user.OTPSMSAdded,
+			OTPEmailAdded:            user.OTPEmailAdded,
 			MFAMaxSetUp:              domain.MFALevel(user.MFAMaxSetUp),
 			MFAInitSkipped:           user.MFAInitSkipped,
 			InitRequired:             user.InitRequired,
@@ -306,8 +312,10 @@ func (u *UserView) AppendEvent(event *models.Event) (err error) {
 	case user.UserReactivatedType,
 		user.UserUnlockedType:
 		u.State = int32(model.UserStateActive)
+		u.LockedUntil = time.Time{}
 	case user.UserLockedType:
 		u.State = int32(model.UserStateLocked)
+		err = u.setLockoutData(event)
 	case user.UserV1MFAOTPAddedType,
 		user.HumanMFAOTPAddedType:
 		if u.HumanView == nil {
@@ -326,6 +334,24 @@ func (u *UserView) AppendEvent(event *models.Event) (err error) {
 	case user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPRemovedType:
 		u.OTPState = int32(model.MFAStateUnspecified)
+	case user.HumanMFAOTPSMSAddedType:
+		if u.HumanView == nil {
+			logging.WithFields("sequence", event.Sequence, "instance", event.InstanceID).Warn("event is ignored because human not exists")
+			return errors.ThrowInvalidArgument(nil, "MODEL-Hs3fq", "event ignored: human not exists")
+		}
+		u.OTPSMSAdded = true
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanMFAOTPSMSRemovedType:
+		u.OTPSMSAdded = false
+	case user.HumanMFAOTPEmailAddedType:
+		if u.HumanView == nil {
+			logging.WithFields("sequence", event.Sequence, "instance", event.InstanceID).Warn("event is ignored because human not exists")
+			return errors.ThrowInvalidArgument(nil, "MODEL-Qw2dg", "event ignored: human not exists")
+		}
+		u.OTPEmailAdded = true
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanMFAOTPEmailRemovedType:
+		u.OTPEmailAdded = false
 	case user.HumanU2FTokenAddedType:
 		err = u.addU2FToken(event)
 	case user.HumanU2FTokenVerifiedType:
@@ -374,6 +400,22 @@ func (u *UserView) setData(event *models.Event) error {
 	return nil
 }
 
+func (u *UserView) setLockoutData(event *models.Event) error {
+	u.LockedUntil = time.Time{}
+	if len(event.Data) == 0 {
+		return nil
+	}
+	lockout := new(user.UserLockedEvent)
+	if err := json.Unmarshal(event.Data, lockout); err != nil {
+		logging.WithError(err).Error("could not unmarshal event data")
+		return errors.ThrowInternal(nil, "MODEL-Lw3fs", "could not unmarshal data")
+	}
+	if lockout.LockoutDuration > 0 {
+		u.LockedUntil = event.CreationDate.Add(lockout.LockoutDuration)
+	}
+	return nil
+}
+
 func (u *UserView) setPasswordData(event *models.Event) error {
 	password := new(es_model.Password)
 	if err := json.Unmarshal(event.Data, password); err != nil {
@@ -520,7 +562,9 @@ func (u *UserView) ComputeMFAMaxSetUp() {
 			return
 		}
 	}
-	if u.OTPState == int32(model.MFAStateReady) {
+	if u.OTPState == int32(model.MFAStateReady) ||
+		(u.OTPSMSAdded && u.IsPhoneVerified) ||
+		(u.OTPEmailAdded && u.IsEmailVerified) {
 		u.MFAMaxSetUp = int32(domain.MFALevelSecondFactor)
 		return
 	}
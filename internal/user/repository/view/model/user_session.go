@@ -139,10 +139,18 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	case user.UserV1MFAOTPCheckSucceededType,
 		user.HumanMFAOTPCheckSucceededType:
 		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTP)
+	case user.HumanMFAOTPSMSCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPSMS)
+	case user.HumanMFAOTPEmailCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPEmail)
 	case user.UserV1MFAOTPCheckFailedType,
 		user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPCheckFailedType,
 		user.HumanMFAOTPRemovedType,
+		user.HumanMFAOTPSMSCheckFailedType,
+		user.HumanMFAOTPSMSRemovedType,
+		user.HumanMFAOTPEmailCheckFailedType,
+		user.HumanMFAOTPEmailRemovedType,
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanU2FTokenRemovedType:
 		v.SecondFactorVerification = time.Time{}
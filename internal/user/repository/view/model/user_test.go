@@ -292,6 +292,14 @@ func TestUserAppendEvent(t *testing.T) {
 			},
 			result: &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country"}, State: int32(model.UserStateLocked)},
 		},
+		{
+			name: "append user lock event with lockout duration",
+			args: args{
+				event: &es_models.Event{AggregateID: "AggregateID", Sequence: 1, Type: es_models.EventType(user.UserLockedType), ResourceOwner: "GrantedOrgID", CreationDate: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), Data: []byte(`{"lockoutDuration":600000000000}`)},
+				user:  &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country"}, State: int32(model.UserStateActive)},
+			},
+			result: &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country"}, State: int32(model.UserStateLocked), LockedUntil: time.Date(2023, 1, 1, 12, 10, 0, 0, time.UTC)},
+		},
 		{
 			name: "append user unlock event",
 			args: args{
@@ -377,6 +385,9 @@ func TestUserAppendEvent(t *testing.T) {
 			if tt.args.user.State != tt.result.State {
 				t.Errorf("got wrong result state: expected: %v, actual: %v ", tt.result.State, tt.args.user.State)
 			}
+			if !tt.args.user.LockedUntil.Equal(tt.result.LockedUntil) {
+				t.Errorf("got wrong result LockedUntil: expected: %v, actual: %v ", tt.result.LockedUntil, tt.args.user.LockedUntil)
+			}
 			if human := tt.args.user.HumanView; human != nil {
 				if human.FirstName != tt.result.FirstName {
 					t.Errorf("got wrong result FirstName: expected: %v, actual: %v ", tt.result.FirstName, tt.args.user.FirstName)